@@ -1,13 +1,18 @@
 package cmd
 
 import (
+	"context"
 	"fmt"
+	"maps"
+	"os"
+	"slices"
 	"strings"
 
-	"github.com/spf13/cobra"
 	"github.com/agnivo988/Repo-lyzer/internal/analyzer"
 	"github.com/agnivo988/Repo-lyzer/internal/github"
 	"github.com/agnivo988/Repo-lyzer/internal/output"
+	"github.com/agnivo988/Repo-lyzer/internal/ui"
+	"github.com/spf13/cobra"
 )
 
 func RunAnalyze(owner, repo string) error {
@@ -16,63 +21,205 @@ func RunAnalyze(owner, repo string) error {
 	return analyzeCmd.Execute()
 }
 
+// analyzeRef is the branch, tag or SHA to analyze instead of the default branch
+var analyzeRef string
+
+// analyzeFormat and analyzeOutput export the analysis instead of printing
+// the report; an empty or "-" output is stdout
+var analyzeFormat, analyzeOutput string
+
+// combineBadges writes the badges to one file rather than a directory
+var combineBadges bool
+
+// mermaidCharts adds Mermaid charts to the Markdown export
+var mermaidCharts bool
+
+// analyzeStore is a SQLite database each analysis is also recorded in
+var analyzeStore string
 
 var analyzeCmd = &cobra.Command{
-	Use:   "analyze owner/repo",
-	Short: "Analyze a GitHub repository",
+	Use:   "analyze owner/repo[@ref]",
+	Short: "Analyze a GitHub or GitLab repository",
 	Args:  cobra.ExactArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
-		parts := strings.Split(args[0], "/")
-		if len(parts) != 2 {
-			return fmt.Errorf("repository must be in owner/repo format")
+		name, ref, _ := strings.Cut(args[0], "@")
+		if analyzeRef != "" {
+			ref = analyzeRef
+		}
+		if analyzeFormat == "" && analyzeOutput != "" {
+			return fmt.Errorf("--output needs a --format (%s)", strings.Join(ui.ExportFormats, ", "))
+		}
+		if analyzeFormat != "" && !slices.Contains(ui.ExportFormats, strings.ToLower(analyzeFormat)) {
+			return fmt.Errorf("unknown --format %q (formats: %s)", analyzeFormat, strings.Join(ui.ExportFormats, ", "))
+		}
+		if strings.EqualFold(analyzeFormat, "badges") && !combineBadges && (analyzeOutput == "" || analyzeOutput == ui.Stdout) {
+			return fmt.Errorf("--format badges needs a directory to write to (-o badges), or --combine-badges to write to stdout")
 		}
 
-		client := github.NewClient()
-		repo, err := client.GetRepo(parts[0], parts[1])
+		ctx := cmd.Context()
+		client, err := newClient()
 		if err != nil {
 			return err
 		}
+		options, err := uiOptions()
+		if err != nil {
+			return err
+		}
+		target := name
+		if ref != "" {
+			target += "@" + ref
+		}
+		if analyzeFormat != "" {
+			return exportAnalysis(ctx, client, target, options)
+		}
+		result, err := ui.Analyze(ctx, client, target, options)
+		if err != nil {
+			return err
+		}
+		printReport(ctx, client, result, options)
+		return storeResult(result)
+	},
+}
 
-		langs, _ := client.GetLanguages(parts[0], parts[1])
-		commits, _ := client.GetCommits(parts[0], parts[1], 365)
-         
-		
-		score := analyzer.CalculateHealth(repo, commits)
-		activity := analyzer.CommitsPerDay(commits)
-		contributors, err := client.GetContributors(parts[0], parts[1])
-            if err != nil {
-	              return err
-                     }
-
-					 busFactor, busRisk := analyzer.BusFactor(contributors)
-
-		maturityScore, maturityLevel :=
-			analyzer.RepoMaturityScore(
-				repo,
-				len(commits),
-				len(contributors),
-				false,
-			)
-
-		summary := analyzer.BuildRecruiterSummary(
-			repo.FullName,
-			repo.Forks,
-		    repo.Stars,
-			len(commits),
-			len(contributors),
-			maturityScore,
-			maturityLevel,
-			busFactor,
-			busRisk,
-		)
-
-		output.PrintRepo(repo)
-		output.PrintLanguages(langs)
-		output.PrintCommitActivity(activity,14)
-		output.PrintHealth(score)
-		output.PrintGitHubAPIStatus(client)
-		output.PrintRecruiterSummary(summary)
-
+// storeResult records the analysis in the --store database, if given
+func storeResult(result ui.AnalysisResult) error {
+	if analyzeStore == "" {
 		return nil
-	},
+	}
+	if err := ui.ExportSQLite(result, analyzeStore); err != nil {
+		return fmt.Errorf("storing the analysis: %w", err)
+	}
+	fmt.Fprintln(os.Stderr, "Stored in", analyzeStore)
+	return nil
+}
+
+// printReport prints the analysis as the terminal report
+func printReport(ctx context.Context, client *github.Client, result ui.AnalysisResult, options ui.Options) {
+	repo := result.Repo
+	if result.RefSHA != "" {
+		fmt.Printf("Analyzing %s at %s (%s)\n", repo.FullName, result.Ref, result.RefSHA)
+	}
+	for _, section := range slices.Sorted(maps.Keys(result.Unavailable)) {
+		fmt.Printf("⚠️ %s unavailable: %s\n", section, result.Unavailable[section])
+	}
+
+	output.PrintRepo(repo)
+	fmt.Println("Status:", result.Abandonment.Summary())
+	if result.LanguagesEstimated {
+		fmt.Println("(languages estimated from file extensions)")
+	}
+	output.PrintLanguages(result.Languages)
+	output.PrintAdjustedLanguages(result.AdjustedLanguages)
+	fmt.Println("Language profile:", result.LanguageProfile.Summary())
+	fmt.Println("Engagement:", result.Engagement.Summary())
+	for _, note := range result.Engagement.Interpretations {
+		fmt.Println("  -", note)
+	}
+	output.PrintCommitActivity(analyzer.CommitsPerDay(result.Commits), 14)
+	fmt.Println("Activity:", result.ActivityTrend.Summary())
+	fmt.Println("Code churn:", result.Churn.Summary())
+	fmt.Println("Commit times:", result.Heatmap.Summary())
+	fmt.Println("Workflow:", result.Workflow.Summary())
+	fmt.Println("Commit messages:", result.CommitHygiene.Summary())
+	if options.Hotspots {
+		output.PrintHotspots(result.Hotspots)
+		output.PrintDirectoryOwners(result.DirectoryOwners)
+	}
+	output.PrintReleases(result.ReleaseStats)
+	fmt.Println("Release automation:", result.ReleaseAutomation.Summary())
+	fmt.Println("Changelog:", result.Changelog.Summary())
+	fmt.Println("Release cadence:", result.ReleaseCadence.Summary())
+	output.PrintMaturity(analyzer.Maturity{
+		Score:      result.MaturityScore,
+		Grade:      result.MaturityGrade,
+		Level:      result.MaturityLevel,
+		Components: result.MaturityComponents,
+	})
+	output.PrintIssues(result.Issues)
+	fmt.Println("First maintainer response:", result.FirstResponse.Summary())
+	fmt.Println("Contributor friendliness:", result.Friendliness.Summary())
+	output.PrintPullRequests(result.PullRequests)
+	fmt.Println("Reviews:", result.Reviews.Summary())
+	output.PrintBranchProtection(result.BranchProtection)
+	fmt.Println("Branches:", result.Branches.Summary())
+	output.PrintCI(result.CI)
+	output.PrintSecurityScore(result.Security)
+	fmt.Println("Signed commits:", result.SignedCommitRatio.Summary())
+	if result.ScorecardChecked {
+		output.PrintScorecard(result.Scorecard)
+	}
+	fmt.Println("Tests:", result.Tests.Summary())
+	output.PrintReadme(result.Readme, result.ReadmeQuality)
+	fmt.Println("Automation:", result.Automation.Summary())
+	output.PrintMonorepo(result.Monorepo)
+	output.PrintRepoSize(result.RepoSize)
+	if options.StarHistory {
+		output.PrintStarHistory(result.Stars)
+	}
+	if result.ForksChecked {
+		output.PrintActiveForks(repo, result.ActiveForks)
+	}
+	output.PrintContributorTiers(result.ContributorTiers)
+	fmt.Println("Timezones:", result.Timezones.Summary())
+	output.PrintBusFactor(result.BusFactorInfo, result.Inequality)
+	output.PrintContributorTrend(result.ContributorTrend)
+	output.PrintHealth(result.HealthScore, result.HealthWeights, result.HealthComponents)
+	output.PrintGitHubAPIStatus(ctx, client)
+	output.PrintRecruiterSummary(analyzer.BuildRecruiterSummary(
+		repo.FullName,
+		repo.Forks,
+		repo.Stars,
+		result.WeeklyCommits.Total(),
+		len(result.Contributors),
+		result.MaturityScore,
+		result.MaturityLevel,
+		result.BusFactor,
+		result.BusRisk,
+	))
+
+	if result.FromStaleCache {
+		fmt.Println("⚠️ GitHub was unreachable: some data came from cache, possibly stale")
+	}
+}
+
+// exportAnalysis runs the full analysis and exports it in analyzeFormat.
+// Warnings and progress go to stderr, keeping stdout clean for piping.
+func exportAnalysis(ctx context.Context, client *github.Client, target string, options ui.Options) error {
+	result, err := ui.Analyze(ctx, client, target, options)
+	if err != nil {
+		return err
+	}
+	for _, section := range slices.Sorted(maps.Keys(result.Unavailable)) {
+		fmt.Fprintf(os.Stderr, "⚠️ %s unavailable: %s\n", section, result.Unavailable[section])
+	}
+	if result.FromStaleCache {
+		fmt.Fprintln(os.Stderr, "⚠️ GitHub was unreachable: some data came from cache, possibly stale")
+	}
+
+	filename := analyzeOutput
+	if filename == "" {
+		filename = ui.Stdout
+	}
+	if strings.EqualFold(analyzeFormat, "badges") && combineBadges {
+		err = ui.ExportBadgesCombined(result, filename)
+	} else {
+		options.Export.MermaidCharts = mermaidCharts
+		err = ui.Export(result, analyzeFormat, filename, options.Export)
+	}
+	if err != nil {
+		return err
+	}
+	if filename != ui.Stdout {
+		fmt.Fprintln(os.Stderr, "Exported to", filename)
+	}
+	return storeResult(result)
+}
+
+func init() {
+	analyzeCmd.Flags().StringVar(&analyzeRef, "ref", "", "Branch, tag or commit SHA to analyze (default: the default branch)")
+	analyzeCmd.Flags().StringVar(&analyzeFormat, "format", "", "Export the analysis instead of printing the report: "+strings.Join(ui.ExportFormats, ", "))
+	analyzeCmd.Flags().StringVarP(&analyzeOutput, "output", "o", "", `File to export to with --format, or "-" for stdout (the default); a directory for badges`)
+	analyzeCmd.Flags().BoolVar(&mermaidCharts, "mermaid", false, "With --format markdown, add Mermaid charts of the languages, weekly commits and contributors")
+	analyzeCmd.Flags().StringVar(&analyzeStore, "store", "", "Also record the analysis in this SQLite database, adding a row per run for tracking a repo over time")
+	analyzeCmd.Flags().BoolVar(&combineBadges, "combine-badges", false, "With --format badges, write all the badges to one JSON object instead of a file each")
 }
@@ -0,0 +1,171 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/agnivo988/Repo-lyzer/internal/ui"
+)
+
+// fakeGitHub serves a small repo, answering 404 for everything else so the
+// analysis has sections to warn about
+func fakeGitHub(t *testing.T) *httptest.Server {
+	pushed := time.Now().AddDate(0, -1, 0).UTC().Format(time.RFC3339)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch strings.TrimPrefix(r.URL.Path, "/api/v3") {
+		case "/repos/corp/tool":
+			fmt.Fprintf(w, `{"name":"tool","full_name":"corp/tool","default_branch":"main","stargazers_count":3,"pushed_at":%q,"has_issues":true}`, pushed)
+		case "/repos/corp/tool/contributors":
+			w.Write([]byte(`[{"login":"dev","contributions":10,"type":"User"}]`))
+		case "/repos/corp/tool/commits":
+			fmt.Fprintf(w, `[{"sha":"abc","commit":{"author":{"name":"Dev","date":%q},"message":"Initial"},"author":{"login":"dev"}}]`, pushed)
+		case "/repos/corp/tool/languages":
+			w.Write([]byte(`{"Go":1200}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"message":"Not Found"}`))
+		}
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+// capture runs f with standard output and error redirected, returning what
+// was written to each
+func capture(t *testing.T, f func() error) (stdout, stderr string) {
+	t.Helper()
+	outR, outW, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	errR, errW, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	oldOut, oldErr := os.Stdout, os.Stderr
+	os.Stdout, os.Stderr = outW, errW
+	defer func() { os.Stdout, os.Stderr = oldOut, oldErr }()
+
+	var out, errOut bytes.Buffer
+	done := make(chan struct{})
+	go func() { io.Copy(&out, outR); done <- struct{}{} }()
+	go func() { io.Copy(&errOut, errR); done <- struct{}{} }()
+
+	runErr := f()
+	outW.Close()
+	errW.Close()
+	<-done
+	<-done
+	if runErr != nil {
+		t.Fatalf("%v\nstderr:\n%s", runErr, errOut.String())
+	}
+	return out.String(), errOut.String()
+}
+
+func TestAnalyzeExportToStdout(t *testing.T) {
+	server := fakeGitHub(t)
+	config := filepath.Join(t.TempDir(), "config.json")
+	if err := os.WriteFile(config, []byte(`{}`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("GITHUB_TOKEN", "")
+	saved := []struct {
+		p *string
+		v string
+	}{{&apiURL, server.URL}, {&configFile, config}, {&token, ""}, {&analyzeOutput, ui.Stdout}}
+	for _, s := range saved {
+		old := *s.p
+		*s.p = s.v
+		t.Cleanup(func() { *s.p = old })
+	}
+	oldNoCache, oldFormat, oldCombine := noCache, analyzeFormat, combineBadges
+	noCache, combineBadges = true, true
+	t.Cleanup(func() { noCache, analyzeFormat, combineBadges = oldNoCache, oldFormat, oldCombine })
+
+	tests := []struct {
+		format string
+		parses func(out string) error
+	}{
+		{"json", jsonDocument},
+		{"badges", jsonDocument},
+		{"yaml", func(out string) error {
+			if !strings.HasPrefix(out, "---\n") {
+				return fmt.Errorf("no document start marker")
+			}
+			for i, line := range strings.Split(strings.TrimSuffix(out, "\n"), "\n")[1:] {
+				text := strings.TrimLeft(line, " ")
+				if !strings.HasPrefix(text, "- ") && text != "-" && !strings.Contains(text, ":") {
+					return fmt.Errorf("line %d isn't a mapping or list entry: %q", i+2, line)
+				}
+			}
+			return nil
+		}},
+		{"markdown", func(out string) error {
+			if !strings.HasPrefix(out, "# ") {
+				return fmt.Errorf("doesn't start with a heading")
+			}
+			return nil
+		}},
+		{"csv", func(out string) error {
+			rows, err := csv.NewReader(strings.NewReader(out)).ReadAll()
+			if err == nil && (len(rows) < 2 || strings.Join(rows[0], ",") != "metric,value") {
+				err = fmt.Errorf("no metric,value table")
+			}
+			return err
+		}},
+		{"html", func(out string) error {
+			if !strings.HasPrefix(strings.ToLower(out), "<!doctype html>") || !strings.HasSuffix(strings.TrimSpace(out), "</html>") {
+				return fmt.Errorf("not a complete HTML document")
+			}
+			return nil
+		}},
+		{"pdf", func(out string) error {
+			if !strings.HasPrefix(out, "%PDF-") || !strings.HasSuffix(strings.TrimSpace(out), "%%EOF") {
+				return fmt.Errorf("not a complete PDF")
+			}
+			return nil
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.format, func(t *testing.T) {
+			analyzeFormat = tt.format
+			analyzeCmd.SetContext(context.Background())
+			stdout, stderr := capture(t, func() error { return analyzeCmd.RunE(analyzeCmd, []string{"corp/tool"}) })
+
+			if err := tt.parses(stdout); err != nil {
+				t.Errorf("stdout doesn't parse as %s: %v\n%s", tt.format, err, stdout)
+			}
+			// The reports mention unavailable sections too, but not as the
+			// warning lines printed to stderr
+			if !strings.Contains(stderr, "unavailable") {
+				t.Errorf("the unavailable sections weren't reported on stderr:\n%s", stderr)
+			}
+			lines := strings.Split(stdout, "\n")
+			for _, progress := range strings.Split(strings.TrimSpace(stderr), "\n") {
+				if slices.Contains(lines, progress) {
+					t.Errorf("progress text %q leaked into stdout", progress)
+				}
+			}
+			if strings.Contains(stdout, "Exported to") {
+				t.Error("the export notice leaked into stdout")
+			}
+		})
+	}
+}
+
+func jsonDocument(out string) error {
+	var doc map[string]any
+	return json.Unmarshal([]byte(out), &doc)
+}
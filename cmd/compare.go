@@ -1,14 +1,17 @@
 package cmd
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"strings"
+	"time"
 
 	"github.com/olekukonko/tablewriter"
 
 	"github.com/agnivo988/Repo-lyzer/internal/analyzer"
 	"github.com/agnivo988/Repo-lyzer/internal/github"
+	"github.com/agnivo988/Repo-lyzer/internal/ui"
 )
 
 // CompareRepos runs the comparison logic directly
@@ -20,33 +23,57 @@ func CompareRepos(repo1Input, repo2Input string) error {
 		return fmt.Errorf("repositories must be in owner/repo format")
 	}
 
-	client := github.NewClient()
+	ctx := context.Background()
+	client, err := newClient()
+	if err != nil {
+		return err
+	}
 
 	// ---------- Fetch Repo 1 ----------
-	repo1, err := client.GetRepo(r1[0], r1[1])
+	repo1, err := client.GetRepo(ctx, r1[0], r1[1])
 	if err != nil {
 		return err
 	}
 
-	commits1, _ := client.GetCommits(r1[0], r1[1], 14)
-	contributors1, _ := client.GetContributors(r1[0], r1[1])
+	commits1, _, _ := client.GetCommits(ctx, r1[0], r1[1], github.LastDays(14))
+	contributors1, _, _ := client.GetContributors(ctx, r1[0], r1[1], github.DefaultMaxContributors)
 	bus1, risk1 := analyzer.BusFactor(contributors1)
 
-	maturityScore1, maturityLevel1 :=
-		analyzer.RepoMaturityScore(repo1, len(commits1), len(contributors1), false)
+	languages1, _ := client.GetLanguages(ctx, r1[0], r1[1])
+	_, releases1 := ui.FetchReleaseStats(ctx, client, r1[0], r1[1])
+	maturity1 := analyzer.ScoreMaturity(analyzer.MaturityInput{
+		Repo:     repo1,
+		Commits:  len(commits1),
+		Releases: releases1,
+		Readme:   ui.FetchReadme(ctx, client, r1[0], r1[1], ""),
+		License:  analyzer.ClassifyLicense(repo1),
+		Now:      time.Now(),
+		// The comparison doesn't fetch file trees
+		FileTreeUnavailable: true,
+	})
 
 	// ---------- Fetch Repo 2 ----------
-	repo2, err := client.GetRepo(r2[0], r2[1])
+	repo2, err := client.GetRepo(ctx, r2[0], r2[1])
 	if err != nil {
 		return err
 	}
 
-	commits2, _ := client.GetCommits(r2[0], r2[1], 14)
-	contributors2, _ := client.GetContributors(r2[0], r2[1])
+	commits2, _, _ := client.GetCommits(ctx, r2[0], r2[1], github.LastDays(14))
+	contributors2, _, _ := client.GetContributors(ctx, r2[0], r2[1], github.DefaultMaxContributors)
 	bus2, risk2 := analyzer.BusFactor(contributors2)
 
-	maturityScore2, maturityLevel2 :=
-		analyzer.RepoMaturityScore(repo2, len(commits2), len(contributors2), false)
+	languages2, _ := client.GetLanguages(ctx, r2[0], r2[1])
+	_, releases2 := ui.FetchReleaseStats(ctx, client, r2[0], r2[1])
+	maturity2 := analyzer.ScoreMaturity(analyzer.MaturityInput{
+		Repo:     repo2,
+		Commits:  len(commits2),
+		Releases: releases2,
+		Readme:   ui.FetchReadme(ctx, client, r2[0], r2[1], ""),
+		License:  analyzer.ClassifyLicense(repo2),
+		Now:      time.Now(),
+		// The comparison doesn't fetch file trees
+		FileTreeUnavailable: true,
+	})
 
 	// ---------- Output Table ----------
 	fmt.Println("\n📊 Repository Comparison")
@@ -64,6 +91,11 @@ func CompareRepos(repo1Input, repo2Input string) error {
 		fmt.Sprintf("%d", repo2.Forks),
 	})
 
+	table.Append([]string{"📜 License",
+		analyzer.LicenseLabel(repo1),
+		analyzer.LicenseLabel(repo2),
+	})
+
 	table.Append([]string{"📦 Commits (1y)",
 		fmt.Sprintf("%d", len(commits1)),
 		fmt.Sprintf("%d", len(commits2)),
@@ -80,17 +112,26 @@ func CompareRepos(repo1Input, repo2Input string) error {
 	})
 
 	table.Append([]string{"🏗️ Maturity",
-		fmt.Sprintf("%s (%d)", maturityLevel1, maturityScore1),
-		fmt.Sprintf("%s (%d)", maturityLevel2, maturityScore2),
+		fmt.Sprintf("%s (%d)", maturity1.Level, maturity1.Score),
+		fmt.Sprintf("%s (%d)", maturity2.Level, maturity2.Score),
+	})
+
+	profile1, profile2 := analyzer.AnalyzeLanguageProfile(languages1), analyzer.AnalyzeLanguageProfile(languages2)
+	table.Append([]string{"💻 Languages",
+		profile1.Summary(),
+		profile2.Summary(),
 	})
 
 	table.Render()
+	if similarity := analyzer.LanguageSimilarity(languages1, languages2); similarity >= 0 {
+		fmt.Printf("Language profile similarity: %.0f%%\n", similarity*100)
+	}
 
 	// ---------- Verdict ----------
 	fmt.Println("\n📌 Verdict")
-	if maturityScore1 > maturityScore2 {
+	if maturity1.Score > maturity2.Score {
 		fmt.Printf("➡️ %s appears more mature and stable.\n", repo1.FullName)
-	} else if maturityScore2 > maturityScore1 {
+	} else if maturity2.Score > maturity1.Score {
 		fmt.Printf("➡️ %s appears more mature and stable.\n", repo2.FullName)
 	} else {
 		fmt.Println("➡️ Both repositories are similarly mature.")
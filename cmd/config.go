@@ -0,0 +1,72 @@
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"github.com/agnivo988/Repo-lyzer/internal/analyzer"
+)
+
+// configFile overrides the config file location
+var configFile string
+
+// Config is the optional config file. Flags override what it sets.
+type Config struct {
+	// HealthWeights are the health score weights, any left out keeping
+	// their defaults, e.g. {"activity": 40, "ci": 0}
+	HealthWeights analyzer.HealthWeights `json:"health_weights"`
+}
+
+// defaultConfigFile returns config.json in the repolyzer directory under
+// the user config dir
+func defaultConfigFile() (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "repolyzer", "config.json"), nil
+}
+
+// loadConfig reads the config file named by --config, or the default one
+// when it exists
+func loadConfig() (Config, error) {
+	path, explicit := configFile, configFile != ""
+	if !explicit {
+		var err error
+		if path, err = defaultConfigFile(); err != nil {
+			return Config{HealthWeights: analyzer.DefaultHealthWeights}, nil
+		}
+	}
+	config, err := readConfig(path)
+	if errors.Is(err, fs.ErrNotExist) && !explicit {
+		return config, nil
+	}
+	return config, err
+}
+
+// readConfig parses the config file at path, validating its weights
+func readConfig(path string) (Config, error) {
+	config := Config{HealthWeights: analyzer.DefaultHealthWeights}
+	f, err := os.Open(path)
+	if err != nil {
+		return config, err
+	}
+	defer f.Close()
+
+	// Decoding over the defaults keeps the weights the file doesn't set
+	decoder := json.NewDecoder(f)
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&config); err != nil {
+		return Config{HealthWeights: analyzer.DefaultHealthWeights}, fmt.Errorf("reading %s: %w", path, err)
+	}
+	scorer, err := analyzer.NewHealthScorer(config.HealthWeights)
+	if err != nil {
+		return Config{HealthWeights: analyzer.DefaultHealthWeights}, fmt.Errorf("%s: invalid health_weights: %w", path, err)
+	}
+	config.HealthWeights = scorer.Weights
+	return config, nil
+}
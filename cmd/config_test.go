@@ -0,0 +1,83 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/agnivo988/Repo-lyzer/internal/analyzer"
+)
+
+func TestReadConfig(t *testing.T) {
+	tests := []struct {
+		name   string
+		config string
+		want   string // the weights' String, or part of the error
+		err    bool
+	}{
+		{"empty", `{}`, analyzer.DefaultHealthWeights.String(), false},
+		{"exact weights", `{"health_weights": {"activity": 60, "contributors": 0, "issues": 0, "pull_requests": 0, "docs": 40, "popularity": 0, "freshness": 0, "ci": 0, "reviews": 0, "tests": 0}}`,
+			"activity 60, contributors 0, issues 0, pull_requests 0, docs 40, popularity 0, freshness 0, ci 0, reviews 0, tests 0", false},
+		// Those left out keep their defaults, then all are normalized
+		{"partial weights", `{"health_weights": {"activity": 40}}`,
+			"activity 36, contributors 8, issues 12, pull_requests 8, docs 12, popularity 4, freshness 8, ci 4, reviews 4, tests 4", false},
+		{"negative weight", `{"health_weights": {"ci": -5}}`, "invalid health_weights", true},
+		{"all zero", `{"health_weights": {"activity": 0, "contributors": 0, "issues": 0, "pull_requests": 0, "docs": 0, "popularity": 0, "freshness": 0, "ci": 0, "reviews": 0, "tests": 0}}`, "invalid health_weights", true},
+		{"unknown weight", `{"health_weights": {"stars": 10}}`, "unknown field", true},
+		{"not JSON", `health_weights: {}`, "reading", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "config.json")
+			if err := os.WriteFile(path, []byte(tt.config), 0o600); err != nil {
+				t.Fatal(err)
+			}
+			config, err := readConfig(path)
+			switch {
+			case tt.err && (err == nil || !strings.Contains(err.Error(), tt.want)):
+				t.Errorf("error = %v, want one containing %q", err, tt.want)
+			case !tt.err && err != nil:
+				t.Error(err)
+			case !tt.err && config.HealthWeights.String() != tt.want:
+				t.Errorf("weights = %s, want %s", config.HealthWeights, tt.want)
+			}
+		})
+	}
+}
+
+func TestWeightsFlagOverridesConfig(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", dir)
+	t.Setenv("HOME", dir)
+	configFile, weights = filepath.Join(dir, "config.json"), ""
+	t.Cleanup(func() { configFile, weights = "", "" })
+
+	// --config naming a missing file is an error; the default one isn't
+	if _, err := uiOptions(); err == nil {
+		t.Error("no error for a missing --config file")
+	}
+	configFile = ""
+	if options, err := uiOptions(); err != nil || options.HealthWeights != analyzer.DefaultHealthWeights {
+		t.Errorf("without a config file: weights %s, %v; want the defaults", options.HealthWeights, err)
+	}
+
+	configFile = filepath.Join(dir, "config.json")
+	os.WriteFile(configFile, []byte(`{"health_weights": {"activity": 0, "contributors": 0, "issues": 0, "pull_requests": 0, "docs": 50, "popularity": 0, "freshness": 0, "ci": 50, "reviews": 0, "tests": 0}}`), 0o600)
+	options, err := uiOptions()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if w := options.HealthWeights; w.Docs != 50 || w.CI != 50 || w.Activity != 0 {
+		t.Errorf("config weights = %s, want docs 50 and ci 50", w)
+	}
+
+	weights = "ci=0"
+	options, err = uiOptions()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if w := options.HealthWeights; w.Docs != 100 || w.CI != 0 {
+		t.Errorf("--weights ci=0 over the config = %s, want docs 100", w)
+	}
+}
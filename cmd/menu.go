@@ -8,7 +8,17 @@ import (
 )
 
 func RunMenu() {
-	if err := ui.Run(); err != nil {
+	client, err := newClient()
+	if err != nil {
+		fmt.Println("Error:", err)
+		os.Exit(1)
+	}
+	options, err := uiOptions()
+	if err != nil {
+		fmt.Println("Error:", err)
+		os.Exit(1)
+	}
+	if err := ui.Run(client, options); err != nil {
 		fmt.Println("Error running application:", err)
 		os.Exit(1)
 	}
@@ -0,0 +1,143 @@
+package analyzer
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/agnivo988/Repo-lyzer/internal/github"
+)
+
+// Project statuses, from alive to dead
+const (
+	StatusActive    = "active"
+	StatusSlowing   = "slowing"
+	StatusDormant   = "dormant"
+	StatusAbandoned = "abandoned"
+	StatusArchived  = "archived"
+)
+
+// Abandonment thresholds. They're tuned together, so they all live here.
+const (
+	// slowingPushDays, dormantPushDays and abandonedPushDays are the days
+	// without a push after which a project is at least slowing, dormant
+	// or abandoned
+	slowingPushDays   = 90
+	dormantPushDays   = 180
+	abandonedPushDays = 365
+	// unreviewedPRShare is the share of open PRs left without a review
+	// that counts as neglect, given at least minUnreviewedPRs of them
+	unreviewedPRShare = 0.5
+	minUnreviewedPRs  = 3
+)
+
+// AbandonmentInput holds the signals the status is judged from
+type AbandonmentInput struct {
+	Repo         *github.Repo
+	Trend        ActivityTrend
+	Issues       IssueStats
+	PullRequests PullRequestStats
+	Now          time.Time
+}
+
+// AbandonmentRisk is a blunt answer to whether a project is dead
+type AbandonmentRisk struct {
+	Status  string   `json:"status"`
+	Reasons []string `json:"reasons,omitempty"` // the signals behind Status
+}
+
+// AssessAbandonment classifies a project. Archived repos are archived
+// whatever their history. Otherwise the status is the worst that any
+// signal triggers:
+//   - abandoned: no push in abandonedPushDays, or no push in
+//     dormantPushDays with maintainers silent and open PRs unreviewed
+//   - dormant: no push in dormantPushDays, or no commits in six months
+//   - slowing: no push in slowingPushDays, commits declining, maintainers
+//     silent for 90 days, or most open PRs unreviewed
+func AssessAbandonment(in AbandonmentInput) AbandonmentRisk {
+	if in.Repo.Archived {
+		return AbandonmentRisk{Status: StatusArchived, Reasons: []string{"archived by its owners"}}
+	}
+
+	risk := AbandonmentRisk{Status: StatusActive}
+	level := 0
+	raise := func(status string, reason string) {
+		if rank := statusRank(status); rank > level {
+			level, risk.Status = rank, status
+		}
+		risk.Reasons = append(risk.Reasons, reason)
+	}
+
+	days := int(in.Now.Sub(in.Repo.PushedAt).Hours() / 24)
+	pushed := fmt.Sprintf("no push in %d days", days)
+	switch {
+	case days >= abandonedPushDays:
+		raise(StatusAbandoned, pushed)
+	case days >= dormantPushDays:
+		raise(StatusDormant, pushed)
+	case days >= slowingPushDays:
+		raise(StatusSlowing, pushed)
+	}
+
+	switch in.Trend.Trend {
+	case ActivityDormant:
+		raise(StatusDormant, "no commits in the last 6 months")
+	case ActivityDeclining:
+		raise(StatusSlowing, fmt.Sprintf("commits down %.0f%% on the 6 months before", -in.Trend.Change*100))
+	}
+
+	silent := maintainersSilent(in.Issues, in.PullRequests)
+	if silent {
+		raise(StatusSlowing, "no issues closed or PRs merged in 90 days")
+	}
+	unreviewed := in.PullRequests.Evaluated && in.PullRequests.UnreviewedOpen >= minUnreviewedPRs &&
+		float64(in.PullRequests.UnreviewedOpen) >= unreviewedPRShare*float64(in.PullRequests.OpenPRs)
+	if unreviewed {
+		raise(StatusSlowing, fmt.Sprintf("%d of %d open PRs without a review", in.PullRequests.UnreviewedOpen, in.PullRequests.OpenPRs))
+	}
+	if days >= dormantPushDays && silent && unreviewed {
+		raise(StatusAbandoned, "maintainers aren't responding to contributions")
+	}
+	return risk
+}
+
+// maintainersSilent reports whether there's work waiting but no issue was
+// closed and no PR merged in 90 days. Without issue or PR data, or with
+// nothing open, silence can't be told from calm.
+func maintainersSilent(issues IssueStats, pulls PullRequestStats) bool {
+	issuesKnown := issues.Enabled && issues.Evaluated
+	if !issuesKnown && !pulls.Evaluated {
+		return false
+	}
+	waiting := issuesKnown && issues.OpenIssues > 0 || pulls.Evaluated && pulls.OpenPRs > 0
+	responded := issuesKnown && issues.ClosedLast90Days > 0 || pulls.Evaluated && pulls.MergedLast90Days > 0
+	return waiting && !responded
+}
+
+func statusRank(status string) int {
+	switch status {
+	case StatusSlowing:
+		return 1
+	case StatusDormant:
+		return 2
+	case StatusAbandoned:
+		return 3
+	case StatusArchived:
+		return 4
+	}
+	return 0
+}
+
+// Summary renders the status and its reasons, e.g.
+// "dormant: no push in 200 days, no commits in the last 6 months"
+func (r AbandonmentRisk) Summary() string {
+	summary := r.Status
+	for i, reason := range r.Reasons {
+		if i == 0 {
+			summary += ": "
+		} else {
+			summary += ", "
+		}
+		summary += reason
+	}
+	return summary
+}
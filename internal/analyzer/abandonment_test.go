@@ -0,0 +1,81 @@
+package analyzer
+
+import (
+	"testing"
+	"time"
+
+	"github.com/agnivo988/Repo-lyzer/internal/github"
+)
+
+func TestAssessAbandonment(t *testing.T) {
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	pushed := func(days int) *github.Repo {
+		return &github.Repo{PushedAt: now.Add(-time.Duration(days) * 24 * time.Hour)}
+	}
+	steady := ActivityTrend{Trend: ActivitySteady}
+	// Issues waiting with none closed in 90 days
+	silentIssues := IssueStats{Enabled: true, Evaluated: true, OpenIssues: 4}
+	respondedIssues := IssueStats{Enabled: true, Evaluated: true, OpenIssues: 4, ClosedLast90Days: 1}
+	unreviewed := func(unreviewed, open int) PullRequestStats {
+		return PullRequestStats{Evaluated: true, OpenPRs: open, UnreviewedOpen: unreviewed, MergedLast90Days: 1}
+	}
+
+	tests := []struct {
+		name   string
+		in     AbandonmentInput
+		status string
+	}{
+		{"pushed today", AbandonmentInput{Repo: pushed(0), Trend: steady}, StatusActive},
+		{"just before slowing", AbandonmentInput{Repo: pushed(slowingPushDays - 1), Trend: steady}, StatusActive},
+		{"at slowing", AbandonmentInput{Repo: pushed(slowingPushDays), Trend: steady}, StatusSlowing},
+		{"just before dormant", AbandonmentInput{Repo: pushed(dormantPushDays - 1), Trend: steady}, StatusSlowing},
+		{"at dormant", AbandonmentInput{Repo: pushed(dormantPushDays), Trend: steady}, StatusDormant},
+		{"just before abandoned", AbandonmentInput{Repo: pushed(abandonedPushDays - 1), Trend: steady}, StatusDormant},
+		{"at abandoned", AbandonmentInput{Repo: pushed(abandonedPushDays), Trend: steady}, StatusAbandoned},
+		{"archived", AbandonmentInput{Repo: &github.Repo{Archived: true, PushedAt: now}, Trend: steady}, StatusArchived},
+		{"archived long ago", AbandonmentInput{Repo: &github.Repo{Archived: true, PushedAt: now.AddDate(-5, 0, 0)}}, StatusArchived},
+
+		{"commits declining", AbandonmentInput{Repo: pushed(1), Trend: ActivityTrend{Trend: ActivityDeclining, Change: -0.5}}, StatusSlowing},
+		{"no commits in 6 months", AbandonmentInput{Repo: pushed(1), Trend: ActivityTrend{Trend: ActivityDormant}}, StatusDormant},
+		{"too new to trend", AbandonmentInput{Repo: pushed(1), Trend: ActivityTrend{Trend: ActivityTooNew}}, StatusActive},
+
+		{"maintainers silent", AbandonmentInput{Repo: pushed(1), Trend: steady, Issues: silentIssues}, StatusSlowing},
+		{"maintainers responding", AbandonmentInput{Repo: pushed(1), Trend: steady, Issues: respondedIssues}, StatusActive},
+		{"nothing waiting", AbandonmentInput{Repo: pushed(1), Trend: steady, Issues: IssueStats{Enabled: true, Evaluated: true}}, StatusActive},
+		{"issue data unavailable", AbandonmentInput{Repo: pushed(1), Trend: steady, Issues: IssueStats{Enabled: true, OpenIssues: 4}}, StatusActive},
+
+		{"unreviewed PRs below the minimum", AbandonmentInput{Repo: pushed(1), Trend: steady, PullRequests: unreviewed(minUnreviewedPRs-1, minUnreviewedPRs-1)}, StatusActive},
+		{"unreviewed PRs at the share", AbandonmentInput{Repo: pushed(1), Trend: steady, PullRequests: unreviewed(3, 6)}, StatusSlowing},
+		{"unreviewed PRs below the share", AbandonmentInput{Repo: pushed(1), Trend: steady, PullRequests: unreviewed(3, 7)}, StatusActive},
+		{"reviews unknown", AbandonmentInput{Repo: pushed(1), Trend: steady, PullRequests: unreviewed(-1, 10)}, StatusActive},
+
+		// Dormant pushes plus ignored contributions are abandonment
+		{"dormant and unresponsive", AbandonmentInput{Repo: pushed(dormantPushDays), Trend: steady, Issues: silentIssues,
+			PullRequests: PullRequestStats{Evaluated: true, OpenPRs: 4, UnreviewedOpen: 4}}, StatusAbandoned},
+		{"just before dormant and unresponsive", AbandonmentInput{Repo: pushed(dormantPushDays - 1), Trend: steady, Issues: silentIssues,
+			PullRequests: PullRequestStats{Evaluated: true, OpenPRs: 4, UnreviewedOpen: 4}}, StatusSlowing},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.in.Now = now
+			got := AssessAbandonment(tt.in)
+			if got.Status != tt.status {
+				t.Errorf("status = %s, want %s", got.Summary(), tt.status)
+			}
+			if (got.Status == StatusActive) != (len(got.Reasons) == 0) {
+				t.Errorf("%s with reasons %v", got.Status, got.Reasons)
+			}
+		})
+	}
+}
+
+func TestAbandonmentSummary(t *testing.T) {
+	got := AssessAbandonment(AbandonmentInput{
+		Repo:  &github.Repo{PushedAt: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)},
+		Trend: ActivityTrend{Trend: ActivityDormant},
+		Now:   time.Date(2025, 7, 20, 0, 0, 0, 0, time.UTC),
+	})
+	if want := "dormant: no push in 200 days, no commits in the last 6 months"; got.Summary() != want {
+		t.Errorf("Summary() = %q, want %q", got.Summary(), want)
+	}
+}
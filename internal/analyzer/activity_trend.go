@@ -0,0 +1,93 @@
+package analyzer
+
+import (
+	"fmt"
+	"math"
+	"time"
+)
+
+// ActivityTrendKind classifies how commit activity is changing
+type ActivityTrendKind string
+
+const (
+	ActivityGrowing   ActivityTrendKind = "growing"
+	ActivitySteady    ActivityTrendKind = "steady"
+	ActivityDeclining ActivityTrendKind = "declining"
+	ActivityDormant   ActivityTrendKind = "dormant"
+	ActivityTooNew    ActivityTrendKind = "too new to trend"
+)
+
+// trendWeeks is the length of each half of the comparison
+const trendWeeks = 26
+
+// steadyChange is the largest change, either way, still counted as steady
+const steadyChange = 0.2
+
+// ActivityTrend compares commits in the last six months with the six
+// months before
+type ActivityTrend struct {
+	Trend  ActivityTrendKind `json:"trend"`
+	Recent int               `json:"recent"` // commits in the last 26 weeks
+	Prior  int               `json:"prior"`  // commits in the 26 weeks before
+	// Change is (Recent - Prior) / Prior, or 0 when Prior is 0
+	Change float64 `json:"change"`
+}
+
+// AnalyzeActivityTrend classifies a year of weekly commits. Repos created
+// within the last year, or with less than a year of weeks, are too new.
+func AnalyzeActivityTrend(weekly WeeklyActivity, createdAt, now time.Time) ActivityTrend {
+	weeks := weekly.Counts()
+	if len(weeks) < 2*trendWeeks || createdAt.After(now.AddDate(-1, 0, 0)) {
+		return ActivityTrend{Trend: ActivityTooNew}
+	}
+	weeks = weeks[len(weeks)-2*trendWeeks:]
+
+	var trend ActivityTrend
+	for i, n := range weeks {
+		if i < trendWeeks {
+			trend.Prior += n
+		} else {
+			trend.Recent += n
+		}
+	}
+	if trend.Prior > 0 {
+		trend.Change = float64(trend.Recent-trend.Prior) / float64(trend.Prior)
+	}
+
+	switch {
+	case trend.Recent == 0:
+		trend.Trend = ActivityDormant
+	case trend.Prior == 0 || trend.Change > steadyChange:
+		trend.Trend = ActivityGrowing
+	case trend.Change < -steadyChange:
+		trend.Trend = ActivityDeclining
+	default:
+		trend.Trend = ActivitySteady
+	}
+	return trend
+}
+
+// Evaluated reports whether there was enough history to classify
+func (t ActivityTrend) Evaluated() bool {
+	return t.Trend != "" && t.Trend != ActivityTooNew
+}
+
+// Summary renders the trend, e.g. "declining (−43% vs prior 6 months)"
+func (t ActivityTrend) Summary() string {
+	switch {
+	case t.Trend == "":
+		return "unknown"
+	case !t.Evaluated():
+		return string(t.Trend)
+	case t.Prior == 0 && t.Recent == 0:
+		return fmt.Sprintf("%s (no commits in a year)", t.Trend)
+	case t.Prior == 0:
+		return fmt.Sprintf("%s (%d commits, none in the prior 6 months)", t.Trend, t.Recent)
+	}
+	pct := int(math.Round(t.Change * 100))
+	sign := "+"
+	if pct < 0 {
+		sign, pct = "−", -pct
+	}
+	return fmt.Sprintf("%s (%s%d%% vs prior 6 months)", t.Trend, sign, pct)
+}
@@ -0,0 +1,48 @@
+package analyzer
+
+import (
+	"testing"
+	"time"
+)
+
+// halves builds a year of weeks with prior commits in the first six
+// months and recent ones in the last
+func halves(prior, recent int) WeeklyActivity {
+	var w WeeklyActivity
+	start := time.Date(2024, 6, 2, 0, 0, 0, 0, time.UTC)
+	for i := 0; i < 2*trendWeeks; i++ {
+		w.Weeks = append(w.Weeks, WeekCount{Start: start.AddDate(0, 0, 7*i)})
+	}
+	w.Weeks[0].Commits = prior
+	w.Weeks[trendWeeks].Commits = recent
+	return w
+}
+
+func TestAnalyzeActivityTrendThresholds(t *testing.T) {
+	now := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+	old := now.AddDate(-3, 0, 0)
+	tests := []struct {
+		name      string
+		weekly    WeeklyActivity
+		createdAt time.Time
+		want      ActivityTrendKind
+	}{
+		{"at the growth threshold", halves(10, 12), old, ActivitySteady},
+		{"above the growth threshold", halves(10, 13), old, ActivityGrowing},
+		{"at the decline threshold", halves(10, 8), old, ActivitySteady},
+		{"below the decline threshold", halves(10, 7), old, ActivityDeclining},
+		{"no recent commits", halves(10, 0), old, ActivityDormant},
+		{"no commits at all", halves(0, 0), old, ActivityDormant},
+		{"restarted", halves(0, 3), old, ActivityGrowing},
+		{"created within the year", halves(10, 10), now.AddDate(0, -11, 0), ActivityTooNew},
+		{"less than a year of weeks", WeeklyActivity{Weeks: halves(10, 10).Weeks[1:]}, old, ActivityTooNew},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := AnalyzeActivityTrend(tt.weekly, tt.createdAt, now)
+			if got.Trend != tt.want {
+				t.Errorf("trend = %s (%+v), want %s", got.Trend, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,176 @@
+package analyzer
+
+import (
+	"fmt"
+	"path"
+	"regexp"
+	"strings"
+
+	"github.com/agnivo988/Repo-lyzer/internal/github"
+)
+
+var (
+	// blankIssuesDisabled is the issue template config.yml setting that
+	// forces reporters to pick a template
+	blankIssuesDisabled = regexp.MustCompile(`(?m)^\s*blank_issues_enabled\s*:\s*false\b`)
+	// dependabotEcosystem is a package-ecosystem entry of dependabot.yml
+	dependabotEcosystem = regexp.MustCompile(`(?m)^[\s-]*package-ecosystem\s*:\s*["']?([\w-]+)`)
+)
+
+// ProjectAutomation records the .github templates and bots a project is
+// configured with
+type ProjectAutomation struct {
+	Evaluated bool `json:"evaluated"`
+	// IssueTemplates counts the issue forms and templates
+	IssueTemplates int `json:"issue_templates"`
+	// BlankIssuesDisabled is set when the template config makes reporters
+	// pick a template
+	BlankIssuesDisabled bool `json:"blank_issues_disabled"`
+	PRTemplate          bool `json:"pr_template"`
+	Funding             bool `json:"funding"`
+	CodeOwners          bool `json:"code_owners"`
+	Dependabot          bool `json:"dependabot"`
+	// DependabotEcosystems are the package ecosystems Dependabot updates,
+	// e.g. "gomod" and "github-actions"
+	DependabotEcosystems []string `json:"dependabot_ecosystems,omitempty"`
+	Renovate             bool     `json:"renovate"`
+	// StaleBot is set for a probot stale.yml or a workflow named for stale
+	// issues
+	StaleBot bool `json:"stale_bot"`
+	// IssueTemplateConfig and DependabotConfig are the paths of the configs
+	// ParseIssueTemplateConfig and ParseDependabotEcosystems read
+	IssueTemplateConfig string `json:"issue_template_config,omitempty"`
+	DependabotConfig    string `json:"dependabot_config,omitempty"`
+}
+
+// DetectAutomation finds the templates and bot configs in the tree. The
+// configs' contents are read separately, see IssueTemplateConfig and
+// DependabotConfig.
+func DetectAutomation(tree []github.TreeEntry) ProjectAutomation {
+	if len(tree) == 0 {
+		return ProjectAutomation{}
+	}
+	a := ProjectAutomation{Evaluated: true}
+	for _, entry := range tree {
+		if entry.Type != "blob" {
+			continue
+		}
+		lower := strings.ToLower(entry.Path)
+		dir, file := path.Split(lower)
+		dir = strings.TrimSuffix(dir, "/")
+		name := strings.TrimSuffix(file, path.Ext(file))
+		switch {
+		case dir == ".github/issue_template" && name == "config":
+			a.IssueTemplateConfig = entry.Path
+		case dir == ".github/issue_template" && isTemplateFile(file):
+			a.IssueTemplates++
+		case name == "issue_template" && isTopLevelConfigDir(dir):
+			a.IssueTemplates++
+		case name == "pull_request_template" && isTopLevelConfigDir(dir),
+			dir == ".github/pull_request_template" && isTemplateFile(file):
+			a.PRTemplate = true
+		case lower == ".github/funding.yml":
+			a.Funding = true
+		case lower == ".github/stale.yml", dir == ".github/workflows" && strings.Contains(name, "stale"):
+			a.StaleBot = true
+		}
+		switch dependencyUpdateConfigs[entry.Path] {
+		case "Dependabot":
+			a.Dependabot = true
+			a.DependabotConfig = entry.Path
+		case "Renovate":
+			a.Renovate = true
+		}
+	}
+	a.CodeOwners = FindCodeOwners(tree) != ""
+	return a
+}
+
+// isTemplateFile reports whether a file is a Markdown template or a YAML
+// issue form
+func isTemplateFile(file string) bool {
+	switch path.Ext(file) {
+	case ".md", ".yml", ".yaml":
+		return true
+	}
+	return false
+}
+
+// isTopLevelConfigDir reports whether GitHub reads single templates from
+// dir: the root, docs or .github
+func isTopLevelConfigDir(dir string) bool {
+	return dir == "" || dir == "docs" || dir == ".github"
+}
+
+// ParseIssueTemplateConfig reports whether an issue template config.yml
+// disables blank issues
+func ParseIssueTemplateConfig(text string) bool {
+	return blankIssuesDisabled.MatchString(text)
+}
+
+// ParseDependabotEcosystems lists the package ecosystems of a
+// dependabot.yml, in order and without repeats
+func ParseDependabotEcosystems(text string) []string {
+	var ecosystems []string
+	seen := make(map[string]bool)
+	for _, m := range dependabotEcosystem.FindAllStringSubmatch(text, -1) {
+		if !seen[m[1]] {
+			seen[m[1]] = true
+			ecosystems = append(ecosystems, m[1])
+		}
+	}
+	return ecosystems
+}
+
+// Checklist returns the templates and bots in display order
+func (a ProjectAutomation) Checklist() []CommunityItem {
+	return []CommunityItem{
+		{"Issue templates", a.IssueTemplates > 0},
+		{"Blank issues disabled", a.BlankIssuesDisabled},
+		{"PR template", a.PRTemplate},
+		{"CODEOWNERS", a.CodeOwners},
+		{"Dependabot", a.Dependabot},
+		{"Renovate", a.Renovate},
+		{"Stale bot", a.StaleBot},
+		{"Funding", a.Funding},
+	}
+}
+
+// Summary renders what's configured, e.g. "issue templates: 3, PR
+// template, Dependabot (gomod, github-actions), CODEOWNERS"
+func (a ProjectAutomation) Summary() string {
+	if !a.Evaluated {
+		return "not evaluated"
+	}
+	var parts []string
+	if a.IssueTemplates > 0 {
+		parts = append(parts, fmt.Sprintf("issue templates: %d", a.IssueTemplates))
+	}
+	if a.BlankIssuesDisabled {
+		parts = append(parts, "blank issues disabled")
+	}
+	if a.PRTemplate {
+		parts = append(parts, "PR template")
+	}
+	if a.Dependabot {
+		dependabot := "Dependabot"
+		if len(a.DependabotEcosystems) > 0 {
+			dependabot += " (" + strings.Join(a.DependabotEcosystems, ", ") + ")"
+		}
+		parts = append(parts, dependabot)
+	}
+	for _, item := range []CommunityItem{
+		{"Renovate", a.Renovate},
+		{"CODEOWNERS", a.CodeOwners},
+		{"stale bot", a.StaleBot},
+		{"funding", a.Funding},
+	} {
+		if item.Present {
+			parts = append(parts, item.Name)
+		}
+	}
+	if len(parts) == 0 {
+		return "no templates or bots configured"
+	}
+	return strings.Join(parts, ", ")
+}
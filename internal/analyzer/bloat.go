@@ -0,0 +1,98 @@
+package analyzer
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/agnivo988/Repo-lyzer/internal/github"
+)
+
+// DefaultLargeFileMB is the size above which a checked-in file is flagged
+const DefaultLargeFileMB = 5
+
+// lfsPointerMaxBytes is the most an LFS pointer file takes; they're about
+// 130 bytes of version, oid and size lines
+const lfsPointerMaxBytes = 200
+
+// binaryExtensions are formats that are binary whatever their content
+var binaryExtensions = map[string]bool{
+	".png": true, ".jpg": true, ".jpeg": true, ".gif": true, ".psd": true,
+	".zip": true, ".tar": true, ".gz": true, ".tgz": true, ".7z": true, ".rar": true,
+	".jar": true, ".war": true, ".exe": true, ".dll": true, ".so": true, ".dylib": true,
+	".bin": true, ".dat": true, ".iso": true, ".dmg": true,
+	".pt": true, ".pth": true, ".onnx": true, ".h5": true, ".ckpt": true, ".safetensors": true, ".pkl": true,
+	".mp4": true, ".mov": true, ".avi": true, ".mkv": true, ".mp3": true, ".wav": true,
+	".pdf": true,
+}
+
+// LargeFile is a checked-in file above the size threshold
+type LargeFile struct {
+	Path   string `json:"path"`
+	Bytes  int64  `json:"bytes"`
+	Binary bool   `json:"binary"` // an archive, binary, model or media format
+}
+
+// RepoSize reports how big a repository's files are and what bloats it
+type RepoSize struct {
+	TotalBytes int64 `json:"total_bytes"`
+	// ThresholdBytes is the size above which files are LargeFiles
+	ThresholdBytes int64       `json:"threshold_bytes"`
+	LargeFiles     []LargeFile `json:"large_files"` // largest first
+	LargeBytes     int64       `json:"large_bytes"`
+	// LFS is set when .gitattributes routes files through Git LFS
+	LFS bool `json:"lfs"`
+	// LFSPointers counts LFS-tracked files stored as pointers, which
+	// aren't bloat however large the real file is
+	LFSPointers int `json:"lfs_pointers"`
+}
+
+// AnalyzeRepoSize flags files over thresholdMB (DefaultLargeFileMB when 0).
+// Files matching an LFS pattern are pointers, so they're counted apart
+// rather than flagged.
+func AnalyzeRepoSize(tree []github.TreeEntry, lfsPatterns []string, thresholdMB int) RepoSize {
+	if thresholdMB <= 0 {
+		thresholdMB = DefaultLargeFileMB
+	}
+	size := RepoSize{ThresholdBytes: int64(thresholdMB) << 20, LFS: len(lfsPatterns) > 0}
+	for _, entry := range tree {
+		if entry.Type != "blob" {
+			continue
+		}
+		bytes := int64(entry.Size)
+		size.TotalBytes += bytes
+		if size.LFS && bytes <= lfsPointerMaxBytes && matchesAttributePattern(lfsPatterns, entry.Path) {
+			size.LFSPointers++
+			continue
+		}
+		if bytes > size.ThresholdBytes {
+			size.LargeFiles = append(size.LargeFiles, LargeFile{
+				Path:   entry.Path,
+				Bytes:  bytes,
+				Binary: binaryExtensions[fileExtension(entry.Path)],
+			})
+			size.LargeBytes += bytes
+		}
+	}
+	sort.Slice(size.LargeFiles, func(i, j int) bool { return size.LargeFiles[i].Bytes > size.LargeFiles[j].Bytes })
+	return size
+}
+
+// Bloated reports whether any large files are checked in
+func (s RepoSize) Bloated() bool {
+	return len(s.LargeFiles) > 0
+}
+
+// Summary renders the size facts for display, e.g.
+// "212.4 MB total, 3 files over 5 MB (180.0 MB), Git LFS configured"
+func (s RepoSize) Summary() string {
+	summary := FormatBytes(s.TotalBytes) + " total"
+	if s.Bloated() {
+		summary += fmt.Sprintf(", %d file(s) over %s (%s)", len(s.LargeFiles), FormatBytes(s.ThresholdBytes), FormatBytes(s.LargeBytes))
+	} else {
+		summary += ", no files over " + FormatBytes(s.ThresholdBytes)
+	}
+	if s.LFS {
+		summary += fmt.Sprintf(", Git LFS configured (%d pointer files)", s.LFSPointers)
+	}
+	return summary
+}
@@ -0,0 +1,83 @@
+package analyzer
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/agnivo988/Repo-lyzer/internal/github"
+)
+
+// StaleBranchMonths is how long a branch goes without commits to be stale
+const StaleBranchMonths = 6
+
+// BranchStats counts a repository's branches and the stale ones
+type BranchStats struct {
+	Evaluated bool `json:"evaluated"`
+	Total     int  `json:"total"`
+	// TotalIsLowerBound is set when the listing was capped without a total
+	TotalIsLowerBound bool `json:"total_is_lower_bound"`
+	// Stale counts branches without a commit in StaleBranchMonths, -1 when
+	// no dates were known
+	Stale int `json:"stale"`
+	// Dated counts the branches whose head commit date was known. When
+	// it's fewer than Total, Stale is extrapolated from them.
+	Dated int `json:"dated"`
+}
+
+// AnalyzeBranches counts the stale branches of a listing, scaling the
+// share stale among dated branches up to the total when not all are dated
+func AnalyzeBranches(list *github.BranchList, now time.Time) BranchStats {
+	if list == nil {
+		return BranchStats{}
+	}
+	stats := BranchStats{
+		Evaluated:         true,
+		Total:             list.TotalCount,
+		TotalIsLowerBound: list.Truncated && list.TotalCount <= len(list.Branches),
+		Stale:             -1,
+	}
+	cutoff := now.AddDate(0, -StaleBranchMonths, 0)
+	stale := 0
+	for _, b := range list.Branches {
+		if b.CommittedAt.IsZero() {
+			continue
+		}
+		stats.Dated++
+		if b.CommittedAt.Before(cutoff) {
+			stale++
+		}
+	}
+	switch {
+	case stats.Dated == stats.Total:
+		stats.Stale = stale
+	case stats.Dated > 0:
+		stats.Stale = int(math.Round(float64(stale) / float64(stats.Dated) * float64(stats.Total)))
+	}
+	return stats
+}
+
+// Estimated reports whether Stale was extrapolated from a sample
+func (b BranchStats) Estimated() bool {
+	return b.Stale >= 0 && b.Dated < b.Total
+}
+
+// Summary renders the counts, e.g. "214 (178 stale >6mo)" or
+// "1,000+ (~640 stale >6mo, from 30 sampled)"
+func (b BranchStats) Summary() string {
+	if !b.Evaluated {
+		return "not evaluated"
+	}
+	total := formatThousands(b.Total)
+	if b.TotalIsLowerBound {
+		total += "+"
+	}
+	switch {
+	case b.Stale < 0:
+		return total
+	case b.Estimated():
+		return fmt.Sprintf("%s (~%s stale >%dmo, from %d sampled)", total, formatThousands(b.Stale), StaleBranchMonths, b.Dated)
+	default:
+		return fmt.Sprintf("%s (%s stale >%dmo)", total, formatThousands(b.Stale), StaleBranchMonths)
+	}
+}
@@ -1,26 +1,136 @@
 package analyzer
 
-import "github.com/agnivo988/Repo-lyzer/internal/github"
+import (
+	"fmt"
+	"sort"
+	"strings"
 
+	"github.com/agnivo988/Repo-lyzer/internal/github"
+)
+
+// ClassicBusFactor is the threshold that keeps the original classification,
+// from the top contributor's share of commits: over 70% is a bus factor of
+// 1, over 40% is 2, anything less is 3.
+const ClassicBusFactor = 0
+
+// DefaultBusFactorThreshold is the share of commits, in percent, the bus
+// factor's contributors must cover. It defaults to ClassicBusFactor so
+// results don't change; 50% is also known as the pony factor.
+const DefaultBusFactorThreshold = ClassicBusFactor
+
+// busFactorFigures are always computed alongside the configured threshold
+var busFactorFigures = []int{50, 80}
+
+// BusFactorInfo is how many people cover a share of the commits
+type BusFactorInfo struct {
+	Threshold int         `json:"threshold"` // percent of commits the primary figure covers, or ClassicBusFactor
+	Factor    int         `json:"factor"`    // at Threshold
+	TopShare  float64     `json:"top_share"` // the top contributor's share of commits, 0-1
+	Risk      string      `json:"risk"`      // keyed off Factor
+	ByShare   map[int]int `json:"by_share"`  // percent of commits -> people
+	// LowerBound is set when the contributor list was truncated, so the
+	// true figures may be higher
+	LowerBound bool `json:"lower_bound"`
+}
+
+// BusFactor computes the bus factor at the default threshold
 func BusFactor(contributors []github.Contributor) (int, string) {
-	if len(contributors) == 0 {
-		return 0, "Unknown"
+	info := AnalyzeBusFactor(contributors, DefaultBusFactorThreshold, false)
+	return info.Factor, info.Risk
+}
+
+// AnalyzeBusFactor counts the fewest contributors covering threshold percent
+// of commits, or classifies the top contributor's share for
+// ClassicBusFactor, plus the 50% and 80% figures. Bots are left out.
+func AnalyzeBusFactor(contributors []github.Contributor, threshold int, truncated bool) BusFactorInfo {
+	var commits []int
+	for _, c := range contributors {
+		if !c.IsBot() && c.Commits > 0 {
+			commits = append(commits, c.Commits)
+		}
 	}
+	sort.Sort(sort.Reverse(sort.IntSlice(commits)))
 
+	info := BusFactorInfo{
+		Threshold:  threshold,
+		ByShare:    make(map[int]int),
+		LowerBound: truncated,
+	}
+	for _, share := range busFactorFigures {
+		info.ByShare[share] = coveringContributors(commits, share)
+	}
 	total := 0
-	for _, c := range contributors {
-		total += c.Commits
+	for _, n := range commits {
+		total += n
+	}
+	if total > 0 {
+		info.TopShare = float64(commits[0]) / float64(total)
 	}
 
-	top := contributors[0].Commits
-	ratio := float64(top) / float64(total)
+	switch {
+	case total == 0:
+	case threshold != ClassicBusFactor:
+		info.Factor = coveringContributors(commits, threshold)
+	case info.TopShare > 0.7:
+		info.Factor = 1
+	case info.TopShare > 0.4:
+		info.Factor = 2
+	default:
+		info.Factor = 3
+	}
 
 	switch {
-	case ratio > 0.7:
-		return 1, "High Risk"
-	case ratio > 0.4:
-		return 2, "Medium Risk"
+	case info.Factor == 0:
+		info.Risk = "Unknown"
+	case info.Factor == 1:
+		info.Risk = "High Risk"
+	case info.Factor == 2:
+		info.Risk = "Medium Risk"
 	default:
-		return 3, "Low Risk"
+		info.Risk = "Low Risk"
+	}
+	return info
+}
+
+// coveringContributors counts how many of the sorted commit counts it takes
+// to reach share percent of the total
+func coveringContributors(commits []int, share int) int {
+	total := 0
+	for _, n := range commits {
+		total += n
+	}
+
+	covered := 0
+	for i, n := range commits {
+		covered += n
+		if covered*100 >= total*share {
+			return i + 1
+		}
+	}
+	return len(commits)
+}
+
+// Label renders the figures, e.g. "3 (50% of commits), 9 (80%)", or
+// "2 (top contributor 55% of commits), 3 (50%), 9 (80%)" for the classic
+// classification
+func (b BusFactorInfo) Label() string {
+	if b.Factor == 0 {
+		return "unknown"
+	}
+	prefix := ""
+	if b.LowerBound {
+		prefix = "≥"
+	}
+
+	primary := fmt.Sprintf("%s%d (%d%% of commits)", prefix, b.Factor, b.Threshold)
+	if b.Threshold == ClassicBusFactor {
+		primary = fmt.Sprintf("%d (top contributor %.0f%% of commits)", b.Factor, b.TopShare*100)
+	}
+	parts := []string{primary}
+	for _, share := range busFactorFigures {
+		if share != b.Threshold {
+			parts = append(parts, fmt.Sprintf("%s%d (%d%%)", prefix, b.ByShare[share], share))
+		}
 	}
+	return strings.Join(parts, ", ")
 }
@@ -0,0 +1,102 @@
+package analyzer
+
+import (
+	"testing"
+
+	"github.com/agnivo988/Repo-lyzer/internal/github"
+)
+
+// people builds contributors with the given commit counts, most first
+func people(commits ...int) []github.Contributor {
+	contributors := make([]github.Contributor, len(commits))
+	for i, n := range commits {
+		contributors[i] = github.Contributor{Login: string(rune('a' + i)), Commits: n}
+	}
+	return contributors
+}
+
+func TestAnalyzeBusFactor(t *testing.T) {
+	bot := github.Contributor{Login: "dependabot[bot]", Commits: 1000}
+	tests := []struct {
+		name         string
+		contributors []github.Contributor
+		threshold    int
+		truncated    bool
+		factor       int
+		risk         string
+		by50, by80   int
+	}{
+		{"no contributors", nil, ClassicBusFactor, false, 0, "Unknown", 0, 0},
+		{"single contributor", people(42), ClassicBusFactor, false, 1, "High Risk", 1, 1},
+		{"single contributor at 50%", people(42), 50, false, 1, "High Risk", 1, 1},
+		// A single maintainer with 100% of commits is a bus factor of 1 at any threshold
+		{"single contributor at 80%", people(42), 80, false, 1, "High Risk", 1, 1},
+		{"single contributor at 100%", people(42), 100, false, 1, "High Risk", 1, 1},
+		{"single human beside a bot", append(people(42), bot), 80, false, 1, "High Risk", 1, 1},
+		// A top contributor with exactly the threshold share covers it alone
+		{"top share exactly 50%", people(50, 30, 20), 50, false, 1, "High Risk", 1, 2},
+		{"top share exactly 80%", people(80, 15, 5), 80, false, 1, "High Risk", 1, 1},
+		{"top share just below 80%", people(79, 16, 5), 80, false, 2, "Medium Risk", 1, 2},
+		// The classic classification from the top contributor's share
+		{"classic over 70%", people(80, 10, 10), ClassicBusFactor, false, 1, "High Risk", 1, 1},
+		{"classic over 40%", people(50, 30, 20), ClassicBusFactor, false, 2, "Medium Risk", 1, 2},
+		{"classic 40% exactly", people(40, 30, 30), ClassicBusFactor, false, 3, "Low Risk", 2, 3},
+		{"classic spread out", people(10, 10, 10, 10, 10, 10, 10, 10, 10, 10), ClassicBusFactor, false, 3, "Low Risk", 5, 8},
+		{"pony factor", people(30, 30, 20, 10, 10), 50, false, 2, "Medium Risk", 2, 3},
+		{"80% coverage", people(30, 30, 20, 10, 10), 80, false, 3, "Low Risk", 2, 3},
+		{"bots excluded", append([]github.Contributor{bot}, people(50, 30, 20)...), ClassicBusFactor, false, 2, "Medium Risk", 1, 2},
+		{"bot type excluded", append(people(50, 50), github.Contributor{Login: "ci", Type: "Bot", Commits: 900}), 50, false, 1, "High Risk", 1, 2},
+		{"only bots", []github.Contributor{bot}, 50, false, 0, "Unknown", 0, 0},
+		{"truncated", people(30, 30, 20, 10, 10), 50, true, 2, "Medium Risk", 2, 3},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			info := AnalyzeBusFactor(tt.contributors, tt.threshold, tt.truncated)
+			if info.Factor != tt.factor || info.Risk != tt.risk {
+				t.Errorf("factor, risk = %d, %q; want %d, %q", info.Factor, info.Risk, tt.factor, tt.risk)
+			}
+			if info.ByShare[50] != tt.by50 || info.ByShare[80] != tt.by80 {
+				t.Errorf("50%%, 80%% figures = %d, %d; want %d, %d", info.ByShare[50], info.ByShare[80], tt.by50, tt.by80)
+			}
+			if info.LowerBound != tt.truncated {
+				t.Errorf("LowerBound = %t, want %t", info.LowerBound, tt.truncated)
+			}
+		})
+	}
+}
+
+func TestBusFactorMatchesClassicDefault(t *testing.T) {
+	// BusFactor keeps the original top-share classification
+	for _, tt := range []struct {
+		contributors []github.Contributor
+		factor       int
+		risk         string
+	}{
+		{people(71, 29), 1, "High Risk"},
+		{people(70, 30), 2, "Medium Risk"},
+		{people(41, 59), 2, "Medium Risk"},
+		{people(40, 35, 25), 3, "Low Risk"},
+	} {
+		factor, risk := BusFactor(tt.contributors)
+		if factor != tt.factor || risk != tt.risk {
+			t.Errorf("BusFactor(%v) = %d, %q; want %d, %q", tt.contributors, factor, risk, tt.factor, tt.risk)
+		}
+	}
+}
+
+func TestBusFactorLabel(t *testing.T) {
+	tests := []struct {
+		info BusFactorInfo
+		want string
+	}{
+		{AnalyzeBusFactor(nil, 50, false), "unknown"},
+		{AnalyzeBusFactor(people(30, 30, 20, 10, 10), 50, false), "2 (50% of commits), 3 (80%)"},
+		{AnalyzeBusFactor(people(30, 30, 20, 10, 10), 80, true), "≥3 (80% of commits), ≥2 (50%)"},
+		{AnalyzeBusFactor(people(55, 25, 20), ClassicBusFactor, false), "2 (top contributor 55% of commits), 1 (50%), 2 (80%)"},
+	}
+	for _, tt := range tests {
+		if got := tt.info.Label(); got != tt.want {
+			t.Errorf("Label() = %q, want %q", got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,115 @@
+package analyzer
+
+import (
+	"fmt"
+	"path"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/agnivo988/Repo-lyzer/internal/github"
+)
+
+// MaxChangelogBytes is how much of the top of a changelog is parsed; the
+// latest entry is near the top, and some changelogs run to megabytes
+const MaxChangelogBytes = 64 << 10
+
+var (
+	// changelogHeading matches a Markdown heading, "## [1.2.3] - 2024-06-01"
+	// or "# v1.2.3"
+	changelogHeading = regexp.MustCompile(`^#{1,4}\s+(.+?)\s*#*$`)
+	// changelogVersion finds the version in a heading
+	changelogVersion = regexp.MustCompile(`\bv?(\d+\.\d+(?:\.\d+)?(?:-[0-9A-Za-z.-]+)?)\b`)
+	// changelogDate finds an ISO date in a heading
+	changelogDate = regexp.MustCompile(`\b(\d{4}-\d{2}-\d{2})\b`)
+)
+
+// ChangelogInfo is the changelog found in the tree and its latest entry
+type ChangelogInfo struct {
+	Path string `json:"path,omitempty"`
+	// LatestHeading is the heading of the newest versioned entry, skipping
+	// an "Unreleased" section
+	LatestHeading string    `json:"latest_heading,omitempty"`
+	LatestVersion string    `json:"latest_version,omitempty"`
+	LatestDate    time.Time `json:"latest_date"` // zero when undated
+	// Behind is set when the latest release or tag is a newer version than
+	// the changelog's latest entry
+	Behind        bool   `json:"behind"`
+	LatestRelease string `json:"latest_release,omitempty"`
+}
+
+// FindChangelog returns the path of a changelog at the root or in docs/,
+// or "" if there's none
+func FindChangelog(tree []github.TreeEntry) string {
+	for _, entry := range tree {
+		dir, name := path.Split(entry.Path)
+		if entry.Type != "blob" || dir != "" && dir != "docs/" {
+			continue
+		}
+		name = strings.ToLower(strings.TrimSuffix(name, path.Ext(name)))
+		if slices.Contains(changelogNames, name) {
+			return entry.Path
+		}
+	}
+	return ""
+}
+
+// ParseChangelog finds the latest versioned entry in the first
+// MaxChangelogBytes of a changelog. Entries are taken to be newest first,
+// as in Keep a Changelog.
+func ParseChangelog(filePath, text string) ChangelogInfo {
+	info := ChangelogInfo{Path: filePath}
+	if len(text) > MaxChangelogBytes {
+		text = text[:MaxChangelogBytes]
+	}
+	for _, line := range strings.Split(text, "\n") {
+		m := changelogHeading.FindStringSubmatch(strings.TrimSpace(line))
+		if m == nil {
+			continue
+		}
+		version := changelogVersion.FindStringSubmatch(m[1])
+		if version == nil {
+			continue
+		}
+		info.LatestHeading = m[1]
+		info.LatestVersion = version[1]
+		if date := changelogDate.FindString(m[1]); date != "" {
+			info.LatestDate, _ = time.Parse("2006-01-02", date)
+		}
+		break
+	}
+	return info
+}
+
+// CompareRelease records whether the latest release or tag is a newer
+// version than the changelog's latest entry. Tags that aren't versions,
+// and changelogs without one, aren't compared.
+func (c *ChangelogInfo) CompareRelease(latestTag string) {
+	c.LatestRelease = latestTag
+	release, ok := parseVersion(latestTag)
+	if !ok || c.LatestVersion == "" {
+		return
+	}
+	entry, ok := parseVersion(c.LatestVersion)
+	c.Behind = ok && versionLess(entry, release)
+}
+
+// Summary renders the changelog's latest entry, e.g. "CHANGELOG.md, latest
+// 1.2.3 (2024-06-01); changelog behind latest release v1.3.0"
+func (c ChangelogInfo) Summary() string {
+	if c.Path == "" {
+		return "none"
+	}
+	if c.LatestVersion == "" {
+		return c.Path + ", no versioned entries found"
+	}
+	summary := fmt.Sprintf("%s, latest %s", c.Path, c.LatestVersion)
+	if !c.LatestDate.IsZero() {
+		summary += " (" + c.LatestDate.Format("2006-01-02") + ")"
+	}
+	if c.Behind {
+		summary += "; changelog behind latest release " + c.LatestRelease
+	}
+	return summary
+}
@@ -0,0 +1,115 @@
+package analyzer
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/agnivo988/Repo-lyzer/internal/github"
+)
+
+// Codebase directions by churn ratio
+const (
+	ChurnGrowing     = "growing"
+	ChurnRefactoring = "being refactored"
+	ChurnShrinking   = "shrinking"
+)
+
+// ChurnStats measures how many lines change, over the last year and per commit
+type ChurnStats struct {
+	Evaluated bool `json:"evaluated"`
+	Additions int  `json:"additions"` // lines added over the last 52 weeks
+	Deletions int  `json:"deletions"`
+	// WeeklyAdditions and WeeklyDeletions are by week, oldest first;
+	// empty when the weekly stats weren't available
+	WeeklyAdditions []int `json:"weekly_additions,omitempty"`
+	WeeklyDeletions []int `json:"weekly_deletions,omitempty"`
+	// Ratio is deletions over additions, 0 when nothing was added
+	Ratio float64 `json:"ratio"`
+	// AverageLinesPerCommit is the lines changed per commit, over the
+	// sampled commits when there are some, else over the year's commits
+	AverageLinesPerCommit float64 `json:"average_lines_per_commit"`
+	// MedianLinesPerCommit is over the sampled commits, -1 without a sample
+	MedianLinesPerCommit float64 `json:"median_lines_per_commit"`
+	SampledCommits       int     `json:"sampled_commits"`
+}
+
+// AnalyzeChurn totals the last 52 weeks of the code_frequency stats, and
+// takes lines per commit from the sample of commits fetched with their
+// stats. Without a sample the average divides the year's lines by the
+// year's commits, from weekly; without weekly stats the totals come from
+// the sample.
+func AnalyzeChurn(weeks []github.CodeFrequencyWeek, weekly WeeklyActivity, sample []github.Commit, now time.Time) ChurnStats {
+	churn := ChurnStats{MedianLinesPerCommit: -1}
+	yearAgo := now.AddDate(0, 0, -52*7)
+	for _, w := range weeks {
+		if w.Start.Before(yearAgo) {
+			continue
+		}
+		churn.Evaluated = true
+		churn.Additions += w.Additions
+		churn.Deletions += w.Deletions
+		churn.WeeklyAdditions = append(churn.WeeklyAdditions, w.Additions)
+		churn.WeeklyDeletions = append(churn.WeeklyDeletions, w.Deletions)
+	}
+
+	var perCommit []float64
+	additions, deletions := 0, 0
+	for _, c := range sample {
+		if c.Stats == nil || c.IsMerge() {
+			continue // merges repeat the lines of the commits they bring in
+		}
+		additions += c.Stats.Additions
+		deletions += c.Stats.Deletions
+		perCommit = append(perCommit, float64(c.Stats.Additions+c.Stats.Deletions))
+	}
+	if len(perCommit) > 0 {
+		churn.SampledCommits = len(perCommit)
+		churn.AverageLinesPerCommit = float64(additions+deletions) / float64(len(perCommit))
+		churn.MedianLinesPerCommit = median(perCommit)
+		if !churn.Evaluated {
+			churn.Evaluated = true
+			churn.Additions, churn.Deletions = additions, deletions
+		}
+	} else if commits := weekly.Total(); churn.Evaluated && commits > 0 {
+		churn.AverageLinesPerCommit = float64(churn.Additions+churn.Deletions) / float64(commits)
+	}
+
+	if churn.Additions > 0 {
+		churn.Ratio = float64(churn.Deletions) / float64(churn.Additions)
+	}
+	return churn
+}
+
+// Direction describes the codebase from the churn ratio: growing while
+// under half as much is deleted as added, shrinking once more is deleted
+func (c ChurnStats) Direction() string {
+	switch {
+	case c.Additions == 0 && c.Deletions == 0:
+		return ""
+	case c.Ratio > 1 || c.Additions == 0:
+		return ChurnShrinking
+	case c.Ratio >= 0.5:
+		return ChurnRefactoring
+	default:
+		return ChurnGrowing
+	}
+}
+
+// Summary renders the churn facts, e.g.
+// "+12,400 / -8,100 lines (ratio 0.65, being refactored), ~85 lines per commit"
+func (c ChurnStats) Summary() string {
+	if !c.Evaluated {
+		return "not evaluated (no line statistics)"
+	}
+	summary := fmt.Sprintf("+%s / -%s lines", formatThousands(c.Additions), formatThousands(c.Deletions))
+	if direction := c.Direction(); direction != "" {
+		summary += fmt.Sprintf(" (ratio %.2f, %s)", c.Ratio, direction)
+	}
+	if c.AverageLinesPerCommit > 0 {
+		summary += fmt.Sprintf(", ~%.0f lines per commit", c.AverageLinesPerCommit)
+	}
+	if c.MedianLinesPerCommit >= 0 {
+		summary += fmt.Sprintf(" (median %.0f over %d commits)", c.MedianLinesPerCommit, c.SampledCommits)
+	}
+	return summary
+}
@@ -0,0 +1,216 @@
+package analyzer
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/agnivo988/Repo-lyzer/internal/github"
+)
+
+// ciRule recognises one CI provider's configuration in a repository tree
+type ciRule struct {
+	Provider string
+	// Path is a file, or a directory when it ends in "/", in which case
+	// any file below it counts
+	Path string
+	// Extensions, when set, limit the files matched under a directory
+	Extensions []string
+	// LikelyDead marks providers that most open source projects have
+	// stopped running, so their config is often left behind
+	LikelyDead bool
+}
+
+// ciRules lists the CI providers detected from the tree; add a line here to
+// recognise another
+var ciRules = []ciRule{
+	{Provider: GitHubActions, Path: ".github/workflows/", Extensions: []string{".yml", ".yaml"}},
+	{Provider: "GitLab CI", Path: ".gitlab-ci.yml"},
+	{Provider: "CircleCI", Path: ".circleci/"},
+	{Provider: "Jenkins", Path: "Jenkinsfile"},
+	{Provider: "Drone", Path: ".drone.yml"},
+	{Provider: "Azure Pipelines", Path: "azure-pipelines.yml"},
+	// travis-ci.org shut down in 2021 and travis-ci.com dropped its free tier
+	{Provider: "Travis CI", Path: ".travis.yml", LikelyDead: true},
+}
+
+// matches reports whether a file path belongs to the rule's provider
+func (r ciRule) matches(file string) bool {
+	dir, isDir := strings.CutSuffix(r.Path, "/")
+	if !isDir {
+		return file == r.Path
+	}
+	if !strings.HasPrefix(file, dir+"/") {
+		return false
+	}
+	if len(r.Extensions) == 0 {
+		return true
+	}
+	for _, ext := range r.Extensions {
+		if strings.HasSuffix(file, ext) {
+			return true
+		}
+	}
+	return false
+}
+
+// GitHubActions is the provider name for .github/workflows
+const GitHubActions = "GitHub Actions"
+
+// CIConfig is the CI configuration found in a repository tree
+type CIConfig struct {
+	Providers []string `json:"providers"`
+	// Files are the configuration files found, workflows included
+	Files []string `json:"files"`
+	// LikelyDead lists the providers whose config probably no longer runs
+	LikelyDead []string `json:"likely_dead"`
+}
+
+// Live reports whether any provider other than a likely-dead one is set up
+func (c CIConfig) Live() bool {
+	return len(c.Providers) > len(c.LikelyDead)
+}
+
+// WorkflowFiles returns the names of the GitHub Actions workflow files
+func (c CIConfig) WorkflowFiles() []string {
+	var workflows []string
+	for _, file := range c.Files {
+		if name, ok := strings.CutPrefix(file, ".github/workflows/"); ok {
+			workflows = append(workflows, name)
+		}
+	}
+	return workflows
+}
+
+// WorkflowStatus is the latest run of one GitHub Actions workflow
+type WorkflowStatus struct {
+	Name       string `json:"name"`
+	Status     string `json:"status"`
+	Conclusion string `json:"conclusion"`
+	URL        string `json:"url"`
+}
+
+// Passing reports whether the run completed successfully
+func (w WorkflowStatus) Passing() bool {
+	return w.Conclusion == "success"
+}
+
+// Failing reports whether the run completed unsuccessfully
+func (w WorkflowStatus) Failing() bool {
+	switch w.Conclusion {
+	case "failure", "timed_out", "startup_failure", "action_required":
+		return true
+	}
+	return false
+}
+
+// Label is the run's conclusion, or its status while still running
+func (w WorkflowStatus) Label() string {
+	if w.Conclusion != "" {
+		return w.Conclusion
+	}
+	return w.Status
+}
+
+// CIInfo describes the CI a repository has configured
+type CIInfo struct {
+	CIConfig
+	// Workflows holds the latest run per GitHub Actions workflow on the
+	// default branch; empty when runs couldn't be read
+	Workflows []WorkflowStatus `json:"workflows"`
+}
+
+// DetectCI finds CI configuration files in a repository tree. Any workflow
+// counts, including ones that only run on a schedule or publish releases.
+func DetectCI(tree []github.TreeEntry) CIInfo {
+	var config CIConfig
+	found := make(map[string]bool)
+	for _, entry := range tree {
+		if entry.Type != "blob" {
+			continue
+		}
+		for _, rule := range ciRules {
+			if !rule.matches(entry.Path) {
+				continue
+			}
+			config.Files = append(config.Files, entry.Path)
+			if !found[rule.Provider] {
+				found[rule.Provider] = true
+				if rule.LikelyDead {
+					config.LikelyDead = append(config.LikelyDead, rule.Provider)
+				}
+			}
+			break
+		}
+	}
+	config.Providers = sortedSet(found)
+	sort.Strings(config.Files)
+	return CIInfo{CIConfig: config}
+}
+
+// LatestWorkflowRuns keeps the newest run of each workflow, given runs
+// ordered newest first as the API returns them
+func LatestWorkflowRuns(runs []github.WorkflowRun) []WorkflowStatus {
+	seen := make(map[int64]bool)
+	var latest []WorkflowStatus
+	for _, run := range runs {
+		if seen[run.WorkflowID] {
+			continue
+		}
+		seen[run.WorkflowID] = true
+		latest = append(latest, WorkflowStatus{
+			Name:       run.Name,
+			Status:     run.Status,
+			Conclusion: run.Conclusion,
+			URL:        run.HTMLURL,
+		})
+	}
+	sort.Slice(latest, func(i, j int) bool { return latest[i].Name < latest[j].Name })
+	return latest
+}
+
+// HasCI reports whether any CI configuration was found
+func (c CIInfo) HasCI() bool {
+	return len(c.Providers) > 0
+}
+
+// Failing returns the number of workflows whose latest run failed
+func (c CIInfo) Failing() int {
+	failing := 0
+	for _, w := range c.Workflows {
+		if w.Failing() {
+			failing++
+		}
+	}
+	return failing
+}
+
+// Summary renders the CI facts for display
+func (c CIInfo) Summary() string {
+	if !c.HasCI() {
+		return "No CI configuration found"
+	}
+	providers := make([]string, len(c.Providers))
+	for i, p := range c.Providers {
+		providers[i] = p
+		if slices.Contains(c.LikelyDead, p) {
+			providers[i] += " (likely dead)"
+		}
+	}
+	summary := strings.Join(providers, ", ")
+	if len(c.Workflows) > 0 {
+		summary += fmt.Sprintf(" (%d/%d workflows passing)", c.passing(), len(c.Workflows))
+	}
+	return summary
+}
+
+func (c CIInfo) passing() int {
+	passing := 0
+	for _, w := range c.Workflows {
+		if w.Passing() {
+			passing++
+		}
+	}
+	return passing
+}
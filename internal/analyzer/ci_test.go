@@ -0,0 +1,75 @@
+package analyzer
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDetectCIProviders(t *testing.T) {
+	tests := []struct {
+		path     string
+		provider string // "" when the path isn't CI configuration
+	}{
+		{".github/workflows/ci.yml", GitHubActions},
+		{".github/workflows/release.yaml", GitHubActions},
+		{".github/workflows/README.md", ""},
+		{".github/dependabot.yml", ""},
+		{".gitlab-ci.yml", "GitLab CI"},
+		{"ci/.gitlab-ci.yml", ""},
+		{".circleci/config.yml", "CircleCI"},
+		{".circleci/scripts/setup.sh", "CircleCI"},
+		{"Jenkinsfile", "Jenkins"},
+		{"jenkins/Jenkinsfile", ""},
+		{".drone.yml", "Drone"},
+		{"azure-pipelines.yml", "Azure Pipelines"},
+		{".travis.yml", "Travis CI"},
+	}
+	covered := make(map[string]bool)
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			ci := DetectCI(blobs(tt.path))
+			var want []string
+			if tt.provider != "" {
+				want = []string{tt.provider}
+				covered[tt.provider] = true
+			}
+			if !reflect.DeepEqual(ci.Providers, want) {
+				t.Errorf("providers = %v, want %v", ci.Providers, want)
+			}
+		})
+	}
+	// Adding a rule needs a case above
+	for _, rule := range ciRules {
+		if !covered[rule.Provider] {
+			t.Errorf("no test case for %s (%s)", rule.Provider, rule.Path)
+		}
+	}
+}
+
+func TestDetectCI(t *testing.T) {
+	ci := DetectCI(blobs(
+		".github/workflows/test.yml",
+		// Scheduled and release-only workflows count as CI too
+		".github/workflows/nightly.yml",
+		".github/workflows/publish.yaml",
+		".travis.yml",
+		"main.go",
+	))
+	if want := []string{GitHubActions, "Travis CI"}; !reflect.DeepEqual(ci.Providers, want) {
+		t.Errorf("providers = %v, want %v", ci.Providers, want)
+	}
+	if want := []string{"nightly.yml", "publish.yaml", "test.yml"}; !reflect.DeepEqual(ci.WorkflowFiles(), want) {
+		t.Errorf("workflow files = %v, want %v", ci.WorkflowFiles(), want)
+	}
+	if !reflect.DeepEqual(ci.LikelyDead, []string{"Travis CI"}) || !ci.Live() {
+		t.Errorf("likely dead = %v, live = %t; want Travis CI and live", ci.LikelyDead, ci.Live())
+	}
+
+	travis := DetectCI(blobs(".travis.yml"))
+	if !travis.HasCI() || travis.Live() {
+		t.Errorf("Travis alone: HasCI = %t, Live = %t; want true, false", travis.HasCI(), travis.Live())
+	}
+	if none := DetectCI(blobs("main.go")); none.HasCI() || none.Summary() != "No CI configuration found" {
+		t.Errorf("no CI: HasCI = %t, Summary() = %q", none.HasCI(), none.Summary())
+	}
+}
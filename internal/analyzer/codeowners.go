@@ -0,0 +1,178 @@
+package analyzer
+
+import (
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/agnivo988/Repo-lyzer/internal/github"
+)
+
+// CodeOwnersPaths are the locations GitHub reads CODEOWNERS from, in order
+var CodeOwnersPaths = []string{".github/CODEOWNERS", "CODEOWNERS", "docs/CODEOWNERS"}
+
+// CodeOwnersRule is one pattern → owners line of a CODEOWNERS file
+type CodeOwnersRule struct {
+	Pattern string
+	Owners  []string // @user, @org/team or email
+}
+
+// OwnershipInfo summarises a repo's CODEOWNERS file
+type OwnershipInfo struct {
+	Path           string   `json:"path"` // "" when the repo has no CODEOWNERS
+	Rules          int      `json:"rules"`
+	Owners         []string `json:"owners"`    // distinct users and emails
+	Teams          []string `json:"teams"`     // distinct @org/team handles
+	CatchAll       bool     `json:"catch_all"` // a "*" rule covers everything
+	TopLevelDirs   int      `json:"top_level_dirs"`
+	CoveredDirs    int      `json:"covered_dirs"`    // top-level directories matched by some rule
+	InactiveOwners []string `json:"inactive_owners"` // user owners without commits in the analyzed window
+}
+
+// FindCodeOwners returns the path of the CODEOWNERS file GitHub would use,
+// or "" if the tree has none
+func FindCodeOwners(tree []github.TreeEntry) string {
+	paths := make(map[string]bool, len(tree))
+	for _, entry := range tree {
+		if entry.Type == "blob" {
+			paths[entry.Path] = true
+		}
+	}
+	for _, p := range CodeOwnersPaths {
+		if paths[p] {
+			return p
+		}
+	}
+	return ""
+}
+
+// ParseCodeOwners parses CODEOWNERS text. Comments, blank lines and lines
+// without a valid owner are skipped.
+func ParseCodeOwners(text string) []CodeOwnersRule {
+	var rules []CodeOwnersRule
+	for _, line := range strings.Split(text, "\n") {
+		if i := strings.Index(line, "#"); i >= 0 {
+			line = line[:i]
+		}
+		fields := strings.Fields(line)
+		if len(fields) < 2 {
+			continue
+		}
+
+		var owners []string
+		for _, owner := range fields[1:] {
+			if strings.HasPrefix(owner, "@") && len(owner) > 1 || strings.Contains(owner, "@") {
+				owners = append(owners, owner)
+			}
+		}
+		if len(owners) == 0 {
+			continue
+		}
+		rules = append(rules, CodeOwnersRule{Pattern: fields[0], Owners: owners})
+	}
+	return rules
+}
+
+// AnalyzeOwnership computes coverage of the tree's top-level directories and
+// cross-references user owners against recent commit authors
+func AnalyzeOwnership(filePath string, rules []CodeOwnersRule, tree []github.TreeEntry, commits []github.Commit) OwnershipInfo {
+	info := OwnershipInfo{Path: filePath, Rules: len(rules)}
+
+	owners := make(map[string]bool)
+	teams := make(map[string]bool)
+	for _, rule := range rules {
+		if rule.Pattern == "*" || rule.Pattern == "/*" || rule.Pattern == "**" {
+			info.CatchAll = true
+		}
+		for _, owner := range rule.Owners {
+			if strings.HasPrefix(owner, "@") && strings.Contains(owner, "/") {
+				teams[owner] = true
+			} else {
+				owners[owner] = true
+			}
+		}
+	}
+	info.Owners = sortedSet(owners)
+	info.Teams = sortedSet(teams)
+
+	for _, entry := range tree {
+		if entry.Type != "tree" || strings.Contains(entry.Path, "/") {
+			continue
+		}
+		info.TopLevelDirs++
+		if info.CatchAll || coversDir(rules, entry.Path) {
+			info.CoveredDirs++
+		}
+	}
+
+	recent := make(map[string]bool)
+	for _, c := range commits {
+		if login := c.AuthorLogin(); login != "" {
+			recent[strings.ToLower(login)] = true
+		}
+	}
+	for _, owner := range info.Owners {
+		if strings.HasPrefix(owner, "@") && !recent[strings.ToLower(owner[1:])] {
+			info.InactiveOwners = append(info.InactiveOwners, owner)
+		}
+	}
+	return info
+}
+
+// coversDir reports whether a rule matches dir or paths inside it. File
+// globs like "*.go" don't count as owning a directory.
+func coversDir(rules []CodeOwnersRule, dir string) bool {
+	for _, rule := range rules {
+		pattern := strings.TrimSuffix(rule.Pattern, "/**")
+		pattern = strings.TrimSuffix(pattern, "/*")
+		anchored := strings.HasPrefix(pattern, "/")
+		pattern = strings.Trim(pattern, "/")
+
+		first := pattern
+		if i := strings.Index(pattern, "/"); i >= 0 {
+			first = pattern[:i]
+			anchored = true // patterns with a slash are relative to the root
+		} else if !strings.HasSuffix(rule.Pattern, "/") && strings.Contains(pattern, ".") {
+			continue // a file glob
+		}
+
+		if matched, _ := path.Match(first, dir); matched && (anchored || first == pattern) {
+			return true
+		}
+	}
+	return false
+}
+
+// Coverage returns the percentage of top-level directories with an owner
+func (o OwnershipInfo) Coverage() float64 {
+	if o.TopLevelDirs == 0 {
+		if o.CatchAll {
+			return 100
+		}
+		return 0
+	}
+	return float64(o.CoveredDirs) * 100 / float64(o.TopLevelDirs)
+}
+
+// Summary renders the ownership facts for display
+func (o OwnershipInfo) Summary() string {
+	if o.Path == "" {
+		return "No CODEOWNERS file"
+	}
+	summary := fmt.Sprintf("%d owner(s), %d team(s), %.0f%% of top-level directories covered",
+		len(o.Owners), len(o.Teams), o.Coverage())
+	if o.CatchAll {
+		summary += ", has a catch-all rule"
+	}
+	return summary
+}
+
+func sortedSet(set map[string]bool) []string {
+	var items []string
+	for item := range set {
+		items = append(items, item)
+	}
+	sort.Strings(items)
+	return items
+}
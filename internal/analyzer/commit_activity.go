@@ -1,6 +1,8 @@
 package analyzer
 
 import (
+	"time"
+
 	"github.com/agnivo988/Repo-lyzer/internal/github"
 )
 
@@ -14,3 +16,90 @@ func CommitsPerDay(commits []github.Commit) map[string]int {
 
 	return result
 }
+
+// WeekCount is the number of commits in the week starting at Start
+type WeekCount struct {
+	Start   time.Time `json:"start"`
+	Commits int       `json:"commits"`
+}
+
+// WeeklyActivity is a year of commit counts by week, oldest first
+type WeeklyActivity struct {
+	Weeks     []WeekCount `json:"weeks"`
+	FromStats bool        `json:"from_stats"` // true when taken from GitHub's stats endpoint rather than the commit list
+}
+
+// WeeklyActivityFromStats converts the stats/commit_activity histogram
+func WeeklyActivityFromStats(weeks []github.CommitActivityWeek) WeeklyActivity {
+	activity := WeeklyActivity{FromStats: true}
+	for _, w := range weeks {
+		activity.Weeks = append(activity.Weeks, WeekCount{Start: w.Start(), Commits: w.Total})
+	}
+	return activity
+}
+
+// WeeklyActivityFromCommits buckets a commit list into the last 52 weeks,
+// for when the stats endpoint is unavailable. It undercounts if the commit
+// list was capped.
+func WeeklyActivityFromCommits(commits []github.Commit, now time.Time) WeeklyActivity {
+	// Weeks start on Sunday, as in GitHub's stats
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	thisWeek := today.AddDate(0, 0, -int(today.Weekday()))
+	first := thisWeek.AddDate(0, 0, -7*51)
+
+	var activity WeeklyActivity
+	for i := 0; i < 52; i++ {
+		activity.Weeks = append(activity.Weeks, WeekCount{Start: first.AddDate(0, 0, 7*i)})
+	}
+	for _, c := range commits {
+		date := c.Commit.Author.Date.UTC()
+		if date.Before(first) {
+			continue // the division below would truncate these into week 0
+		}
+		week := int(date.Sub(first).Hours() / (24 * 7))
+		if week < len(activity.Weeks) {
+			activity.Weeks[week].Commits++
+		}
+	}
+	return activity
+}
+
+// Total returns the number of commits over all weeks
+func (w WeeklyActivity) Total() int {
+	total := 0
+	for _, week := range w.Weeks {
+		total += week.Commits
+	}
+	return total
+}
+
+// Counts returns the weekly commit counts, oldest first
+func (w WeeklyActivity) Counts() []int {
+	counts := make([]int, len(w.Weeks))
+	for i, week := range w.Weeks {
+		counts[i] = week.Commits
+	}
+	return counts
+}
+
+// Peak returns the most commits in any one week
+func (w WeeklyActivity) Peak() int {
+	peak := 0
+	for _, week := range w.Weeks {
+		if week.Commits > peak {
+			peak = week.Commits
+		}
+	}
+	return peak
+}
+
+// ActiveWeeks returns how many weeks had at least one commit
+func (w WeeklyActivity) ActiveWeeks() int {
+	active := 0
+	for _, week := range w.Weeks {
+		if week.Commits > 0 {
+			active++
+		}
+	}
+	return active
+}
@@ -0,0 +1,95 @@
+package analyzer
+
+import (
+	"testing"
+	"time"
+
+	"github.com/agnivo988/Repo-lyzer/internal/github"
+)
+
+func committedAt(date time.Time) github.Commit {
+	var c github.Commit
+	c.Commit.Author.Date = date
+	return c
+}
+
+func TestWeeklyActivityFromCommits(t *testing.T) {
+	// A Wednesday, so the current week began on Sunday the 8th
+	now := time.Date(2025, 6, 11, 15, 0, 0, 0, time.UTC)
+	thisWeek := time.Date(2025, 6, 8, 0, 0, 0, 0, time.UTC)
+	first := thisWeek.AddDate(0, 0, -7*51)
+
+	commits := []github.Commit{
+		committedAt(now),
+		committedAt(thisWeek),
+		committedAt(thisWeek.Add(-time.Second)), // the Saturday before
+		committedAt(first),
+		committedAt(first.Add(-time.Second)),   // before the window
+		committedAt(thisWeek.AddDate(0, 0, 7)), // after it
+		committedAt(first.AddDate(0, 0, 7*10).Add(time.Hour).In(time.FixedZone("", -8*3600))),
+	}
+	got := WeeklyActivityFromCommits(commits, now)
+
+	if got.FromStats {
+		t.Error("FromStats = true for a commit list")
+	}
+	if len(got.Weeks) != 52 {
+		t.Fatalf("got %d weeks, want 52", len(got.Weeks))
+	}
+	for i, week := range got.Weeks {
+		if week.Start.Weekday() != time.Sunday {
+			t.Errorf("week %d starts on %s", i, week.Start.Weekday())
+		}
+		if want := first.AddDate(0, 0, 7*i); !week.Start.Equal(want) {
+			t.Errorf("week %d starts %s, want %s", i, week.Start, want)
+		}
+	}
+
+	want := map[int]int{0: 1, 10: 1, 50: 1, 51: 2}
+	for i, week := range got.Weeks {
+		if week.Commits != want[i] {
+			t.Errorf("week %d has %d commits, want %d", i, week.Commits, want[i])
+		}
+	}
+	if got.Total() != 5 {
+		t.Errorf("Total() = %d, want the 5 commits inside the window", got.Total())
+	}
+}
+
+func TestWeeklyActivityWithoutCommits(t *testing.T) {
+	got := WeeklyActivityFromCommits(nil, time.Date(2025, 6, 11, 0, 0, 0, 0, time.UTC))
+	if len(got.Weeks) != 52 {
+		t.Fatalf("got %d weeks, want 52 zero weeks", len(got.Weeks))
+	}
+	if got.Total() != 0 || got.Peak() != 0 || got.ActiveWeeks() != 0 {
+		t.Errorf("Total, Peak, ActiveWeeks = %d, %d, %d, want all 0", got.Total(), got.Peak(), got.ActiveWeeks())
+	}
+	if counts := got.Counts(); len(counts) != 52 {
+		t.Errorf("Counts() has %d entries, want 52", len(counts))
+	}
+}
+
+func TestWeeklyActivityFromStats(t *testing.T) {
+	sunday := time.Date(2025, 6, 8, 0, 0, 0, 0, time.UTC)
+	got := WeeklyActivityFromStats([]github.CommitActivityWeek{
+		{Week: sunday.AddDate(0, 0, -14).Unix(), Total: 4},
+		{Week: sunday.AddDate(0, 0, -7).Unix(), Total: 0},
+		{Week: sunday.Unix(), Total: 9},
+	})
+
+	if !got.FromStats {
+		t.Error("FromStats = false for the stats histogram")
+	}
+	if len(got.Weeks) != 3 {
+		t.Fatalf("got %d weeks, want the zero week kept", len(got.Weeks))
+	}
+	if !got.Weeks[2].Start.Equal(sunday) {
+		t.Errorf("last week starts %s, want %s", got.Weeks[2].Start, sunday)
+	}
+	if got.Total() != 13 || got.Peak() != 9 || got.ActiveWeeks() != 2 {
+		t.Errorf("Total, Peak, ActiveWeeks = %d, %d, %d, want 13, 9, 2", got.Total(), got.Peak(), got.ActiveWeeks())
+	}
+	if counts := got.Counts(); counts[1] != 0 {
+		t.Errorf("Counts() = %v, want a zero middle week", counts)
+	}
+}
@@ -0,0 +1,101 @@
+package analyzer
+
+import (
+	"fmt"
+	"regexp"
+
+	"github.com/agnivo988/Repo-lyzer/internal/github"
+)
+
+// MaxSubjectLength is the conventional limit on a commit subject line
+const MaxSubjectLength = 72
+
+// conventionalCommit matches a Conventional Commits subject, e.g.
+// "feat(api)!: drop v1 routes"
+var conventionalCommit = regexp.MustCompile(`^(?i:feat|fix|chore|docs|style|refactor|perf|test|tests|build|ci|revert)(\([^()]+\))?!?: \S`)
+
+// bareMessage matches subjects that say nothing about the change, e.g.
+// "fix", "WIP", "update." or "..."
+var bareMessage = regexp.MustCompile(`^(?i:fix(es|ed)?|wip|update[sd]?|changes?|misc|stuff|tweaks?|cleanup|minor|typo|tmp|temp|test(ing)?)?[.!]*$`)
+
+// CommitHygiene measures the quality of a sample of commit messages
+type CommitHygiene struct {
+	Evaluated bool `json:"evaluated"`
+	// Sampled counts the messages looked at; merges and bots are left out
+	Sampled int `json:"sampled"`
+	// ConventionalShare is the share of subjects following Conventional Commits
+	ConventionalShare    float64 `json:"conventional_share"`
+	AverageSubjectLength float64 `json:"average_subject_length"`
+	// LongSubjectShare is the share of subjects over MaxSubjectLength
+	LongSubjectShare float64 `json:"long_subject_share"`
+	// BareShare is the share of subjects like "fix" or "wip"
+	BareShare float64 `json:"bare_share"`
+	// MergeShare is the share of all commits that are merges
+	MergeShare float64 `json:"merge_share"`
+	Score      int     `json:"score"` // from 0 to 100
+}
+
+// AnalyzeCommitHygiene scores commit messages out of 100: 40 for avoiding
+// bare messages, 30 for subjects within MaxSubjectLength, 20 for using
+// Conventional Commits and 10 for subjects of a useful length (full marks
+// from 15 characters on average). Commits without a message, as some
+// providers return them, aren't counted.
+func AnalyzeCommitHygiene(commits []github.Commit) CommitHygiene {
+	var hygiene CommitHygiene
+	merges, total, subjectLength := 0, 0, 0
+	conventional, long, bare := 0, 0, 0
+	for _, c := range commits {
+		if c.Commit.Message == "" {
+			continue
+		}
+		total++
+		if c.IsMerge() {
+			merges++
+			continue
+		}
+		if commitIdentity(c, nil) == "" {
+			continue // bots, or no author at all
+		}
+		subject := c.Subject()
+		hygiene.Sampled++
+		subjectLength += len([]rune(subject))
+		if conventionalCommit.MatchString(subject) {
+			conventional++
+		}
+		if len([]rune(subject)) > MaxSubjectLength {
+			long++
+		}
+		if bareMessage.MatchString(subject) {
+			bare++
+		}
+	}
+	if total > 0 {
+		hygiene.MergeShare = float64(merges) / float64(total)
+	}
+	if hygiene.Sampled == 0 {
+		return hygiene
+	}
+
+	n := float64(hygiene.Sampled)
+	hygiene.Evaluated = true
+	hygiene.ConventionalShare = float64(conventional) / n
+	hygiene.AverageSubjectLength = float64(subjectLength) / n
+	hygiene.LongSubjectShare = float64(long) / n
+	hygiene.BareShare = float64(bare) / n
+	score := 40*(1-hygiene.BareShare) +
+		30*(1-hygiene.LongSubjectShare) +
+		20*hygiene.ConventionalShare +
+		10*ratio(hygiene.AverageSubjectLength, 15)
+	hygiene.Score = NormalizeScore(score)
+	return hygiene
+}
+
+// Summary renders the headline facts, e.g.
+// "82/100: 64% conventional, 3% bare, 5% over 72 chars"
+func (h CommitHygiene) Summary() string {
+	if !h.Evaluated {
+		return "not evaluated (no commit messages)"
+	}
+	return fmt.Sprintf("%d/100: %.0f%% conventional, %.0f%% bare, %.0f%% over %d chars",
+		h.Score, h.ConventionalShare*100, h.BareShare*100, h.LongSubjectShare*100, MaxSubjectLength)
+}
@@ -0,0 +1,126 @@
+package analyzer
+
+import (
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/agnivo988/Repo-lyzer/internal/github"
+)
+
+// authored builds a commit by login with message
+func authored(login, message string) github.Commit {
+	var c github.Commit
+	c.Commit.Message = message
+	c.Author = &github.CommitAuthor{Login: login}
+	return c
+}
+
+func TestConventionalCommit(t *testing.T) {
+	tests := []struct {
+		subject string
+		want    bool
+	}{
+		{"feat: add a flag", true},
+		{"fix(api): handle 404s", true},
+		{"feat(api)!: drop v1 routes", true},
+		{"refactor!: rename Client", true},
+		{"Docs: fix a typo", true},
+		{"chore(deps): bump x", true},
+		{"revert: feat: add a flag", true},
+		{"feat:add a flag", false},
+		{"feat: ", false},
+		{"feature: add a flag", false},
+		{"fix(): nothing scoped", false},
+		{"Fix the parser", false},
+		{"wip", false},
+	}
+	for _, tt := range tests {
+		if got := conventionalCommit.MatchString(tt.subject); got != tt.want {
+			t.Errorf("conventional %q = %t, want %t", tt.subject, got, tt.want)
+		}
+	}
+}
+
+func TestBareMessage(t *testing.T) {
+	tests := []struct {
+		subject string
+		want    bool
+	}{
+		{"fix", true},
+		{"Fixed.", true},
+		{"WIP", true},
+		{"update", true},
+		{"updates!", true},
+		{"changes", true},
+		{"typo", true},
+		{"...", true},
+		{"", true},
+		{"testing", true},
+		{"fix the parser", false},
+		{"update README", false},
+		{"feat: add a flag", false},
+		{"fixup", false},
+	}
+	for _, tt := range tests {
+		if got := bareMessage.MatchString(tt.subject); got != tt.want {
+			t.Errorf("bare %q = %t, want %t", tt.subject, got, tt.want)
+		}
+	}
+}
+
+func TestAnalyzeCommitHygiene(t *testing.T) {
+	merge := authored("alice", "Merge branch 'main'")
+	merge.Parents = make([]github.CommitParent, 2)
+	commits := []github.Commit{
+		authored("alice", "feat: add the export command\n\nWith a body"),
+		authored("alice", "fix(cli): exit non-zero on errors"),
+		authored("bob", "wip"),
+		authored("bob", strings.Repeat("x", MaxSubjectLength)),
+		authored("bob", strings.Repeat("y", MaxSubjectLength+1)),
+		merge,
+		// Bots and empty messages aren't judged
+		authored("dependabot[bot]", "bump"),
+		authored("carol", ""),
+	}
+	got := AnalyzeCommitHygiene(commits)
+
+	if !got.Evaluated || got.Sampled != 5 {
+		t.Fatalf("Evaluated = %t, sampled %d; want true, 5", got.Evaluated, got.Sampled)
+	}
+	want := CommitHygiene{
+		Evaluated:            true,
+		Sampled:              5,
+		ConventionalShare:    0.4,
+		AverageSubjectLength: float64(28+33+3+72+73) / 5,
+		LongSubjectShare:     0.2,
+		BareShare:            0.2,
+		MergeShare:           1.0 / 7, // of the commits with a message, bots included
+		// 40*0.8 + 30*0.8 + 20*0.4 + 10*1
+		Score: 74,
+	}
+	for _, f := range []struct {
+		name      string
+		got, want float64
+	}{
+		{"conventional", got.ConventionalShare, want.ConventionalShare},
+		{"average length", got.AverageSubjectLength, want.AverageSubjectLength},
+		{"long", got.LongSubjectShare, want.LongSubjectShare},
+		{"bare", got.BareShare, want.BareShare},
+		{"merges", got.MergeShare, want.MergeShare},
+	} {
+		if math.Abs(f.got-f.want) > 1e-9 {
+			t.Errorf("%s share = %.3f, want %.3f", f.name, f.got, f.want)
+		}
+	}
+	if got.Score != want.Score {
+		t.Errorf("score = %d, want %d", got.Score, want.Score)
+	}
+}
+
+func TestAnalyzeCommitHygieneWithoutMessages(t *testing.T) {
+	got := AnalyzeCommitHygiene([]github.Commit{authored("renovate[bot]", "chore: bump"), authored("a", "")})
+	if got.Evaluated || got.Score != 0 || got.Summary() != "not evaluated (no commit messages)" {
+		t.Errorf("got %+v, %q; want not evaluated", got, got.Summary())
+	}
+}
@@ -0,0 +1,114 @@
+package analyzer
+
+import (
+	"path"
+	"strings"
+
+	"github.com/agnivo988/Repo-lyzer/internal/github"
+)
+
+// Where community health data came from
+const (
+	CommunityFromProfile = "community profile"
+	CommunityFromTree    = "file tree"
+)
+
+// CommunityHealth records which community health files a repo has
+type CommunityHealth struct {
+	Source           string `json:"source"`            // CommunityFromProfile, CommunityFromTree or "" if unknown
+	HealthPercentage int    `json:"health_percentage"` // from the community profile, -1 when unavailable
+	Readme           bool   `json:"readme"`
+	Contributing     bool   `json:"contributing"`
+	CodeOfConduct    bool   `json:"code_of_conduct"`
+	License          bool   `json:"license"`
+	IssueTemplate    bool   `json:"issue_template"`
+	PRTemplate       bool   `json:"pr_template"`
+}
+
+// CommunityItem is one line of the community checklist
+type CommunityItem struct {
+	Name    string
+	Present bool
+}
+
+// CommunityHealthFromProfile reads the checklist from GitHub's community profile
+func CommunityHealthFromProfile(p *github.CommunityProfile) CommunityHealth {
+	return CommunityHealth{
+		Source:           CommunityFromProfile,
+		HealthPercentage: p.HealthPercentage,
+		Readme:           p.Files.Readme != nil,
+		Contributing:     p.Files.Contributing != nil,
+		CodeOfConduct:    p.Files.CodeOfConduct != nil,
+		License:          p.Files.License != nil,
+		IssueTemplate:    p.Files.IssueTemplate != nil,
+		PRTemplate:       p.Files.PullRequestTemplate != nil,
+	}
+}
+
+// CommunityHealthFromTree approximates the checklist by looking for the same
+// files in the repository tree, in the root, docs/ or .github/ like GitHub does
+func CommunityHealthFromTree(tree []github.TreeEntry) CommunityHealth {
+	health := CommunityHealth{HealthPercentage: -1}
+	if len(tree) == 0 {
+		return health
+	}
+	health.Source = CommunityFromTree
+
+	for _, entry := range tree {
+		dir, file := path.Split(strings.ToLower(entry.Path))
+		dir = strings.TrimSuffix(dir, "/")
+
+		if entry.Type == "tree" {
+			if dir == ".github" && file == "issue_template" {
+				health.IssueTemplate = true
+			}
+			continue
+		}
+		if dir != "" && dir != "docs" && dir != ".github" {
+			if dir == ".github/issue_template" {
+				health.IssueTemplate = true
+			}
+			continue
+		}
+
+		name := strings.TrimSuffix(file, path.Ext(file))
+		switch name {
+		case "readme":
+			health.Readme = true
+		case "contributing":
+			health.Contributing = true
+		case "code_of_conduct":
+			health.CodeOfConduct = true
+		case "license", "licence", "copying":
+			health.License = true
+		case "issue_template":
+			health.IssueTemplate = true
+		case "pull_request_template":
+			health.PRTemplate = true
+		}
+	}
+	return health
+}
+
+// Checklist returns the health files in display order
+func (h CommunityHealth) Checklist() []CommunityItem {
+	return []CommunityItem{
+		{"README", h.Readme},
+		{"CONTRIBUTING", h.Contributing},
+		{"Code of conduct", h.CodeOfConduct},
+		{"License", h.License},
+		{"Issue templates", h.IssueTemplate},
+		{"PR template", h.PRTemplate},
+	}
+}
+
+// Present counts the health files the repo has
+func (h CommunityHealth) Present() int {
+	count := 0
+	for _, item := range h.Checklist() {
+		if item.Present {
+			count++
+		}
+	}
+	return count
+}
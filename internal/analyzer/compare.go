@@ -0,0 +1,108 @@
+package analyzer
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/agnivo988/Repo-lyzer/internal/github"
+)
+
+// CompareFigures are what a comparison lines up for one repo
+type CompareFigures struct {
+	Stars         int
+	Forks         int
+	Commits       int // over the last year
+	Contributors  []github.Contributor
+	HealthScore   int
+	MaturityScore int
+	SecurityScore int
+	BusFactor     int
+	Languages     map[string]int
+	Topics        []string
+}
+
+// MetricDelta is one figure for both repos. Every compared figure is
+// better higher, so the repo with the larger value leads.
+type MetricDelta struct {
+	Metric string `json:"metric"`
+	First  int    `json:"first"`
+	Second int    `json:"second"`
+	Delta  int    `json:"delta"`  // Second minus First
+	Leader int    `json:"leader"` // 1 or 2 for the repo ahead, 0 when tied
+}
+
+// RepoComparison is the difference between two repos, shared by the
+// compare view and its exports so they show the same numbers
+type RepoComparison struct {
+	Metrics []MetricDelta `json:"metrics"`
+	// LanguageSimilarity is the cosine similarity of the language
+	// breakdowns, -1 when either is empty
+	LanguageSimilarity float64  `json:"language_similarity"`
+	SharedLanguages    []string `json:"shared_languages"`
+	SharedContributors []string `json:"shared_contributors"` // bots excluded
+	SharedTopics       []string `json:"shared_topics"`
+}
+
+// CompareRepos works out how b differs from a
+func CompareRepos(a, b CompareFigures) RepoComparison {
+	c := RepoComparison{
+		LanguageSimilarity: LanguageSimilarity(a.Languages, b.Languages),
+		SharedLanguages:    []string{},
+		SharedContributors: []string{},
+		SharedTopics:       []string{},
+	}
+	for _, m := range []struct {
+		name          string
+		first, second int
+	}{
+		{"stars", a.Stars, b.Stars},
+		{"forks", a.Forks, b.Forks},
+		{"commits", a.Commits, b.Commits},
+		{"contributors", len(a.Contributors), len(b.Contributors)},
+		{"health_score", a.HealthScore, b.HealthScore},
+		{"maturity_score", a.MaturityScore, b.MaturityScore},
+		{"security_score", a.SecurityScore, b.SecurityScore},
+		{"bus_factor", a.BusFactor, b.BusFactor},
+	} {
+		delta := MetricDelta{Metric: m.name, First: m.first, Second: m.second, Delta: m.second - m.first}
+		switch {
+		case delta.Delta < 0:
+			delta.Leader = 1
+		case delta.Delta > 0:
+			delta.Leader = 2
+		}
+		c.Metrics = append(c.Metrics, delta)
+	}
+
+	for language, bytes := range a.Languages {
+		if bytes > 0 && b.Languages[language] > 0 {
+			c.SharedLanguages = append(c.SharedLanguages, language)
+		}
+	}
+	sort.Strings(c.SharedLanguages)
+
+	// Logins are case-insensitive; a's spelling is kept
+	inB := make(map[string]bool, len(b.Contributors))
+	for _, contributor := range b.Contributors {
+		inB[strings.ToLower(contributor.Login)] = true
+	}
+	for _, contributor := range a.Contributors {
+		if !contributor.IsBot() && inB[strings.ToLower(contributor.Login)] {
+			c.SharedContributors = append(c.SharedContributors, contributor.Login)
+		}
+	}
+	sort.Slice(c.SharedContributors, func(i, j int) bool {
+		return strings.ToLower(c.SharedContributors[i]) < strings.ToLower(c.SharedContributors[j])
+	})
+
+	topicsB := make(map[string]bool, len(b.Topics))
+	for _, topic := range b.Topics {
+		topicsB[topic] = true
+	}
+	for _, topic := range a.Topics {
+		if topicsB[topic] {
+			c.SharedTopics = append(c.SharedTopics, topic)
+		}
+	}
+	return c
+}
@@ -0,0 +1,103 @@
+package analyzer
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/agnivo988/Repo-lyzer/internal/github"
+)
+
+// SoleOwnerShare is the share of a directory's changes above which its
+// dominant author is a bus factor risk
+const SoleOwnerShare = 0.9
+
+// minOwnershipChanges is how many changes a directory needs before a sole
+// owner means more than a quiet directory
+const minOwnershipChanges = 5
+
+// RootDirectory groups the files at the top of the tree
+const RootDirectory = "(root)"
+
+// DirectoryOwner is who changes a top-level directory the most
+type DirectoryOwner struct {
+	Directory string  `json:"directory"`
+	Owner     string  `json:"owner"`   // login, else email or name
+	Share     float64 `json:"share"`   // of the directory's changes, from 0 to 1
+	Changes   int     `json:"changes"` // file changes in the directory
+	Authors   int     `json:"authors"`
+	// CodeOwners are the CODEOWNERS owners of the directory, when it has
+	// a rule
+	CodeOwners []string `json:"code_owners,omitempty"`
+}
+
+// Risky reports whether one person makes nearly all of the changes
+func (d DirectoryOwner) Risky() bool {
+	return d.Changes >= minOwnershipChanges && d.Share > SoleOwnerShare
+}
+
+// AnalyzeDirectoryOwnership counts each author's file changes per
+// top-level directory, given commits with their changed Files, and names
+// each directory's dominant author. Bots are left out. Directories are
+// ordered by changes, most first.
+func AnalyzeDirectoryOwnership(commits []github.Commit, rules []CodeOwnersRule) []DirectoryOwner {
+	changes := make(map[string]map[string]int) // directory → author → changes
+	for _, c := range commits {
+		author := commitIdentity(c, nil)
+		if author == "" {
+			continue
+		}
+		for _, f := range c.Files {
+			dir, _, nested := strings.Cut(f.Filename, "/")
+			if !nested {
+				dir = RootDirectory
+			}
+			if changes[dir] == nil {
+				changes[dir] = make(map[string]int)
+			}
+			changes[dir][author]++
+		}
+	}
+
+	var owners []DirectoryOwner
+	for dir, byAuthor := range changes {
+		d := DirectoryOwner{Directory: dir, Authors: len(byAuthor)}
+		most := 0
+		for author, n := range byAuthor {
+			d.Changes += n
+			if n > most || n == most && author < d.Owner {
+				most, d.Owner = n, author
+			}
+		}
+		d.Share = float64(most) / float64(d.Changes)
+		d.Owner = strings.TrimPrefix(strings.TrimPrefix(d.Owner, "email:"), "name:")
+		if dir != RootDirectory {
+			d.CodeOwners = dirCodeOwners(rules, dir)
+		}
+		owners = append(owners, d)
+	}
+	sort.Slice(owners, func(i, j int) bool {
+		if owners[i].Changes != owners[j].Changes {
+			return owners[i].Changes > owners[j].Changes
+		}
+		return owners[i].Directory < owners[j].Directory
+	})
+	return owners
+}
+
+// dirCodeOwners returns the owners of the last CODEOWNERS rule covering
+// dir, as the last matching rule wins
+func dirCodeOwners(rules []CodeOwnersRule, dir string) []string {
+	var owners []string
+	for _, rule := range rules {
+		if coversDir([]CodeOwnersRule{rule}, dir) {
+			owners = rule.Owners
+		}
+	}
+	return owners
+}
+
+// Summary renders the owner and their share, e.g. "alice (94%)"
+func (d DirectoryOwner) Summary() string {
+	return fmt.Sprintf("%s (%.0f%%)", d.Owner, d.Share*100)
+}
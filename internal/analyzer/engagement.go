@@ -0,0 +1,110 @@
+package analyzer
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/agnivo988/Repo-lyzer/internal/github"
+)
+
+// Thresholds for the engagement interpretations. A ratio has to clear a
+// threshold outright, so a value sitting on one gets the milder reading.
+const (
+	// minEngagementStars is the star count below which the ratios are too
+	// noisy to interpret
+	minEngagementStars = 50
+	// highForkRatio and lowForkRatio bound the usual forks per star
+	highForkRatio = 0.4
+	lowForkRatio  = 0.05
+	// highIssuesPerKStars is the open issue load per 1,000 stars that
+	// suggests maintainers can't keep up
+	highIssuesPerKStars = 50.0
+	// highContributorsPerKStars and lowContributorsPerKStars bound the
+	// usual contributors per 1,000 stars
+	highContributorsPerKStars = 100.0
+	lowContributorsPerKStars  = 2.0
+	// highWatchersPerKStars is the watcher count per 1,000 stars that
+	// marks a closely followed project
+	highWatchersPerKStars = 50.0
+)
+
+// Engagement relates forks, watchers, issues and contributors to stars,
+// which alone say little more than how popular a repo is
+type Engagement struct {
+	Stars int `json:"stars"`
+	// Watchers is the number of people notified of all activity; 0 when
+	// the host doesn't report it
+	Watchers int `json:"watchers"`
+	// The ratios are -1 for repos without stars
+	ForkRatio             float64 `json:"fork_ratio"`
+	IssuesPerKStars       float64 `json:"issues_per_1k_stars"`
+	ContributorsPerKStars float64 `json:"contributors_per_1k_stars"`
+	// Interpretations explain the ratios that stand out, left empty below
+	// minEngagementStars
+	Interpretations []string `json:"interpretations,omitempty"`
+}
+
+// AnalyzeEngagement derives the engagement ratios. Open issues come from
+// the issue stats when evaluated, as the repo's count includes pull
+// requests; contributors is the number of contributors fetched.
+func AnalyzeEngagement(repo *github.Repo, issues IssueStats, contributors int) Engagement {
+	e := Engagement{
+		Stars:                 repo.Stars,
+		Watchers:              repo.Subscribers,
+		ForkRatio:             -1,
+		IssuesPerKStars:       -1,
+		ContributorsPerKStars: -1,
+	}
+	if repo.Stars == 0 {
+		return e
+	}
+	openIssues := repo.OpenIssues
+	if issues.Evaluated {
+		openIssues = issues.OpenIssues
+	}
+	stars := float64(repo.Stars)
+	e.ForkRatio = float64(repo.Forks) / stars
+	e.IssuesPerKStars = float64(openIssues) * 1000 / stars
+	e.ContributorsPerKStars = float64(contributors) * 1000 / stars
+	if repo.Stars < minEngagementStars {
+		return e
+	}
+
+	switch {
+	case e.ForkRatio > highForkRatio:
+		e.Interpretations = append(e.Interpretations, "high fork ratio — commonly used as a template or base")
+	case e.ForkRatio < lowForkRatio:
+		e.Interpretations = append(e.Interpretations, "low fork ratio — used as is more than built on")
+	}
+	if e.IssuesPerKStars > highIssuesPerKStars {
+		e.Interpretations = append(e.Interpretations, "many open issues for its popularity — maintainers may be stretched")
+	}
+	switch {
+	case e.ContributorsPerKStars > highContributorsPerKStars:
+		e.Interpretations = append(e.Interpretations, "many contributors for its stars — community-built")
+	case e.ContributorsPerKStars < lowContributorsPerKStars:
+		e.Interpretations = append(e.Interpretations, "few contributors for its stars — built by a small team")
+	}
+	if float64(e.Watchers)*1000/stars > highWatchersPerKStars {
+		e.Interpretations = append(e.Interpretations, "many watchers — followed closely")
+	}
+	return e
+}
+
+// Summary renders the ratios, e.g. "forks/stars 0.12, 340 watchers, 8 open
+// issues and 12 contributors per 1k stars"
+func (e Engagement) Summary() string {
+	if e.Stars == 0 {
+		if e.Watchers > 0 {
+			return fmt.Sprintf("no stars yet, %d watchers", e.Watchers)
+		}
+		return "no stars yet"
+	}
+	parts := []string{fmt.Sprintf("forks/stars %.2f", e.ForkRatio)}
+	if e.Watchers > 0 {
+		parts = append(parts, fmt.Sprintf("%d watchers", e.Watchers))
+	}
+	parts = append(parts, fmt.Sprintf("%.0f open issues and %.0f contributors per 1k stars",
+		e.IssuesPerKStars, e.ContributorsPerKStars))
+	return strings.Join(parts, ", ")
+}
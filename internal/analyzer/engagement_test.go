@@ -0,0 +1,77 @@
+package analyzer
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/agnivo988/Repo-lyzer/internal/github"
+)
+
+func TestEngagementThresholds(t *testing.T) {
+	// At 1,000 stars: 0.1 forks per star, and 10 open issues, 20
+	// contributors and 10 watchers per 1k stars, none of which stand out
+	type counts struct{ stars, forks, issues, contributors, watchers int }
+	usual := counts{1000, 100, 10, 20, 10}
+	tests := []struct {
+		name   string
+		modify func(*counts)
+		want   []string // interpretation prefixes
+	}{
+		{"usual", func(*counts) {}, nil},
+		{"fork ratio at high", func(c *counts) { c.forks = 400 }, nil},
+		{"fork ratio above high", func(c *counts) { c.forks = 401 }, []string{"high fork ratio"}},
+		{"fork ratio at low", func(c *counts) { c.forks = 50 }, nil},
+		{"fork ratio below low", func(c *counts) { c.forks = 49 }, []string{"low fork ratio"}},
+		{"issues at high", func(c *counts) { c.issues = 50 }, nil},
+		{"issues above high", func(c *counts) { c.issues = 51 }, []string{"many open issues"}},
+		{"contributors at high", func(c *counts) { c.contributors = 100 }, nil},
+		{"contributors above high", func(c *counts) { c.contributors = 101 }, []string{"many contributors"}},
+		{"contributors at low", func(c *counts) { c.contributors = 2 }, nil},
+		{"contributors below low", func(c *counts) { c.contributors = 1 }, []string{"few contributors"}},
+		{"watchers at high", func(c *counts) { c.watchers = 50 }, nil},
+		{"watchers above high", func(c *counts) { c.watchers = 51 }, []string{"many watchers"}},
+		{"several", func(c *counts) { c.forks, c.issues, c.watchers = 500, 80, 90 },
+			[]string{"high fork ratio", "many open issues", "many watchers"}},
+		// Too few stars for the ratios to mean much
+		{"below the minimum stars", func(c *counts) { *c = counts{minEngagementStars - 1, 40, 10, 5, 10} }, nil},
+		{"at the minimum stars", func(c *counts) { *c = counts{minEngagementStars, 40, 1, 1, 1} }, []string{"high fork ratio"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := usual
+			tt.modify(&c)
+			repo := &github.Repo{Stars: c.stars, Forks: c.forks, OpenIssues: c.issues, Subscribers: c.watchers}
+			got := AnalyzeEngagement(repo, IssueStats{}, c.contributors).Interpretations
+			if len(got) != len(tt.want) {
+				t.Fatalf("interpretations = %q, want %q", got, tt.want)
+			}
+			for i, prefix := range tt.want {
+				if !strings.HasPrefix(got[i], prefix) {
+					t.Errorf("interpretation %d = %q, want %q", i, got[i], prefix)
+				}
+			}
+		})
+	}
+}
+
+func TestEngagementRatios(t *testing.T) {
+	repo := &github.Repo{Stars: 2000, Forks: 300, OpenIssues: 90, Subscribers: 40}
+	// The repo's open count includes PRs, so evaluated issue stats win
+	e := AnalyzeEngagement(repo, IssueStats{Enabled: true, Evaluated: true, OpenIssues: 30}, 24)
+	if e.ForkRatio != 0.15 || e.IssuesPerKStars != 15 || e.ContributorsPerKStars != 12 {
+		t.Errorf("ratios = %.2f, %.1f, %.1f; want 0.15, 15, 12", e.ForkRatio, e.IssuesPerKStars, e.ContributorsPerKStars)
+	}
+	if want := "forks/stars 0.15, 40 watchers, 15 open issues and 12 contributors per 1k stars"; e.Summary() != want {
+		t.Errorf("Summary() = %q, want %q", e.Summary(), want)
+	}
+}
+
+func TestEngagementWithoutStars(t *testing.T) {
+	e := AnalyzeEngagement(&github.Repo{Forks: 3, OpenIssues: 2, Subscribers: 4}, IssueStats{}, 5)
+	if e.ForkRatio != -1 || e.IssuesPerKStars != -1 || e.ContributorsPerKStars != -1 || e.Interpretations != nil {
+		t.Errorf("without stars = %+v, want -1 ratios and no interpretations", e)
+	}
+	if e.Summary() != "no stars yet, 4 watchers" {
+		t.Errorf("Summary() = %q", e.Summary())
+	}
+}
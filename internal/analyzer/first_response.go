@@ -0,0 +1,92 @@
+package analyzer
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/agnivo988/Repo-lyzer/internal/github"
+)
+
+// firstResponseGrace is how long an issue may go without a maintainer
+// response before it counts as unanswered; younger unanswered issues are
+// left out of the sample
+const firstResponseGrace = 3 * 24 * time.Hour
+
+// FirstResponse measures how soon maintainers reply to new issues
+type FirstResponse struct {
+	Evaluated bool `json:"evaluated"`
+	// Sampled counts the issues measured, leaving out those opened by
+	// maintainers and unanswered ones still within the grace period
+	Sampled   int `json:"sampled"`
+	Responded int `json:"responded"`
+	// MedianHours is the median time to the first maintainer comment, over
+	// the issues that got one
+	MedianHours     float64 `json:"median_hours"`
+	NoResponseShare float64 `json:"no_response_share"`
+}
+
+// IsMaintainer reports whether an author association marks someone with
+// commit access or in the owning organization
+func IsMaintainer(association string) bool {
+	switch association {
+	case "OWNER", "MEMBER", "COLLABORATOR":
+		return true
+	}
+	return false
+}
+
+// AnalyzeFirstResponse finds the first comment by a maintainer, going by
+// its author association, on each issue. The collaborators endpoint would
+// be more exact but needs push access to the repo.
+func AnalyzeFirstResponse(threads []github.IssueThread, now time.Time) FirstResponse {
+	var r FirstResponse
+	var hours []float64
+	for _, t := range threads {
+		if IsMaintainer(t.AuthorAssociation) {
+			continue
+		}
+		responded := false
+		for _, c := range t.Comments {
+			if IsMaintainer(c.AuthorAssociation) && c.User.Login != t.User.Login {
+				hours = append(hours, c.CreatedAt.Sub(t.CreatedAt).Hours())
+				responded = true
+				break
+			}
+		}
+		if !responded && now.Sub(t.CreatedAt) < firstResponseGrace {
+			continue
+		}
+		r.Sampled++
+		if responded {
+			r.Responded++
+		}
+	}
+	if r.Sampled == 0 {
+		return FirstResponse{}
+	}
+	r.Evaluated = true
+	r.MedianHours = median(hours)
+	r.NoResponseShare = float64(r.Sampled-r.Responded) / float64(r.Sampled)
+	return r
+}
+
+// MedianLabel renders the median time to first response in hours or days,
+// or "n/a" when no issue got one
+func (r FirstResponse) MedianLabel() string {
+	switch {
+	case r.Responded == 0:
+		return "n/a"
+	case r.MedianHours < 48:
+		return fmt.Sprintf("%.1f hours", r.MedianHours)
+	}
+	return fmt.Sprintf("%.1f days", r.MedianHours/24)
+}
+
+// Summary renders the figures, e.g. "median 5.2 hours, 20% unanswered
+// (of 25 issues)"
+func (r FirstResponse) Summary() string {
+	if !r.Evaluated {
+		return "not evaluated"
+	}
+	return fmt.Sprintf("median %s, %.0f%% unanswered (of %d issues)", r.MedianLabel(), r.NoResponseShare*100, r.Sampled)
+}
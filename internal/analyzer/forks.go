@@ -0,0 +1,64 @@
+package analyzer
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/agnivo988/Repo-lyzer/internal/github"
+)
+
+// DefaultAbandonedAfterDays is how long without a push makes a repo look
+// abandoned, prompting a search for active forks
+const DefaultAbandonedAfterDays = 730
+
+// forkLead is how much later than the upstream a fork must have been pushed
+// to, so forks that merely synced before the upstream stalled don't count
+const forkLead = 30 * 24 * time.Hour
+
+// ForkCandidate is a fork that may have taken over maintenance
+type ForkCandidate struct {
+	FullName string    `json:"full_name"`
+	Stars    int       `json:"stars"`
+	PushedAt time.Time `json:"pushed_at"`
+	// AheadBy is how many commits the fork has that the upstream lacks,
+	// -1 if it couldn't be compared
+	AheadBy int `json:"ahead_by"`
+}
+
+// IsAbandoned reports whether repo has gone without a push for longer than
+// the given number of days; days <= 0 disables the check
+func IsAbandoned(repo *github.Repo, days int, now time.Time) bool {
+	return days > 0 && repo.PushedAt.Before(now.AddDate(0, 0, -days))
+}
+
+// FindActiveForks picks forks pushed to well after the upstream and within
+// the last year, most recently pushed first. AheadBy is left at -1 for the
+// caller to fill in.
+func FindActiveForks(upstream *github.Repo, forks []github.Repo, now time.Time) []ForkCandidate {
+	recent := now.AddDate(-1, 0, 0)
+
+	var candidates []ForkCandidate
+	for _, fork := range forks {
+		if fork.PushedAt.Before(recent) || fork.PushedAt.Sub(upstream.PushedAt) < forkLead {
+			continue
+		}
+		candidates = append(candidates, ForkCandidate{
+			FullName: fork.FullName,
+			Stars:    fork.Stars,
+			PushedAt: fork.PushedAt,
+			AheadBy:  -1,
+		})
+	}
+	sort.Slice(candidates, func(i, j int) bool { return candidates[i].PushedAt.After(candidates[j].PushedAt) })
+	return candidates
+}
+
+// Summary renders the fork for display
+func (f ForkCandidate) Summary() string {
+	summary := fmt.Sprintf("%s — ⭐ %d, last push %s", f.FullName, f.Stars, f.PushedAt.Format("2006-01-02"))
+	if f.AheadBy > 0 {
+		summary += fmt.Sprintf(", %d commit(s) ahead", f.AheadBy)
+	}
+	return summary
+}
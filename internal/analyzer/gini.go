@@ -0,0 +1,74 @@
+package analyzer
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/agnivo988/Repo-lyzer/internal/github"
+)
+
+// ContributionInequality measures how concentrated commits are among
+// contributors, bots excluded
+type ContributionInequality struct {
+	Contributors int     `json:"contributors"`
+	Gini         float64 `json:"gini"` // 0 when evenly spread, 1 when one person does everything
+}
+
+// ContributionGini computes the Gini coefficient of contributor commit
+// counts. It's scaled by n/(n-1) so one person doing everything scores 1
+// however many contributors there are; a sole contributor also scores 1.
+func ContributionGini(contributors []github.Contributor) ContributionInequality {
+	var commits []int
+	total := 0
+	for _, c := range contributors {
+		if !c.IsBot() && c.Commits > 0 {
+			commits = append(commits, c.Commits)
+			total += c.Commits
+		}
+	}
+	n := len(commits)
+	switch n {
+	case 0:
+		return ContributionInequality{}
+	case 1:
+		return ContributionInequality{Contributors: 1, Gini: 1}
+	}
+
+	sort.Ints(commits)
+	weighted := 0.0
+	for i, c := range commits {
+		weighted += float64(i+1) * float64(c)
+	}
+	gini := 2*weighted/(float64(n)*float64(total)) - float64(n+1)/float64(n)
+	return ContributionInequality{
+		Contributors: n,
+		Gini:         gini * float64(n) / float64(n-1),
+	}
+}
+
+// Evaluated reports whether there were any contributors to measure
+func (c ContributionInequality) Evaluated() bool {
+	return c.Contributors > 0
+}
+
+// Label describes the distribution of work
+func (c ContributionInequality) Label() string {
+	switch {
+	case !c.Evaluated():
+		return "unknown"
+	case c.Contributors == 1 || c.Gini >= 0.8:
+		return "single-maintainer"
+	case c.Gini >= 0.5:
+		return "concentrated"
+	default:
+		return "well-distributed"
+	}
+}
+
+// Summary renders the coefficient with its label, e.g. "0.42 (well-distributed)"
+func (c ContributionInequality) Summary() string {
+	if !c.Evaluated() {
+		return "unknown"
+	}
+	return fmt.Sprintf("%.2f (%s)", c.Gini, c.Label())
+}
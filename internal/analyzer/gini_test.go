@@ -0,0 +1,43 @@
+package analyzer
+
+import (
+	"math"
+	"testing"
+
+	"github.com/agnivo988/Repo-lyzer/internal/github"
+)
+
+func TestContributionGini(t *testing.T) {
+	bot := github.Contributor{Login: "renovate[bot]", Commits: 5000}
+	tests := []struct {
+		name         string
+		contributors []github.Contributor
+		want         float64
+		counted      int
+		label        string
+	}{
+		{"no contributors", nil, 0, 0, "unknown"},
+		{"all equal", people(10, 10, 10, 10), 0, 4, "well-distributed"},
+		{"single contributor", people(42), 1, 1, "single-maintainer"},
+		// The maximum, however many contributors there are
+		{"one does everything", people(90, 0, 0), 1, 1, "single-maintainer"},
+		// Raw Gini of 1..4 is 0.25, scaled by n/(n-1) = 4/3
+		{"one to four", people(4, 3, 2, 1), 1.0 / 3, 4, "well-distributed"},
+		// Raw Gini of 5, 5, 90 is 2*(5+10+270)/300 - 4/3 = 17/30, scaled by 3/2
+		{"one dominant", people(90, 5, 5), 0.85, 3, "single-maintainer"},
+		// 2*(10+20+60+240)/400 - 5/4 = 0.4, scaled by 4/3
+		{"concentrated", people(60, 20, 10, 10), 8.0 / 15, 4, "concentrated"},
+		{"bots excluded", append(people(10, 10), bot), 0, 2, "well-distributed"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ContributionGini(tt.contributors)
+			if math.Abs(got.Gini-tt.want) > 1e-9 || got.Contributors != tt.counted {
+				t.Errorf("ContributionGini() = %.4f over %d, want %.4f over %d", got.Gini, got.Contributors, tt.want, tt.counted)
+			}
+			if got.Label() != tt.label {
+				t.Errorf("Label() = %s, want %s", got.Label(), tt.label)
+			}
+		})
+	}
+}
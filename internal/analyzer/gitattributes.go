@@ -0,0 +1,60 @@
+package analyzer
+
+import (
+	"path"
+	"slices"
+	"strings"
+)
+
+// ParseLFSPatterns returns the .gitattributes patterns stored with Git LFS
+func ParseLFSPatterns(gitattributes string) []string {
+	return attributePatterns(gitattributes, "filter=lfs")
+}
+
+// ParseLinguistExclusions returns the .gitattributes patterns marked as
+// generated or vendored, which GitHub's language statistics leave out
+func ParseLinguistExclusions(gitattributes string) []string {
+	return attributePatterns(gitattributes,
+		"linguist-generated", "linguist-generated=true", "linguist-vendored", "linguist-vendored=true")
+}
+
+// attributePatterns returns the patterns of the lines setting any of attrs
+func attributePatterns(gitattributes string, attrs ...string) []string {
+	var patterns []string
+	for _, line := range strings.Split(gitattributes, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) < 2 || strings.HasPrefix(fields[0], "#") {
+			continue
+		}
+		for _, attr := range fields[1:] {
+			if slices.Contains(attrs, attr) {
+				patterns = append(patterns, fields[0])
+				break
+			}
+		}
+	}
+	return patterns
+}
+
+// matchesAttributePattern reports whether a path matches a .gitattributes
+// pattern: patterns without a slash match the file name anywhere, others
+// the path from the root, with a trailing /** covering a whole directory
+func matchesAttributePattern(patterns []string, file string) bool {
+	for _, pattern := range patterns {
+		pattern = strings.TrimPrefix(pattern, "/")
+		if dir, ok := strings.CutSuffix(pattern, "/**"); ok {
+			if strings.HasPrefix(file, dir+"/") {
+				return true
+			}
+			continue
+		}
+		target := file
+		if !strings.Contains(pattern, "/") {
+			target = path.Base(file)
+		}
+		if ok, _ := path.Match(pattern, target); ok {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,63 @@
+package analyzer
+
+import (
+	"fmt"
+	"math"
+)
+
+// Grade is a letter for a score out of 100
+type Grade string
+
+// Grades, best first
+const (
+	GradeAPlus Grade = "A+"
+	GradeA     Grade = "A"
+	GradeB     Grade = "B"
+	GradeC     Grade = "C"
+	GradeD     Grade = "D"
+	GradeF     Grade = "F"
+)
+
+// gradeCutLines are the lowest scores that earn each grade but F
+var gradeCutLines = []struct {
+	Min   int
+	Grade Grade
+}{
+	{95, GradeAPlus},
+	{85, GradeA},
+	{70, GradeB},
+	{55, GradeC},
+	{40, GradeD},
+}
+
+// GradeFor grades a score out of 100
+func GradeFor(score int) Grade {
+	for _, cut := range gradeCutLines {
+		if score >= cut.Min {
+			return cut.Grade
+		}
+	}
+	return GradeF
+}
+
+// NormalizeScore rounds a score into the 0 to 100 range every scorer
+// reports on. NaN, as from a 0/0 share, is 0.
+func NormalizeScore(score float64) int {
+	if math.IsNaN(score) {
+		return 0
+	}
+	return int(math.Round(math.Max(0, math.Min(100, score))))
+}
+
+// clampUnit keeps a component sub-score within 0 to 1
+func clampUnit(score float64) float64 {
+	if math.IsNaN(score) {
+		return 0
+	}
+	return math.Max(0, math.Min(1, score))
+}
+
+// ScoreWithGrade renders a score and its grade, e.g. "82/100 (B)"
+func ScoreWithGrade(score int) string {
+	return fmt.Sprintf("%d/100 (%s)", score, GradeFor(score))
+}
@@ -0,0 +1,211 @@
+package analyzer
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/agnivo988/Repo-lyzer/internal/github"
+)
+
+func TestGradeFor(t *testing.T) {
+	tests := []struct {
+		score int
+		want  Grade
+	}{
+		{-10, GradeF}, {0, GradeF}, {39, GradeF},
+		{40, GradeD}, {54, GradeD},
+		{55, GradeC}, {69, GradeC},
+		{70, GradeB}, {84, GradeB},
+		{85, GradeA}, {94, GradeA},
+		{95, GradeAPlus}, {100, GradeAPlus}, {150, GradeAPlus},
+	}
+	for _, tt := range tests {
+		if got := GradeFor(tt.score); got != tt.want {
+			t.Errorf("GradeFor(%d) = %s, want %s", tt.score, got, tt.want)
+		}
+	}
+}
+
+func FuzzNormalizeScore(f *testing.F) {
+	for _, seed := range []float64{0, 49.5, 100, 100.4, 100.6, -0.4, -1e9, 1e18, math.NaN(), math.Inf(1), math.Inf(-1)} {
+		f.Add(seed)
+	}
+	f.Fuzz(func(t *testing.T, score float64) {
+		got := NormalizeScore(score)
+		if got < 0 || got > 100 {
+			t.Fatalf("NormalizeScore(%v) = %d, outside 0 to 100", score, got)
+		}
+		if score >= 0 && score <= 100 && math.Abs(float64(got)-score) > 0.5 {
+			t.Fatalf("NormalizeScore(%v) = %d, more than rounding away", score, got)
+		}
+	})
+}
+
+// extremes picks from values at and beyond the edges of what the APIs
+// return, and sometimes something ordinary
+type extremes struct{ *rand.Rand }
+
+func (r extremes) int() int {
+	values := []int{math.MinInt32, -1, 0, 1, 7, 100, 1 << 30}
+	if r.Intn(3) == 0 {
+		return r.Intn(1000)
+	}
+	return values[r.Intn(len(values))]
+}
+
+func (r extremes) float() float64 {
+	values := []float64{math.NaN(), math.Inf(1), math.Inf(-1), -1e9, -1, 0, 0.5, 1, 2, 1e9}
+	if r.Intn(3) == 0 {
+		return r.Float64() * 100
+	}
+	return values[r.Intn(len(values))]
+}
+
+func (r extremes) bool() bool {
+	return r.Intn(2) == 0
+}
+
+func (r extremes) time(now time.Time) time.Time {
+	switch r.Intn(4) {
+	case 0:
+		return time.Time{}
+	case 1:
+		return now.AddDate(5, 0, 0) // clocks disagree
+	}
+	return now.AddDate(0, 0, -r.Intn(5000))
+}
+
+func (r extremes) tree() []github.TreeEntry {
+	files := []string{"main.go", "main_test.go", "SECURITY.md", "CHANGELOG.md", ".travis.yml",
+		".github/workflows/codeql.yml", ".github/dependabot.yml", "go.mod", "go.sum", "package.json", "vendor/x.go"}
+	var tree []github.TreeEntry
+	for _, f := range files {
+		if r.bool() {
+			tree = append(tree, github.TreeEntry{Path: f, Type: "blob", Size: r.int()})
+		}
+	}
+	return tree
+}
+
+// TestScoresStayInRange feeds every scorer random extreme inputs and checks
+// each score stays in 0 to 100 with sub-scores in 0 to 1
+func TestScoresStayInRange(t *testing.T) {
+	r := extremes{rand.New(rand.NewSource(874))}
+	now := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+	trends := []ActivityTrendKind{"", ActivityGrowing, ActivitySteady, ActivityDeclining, ActivityDormant, ActivityTooNew}
+	protections := []ProtectionStatus{ProtectionProtected, ProtectionUnprotected, ProtectionUnknown}
+	licenses := []LicenseClass{LicensePermissive, LicenseStrongCopyleft, LicenseSourceAvailable, LicenseCustom, LicenseNone, LicenseUnknown}
+
+	checkComponents := func(t *testing.T, scorer string, components []ScoreComponent) {
+		total := 0.0
+		for _, c := range components {
+			if c.Score < 0 || c.Score > 1 || math.IsNaN(c.Score) {
+				t.Fatalf("%s %s sub-score %v outside 0 to 1", scorer, c.Name, c.Score)
+			}
+			total += c.Contribution
+		}
+		if total < -1e-9 || total > 100+1e-9 || math.IsNaN(total) {
+			t.Fatalf("%s contributions sum to %v", scorer, total)
+		}
+	}
+	checkScore := func(t *testing.T, scorer string, score int) {
+		if score < 0 || score > 100 {
+			t.Fatalf("%s score %d outside 0 to 100", scorer, score)
+		}
+	}
+
+	for i := 0; i < 2000; i++ {
+		repo := &github.Repo{
+			Stars:     r.int(),
+			CreatedAt: r.time(now),
+			PushedAt:  r.time(now),
+			Archived:  r.bool(),
+		}
+		if r.bool() {
+			repo.Description = "a project"
+		}
+		tree := r.tree()
+		ci := DetectCI(tree)
+		if r.bool() {
+			ci.Workflows = []WorkflowStatus{{Conclusion: "failure"}, {Conclusion: "success"}}
+		}
+		trend := ActivityTrend{Trend: trends[r.Intn(len(trends))], Change: r.float()}
+		issues := IssueStats{Enabled: r.bool(), Evaluated: r.bool(), OpenIssues: r.int(), SampleSize: r.int(),
+			MedianDaysToClose: r.float(), StaleOpen: r.int(), OpenedLast90Days: r.int(), ClosedLast90Days: r.int()}
+		pulls := PullRequestStats{Evaluated: r.bool(), OpenPRs: r.int(), MergeSampleSize: r.int(),
+			MedianDaysToMerge: r.float(), UnreviewedOpen: r.int(), MergedLast90Days: r.int()}
+		responsiveness := RateIssueResponsiveness(issues)
+		weights := HealthWeights{Activity: r.Intn(50), Contributors: r.Intn(50), Issues: r.Intn(50), Docs: 1, Tests: r.Intn(50)}
+
+		health := HealthInput{
+			Repo:                    repo,
+			Commits:                 r.int(),
+			ActivityTrend:           &trend,
+			Contributors:            r.int(),
+			Inequality:              &ContributionInequality{Contributors: r.int(), Gini: r.float()},
+			Issues:                  issues,
+			Responsiveness:          &responsiveness,
+			PullRequests:            pulls,
+			Reviews:                 &ReviewCoverage{Evaluated: r.bool(), Score: r.float(), ApprovedShare: r.float(), SingleMaintainer: r.bool()},
+			Readme:                  ReadmeInfo{Exists: r.bool()},
+			ReadmeQuality:           &ReadmeQuality{Evaluated: r.bool(), Score: r.int()},
+			CI:                      ci,
+			Tests:                   DetectTests(tree),
+			Now:                     now,
+			CommitsUnavailable:      r.bool(),
+			ContributorsUnavailable: r.bool(),
+			FileTreeUnavailable:     r.bool(),
+		}
+		if r.bool() {
+			health.ActivityTrend, health.Inequality, health.Responsiveness, health.Reviews, health.ReadmeQuality = nil, nil, nil, nil, nil
+		}
+		for _, scorer := range []HealthScorer{DefaultHealthScorer(), mustScorer(t, weights)} {
+			checkComponents(t, "health", scorer.Components(health))
+			checkScore(t, "health", scorer.Score(health))
+		}
+
+		maturity := ScoreMaturity(MaturityInput{
+			Repo:    repo,
+			Commits: r.int(),
+			Releases: ReleaseStats{Count: r.int(), LatestDate: r.time(now), MedianDaysBetween: r.float(),
+				Semver: r.bool(), Source: VersionsFromReleases},
+			Readme:              ReadmeInfo{Exists: r.bool(), HasInstall: r.bool(), HasUsage: r.bool()},
+			Tree:                tree,
+			CI:                  ci,
+			License:             LicenseInfo{Class: licenses[r.Intn(len(licenses))]},
+			ReleaseAutomation:   ReleaseAutomation{Evaluated: true, Tools: []string{GoReleaser}},
+			Now:                 now,
+			FileTreeUnavailable: r.bool(),
+		})
+		checkComponents(t, "maturity", maturity.Components)
+		checkScore(t, "maturity", maturity.Score)
+
+		vulnerabilities := r.int()
+		security := SecurityInput{
+			Tree:                tree,
+			CI:                  ci,
+			BranchProtection:    BranchProtectionInfo{Status: protections[r.Intn(len(protections))], RequiredReviews: r.int()},
+			Vulnerabilities:     &vulnerabilities,
+			FileTreeUnavailable: r.bool(),
+		}
+		if r.bool() {
+			security.Vulnerabilities = nil
+		}
+		score := ScoreSecurity(security)
+		checkComponents(t, "security", score.Components)
+		checkScore(t, "security", score.Score)
+
+		checkScore(t, "responsiveness", int(math.Round(responsiveness.Score*100)))
+	}
+}
+
+func mustScorer(t *testing.T, weights HealthWeights) HealthScorer {
+	t.Helper()
+	scorer, err := NewHealthScorer(weights)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return scorer
+}
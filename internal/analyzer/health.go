@@ -1,25 +1,502 @@
 package analyzer
 
-import "github.com/agnivo988/Repo-lyzer/internal/github"
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
 
-func CalculateHealth(repo *github.Repo, commits []github.Commit) int {
-	score := 50
+	"github.com/agnivo988/Repo-lyzer/internal/github"
+)
 
-	if repo.Description != "" {
-		score += 10
+// HealthWeights sets how much each component counts towards the health
+// score. Weights are relative: they're normalized to sum to 100.
+type HealthWeights struct {
+	Activity     int `json:"activity"`      // commits in the last year, full marks at one a week
+	Contributors int `json:"contributors"`  // full marks at 10 contributors
+	Issues       int `json:"issues"`        // median time to close, full marks within a week
+	PullRequests int `json:"pull_requests"` // median time to merge, full marks within two days
+	Docs         int `json:"docs"`          // a description and a README
+	Popularity   int `json:"popularity"`    // stars, on a log scale up to 1000
+	Freshness    int `json:"freshness"`     // days since the last push, full marks within a month
+	CI           int `json:"ci"`            // CI configured, with passing runs
+	Reviews      int `json:"reviews"`       // merged pull requests reviewed and merged by someone else
+	Tests        int `json:"tests"`         // test files, full marks at one per four source files
+}
+
+// DefaultHealthWeights is the formula used unless overridden with --weights.
+// The weights sum to 100.
+var DefaultHealthWeights = HealthWeights{
+	Activity:     20,
+	Contributors: 10,
+	Issues:       15,
+	PullRequests: 10,
+	Docs:         15,
+	Popularity:   5,
+	Freshness:    10,
+	CI:           5,
+	Reviews:      5,
+	Tests:        5,
+}
+
+// fields lists the weights by name, in display order
+func (w *HealthWeights) fields() []struct {
+	name  string
+	value *int
+} {
+	return []struct {
+		name  string
+		value *int
+	}{
+		{"activity", &w.Activity},
+		{"contributors", &w.Contributors},
+		{"issues", &w.Issues},
+		{"pull_requests", &w.PullRequests},
+		{"docs", &w.Docs},
+		{"popularity", &w.Popularity},
+		{"freshness", &w.Freshness},
+		{"ci", &w.CI},
+		{"reviews", &w.Reviews},
+		{"tests", &w.Tests},
+	}
+}
+
+// ParseHealthWeights reads overrides like "activity=40,ci=0" on top of the
+// defaults
+func ParseHealthWeights(spec string) (HealthWeights, error) {
+	return DefaultHealthWeights.Override(spec)
+}
+
+// Override applies overrides like "activity=40,ci=0" on top of w, e.g. the
+// weights from the config file, and normalizes the result
+func (w HealthWeights) Override(spec string) (HealthWeights, error) {
+	weights := w
+	if strings.TrimSpace(spec) == "" {
+		return weights.Normalized()
+	}
+
+	for _, pair := range strings.Split(spec, ",") {
+		name, value, ok := strings.Cut(strings.TrimSpace(pair), "=")
+		if !ok {
+			return weights, fmt.Errorf("invalid weight %q, expected name=value", pair)
+		}
+		n, err := strconv.Atoi(strings.TrimSpace(value))
+		if err != nil || n < 0 {
+			return weights, fmt.Errorf("invalid weight %q, expected a non-negative integer", pair)
+		}
+
+		found := false
+		for _, f := range weights.fields() {
+			if f.name == strings.TrimSpace(name) {
+				*f.value = n
+				found = true
+			}
+		}
+		if !found {
+			return weights, fmt.Errorf("unknown weight %q (valid: %s)", name, strings.Join(HealthWeightNames(), ", "))
+		}
+	}
+	return weights.Normalized()
+}
+
+// HealthWeightNames lists the names accepted by ParseHealthWeights
+func HealthWeightNames() []string {
+	var w HealthWeights
+	var names []string
+	for _, f := range w.fields() {
+		names = append(names, f.name)
+	}
+	return names
+}
+
+// Normalized scales the weights to sum to 100, keeping their proportions
+func (w HealthWeights) Normalized() (HealthWeights, error) {
+	fields := w.fields()
+	total := 0
+	for _, f := range fields {
+		if *f.value < 0 {
+			return w, fmt.Errorf("weight %s is negative", f.name)
+		}
+		total += *f.value
+	}
+	if total == 0 {
+		return w, fmt.Errorf("at least one health weight must be positive")
+	}
+	if total == 100 {
+		return w, nil
+	}
+
+	// Round down, then hand the remainder to the largest weights
+	assigned := 0
+	for _, f := range fields {
+		*f.value = *f.value * 100 / total
+		assigned += *f.value
+	}
+	for assigned < 100 {
+		largest := fields[0].value
+		for _, f := range fields {
+			if *f.value > *largest {
+				largest = f.value
+			}
+		}
+		*largest++
+		assigned++
+	}
+	return w, nil
+}
+
+// String renders the formula, e.g. "activity 25, contributors 10, ..."
+func (w HealthWeights) String() string {
+	var parts []string
+	for _, f := range w.fields() {
+		parts = append(parts, fmt.Sprintf("%s %d", f.name, *f.value))
+	}
+	return strings.Join(parts, ", ")
+}
+
+// HealthInput is the data the health score is computed from
+type HealthInput struct {
+	Repo    *github.Repo
+	Commits int // in the last year
+	// ActivityTrend, when set, marks down activity that's declining or
+	// has stopped
+	ActivityTrend *ActivityTrend
+	Contributors  int
+	// Inequality, when set, makes the contributors component reward work
+	// being spread evenly as well as the number of contributors
+	Inequality *ContributionInequality
+	Issues     IssueStats
+	// Responsiveness, when set, replaces the issues component's close time
+	// with the responsiveness rating
+	Responsiveness *IssueResponsiveness
+	PullRequests   PullRequestStats
+	// Reviews, when set and scored, adds a review coverage component
+	Reviews *ReviewCoverage
+	Readme  ReadmeInfo
+	// ReadmeQuality, when set and evaluated, scores the README by quality
+	// rather than existence alone
+	ReadmeQuality *ReadmeQuality
+	CI            CIInfo
+	Tests         TestStats
+	Now           time.Time
+
+	// Set when the data behind a component couldn't be fetched, so the
+	// component is left out rather than scored as zero
+	CommitsUnavailable      bool
+	ContributorsUnavailable bool
+	FileTreeUnavailable     bool // CI and tests are detected from the tree
+}
+
+// ScoreComponent is one weighted part of the health score
+type ScoreComponent struct {
+	Name      string  `json:"name"`
+	Raw       float64 `json:"raw"`    // the measured value, e.g. commits or days
+	Detail    string  `json:"detail"` // Raw for display, e.g. "34 commits"
+	Score     float64 `json:"score"`  // from 0 to 1
+	Weight    int     `json:"weight"`
+	Evaluated bool    `json:"evaluated"` // false when the data was unavailable
+	// Contribution is the points this component adds to the score. Weights
+	// are shared among evaluated components, so contributions sum to it.
+	Contribution float64 `json:"contribution"`
+}
+
+// HealthScorer computes the composite health score from weighted
+// components, each scored from 0 to 1
+type HealthScorer struct {
+	Weights HealthWeights
+}
+
+// NewHealthScorer validates and normalizes weights
+func NewHealthScorer(weights HealthWeights) (HealthScorer, error) {
+	normalized, err := weights.Normalized()
+	if err != nil {
+		return HealthScorer{}, err
+	}
+	return HealthScorer{Weights: normalized}, nil
+}
+
+// DefaultHealthScorer scores with DefaultHealthWeights
+func DefaultHealthScorer() HealthScorer {
+	return HealthScorer{Weights: DefaultHealthWeights}
+}
+
+// Score returns the health score out of 100
+func (s HealthScorer) Score(in HealthInput) int {
+	return ComponentsScore(s.Components(in))
+}
+
+// ComponentsScore sums the contributions of components into a score
+func ComponentsScore(components []ScoreComponent) int {
+	total := 0.0
+	for _, c := range components {
+		total += c.Contribution
+	}
+	return NormalizeScore(total)
+}
+
+// Components scores each part of the health score. Components whose data
+// is unavailable aren't evaluated, and the others' weights grow to cover
+// for them.
+func (s HealthScorer) Components(in HealthInput) []ScoreComponent {
+	w := s.Weights
+	components := []ScoreComponent{
+		activityComponent(in, w.Activity),
+		contributorsComponent(in, w.Contributors),
+		issuesComponent(in, w.Issues),
+		pullRequestsComponent(in, w.PullRequests),
+		docsComponent(in, w.Docs),
+		popularityComponent(in, w.Popularity),
+		freshnessComponent(in, w.Freshness),
+		ciComponent(in, w.CI),
+		reviewsComponent(in, w.Reviews),
+		testsComponent(in, w.Tests),
+	}
+	shareWeights(components)
+	return components
+}
+
+// shareWeights sets each evaluated component's contribution out of 100,
+// sharing the weight of those not evaluated among the rest. Sub-scores are
+// clamped to 0 to 1 first, so no input can push the total out of range.
+func shareWeights(components []ScoreComponent) {
+	for i := range components {
+		components[i].Score = clampUnit(components[i].Score)
+	}
+	evaluatedWeight := 0
+	for _, c := range components {
+		if c.Evaluated {
+			evaluatedWeight += c.Weight
+		}
+	}
+	if evaluatedWeight == 0 {
+		return
+	}
+	for i, c := range components {
+		if c.Evaluated {
+			components[i].Contribution = c.Score * float64(c.Weight) * 100 / float64(evaluatedWeight)
+		}
+	}
+}
+
+// notEvaluated is a component whose data is unavailable
+func notEvaluated(name string, weight int) ScoreComponent {
+	return ScoreComponent{Name: name, Detail: "not evaluated", Weight: weight}
+}
+
+func activityComponent(in HealthInput, weight int) ScoreComponent {
+	if in.CommitsUnavailable {
+		return notEvaluated("activity", weight)
+	}
+	c := ScoreComponent{
+		Name:      "activity",
+		Raw:       float64(in.Commits),
+		Detail:    fmt.Sprintf("%d commits in the last year", in.Commits),
+		Score:     ratio(float64(in.Commits), 52),
+		Weight:    weight,
+		Evaluated: true,
+	}
+	if t := in.ActivityTrend; t != nil && t.Evaluated() {
+		switch t.Trend {
+		case ActivityDeclining:
+			c.Score *= 0.75
+		case ActivityDormant:
+			c.Score *= 0.5
+		}
+		c.Detail += ", " + string(t.Trend)
+	}
+	return c
+}
+
+func contributorsComponent(in HealthInput, weight int) ScoreComponent {
+	if in.ContributorsUnavailable {
+		return notEvaluated("contributors", weight)
+	}
+	c := ScoreComponent{
+		Name:      "contributors",
+		Raw:       float64(in.Contributors),
+		Detail:    fmt.Sprintf("%d contributors", in.Contributors),
+		Score:     ratio(float64(in.Contributors), 10),
+		Weight:    weight,
+		Evaluated: true,
+	}
+	if in.Inequality != nil && in.Inequality.Evaluated() {
+		c.Score = (c.Score + 1 - in.Inequality.Gini) / 2
+		c.Detail += fmt.Sprintf(", Gini %.2f", in.Inequality.Gini)
+	}
+	return c
+}
+
+// issuesComponent rewards closing issues quickly, falling back to a small
+// open-issue count when close times aren't known
+func issuesComponent(in HealthInput, weight int) ScoreComponent {
+	issues := in.Issues
+	if r := in.Responsiveness; r != nil {
+		if !r.Evaluated {
+			return notEvaluated("issues", weight)
+		}
+		return ScoreComponent{
+			Name:      "issues",
+			Raw:       r.Score,
+			Detail:    r.Label + ", median time to close " + formatDays(r.MedianDaysToClose),
+			Score:     r.Score,
+			Weight:    weight,
+			Evaluated: true,
+		}
+	}
+	switch {
+	case issues.HasCloseTimes():
+		return ScoreComponent{
+			Name:      "issues",
+			Raw:       issues.MedianDaysToClose,
+			Detail:    "median time to close " + formatDays(issues.MedianDaysToClose),
+			Score:     decline(issues.MedianDaysToClose, 7, 90),
+			Weight:    weight,
+			Evaluated: true,
+		}
+	case issues.Enabled && issues.Evaluated:
+		c := ScoreComponent{
+			Name:      "issues",
+			Raw:       float64(issues.OpenIssues),
+			Detail:    fmt.Sprintf("%d open issues", issues.OpenIssues),
+			Weight:    weight,
+			Evaluated: true,
+		}
+		if issues.OpenIssues < 20 {
+			c.Score = 1
+		}
+		return c
+	default:
+		return notEvaluated("issues", weight)
+	}
+}
+
+func pullRequestsComponent(in HealthInput, weight int) ScoreComponent {
+	prs := in.PullRequests
+	if !prs.HasMergeTimes() {
+		return notEvaluated("pull_requests", weight)
+	}
+	return ScoreComponent{
+		Name:      "pull_requests",
+		Raw:       prs.MedianDaysToMerge,
+		Detail:    "median time to merge " + formatDays(prs.MedianDaysToMerge),
+		Score:     decline(prs.MedianDaysToMerge, 2, 30),
+		Weight:    weight,
+		Evaluated: true,
 	}
-	if repo.Stars > 50 {
-		score += 10
+}
+
+func docsComponent(in HealthInput, weight int) ScoreComponent {
+	c := ScoreComponent{Name: "docs", Weight: weight, Evaluated: true}
+	var present []string
+	if in.Repo.Description != "" {
+		present = append(present, "description")
+	}
+	if in.Readme.Exists {
+		present = append(present, "README")
+	}
+	c.Raw = float64(len(present))
+	c.Score = c.Raw / 2
+	c.Detail = "no description or README"
+	if len(present) > 0 {
+		c.Detail = strings.Join(present, " and ")
+	}
+	if q := in.ReadmeQuality; q != nil && q.Evaluated {
+		description := 0.0
+		if in.Repo.Description != "" {
+			description = 1
+		}
+		c.Score = (description + float64(q.Score)/100) / 2
+		c.Detail += fmt.Sprintf(" (README quality %d)", q.Score)
+	}
+	return c
+}
+
+func popularityComponent(in HealthInput, weight int) ScoreComponent {
+	return ScoreComponent{
+		Name:      "popularity",
+		Raw:       float64(in.Repo.Stars),
+		Detail:    fmt.Sprintf("%d stars", in.Repo.Stars),
+		Score:     ratio(math.Log10(float64(in.Repo.Stars)+1), 3),
+		Weight:    weight,
+		Evaluated: true,
+	}
+}
+
+func freshnessComponent(in HealthInput, weight int) ScoreComponent {
+	days := in.Now.Sub(in.Repo.PushedAt).Hours() / 24
+	return ScoreComponent{
+		Name:      "freshness",
+		Raw:       days,
+		Detail:    "last push " + formatDays(days) + " ago",
+		Score:     decline(days, 30, 365),
+		Weight:    weight,
+		Evaluated: true,
+	}
+}
+
+func ciComponent(in HealthInput, weight int) ScoreComponent {
+	if in.FileTreeUnavailable {
+		return notEvaluated("ci", weight)
+	}
+	c := ScoreComponent{Name: "ci", Detail: in.CI.Summary(), Weight: weight, Evaluated: true}
+	switch {
+	case !in.CI.HasCI():
+	case !in.CI.Live():
+		c.Score = 0.25
+	case in.CI.Failing() > 0:
+		c.Raw, c.Score = float64(in.CI.Failing()), 0.5
+	default:
+		c.Score = 1
+	}
+	return c
+}
+
+func reviewsComponent(in HealthInput, weight int) ScoreComponent {
+	if in.Reviews == nil || !in.Reviews.Scored() {
+		c := notEvaluated("reviews", weight)
+		if in.Reviews != nil && in.Reviews.SingleMaintainer {
+			c.Detail = "not evaluated (single maintainer)"
+		}
+		return c
 	}
-	if len(commits) > 10 {
-		score += 20
+	return ScoreComponent{
+		Name:      "reviews",
+		Raw:       in.Reviews.ApprovedShare,
+		Detail:    fmt.Sprintf("%.0f%% of merged PRs approved", in.Reviews.ApprovedShare*100),
+		Score:     in.Reviews.Score,
+		Weight:    weight,
+		Evaluated: true,
 	}
-	if repo.OpenIssues < 20 {
-		score += 10
+}
+
+func testsComponent(in HealthInput, weight int) ScoreComponent {
+	if in.FileTreeUnavailable || !in.Tests.Evaluated {
+		return notEvaluated("tests", weight)
+	}
+	return ScoreComponent{
+		Name:      "tests",
+		Raw:       in.Tests.Ratio(),
+		Detail:    in.Tests.Summary(),
+		Score:     ratio(in.Tests.Ratio(), 0.25),
+		Weight:    weight,
+		Evaluated: true,
 	}
+}
+
+// ratio scores value against the level that earns full marks
+func ratio(value, full float64) float64 {
+	return math.Max(0, math.Min(1, value/full))
+}
 
-	if score > 100 {
-		score = 100
+// decline scores 1 up to good, falling linearly to 0 at bad
+func decline(value, good, bad float64) float64 {
+	switch {
+	case value <= good:
+		return 1
+	case value >= bad:
+		return 0
+	default:
+		return (bad - value) / (bad - good)
 	}
-	return score
 }
@@ -0,0 +1,171 @@
+package analyzer
+
+import (
+	"math"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/agnivo988/Repo-lyzer/internal/github"
+)
+
+// sum adds up the weights
+func (w HealthWeights) sum() int {
+	total := 0
+	for _, f := range w.fields() {
+		total += *f.value
+	}
+	return total
+}
+
+func TestDefaultHealthWeights(t *testing.T) {
+	if got := DefaultHealthWeights.sum(); got != 100 {
+		t.Errorf("default weights sum to %d, want 100", got)
+	}
+	// Pinned so a rebalance shows up here, not silently in scores
+	want := "activity 20, contributors 10, issues 15, pull_requests 10, docs 15, popularity 5, freshness 10, ci 5, reviews 5, tests 5"
+	if got := DefaultHealthWeights.String(); got != want {
+		t.Errorf("default weights = %s, want %s", got, want)
+	}
+	if normalized, err := DefaultHealthWeights.Normalized(); err != nil || normalized != DefaultHealthWeights {
+		t.Errorf("Normalized() changed the defaults: %v, %v", normalized, err)
+	}
+}
+
+func TestNormalized(t *testing.T) {
+	tests := []struct {
+		name    string
+		weights HealthWeights
+		want    HealthWeights
+		err     bool
+	}{
+		{"already 100", HealthWeights{Activity: 60, CI: 40}, HealthWeights{Activity: 60, CI: 40}, false},
+		{"scaled up", HealthWeights{Activity: 3, CI: 2}, HealthWeights{Activity: 60, CI: 40}, false},
+		{"scaled down", HealthWeights{Activity: 150, Docs: 50}, HealthWeights{Activity: 75, Docs: 25}, false},
+		// 100/3 rounds down to 33 each; the remainder goes to the first largest
+		{"remainder", HealthWeights{Activity: 1, Issues: 1, Docs: 1}, HealthWeights{Activity: 34, Issues: 33, Docs: 33}, false},
+		{"all zero", HealthWeights{}, HealthWeights{}, true},
+		{"negative", HealthWeights{Activity: 110, CI: -10}, HealthWeights{}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.weights.Normalized()
+			if tt.err {
+				if err == nil {
+					t.Errorf("Normalized() = %v, want an error", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != tt.want {
+				t.Errorf("Normalized() = %v, want %v", got, tt.want)
+			}
+			if got.sum() != 100 {
+				t.Errorf("Normalized() sums to %d, want 100", got.sum())
+			}
+		})
+	}
+}
+
+func TestParseHealthWeights(t *testing.T) {
+	tests := []struct {
+		spec string
+		want string // the weights' String, or part of the error
+		err  bool
+	}{
+		{"", DefaultHealthWeights.String(), false},
+		// Defaults sum to 100, so raising activity by 20 rebalances the rest
+		{"activity=40", "activity 36, contributors 8, issues 12, pull_requests 8, docs 12, popularity 4, freshness 8, ci 4, reviews 4, tests 4", false},
+		{"activity=100, contributors=0,issues=0,pull_requests=0,docs=0,popularity=0,freshness=0,ci=0,reviews=0,tests=0", "activity 100, contributors 0, issues 0, pull_requests 0, docs 0, popularity 0, freshness 0, ci 0, reviews 0, tests 0", false},
+		{"activity", "expected name=value", true},
+		{"activity=-1", "non-negative", true},
+		{"stars=10", "unknown weight", true},
+	}
+	for _, tt := range tests {
+		got, err := ParseHealthWeights(tt.spec)
+		switch {
+		case tt.err && (err == nil || !strings.Contains(err.Error(), tt.want)):
+			t.Errorf("ParseHealthWeights(%q) error = %v, want one containing %q", tt.spec, err, tt.want)
+		case !tt.err && err != nil:
+			t.Errorf("ParseHealthWeights(%q): %v", tt.spec, err)
+		case !tt.err && got.String() != tt.want:
+			t.Errorf("ParseHealthWeights(%q) = %s, want %s", tt.spec, got, tt.want)
+		case !tt.err && got.sum() != 100:
+			t.Errorf("ParseHealthWeights(%q) sums to %d, want 100", tt.spec, got.sum())
+		}
+	}
+}
+
+func TestNotEvaluatedComponentsAreExcluded(t *testing.T) {
+	now := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+	// Activity earns full marks and docs half; issues, tests and the
+	// rest are either unweighted or have no data
+	in := HealthInput{
+		Repo:    &github.Repo{Description: "a tool", PushedAt: now},
+		Commits: 52,
+		Now:     now,
+	}
+	tests := []struct {
+		name    string
+		weights HealthWeights
+		modify  func(*HealthInput)
+		want    int
+	}{
+		// Issues have no data, so activity and docs share its weight
+		// rather than it counting as 0 (which would give 38)
+		{"issues unavailable", HealthWeights{Activity: 25, Issues: 50, Docs: 25}, nil, 75},
+		{"issues evaluated", HealthWeights{Activity: 25, Issues: 50, Docs: 25}, func(in *HealthInput) {
+			in.Issues = IssueStats{Enabled: true, Evaluated: true, OpenIssues: 50}
+		}, 38},
+		{"commits unavailable", HealthWeights{Activity: 50, Docs: 50}, func(in *HealthInput) {
+			in.CommitsUnavailable = true
+		}, 50},
+		{"file tree unavailable", HealthWeights{Activity: 50, CI: 25, Tests: 25}, func(in *HealthInput) {
+			in.FileTreeUnavailable = true
+		}, 100},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			in := in
+			if tt.modify != nil {
+				tt.modify(&in)
+			}
+			scorer, err := NewHealthScorer(tt.weights)
+			if err != nil {
+				t.Fatal(err)
+			}
+			components := scorer.Components(in)
+
+			for _, c := range components {
+				if !c.Evaluated && (c.Contribution != 0 || !strings.HasPrefix(c.Detail, "not evaluated")) {
+					t.Errorf("%s: not evaluated but contributes %.1f (%s)", c.Name, c.Contribution, c.Detail)
+				}
+			}
+			if got := scorer.Score(in); got != tt.want {
+				t.Errorf("score = %d, want %d", got, tt.want)
+			}
+			// Every evaluated component scoring 1 would still reach 100
+			if evaluated := evaluatedWeightShare(components); math.Abs(evaluated-100) > 1e-9 {
+				t.Errorf("evaluated components share %.2f points, want 100", evaluated)
+			}
+		})
+	}
+}
+
+// evaluatedWeightShare is what the evaluated components would contribute
+// with full marks
+func evaluatedWeightShare(components []ScoreComponent) float64 {
+	full := make([]ScoreComponent, len(components))
+	for i, c := range components {
+		full[i] = c
+		full[i].Score = 1
+	}
+	shareWeights(full)
+	total := 0.0
+	for _, c := range full {
+		total += c.Contribution
+	}
+	return total
+}
@@ -0,0 +1,114 @@
+package analyzer
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/agnivo988/Repo-lyzer/internal/github"
+)
+
+// earliestCommitDate is before any plausible commit; older dates are bogus
+var earliestCommitDate = time.Date(1990, 1, 1, 0, 0, 0, 0, time.UTC)
+
+// CommitHeatmap counts commits by weekday and hour, the classic punch card
+type CommitHeatmap struct {
+	// Matrix is indexed by time.Weekday (Sunday first), then hour
+	Matrix [7][24]int `json:"matrix"`
+	Total  int        `json:"total"`
+	// UTCOffset is the dominant author timezone, in seconds east of UTC.
+	// All commits are placed in it.
+	UTCOffset int `json:"utc_offset"`
+	// WorkingHoursShare is the share of commits Monday to Friday, 9:00 to
+	// 18:00 in the dominant timezone
+	WorkingHoursShare float64 `json:"working_hours_share"`
+	// Dropped counts commits with implausible dates (before 1990 or in the
+	// future) that were left out
+	Dropped int `json:"dropped"`
+}
+
+// BuildCommitHeatmap aggregates commit author dates. The timezone most
+// commits were authored in is taken as the project's; where providers only
+// report UTC, that's UTC.
+func BuildCommitHeatmap(commits []github.Commit, now time.Time) CommitHeatmap {
+	var heatmap CommitHeatmap
+	var dates []time.Time
+	offsets := make(map[int]int)
+	for _, c := range commits {
+		date := c.Commit.Author.Date
+		if date.Before(earliestCommitDate) || date.After(now.Add(24*time.Hour)) {
+			heatmap.Dropped++
+			continue
+		}
+		dates = append(dates, date)
+		_, offset := date.Zone()
+		offsets[offset]++
+	}
+	if len(dates) == 0 {
+		return heatmap
+	}
+
+	for offset, n := range offsets {
+		if n > offsets[heatmap.UTCOffset] || (n == offsets[heatmap.UTCOffset] && offset < heatmap.UTCOffset) {
+			heatmap.UTCOffset = offset
+		}
+	}
+	zone := time.FixedZone("", heatmap.UTCOffset)
+
+	working := 0
+	for _, date := range dates {
+		local := date.In(zone)
+		heatmap.Matrix[local.Weekday()][local.Hour()]++
+		if local.Weekday() != time.Saturday && local.Weekday() != time.Sunday &&
+			local.Hour() >= 9 && local.Hour() < 18 {
+			working++
+		}
+	}
+	heatmap.Total = len(dates)
+	heatmap.WorkingHoursShare = float64(working) / float64(heatmap.Total)
+	return heatmap
+}
+
+// Max returns the largest cell count
+func (h CommitHeatmap) Max() int {
+	max := 0
+	for _, day := range h.Matrix {
+		for _, n := range day {
+			if n > max {
+				max = n
+			}
+		}
+	}
+	return max
+}
+
+// ZoneLabel renders the dominant timezone, e.g. "UTC+02:00"
+func (h CommitHeatmap) ZoneLabel() string {
+	offset, sign := h.UTCOffset, "+"
+	if offset < 0 {
+		offset, sign = -offset, "-"
+	}
+	return fmt.Sprintf("UTC%s%02d:%02d", sign, offset/3600, offset%3600/60)
+}
+
+// WorkingHoursLabel hints whether development happens on the clock
+func (h CommitHeatmap) WorkingHoursLabel() string {
+	switch {
+	case h.Total == 0:
+		return "unknown"
+	case h.WorkingHoursShare >= 0.6:
+		return "mostly working hours (corporate-like)"
+	case h.WorkingHoursShare <= 0.35:
+		return "mostly evenings and weekends (hobbyist-like)"
+	default:
+		return "mixed"
+	}
+}
+
+// Summary renders the working-hours concentration for display
+func (h CommitHeatmap) Summary() string {
+	if h.Total == 0 {
+		return "No commit times available"
+	}
+	return fmt.Sprintf("%.0f%% of commits Mon–Fri 9–18 %s: %s",
+		h.WorkingHoursShare*100, h.ZoneLabel(), h.WorkingHoursLabel())
+}
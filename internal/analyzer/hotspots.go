@@ -0,0 +1,66 @@
+package analyzer
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/agnivo988/Repo-lyzer/internal/github"
+)
+
+// MaxHotspots is how many of the most changed files are reported
+const MaxHotspots = 15
+
+// Hotspot is a file that changes in many commits
+type Hotspot struct {
+	Path    string  `json:"path"`
+	Changes int     `json:"changes"` // commits touching the file
+	Share   float64 `json:"share"`   // of the sampled commits, from 0 to 1
+}
+
+// HotspotReport lists the most frequently changed files
+type HotspotReport struct {
+	// Sampled is how many commits' changed files were read; 0 when the
+	// hotspot analysis didn't run
+	Sampled  int       `json:"sampled"`
+	Hotspots []Hotspot `json:"hotspots"`
+}
+
+// AnalyzeHotspots counts how many commits touch each file, given commits
+// with their changed Files, and keeps the MaxHotspots most changed
+func AnalyzeHotspots(commits []github.Commit) HotspotReport {
+	report := HotspotReport{Sampled: len(commits)}
+	changes := make(map[string]int)
+	for _, c := range commits {
+		for _, f := range c.Files {
+			changes[f.Filename]++
+		}
+	}
+	for file, n := range changes {
+		report.Hotspots = append(report.Hotspots, Hotspot{
+			Path:    file,
+			Changes: n,
+			Share:   float64(n) / float64(report.Sampled),
+		})
+	}
+	sort.Slice(report.Hotspots, func(i, j int) bool {
+		a, b := report.Hotspots[i], report.Hotspots[j]
+		if a.Changes != b.Changes {
+			return a.Changes > b.Changes
+		}
+		return a.Path < b.Path
+	})
+	report.Hotspots = report.Hotspots[:min(len(report.Hotspots), MaxHotspots)]
+	return report
+}
+
+// Summary renders the hottest file for display
+func (r HotspotReport) Summary() string {
+	switch {
+	case r.Sampled == 0:
+		return "not analyzed (use --hotspots)"
+	case len(r.Hotspots) == 0:
+		return fmt.Sprintf("no changed files in %d commits", r.Sampled)
+	}
+	top := r.Hotspots[0]
+	return fmt.Sprintf("%s changed in %.0f%% of %d sampled commits", top.Path, top.Share*100, r.Sampled)
+}
@@ -0,0 +1,99 @@
+package analyzer
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/agnivo988/Repo-lyzer/internal/github"
+)
+
+// IssueAgeBuckets are the upper bounds in days of the open-issue age
+// buckets; the last bucket has no bound
+var IssueAgeBuckets = []int{30, 180, 365}
+
+// IssueAgeLabels name the buckets, youngest first
+var IssueAgeLabels = []string{"<30d", "30-180d", "180d-1y", ">1y"}
+
+// IssueAges is a histogram of how long open issues have been open
+type IssueAges struct {
+	Evaluated bool `json:"evaluated"`
+	// Buckets counts open issues by IssueAgeLabels
+	Buckets [4]int `json:"buckets"`
+	// Unbucketed counts open issues outside the sample whose bucket can't
+	// be told; they're all older than the sample's oldest issue
+	Unbucketed int `json:"unbucketed"`
+	// OldestDays is the age of the oldest open issue, -1 when unknown
+	OldestDays float64 `json:"oldest_days"`
+	// OldestNumber is that issue's number
+	OldestNumber int `json:"oldest_number,omitempty"`
+}
+
+// AnalyzeIssueAges buckets a sample of the newest open issues. Open issues
+// past the sample (open minus the sample size) are older than all of it;
+// when oldest, the oldest open issue, falls in the same bucket as the
+// sample's oldest, or that is already over a year old, so do they all.
+// Pull requests are left out.
+func AnalyzeIssueAges(newest []github.Issue, oldest *github.Issue, open int, now time.Time) IssueAges {
+	ages := IssueAges{OldestDays: -1}
+	sampled := 0
+	var sampleOldest time.Time
+	for _, issue := range newest {
+		if issue.IsPullRequest() {
+			continue
+		}
+		sampled++
+		ages.Buckets[issueAgeBucket(issue.CreatedAt, now)]++
+		if sampleOldest.IsZero() || issue.CreatedAt.Before(sampleOldest) {
+			sampleOldest = issue.CreatedAt
+			ages.OldestDays = now.Sub(issue.CreatedAt).Hours() / 24
+			ages.OldestNumber = issue.Number
+		}
+	}
+	if oldest != nil && !oldest.IsPullRequest() && (sampleOldest.IsZero() || oldest.CreatedAt.Before(sampleOldest)) {
+		ages.OldestDays = now.Sub(oldest.CreatedAt).Hours() / 24
+		ages.OldestNumber = oldest.Number
+	}
+	ages.Evaluated = sampled > 0
+
+	if rest := open - sampled; rest > 0 && ages.Evaluated {
+		bucket := issueAgeBucket(sampleOldest, now)
+		if bucket == len(IssueAgeBuckets) || oldest != nil && issueAgeBucket(oldest.CreatedAt, now) == bucket {
+			ages.Buckets[bucket] += rest
+		} else {
+			ages.Unbucketed = rest
+		}
+	}
+	return ages
+}
+
+func issueAgeBucket(created, now time.Time) int {
+	days := now.Sub(created).Hours() / 24
+	for i, bound := range IssueAgeBuckets {
+		if days < float64(bound) {
+			return i
+		}
+	}
+	return len(IssueAgeBuckets)
+}
+
+// Summary renders the histogram, e.g.
+// "<30d 12, 30-180d 30, 180d-1y 8, >1y 41; oldest #210, 1,530 days"
+func (a IssueAges) Summary() string {
+	if !a.Evaluated {
+		return "no open issues sampled"
+	}
+	summary := ""
+	for i, label := range IssueAgeLabels {
+		if i > 0 {
+			summary += ", "
+		}
+		summary += fmt.Sprintf("%s %d", label, a.Buckets[i])
+	}
+	if a.Unbucketed > 0 {
+		summary += fmt.Sprintf(", %d older not sampled", a.Unbucketed)
+	}
+	if a.OldestDays >= 0 {
+		summary += fmt.Sprintf("; oldest #%d, %s days", a.OldestNumber, formatThousands(int(a.OldestDays+0.5)))
+	}
+	return summary
+}
@@ -0,0 +1,64 @@
+package analyzer
+
+import (
+	"testing"
+	"time"
+
+	"github.com/agnivo988/Repo-lyzer/internal/github"
+)
+
+func TestAnalyzeIssueAges(t *testing.T) {
+	now := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	issue := func(number, daysOld int) github.Issue {
+		return github.Issue{Number: number, CreatedAt: now.AddDate(0, 0, -daysOld)}
+	}
+	pull := func(number, daysOld int) github.Issue {
+		pr := issue(number, daysOld)
+		pr.PullRequest = &struct{}{}
+		return pr
+	}
+	ptr := func(i github.Issue) *github.Issue { return &i }
+
+	tests := []struct {
+		name         string
+		newest       []github.Issue
+		oldest       *github.Issue
+		open         int
+		evaluated    bool
+		buckets      [4]int
+		unbucketed   int
+		oldestDays   float64
+		oldestNumber int
+	}{
+		{"no open issues", nil, nil, 0, false, [4]int{}, 0, -1, 0},
+		{"only pull requests", []github.Issue{pull(9, 5)}, nil, 0, false, [4]int{}, 0, -1, 0},
+		{"whole sample", []github.Issue{issue(4, 2), issue(3, 40), issue(2, 200), issue(1, 400)}, nil, 4,
+			true, [4]int{1, 1, 1, 1}, 0, 400, 1},
+		{"pull requests left out", []github.Issue{issue(5, 2), pull(4, 10), issue(3, 40), pull(2, 900)}, nil, 2,
+			true, [4]int{1, 1, 0, 0}, 0, 40, 3},
+		{"rest of a sample reaching past a year", []github.Issue{issue(3, 10), issue(2, 400)}, ptr(issue(1, 2000)), 50,
+			true, [4]int{1, 0, 0, 49}, 0, 2000, 1},
+		{"rest in the same bucket as the oldest", []github.Issue{issue(3, 10), issue(2, 50)}, ptr(issue(1, 150)), 50,
+			true, [4]int{1, 49, 0, 0}, 0, 150, 1},
+		{"rest spanning buckets", []github.Issue{issue(3, 10), issue(2, 50)}, ptr(issue(1, 500)), 50,
+			true, [4]int{1, 1, 0, 0}, 48, 500, 1},
+		{"oldest issue not found", []github.Issue{issue(3, 10), issue(2, 50)}, nil, 50,
+			true, [4]int{1, 1, 0, 0}, 48, 50, 2},
+		{"oldest issue a pull request", []github.Issue{issue(3, 10), issue(2, 50)}, ptr(pull(1, 700)), 50,
+			true, [4]int{1, 1, 0, 0}, 48, 50, 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := AnalyzeIssueAges(tt.newest, tt.oldest, tt.open, now)
+			if got.Evaluated != tt.evaluated {
+				t.Errorf("Evaluated = %t, want %t", got.Evaluated, tt.evaluated)
+			}
+			if got.Buckets != tt.buckets || got.Unbucketed != tt.unbucketed {
+				t.Errorf("buckets = %v + %d unbucketed, want %v + %d", got.Buckets, got.Unbucketed, tt.buckets, tt.unbucketed)
+			}
+			if got.OldestDays != tt.oldestDays || got.OldestNumber != tt.oldestNumber {
+				t.Errorf("oldest = #%d at %.0f days, want #%d at %.0f", got.OldestNumber, got.OldestDays, tt.oldestNumber, tt.oldestDays)
+			}
+		})
+	}
+}
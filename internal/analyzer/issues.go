@@ -0,0 +1,68 @@
+package analyzer
+
+import (
+	"fmt"
+
+	"github.com/agnivo988/Repo-lyzer/internal/github"
+)
+
+// IssueStats summarises how a project handles its issue tracker
+type IssueStats struct {
+	Enabled           bool    `json:"enabled"`     // false when the repo has issues disabled
+	Evaluated         bool    `json:"evaluated"`   // false when the issue data couldn't be fetched
+	OpenIssues        int     `json:"open_issues"` // open issues excluding pull requests
+	ClosedLast90Days  int     `json:"closed_last_90_days"`
+	OpenedLast90Days  int     `json:"opened_last_90_days"`
+	StaleOpen         int     `json:"stale_open"`           // open issues created over 180 days ago
+	MedianDaysToClose float64 `json:"median_days_to_close"` // over the closed-issue sample
+	SampleSize        int     `json:"sample_size"`
+	// Ages buckets the open issues by how long they've been open
+	Ages IssueAges `json:"ages"`
+	// Labeling is how the open issues are labeled
+	Labeling IssueLabeling `json:"labeling"`
+}
+
+// MedianDaysToClose returns the median open-to-close time of closed issues,
+// along with how many issues it was measured over
+func MedianDaysToClose(closed []github.Issue) (float64, int) {
+	var days []float64
+	for _, issue := range closed {
+		if issue.IsPullRequest() || issue.ClosedAt == nil {
+			continue
+		}
+		days = append(days, issue.ClosedAt.Sub(issue.CreatedAt).Hours()/24)
+	}
+	return median(days), len(days)
+}
+
+// HasCloseTimes reports whether a time-to-close figure is available
+func (s IssueStats) HasCloseTimes() bool {
+	return s.Enabled && s.Evaluated && s.SampleSize > 0
+}
+
+// Summary renders the stats for display
+func (s IssueStats) Summary() string {
+	if !s.Enabled {
+		return "Issues disabled"
+	}
+	if !s.Evaluated {
+		return "Issue data unavailable"
+	}
+	summary := fmt.Sprintf("%d open, %d closed in the last 90 days", s.OpenIssues, s.ClosedLast90Days)
+	if s.SampleSize > 0 {
+		summary += fmt.Sprintf(", median time to close %s", formatDays(s.MedianDaysToClose))
+	}
+	return summary
+}
+
+// formatDays renders a duration in days as "5h", "3.5 days" or "42 days"
+func formatDays(days float64) string {
+	switch {
+	case days < 1:
+		return fmt.Sprintf("%.0fh", days*24)
+	case days < 10:
+		return fmt.Sprintf("%.1f days", days)
+	default:
+		return fmt.Sprintf("%.0f days", days)
+	}
+}
@@ -0,0 +1,178 @@
+package analyzer
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/agnivo988/Repo-lyzer/internal/github"
+)
+
+// goodFirstIssueLabels and helpWantedLabels are matched as whole words of
+// normalized label names, anywhere in the name so prefixes like
+// "status: help wanted" still match
+var (
+	goodFirstIssueLabels = []string{"good first", "first timer", "first timers", "beginner", "beginners", "newcomer", "newcomers", "starter", "easy"}
+	helpWantedLabels     = []string{"help wanted", "help needed", "contributions welcome", "prs welcome", "pr welcome", "up for grabs"}
+)
+
+// labelNegations before a phrase turn it around, as in "not easy"
+var labelNegations = map[string]bool{"not": true, "no": true, "non": true}
+
+// Contributor friendliness levels
+const (
+	Welcoming        = "welcoming"
+	SomeSignals      = "some signals"
+	FewSignals       = "few signals"
+	welcomingScore   = 70
+	someSignalsScore = 40
+)
+
+// IssueLabeling describes how a project labels its open issues
+type IssueLabeling struct {
+	Evaluated bool `json:"evaluated"`
+	Labels    int  `json:"labels"`  // labels defined in the repo
+	Sampled   int  `json:"sampled"` // open issues looked at
+	Labeled   int  `json:"labeled"` // of them, with at least one label
+	// GoodFirstIssues and HelpWanted count the sampled open issues with
+	// such labels
+	GoodFirstIssues int `json:"good_first_issues"`
+	HelpWanted      int `json:"help_wanted"`
+}
+
+// AnalyzeIssueLabeling counts the labels and how the sampled open issues
+// use them. Pull requests are left out.
+func AnalyzeIssueLabeling(labels []github.Label, open []github.Issue) IssueLabeling {
+	labeling := IssueLabeling{Evaluated: true, Labels: len(labels)}
+	for _, issue := range open {
+		if issue.IsPullRequest() {
+			continue
+		}
+		labeling.Sampled++
+		if len(issue.Labels) > 0 {
+			labeling.Labeled++
+		}
+		goodFirst, helpWanted := false, false
+		for _, label := range issue.Labels {
+			name := normalizeLabel(label.Name)
+			goodFirst = goodFirst || labelMatches(name, goodFirstIssueLabels)
+			helpWanted = helpWanted || labelMatches(name, helpWantedLabels)
+		}
+		if goodFirst {
+			labeling.GoodFirstIssues++
+		}
+		if helpWanted {
+			labeling.HelpWanted++
+		}
+	}
+	return labeling
+}
+
+// normalizeLabel lowercases a label and turns separators into single
+// spaces, so "Good-First-Issue" and "good_first_issue" read alike
+func normalizeLabel(name string) string {
+	name = strings.ToLower(name)
+	name = strings.Map(func(r rune) rune {
+		switch r {
+		case '-', '_', ':', '/', '.':
+			return ' '
+		}
+		return r
+	}, name)
+	return strings.Join(strings.Fields(name), " ")
+}
+
+// labelMatches reports whether a normalized label name has one of the
+// phrases as whole words, not negated by the word before it
+func labelMatches(name string, phrases []string) bool {
+	words := strings.Fields(name)
+	for _, phrase := range phrases {
+		want := strings.Fields(phrase)
+		for i := 0; i+len(want) <= len(words); i++ {
+			if slices.Equal(words[i:i+len(want)], want) && (i == 0 || !labelNegations[words[i-1]]) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func containsAnyOf(s string, substrings []string) bool {
+	for _, sub := range substrings {
+		if strings.Contains(s, sub) {
+			return true
+		}
+	}
+	return false
+}
+
+// LabeledShare is the share of sampled open issues with a label
+func (l IssueLabeling) LabeledShare() float64 {
+	if l.Sampled == 0 {
+		return 0
+	}
+	return float64(l.Labeled) / float64(l.Sampled)
+}
+
+// Friendliness rates how welcoming a project is to new contributors
+type Friendliness struct {
+	Evaluated      bool          `json:"evaluated"`
+	Labeling       IssueLabeling `json:"labeling"`
+	IssueTemplates bool          `json:"issue_templates"`
+	Score          int           `json:"score"` // from 0 to 100
+	Level          string        `json:"level"`
+}
+
+// RateFriendliness scores out of 100: 30 for the share of open issues
+// labeled, 20 for issue templates, 20 for open good first issues, 15 for
+// help wanted ones and 15 for defining at least 5 labels. A project
+// without open issues has nothing to label, so the labeled share counts
+// in full. Projects with issues disabled aren't rated.
+func RateFriendliness(issues IssueStats, templates bool) Friendliness {
+	if !issues.Enabled || !issues.Labeling.Evaluated {
+		return Friendliness{}
+	}
+	f := Friendliness{Evaluated: true, Labeling: issues.Labeling, IssueTemplates: templates}
+	labeled := 1.0
+	if f.Labeling.Sampled > 0 {
+		labeled = f.Labeling.LabeledShare()
+	}
+	score := 30 * labeled
+	if templates {
+		score += 20
+	}
+	if f.Labeling.GoodFirstIssues > 0 {
+		score += 20
+	}
+	if f.Labeling.HelpWanted > 0 {
+		score += 15
+	}
+	if f.Labeling.Labels >= 5 {
+		score += 15
+	}
+	f.Score = NormalizeScore(score)
+	switch {
+	case f.Score >= welcomingScore:
+		f.Level = Welcoming
+	case f.Score >= someSignalsScore:
+		f.Level = SomeSignals
+	default:
+		f.Level = FewSignals
+	}
+	return f
+}
+
+// Summary renders the rating, e.g. "welcoming (85): 24 labels, 80% of open
+// issues labeled, 5 good first issues, 3 help wanted, issue templates"
+func (f Friendliness) Summary() string {
+	if !f.Evaluated {
+		return "not evaluated"
+	}
+	templates := "no issue templates"
+	if f.IssueTemplates {
+		templates = "issue templates"
+	}
+	return fmt.Sprintf("%s (%d): %d labels, %.0f%% of open issues labeled, %d good first issues, %d help wanted, %s",
+		f.Level, f.Score, f.Labeling.Labels, f.Labeling.LabeledShare()*100,
+		f.Labeling.GoodFirstIssues, f.Labeling.HelpWanted, templates)
+}
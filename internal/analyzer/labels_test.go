@@ -0,0 +1,67 @@
+package analyzer
+
+import (
+	"testing"
+
+	"github.com/agnivo988/Repo-lyzer/internal/github"
+)
+
+func TestIssueLabelMatching(t *testing.T) {
+	tests := []struct {
+		label                 string
+		goodFirst, helpWanted bool
+	}{
+		{"good first issue", true, false},
+		{"good-first-issue", true, false},
+		{"Good_First_Issue", true, false},
+		{"beginner friendly", true, false},
+		{"Beginners", true, false},
+		{"first-timers-only", true, false},
+		{"difficulty: easy", true, false},
+		{"starter", true, false},
+		{"help wanted", false, true},
+		{"help-wanted", false, true},
+		{"status: help wanted", false, true},
+		{"PRs welcome", false, true},
+		{"not easy", false, false},
+		{"uneasy", false, false},
+		{"kickstarter", false, false},
+		{"no help wanted", false, false},
+		{"bug", false, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.label, func(t *testing.T) {
+			name := normalizeLabel(tt.label)
+			if got := labelMatches(name, goodFirstIssueLabels); got != tt.goodFirst {
+				t.Errorf("good first issue = %t, want %t", got, tt.goodFirst)
+			}
+			if got := labelMatches(name, helpWantedLabels); got != tt.helpWanted {
+				t.Errorf("help wanted = %t, want %t", got, tt.helpWanted)
+			}
+		})
+	}
+}
+
+func TestAnalyzeIssueLabeling(t *testing.T) {
+	issue := func(labels ...string) github.Issue {
+		var i github.Issue
+		for _, name := range labels {
+			i.Labels = append(i.Labels, github.Label{Name: name})
+		}
+		return i
+	}
+	pull := issue("good first issue", "help wanted")
+	pull.PullRequest = &struct{}{}
+
+	got := AnalyzeIssueLabeling(make([]github.Label, 7), []github.Issue{
+		issue("good-first-issue", "Good First Issue"),
+		issue("beginner friendly", "help-wanted"),
+		issue("not easy"),
+		issue(),
+		pull,
+	})
+	want := IssueLabeling{Evaluated: true, Labels: 7, Sampled: 4, Labeled: 3, GoodFirstIssues: 2, HelpWanted: 1}
+	if got != want {
+		t.Errorf("got %+v, want %+v", got, want)
+	}
+}
@@ -0,0 +1,168 @@
+package analyzer
+
+import (
+	"sort"
+
+	"github.com/agnivo988/Repo-lyzer/internal/github"
+)
+
+// OtherLanguage collects the bytes of files with unrecognised extensions
+const OtherLanguage = "Other"
+
+// ListedLanguages is how many languages text reports list before rolling
+// the rest into OtherLanguage
+const ListedLanguages = 10
+
+// extensionLanguages maps lowercase file extensions to the language names
+// GitHub's languages endpoint uses
+var extensionLanguages = map[string]string{
+	".go":     "Go",
+	".ts":     "TypeScript",
+	".tsx":    "TypeScript",
+	".mts":    "TypeScript",
+	".js":     "JavaScript",
+	".jsx":    "JavaScript",
+	".mjs":    "JavaScript",
+	".cjs":    "JavaScript",
+	".py":     "Python",
+	".rs":     "Rust",
+	".rb":     "Ruby",
+	".java":   "Java",
+	".kt":     "Kotlin",
+	".kts":    "Kotlin",
+	".scala":  "Scala",
+	".c":      "C",
+	".h":      "C",
+	".cc":     "C++",
+	".cpp":    "C++",
+	".cxx":    "C++",
+	".hpp":    "C++",
+	".cs":     "C#",
+	".php":    "PHP",
+	".swift":  "Swift",
+	".m":      "Objective-C",
+	".dart":   "Dart",
+	".lua":    "Lua",
+	".ex":     "Elixir",
+	".exs":    "Elixir",
+	".erl":    "Erlang",
+	".hs":     "Haskell",
+	".clj":    "Clojure",
+	".r":      "R",
+	".jl":     "Julia",
+	".zig":    "Zig",
+	".sh":     "Shell",
+	".bash":   "Shell",
+	".ps1":    "PowerShell",
+	".html":   "HTML",
+	".css":    "CSS",
+	".scss":   "SCSS",
+	".vue":    "Vue",
+	".svelte": "Svelte",
+	".sql":    "SQL",
+	".tf":     "HCL",
+	".nix":    "Nix",
+}
+
+// DefaultLanguageExclusions are generated file patterns left out of the
+// adjusted language breakdown, on top of vendored and generated paths
+// like vendor/, node_modules/, dist/, *.pb.go and *.min.js
+var DefaultLanguageExclusions = []string{"*_pb.go", "*_pb2.py", "*_pb.js", "*_pb.ts"}
+
+// EstimateLanguages approximates the languages endpoint from a file tree,
+// weighting each language by the size of its files. Files with unknown
+// extensions count as OtherLanguage; vendored and generated code and files
+// without an extension are left out.
+func EstimateLanguages(tree []github.TreeEntry) map[string]int {
+	return languageBytes(tree, nil, true)
+}
+
+// AdjustedLanguages is the language breakdown of the code a project wrote:
+// like EstimateLanguages, but also leaving out files matching exclusions
+// (.gitattributes-style patterns) and files in no known language
+func AdjustedLanguages(tree []github.TreeEntry, exclusions []string) map[string]int {
+	return languageBytes(tree, exclusions, false)
+}
+
+// languageBytes sums file sizes by language
+func languageBytes(tree []github.TreeEntry, exclusions []string, withOther bool) map[string]int {
+	languages := make(map[string]int)
+	for _, entry := range tree {
+		if entry.Type != "blob" || skippedPath(entry.Path) || matchesAttributePattern(exclusions, entry.Path) {
+			continue
+		}
+		ext := fileExtension(entry.Path)
+		if ext == NoExtension {
+			continue
+		}
+		language, ok := extensionLanguages[ext]
+		if !ok {
+			if !withOther {
+				continue
+			}
+			language = OtherLanguage
+		}
+		languages[language] += entry.Size
+	}
+	return languages
+}
+
+// PrimaryLanguage is the language with the most bytes, "" for none
+func PrimaryLanguage(languages map[string]int) string {
+	primary, most := "", 0
+	for language, bytes := range languages {
+		if bytes > most || bytes == most && language < primary {
+			primary, most = language, bytes
+		}
+	}
+	return primary
+}
+
+// LanguageShare is one language's part of a breakdown
+type LanguageShare struct {
+	Name    string  `json:"name"`
+	Bytes   int     `json:"bytes"`
+	Percent float64 `json:"percent"`
+}
+
+// RankLanguages orders a breakdown by bytes, most first, breaking ties by
+// name so the order is the same on every run; nil when there are no bytes
+func RankLanguages(languages map[string]int) []LanguageShare {
+	total := 0
+	for _, bytes := range languages {
+		total += bytes
+	}
+	if total == 0 {
+		return nil
+	}
+	ranked := make([]LanguageShare, 0, len(languages))
+	for name, bytes := range languages {
+		ranked = append(ranked, LanguageShare{name, bytes, float64(bytes) / float64(total) * 100})
+	}
+	sort.Slice(ranked, func(i, j int) bool {
+		if ranked[i].Bytes != ranked[j].Bytes {
+			return ranked[i].Bytes > ranked[j].Bytes
+		}
+		return ranked[i].Name < ranked[j].Name
+	})
+	return ranked
+}
+
+// TopLanguages keeps the n largest of ranked and rolls the rest into a
+// trailing OtherLanguage entry, merged with any OtherLanguage already there
+func TopLanguages(ranked []LanguageShare, n int) []LanguageShare {
+	if len(ranked) <= n {
+		return ranked
+	}
+	var top []LanguageShare
+	other := LanguageShare{Name: OtherLanguage}
+	for i, share := range ranked {
+		if i < n && share.Name != OtherLanguage {
+			top = append(top, share)
+			continue
+		}
+		other.Bytes += share.Bytes
+		other.Percent += share.Percent
+	}
+	return append(top, other)
+}
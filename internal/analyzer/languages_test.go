@@ -0,0 +1,136 @@
+package analyzer
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/agnivo988/Repo-lyzer/internal/github"
+)
+
+// sized builds a file of size bytes
+func sized(p string, size int) github.TreeEntry {
+	return github.TreeEntry{Path: p, Type: "blob", Size: size}
+}
+
+func TestExtensionLanguagesTable(t *testing.T) {
+	for ext, language := range extensionLanguages {
+		for _, file := range []string{"src/main" + ext, "src/MAIN" + strings.ToUpper(ext)} {
+			got := EstimateLanguages([]github.TreeEntry{sized(file, 100)})
+			if want := map[string]int{language: 100}; !reflect.DeepEqual(got, want) {
+				t.Errorf("EstimateLanguages(%s) = %v, want %v", file, got, want)
+			}
+		}
+	}
+}
+
+func TestEstimateLanguages(t *testing.T) {
+	tests := []struct {
+		name string
+		tree []github.TreeEntry
+		want map[string]int
+	}{
+		{"empty", nil, map[string]int{}},
+		{"weighted by size", []github.TreeEntry{sized("main.go", 3000), sized("util.go", 1000), sized("web/app.tsx", 500)},
+			map[string]int{"Go": 4000, "TypeScript": 500}},
+		{"unknown extensions", []github.TreeEntry{sized("main.go", 10), sized("notes.txt", 7), sized("data.xyz", 3)},
+			map[string]int{"Go": 10, OtherLanguage: 10}},
+		{"no extension", []github.TreeEntry{sized("Makefile", 50), sized(".gitignore", 20), sized("main.rs", 5)},
+			map[string]int{"Rust": 5}},
+		{"vendored and generated", []github.TreeEntry{sized("vendor/x/x.go", 900), sized("api/api.pb.go", 800), sized("main.py", 5)},
+			map[string]int{"Python": 5}},
+		{"directories", []github.TreeEntry{{Path: "src.go", Type: "tree"}, sized("src.go/a.c", 1)},
+			map[string]int{"C": 1}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := EstimateLanguages(tt.tree); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("EstimateLanguages() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAdjustedLanguages(t *testing.T) {
+	// A Go service that commits its vendor tree, generated protobufs and a
+	// minified bundle, all far bigger than the code its authors wrote
+	tree := []github.TreeEntry{
+		sized("cmd/server/main.go", 4000),
+		sized("internal/store/store.go", 6000),
+		sized("web/src/app.ts", 3000),
+		sized("scripts/release.sh", 500),
+		sized("README.md", 2000),
+		sized("vendor/github.com/lib/pq/conn.go", 90000),
+		sized("web/node_modules/react/index.js", 80000),
+		sized("web/dist/app.js", 70000),
+		sized("web/static/app.min.js", 60000),
+		sized("api/service.pb.go", 50000),
+		sized("api/service_pb.go", 40000),
+		sized("api/service_pb2.py", 30000),
+		sized("web/src/api.generated.ts", 20000),
+		sized("internal/mocks/store_mock.go", 10000),
+		sized("web/src/schema.graphql.ts", 9000),
+	}
+	gitattributes := `# keep these out of the stats
+internal/mocks/** linguist-generated
+*.graphql.ts linguist-generated=true
+*.md linguist-documentation
+`
+	exclusions := append(append([]string{}, DefaultLanguageExclusions...), ParseLinguistExclusions(gitattributes)...)
+
+	want := map[string]int{"Go": 10000, "TypeScript": 3000, "Shell": 500}
+	got := AdjustedLanguages(tree, exclusions)
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("AdjustedLanguages() = %v, want %v", got, want)
+	}
+	if primary := PrimaryLanguage(got); primary != "Go" {
+		t.Errorf("primary language = %s, want Go", primary)
+	}
+
+	// Extra patterns, like --exclude-paths, apply on top
+	got = AdjustedLanguages(tree, append(exclusions, "scripts/**", "*.ts"))
+	if want := map[string]int{"Go": 10000}; !reflect.DeepEqual(got, want) {
+		t.Errorf("with extra exclusions = %v, want %v", got, want)
+	}
+
+	// The unadjusted estimate only skips the vendored and generated paths
+	// every breakdown leaves out
+	raw := EstimateLanguages(tree)
+	if raw["Go"] != 10000+40000+10000 || raw["Python"] != 30000 || raw[OtherLanguage] != 2000 {
+		t.Errorf("EstimateLanguages() = %v", raw)
+	}
+}
+
+func TestParseLinguistExclusions(t *testing.T) {
+	gitattributes := `*.pb.go linguist-generated
+/gen/** linguist-generated=true -diff
+third_party/** linguist-vendored
+docs/** linguist-documentation
+# *.ts linguist-generated
+*.bin filter=lfs diff=lfs merge=lfs -text
+*.lock linguist-generated=false
+`
+	want := []string{"*.pb.go", "/gen/**", "third_party/**"}
+	if got := ParseLinguistExclusions(gitattributes); !reflect.DeepEqual(got, want) {
+		t.Errorf("ParseLinguistExclusions() = %v, want %v", got, want)
+	}
+}
+
+func TestMatchesAttributePattern(t *testing.T) {
+	tests := []struct {
+		pattern, path string
+		want          bool
+	}{
+		{"*.pb.go", "api/v1/service.pb.go", true},
+		{"*.pb.go", "api/v1/service.go", false},
+		{"/gen/**", "gen/a/b.go", true},
+		{"gen/**", "src/gen/b.go", false},
+		{"api/*.go", "api/x.go", true},
+		{"api/*.go", "api/v1/x.go", false},
+	}
+	for _, tt := range tests {
+		if got := matchesAttributePattern([]string{tt.pattern}, tt.path); got != tt.want {
+			t.Errorf("matchesAttributePattern(%q, %q) = %t, want %t", tt.pattern, tt.path, got, tt.want)
+		}
+	}
+}
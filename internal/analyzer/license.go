@@ -0,0 +1,140 @@
+package analyzer
+
+import (
+	"strings"
+
+	"github.com/agnivo988/Repo-lyzer/internal/github"
+)
+
+// osiLicenses are the SPDX identifiers of commonly used OSI-approved licenses
+var osiLicenses = map[string]bool{
+	"MIT":          true,
+	"Apache-2.0":   true,
+	"BSD-2-Clause": true,
+	"BSD-3-Clause": true,
+	"GPL-2.0":      true,
+	"GPL-3.0":      true,
+	"LGPL-2.1":     true,
+	"LGPL-3.0":     true,
+	"AGPL-3.0":     true,
+	"MPL-2.0":      true,
+	"EPL-1.0":      true,
+	"EPL-2.0":      true,
+	"ISC":          true,
+	"Zlib":         true,
+	"Unlicense":    true,
+	"0BSD":         true,
+	"BSL-1.0":      true,
+	"Artistic-2.0": true,
+	"OSL-3.0":      true,
+	"MS-PL":        true,
+	"EUPL-1.2":     true,
+	"UPL-1.0":      true,
+	"ECL-2.0":      true,
+}
+
+// HasLicense reports whether GitHub found a license file at all
+func HasLicense(repo *github.Repo) bool {
+	return repo.License != nil && repo.License.Key != ""
+}
+
+// IsOSILicense reports whether the repo's license is a recognized
+// OSI-approved license
+func IsOSILicense(repo *github.Repo) bool {
+	return HasLicense(repo) && osiLicenses[repo.License.SPDXID]
+}
+
+// LicenseLabel renders the license for display: the SPDX identifier, "Custom
+// license" when GitHub couldn't match it (NOASSERTION), or "No license".
+// Hosts that don't report SPDX identifiers get the license name.
+func LicenseLabel(repo *github.Repo) string {
+	if !HasLicense(repo) {
+		return "No license"
+	}
+	switch repo.License.SPDXID {
+	case "NOASSERTION":
+		return "Custom license (" + repo.License.Name + ")"
+	case "":
+		return repo.License.Name
+	}
+	return repo.License.SPDXID
+}
+
+// LicenseClass groups licenses by what they let users do
+type LicenseClass string
+
+const (
+	LicensePermissive      LicenseClass = "permissive"
+	LicenseWeakCopyleft    LicenseClass = "weak copyleft"
+	LicenseStrongCopyleft  LicenseClass = "strong copyleft"
+	LicenseSourceAvailable LicenseClass = "source-available" // not OSI approved
+	LicenseCustom          LicenseClass = "custom"           // GitHub couldn't match it
+	LicenseNone            LicenseClass = "none"
+	LicenseUnknown         LicenseClass = "unknown" // a license missing from licenseClasses
+)
+
+// licenseClasses maps lowercase SPDX identifiers, which are also GitHub's
+// and GitLab's license keys, to their class
+var licenseClasses = map[string]LicenseClass{
+	"mit":          LicensePermissive,
+	"mit-0":        LicensePermissive,
+	"apache-2.0":   LicensePermissive,
+	"bsd-2-clause": LicensePermissive,
+	"bsd-3-clause": LicensePermissive,
+	"0bsd":         LicensePermissive,
+	"isc":          LicensePermissive,
+	"zlib":         LicensePermissive,
+	"unlicense":    LicensePermissive,
+	"bsl-1.0":      LicensePermissive,
+	"upl-1.0":      LicensePermissive,
+	"ecl-2.0":      LicensePermissive,
+	"artistic-2.0": LicensePermissive,
+	"ms-pl":        LicensePermissive,
+	"cc0-1.0":      LicensePermissive,
+	"wtfpl":        LicensePermissive,
+	"mpl-2.0":      LicenseWeakCopyleft,
+	"lgpl-2.1":     LicenseWeakCopyleft,
+	"lgpl-3.0":     LicenseWeakCopyleft,
+	"epl-1.0":      LicenseWeakCopyleft,
+	"epl-2.0":      LicenseWeakCopyleft,
+	"cddl-1.0":     LicenseWeakCopyleft,
+	"ms-rl":        LicenseWeakCopyleft,
+	"gpl-2.0":      LicenseStrongCopyleft,
+	"gpl-3.0":      LicenseStrongCopyleft,
+	"agpl-3.0":     LicenseStrongCopyleft,
+	"osl-3.0":      LicenseStrongCopyleft,
+	"eupl-1.1":     LicenseStrongCopyleft,
+	"eupl-1.2":     LicenseStrongCopyleft,
+	"busl-1.1":     LicenseSourceAvailable,
+	"sspl-1.0":     LicenseSourceAvailable,
+	"elastic-2.0":  LicenseSourceAvailable,
+	"cc-by-nc-4.0": LicenseSourceAvailable,
+}
+
+// LicenseInfo is a repo's license identifier and its class
+type LicenseInfo struct {
+	SPDXID string       `json:"spdx_id"` // the host's key when it reports no SPDX identifier
+	Class  LicenseClass `json:"class"`
+}
+
+// ClassifyLicense looks up the class of a repo's license, by SPDX
+// identifier or else by the host's license key
+func ClassifyLicense(repo *github.Repo) LicenseInfo {
+	if !HasLicense(repo) {
+		return LicenseInfo{Class: LicenseNone}
+	}
+	id := repo.License.SPDXID
+	if id == "" {
+		id = repo.License.Key
+	}
+	if id == "NOASSERTION" || repo.License.Key == "other" {
+		return LicenseInfo{SPDXID: id, Class: LicenseCustom}
+	}
+	// GitHub and GitLab keys drop the "-only"/"-or-later" suffixes
+	key := strings.TrimSuffix(strings.TrimSuffix(strings.ToLower(id), "-only"), "-or-later")
+	class, ok := licenseClasses[key]
+	if !ok {
+		class = LicenseUnknown
+	}
+	return LicenseInfo{SPDXID: id, Class: class}
+}
@@ -0,0 +1,97 @@
+package analyzer
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/agnivo988/Repo-lyzer/internal/github"
+)
+
+// licensed builds a repo with a license
+func licensed(key, spdxID, name string) *github.Repo {
+	return &github.Repo{License: &github.License{Key: key, SPDXID: spdxID, Name: name}}
+}
+
+func TestClassifyLicense(t *testing.T) {
+	tests := []struct {
+		name  string
+		repo  *github.Repo
+		id    string
+		class LicenseClass
+	}{
+		{"no license", &github.Repo{}, "", LicenseNone},
+		{"empty key", licensed("", "", ""), "", LicenseNone},
+		{"MIT", licensed("mit", "MIT", "MIT License"), "MIT", LicensePermissive},
+		{"Apache", licensed("apache-2.0", "Apache-2.0", "Apache License 2.0"), "Apache-2.0", LicensePermissive},
+		{"MPL", licensed("mpl-2.0", "MPL-2.0", ""), "MPL-2.0", LicenseWeakCopyleft},
+		{"AGPL", licensed("agpl-3.0", "AGPL-3.0", ""), "AGPL-3.0", LicenseStrongCopyleft},
+		{"BUSL", licensed("busl-1.1", "BUSL-1.1", ""), "BUSL-1.1", LicenseSourceAvailable},
+		// SPDX suffixes that GitHub's keys drop
+		{"GPL only", licensed("gpl-3.0", "GPL-3.0-only", ""), "GPL-3.0-only", LicenseStrongCopyleft},
+		{"LGPL or later", licensed("lgpl-2.1", "LGPL-2.1-or-later", ""), "LGPL-2.1-or-later", LicenseWeakCopyleft},
+		// Hosts without SPDX identifiers fall back to the key
+		{"key only", licensed("bsd-3-clause", "", "BSD 3-Clause"), "bsd-3-clause", LicensePermissive},
+		{"no assertion", licensed("other", "NOASSERTION", "Other"), "NOASSERTION", LicenseCustom},
+		{"other key", licensed("other", "", "Other"), "other", LicenseCustom},
+		{"unmapped", licensed("vim", "Vim", "Vim License"), "Vim", LicenseUnknown},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ClassifyLicense(tt.repo)
+			if got.SPDXID != tt.id || got.Class != tt.class {
+				t.Errorf("ClassifyLicense() = %s (%s), want %s (%s)", got.SPDXID, got.Class, tt.id, tt.class)
+			}
+		})
+	}
+}
+
+func TestLicenseClassesTable(t *testing.T) {
+	for key, class := range licenseClasses {
+		// By SPDX identifier, in any case, and by the host's key alone
+		for _, repo := range []*github.Repo{
+			licensed(key, strings.ToUpper(key), ""),
+			licensed(key, "", ""),
+		} {
+			if got := ClassifyLicense(repo).Class; got != class {
+				t.Errorf("%s (SPDX %q) = %s, want %s", key, repo.License.SPDXID, got, class)
+			}
+		}
+	}
+}
+
+func TestOSILicenses(t *testing.T) {
+	for id := range osiLicenses {
+		repo := licensed(strings.ToLower(id), id, "")
+		if !IsOSILicense(repo) {
+			t.Errorf("IsOSILicense(%s) = false", id)
+		}
+		// Every OSI license is classified as open source
+		switch class := ClassifyLicense(repo).Class; class {
+		case LicensePermissive, LicenseWeakCopyleft, LicenseStrongCopyleft:
+		default:
+			t.Errorf("OSI license %s classified as %s", id, class)
+		}
+	}
+	for _, repo := range []*github.Repo{{}, licensed("busl-1.1", "BUSL-1.1", ""), licensed("other", "NOASSERTION", "")} {
+		if IsOSILicense(repo) {
+			t.Errorf("IsOSILicense(%+v) = true", repo.License)
+		}
+	}
+}
+
+func TestLicenseLabel(t *testing.T) {
+	tests := []struct {
+		repo *github.Repo
+		want string
+	}{
+		{&github.Repo{}, "No license"},
+		{licensed("mit", "MIT", "MIT License"), "MIT"},
+		{licensed("other", "NOASSERTION", "Acme License"), "Custom license (Acme License)"},
+		{licensed("mit", "", "MIT License"), "MIT License"},
+	}
+	for _, tt := range tests {
+		if got := LicenseLabel(tt.repo); got != tt.want {
+			t.Errorf("LicenseLabel(%+v) = %q, want %q", tt.repo.License, got, tt.want)
+		}
+	}
+}
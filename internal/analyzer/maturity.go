@@ -1,49 +1,300 @@
 package analyzer
 
 import (
+	"fmt"
+	"strings"
 	"time"
 
 	"github.com/agnivo988/Repo-lyzer/internal/github"
 )
 
-func RepoMaturityScore(repo *github.Repo, commits int, contributors int, hasReleases bool) (int, string) {
-	score := 0
+// Maturity levels. A repository at least legacyAgeYears old with no push
+// in legacyIdleDays, or archived, is Legacy whatever its score; otherwise
+// the score decides: Mature from 70, Developing from 40, Experimental below.
+const (
+	MaturityExperimental = "Experimental"
+	MaturityDeveloping   = "Developing"
+	MaturityMature       = "Mature"
+	MaturityLegacy       = "Legacy"
+)
+
+// Maturity level thresholds
+const (
+	matureScore     = 70
+	developingScore = 40
+	legacyAgeYears  = 2
+	legacyIdleDays  = 365
+)
+
+// Maturity score component weights, out of 100; the release cadence has
+// ReleaseCadenceWeight
+const (
+	maturityAgeWeight       = 15
+	maturitySemverWeight    = 15
+	maturityChangelogWeight = 10
+	maturityDocsWeight      = 15
+	maturityCIWeight        = 10
+	maturityLicenseWeight   = 15
+	// maturityAutomationWeight is a bonus: the component is only evaluated
+	// for automated releases, so repos without lose nothing
+	maturityAutomationWeight = 5
+)
+
+// changelogNames are the root or docs/ files that record changes
+var changelogNames = []string{"changelog", "changes", "history", "news", "releases"}
+
+// MaturityInput holds the signals the maturity score is computed from
+type MaturityInput struct {
+	Repo     *github.Repo
+	Commits  int // in the last year
+	Releases ReleaseStats
+	Readme   ReadmeInfo
+	Tree     []github.TreeEntry
+	CI       CIInfo
+	License  LicenseInfo
+	// ReleaseAutomation, when detected alongside releases, adds a small
+	// bonus
+	ReleaseAutomation ReleaseAutomation
+	// Changelog, when read, halves the changelog's marks if it's behind
+	// the latest release
+	Changelog *ChangelogInfo
+	Now       time.Time
+
+	FileTreeUnavailable bool
+}
+
+// Maturity is a 0-100 rating of how established a repository is, with the
+// components it's made of
+type Maturity struct {
+	Score      int              `json:"score"`
+	Grade      Grade            `json:"grade"`
+	Level      string           `json:"level"`
+	Components []ScoreComponent `json:"components"`
+}
+
+// ScoreMaturity combines age, release cadence, semver tags, a changelog,
+// documentation, CI and the license. Components without data aren't
+// evaluated, and the others' weights grow to cover for them.
+func ScoreMaturity(in MaturityInput) Maturity {
+	components := []ScoreComponent{
+		ageComponent(in),
+		releasesComponent(in),
+		semverComponent(in),
+		changelogComponent(in),
+		maturityDocsComponent(in),
+		maturityCIComponent(in),
+		maturityLicenseComponent(in),
+		releaseAutomationComponent(in),
+	}
+	shareWeights(components)
+	m := Maturity{Score: ComponentsScore(components), Components: components}
+	m.Grade = GradeFor(m.Score)
+	m.Level = maturityLevel(in, m.Score)
+	return m
+}
+
+func maturityLevel(in MaturityInput, score int) string {
+	if in.Repo.Archived {
+		return MaturityLegacy
+	}
+	if !in.Repo.CreatedAt.IsZero() && !in.Repo.PushedAt.IsZero() &&
+		in.Now.Sub(in.Repo.CreatedAt).Hours() >= legacyAgeYears*365*24 &&
+		in.Now.Sub(in.Repo.PushedAt).Hours() > legacyIdleDays*24 {
+		return MaturityLegacy
+	}
+	switch {
+	case score >= matureScore:
+		return MaturityMature
+	case score >= developingScore:
+		return MaturityDeveloping
+	default:
+		return MaturityExperimental
+	}
+}
+
+// ageComponent gives full marks from three years old
+func ageComponent(in MaturityInput) ScoreComponent {
+	if in.Repo.CreatedAt.IsZero() {
+		return notEvaluated("age", maturityAgeWeight)
+	}
+	years := in.Now.Sub(in.Repo.CreatedAt).Hours() / (24 * 365)
+	return ScoreComponent{
+		Name:      "age",
+		Raw:       years,
+		Detail:    fmt.Sprintf("%.1f years", years),
+		Score:     ratio(years, 3),
+		Weight:    maturityAgeWeight,
+		Evaluated: true,
+	}
+}
+
+func releasesComponent(in MaturityInput) ScoreComponent {
+	rating := RateReleaseCadence(in.Releases, in.Commits)
+	return ScoreComponent{
+		Name:      "releases",
+		Raw:       float64(in.Releases.Count),
+		Detail:    rating.Summary(),
+		Score:     float64(rating.Points) / ReleaseCadenceWeight,
+		Weight:    ReleaseCadenceWeight,
+		Evaluated: true,
+	}
+}
+
+// semverComponent needs versions to judge, so it isn't evaluated without
+func semverComponent(in MaturityInput) ScoreComponent {
+	if in.Releases.Count == 0 {
+		c := notEvaluated("semver", maturitySemverWeight)
+		c.Detail = "not evaluated (no versions)"
+		return c
+	}
+	c := ScoreComponent{Name: "semver", Detail: "versions aren't semantic", Weight: maturitySemverWeight, Evaluated: true}
+	if in.Releases.Semver {
+		c.Raw, c.Score, c.Detail = 1, 1, "semantic versions, latest "+in.Releases.LatestTag
+	}
+	return c
+}
+
+func changelogComponent(in MaturityInput) ScoreComponent {
+	if in.FileTreeUnavailable {
+		return notEvaluated("changelog", maturityChangelogWeight)
+	}
+	c := ScoreComponent{Name: "changelog", Detail: "no changelog", Weight: maturityChangelogWeight, Evaluated: true}
+	if found := FindChangelog(in.Tree); found != "" {
+		c.Raw, c.Score, c.Detail = 1, 1, found
+	}
+	if in.Changelog != nil && in.Changelog.Path != "" && in.Changelog.Behind {
+		c.Score, c.Detail = 0.5, in.Changelog.Summary()
+	}
+	return c
+}
 
-	// Age
-	ageYears := time.Since(repo.CreatedAt).Hours() / (24 * 365)
-	if ageYears >= 1 {
-		score += 20
+// releaseAutomationComponent gives full marks for releases cut by a tool,
+// and isn't evaluated otherwise
+func releaseAutomationComponent(in MaturityInput) ScoreComponent {
+	if !in.ReleaseAutomation.Automated() || in.Releases.Count == 0 {
+		c := notEvaluated("release_automation", maturityAutomationWeight)
+		c.Detail = "no release automation"
+		return c
+	}
+	return ScoreComponent{
+		Name:      "release_automation",
+		Raw:       float64(len(in.ReleaseAutomation.Tools)),
+		Detail:    in.ReleaseAutomation.Summary(),
+		Score:     1,
+		Weight:    maturityAutomationWeight,
+		Evaluated: true,
 	}
+}
 
-	// Activity
-	if commits > 100 {
-		score += 25
+// maturityDocsComponent scores a description and a README covering
+// installation and usage, a quarter each
+func maturityDocsComponent(in MaturityInput) ScoreComponent {
+	var present []string
+	if in.Repo.Description != "" {
+		present = append(present, "description")
+	}
+	if in.Readme.Exists {
+		present = append(present, "README")
+	}
+	if in.Readme.HasInstall {
+		present = append(present, "install")
+	}
+	if in.Readme.HasUsage {
+		present = append(present, "usage")
+	}
+	c := ScoreComponent{
+		Name:      "docs",
+		Raw:       float64(len(present)),
+		Detail:    "no description or README",
+		Score:     float64(len(present)) / 4,
+		Weight:    maturityDocsWeight,
+		Evaluated: true,
 	}
+	if len(present) > 0 {
+		c.Detail = strings.Join(present, ", ")
+	}
+	return c
+}
 
-	// Contributors
-	if contributors > 1 {
-		score += 20
+// maturityCIComponent gives half marks for CI that looks dead
+func maturityCIComponent(in MaturityInput) ScoreComponent {
+	if in.FileTreeUnavailable {
+		return notEvaluated("ci", maturityCIWeight)
+	}
+	c := ScoreComponent{Name: "ci", Raw: float64(len(in.CI.Providers)), Detail: in.CI.Summary(), Weight: maturityCIWeight, Evaluated: true}
+	switch {
+	case in.CI.Live():
+		c.Score = 1
+	case len(in.CI.Providers) > 0:
+		c.Score = 0.5
 	}
+	return c
+}
 
-	// Releases
-	if hasReleases {
-		score += 20
+// maturityLicenseComponent gives full marks for open source licenses and
+// half for others; without any, nobody may legally reuse the code
+func maturityLicenseComponent(in MaturityInput) ScoreComponent {
+	c := ScoreComponent{Name: "license", Detail: string(in.License.Class), Weight: maturityLicenseWeight, Evaluated: true}
+	switch in.License.Class {
+	case LicensePermissive, LicenseWeakCopyleft, LicenseStrongCopyleft:
+		c.Raw, c.Score = 1, 1
+	case LicenseNone:
+		c.Detail = "no license"
+	default:
+		c.Raw, c.Score = 1, 0.5
+	}
+	if in.License.SPDXID != "" {
+		c.Detail = in.License.SPDXID + " (" + c.Detail + ")"
 	}
+	return c
+}
 
-	// Issues sanity
-	if repo.OpenIssues < 50 {
-		score += 15
+// ReleaseCadenceWeight is the most the release cadence adds to maturity
+const ReleaseCadenceWeight = 20
+
+// regularReleaseDays is the longest median gap between regular releases
+const regularReleaseDays = 90
+
+// ReleaseCadenceRating is the release cadence's part of the maturity score
+type ReleaseCadenceRating struct {
+	Points int    `json:"points"` // out of ReleaseCadenceWeight
+	Label  string `json:"label"`  // regular, occasional, stale, continuous or none
+	Detail string `json:"detail"`
+}
+
+// RateReleaseCadence scores releases: full marks for regular releases in
+// the last year, less for occasional or stale ones. Projects without
+// releases but with over 100 commits in the last year likely deploy
+// continuously, so they get half marks rather than none.
+func RateReleaseCadence(releases ReleaseStats, commits int) ReleaseCadenceRating {
+	days := releases.DaysSinceLatest()
+	switch {
+	case days < 0 && commits > 100:
+		return ReleaseCadenceRating{
+			Points: ReleaseCadenceWeight / 2,
+			Label:  "continuous",
+			Detail: "no releases, but active enough to be deploying continuously",
+		}
+	case days < 0:
+		return ReleaseCadenceRating{Label: "none", Detail: "no releases"}
 	}
 
-	level := "Prototype"
+	rating := ReleaseCadenceRating{Detail: fmt.Sprintf("last %d days ago", days)}
+	if releases.MedianDaysBetween > 0 {
+		rating.Detail = approxInterval(releases.MedianDaysBetween) + " apart, " + rating.Detail
+	}
 	switch {
-	case score >= 80:
-		level = "Production-Ready"
-	case score >= 60:
-		level = "Stable"
-	case score >= 40:
-		level = "Growing"
+	case days > 365:
+		rating.Points, rating.Label = ReleaseCadenceWeight/4, "stale"
+	case releases.MedianDaysBetween > 0 && releases.MedianDaysBetween <= regularReleaseDays:
+		rating.Points, rating.Label = ReleaseCadenceWeight, "regular"
+	default:
+		rating.Points, rating.Label = ReleaseCadenceWeight*3/4, "occasional"
 	}
+	return rating
+}
 
-	return score, level
+// Summary renders the rating, e.g. "regular, ~5 weeks apart, last 18 days ago"
+func (r ReleaseCadenceRating) Summary() string {
+	return r.Label + ", " + r.Detail
 }
@@ -0,0 +1,173 @@
+package analyzer
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/agnivo988/Repo-lyzer/internal/github"
+)
+
+func TestRateReleaseCadence(t *testing.T) {
+	released := func(daysAgo int, medianGap float64) ReleaseStats {
+		return ReleaseStats{
+			Count:             5,
+			LatestTag:         "v1.4.0",
+			LatestDate:        time.Now().AddDate(0, 0, -daysAgo),
+			MedianDaysBetween: medianGap,
+			Source:            VersionsFromReleases,
+		}
+	}
+	none := ReleaseStats{Source: NoVersioning}
+	tests := []struct {
+		name     string
+		releases ReleaseStats
+		commits  int
+		points   int
+		label    string
+	}{
+		// Without releases, an active project is neutral rather than
+		// marked down: it's probably deploying continuously
+		{"no releases, active", none, 500, ReleaseCadenceWeight / 2, "continuous"},
+		{"no releases, just active enough", none, 101, ReleaseCadenceWeight / 2, "continuous"},
+		{"no releases, at the activity cutoff", none, 100, 0, "none"},
+		{"no releases, quiet", none, 3, 0, "none"},
+		{"regular", released(18, 35), 40, ReleaseCadenceWeight, "regular"},
+		{"regular at the cutoff", released(18, regularReleaseDays), 40, ReleaseCadenceWeight, "regular"},
+		{"occasional", released(18, regularReleaseDays+1), 40, ReleaseCadenceWeight * 3 / 4, "occasional"},
+		{"single release", released(18, 0), 40, ReleaseCadenceWeight * 3 / 4, "occasional"},
+		{"stale", released(400, 35), 500, ReleaseCadenceWeight / 4, "stale"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := RateReleaseCadence(tt.releases, tt.commits)
+			if got.Points != tt.points || got.Label != tt.label {
+				t.Errorf("RateReleaseCadence() = %d (%s), want %d (%s)", got.Points, got.Label, tt.points, tt.label)
+			}
+		})
+	}
+}
+
+func TestNoReleasesIsNeutralInMaturity(t *testing.T) {
+	now := time.Now()
+	in := MaturityInput{
+		Repo:     &github.Repo{CreatedAt: now.AddDate(-1, 0, 0), PushedAt: now},
+		Commits:  300,
+		Releases: ReleaseStats{Source: NoVersioning},
+		Now:      now,
+	}
+	var releases ScoreComponent
+	for _, c := range ScoreMaturity(in).Components {
+		if c.Name == "releases" {
+			releases = c
+		}
+	}
+	if !releases.Evaluated || releases.Score != 0.5 {
+		t.Errorf("releases component = %+v, want evaluated at 0.5", releases)
+	}
+
+	in.Commits = 10
+	for _, c := range ScoreMaturity(in).Components {
+		if c.Name == "releases" && c.Score != 0 {
+			t.Errorf("a quiet repo without releases scored %.2f for releases, want 0", c.Score)
+		}
+	}
+}
+
+func TestScoreMaturityPinned(t *testing.T) {
+	now := time.Now()
+	fullReadme := ReadmeInfo{Exists: true, HasInstall: true, HasUsage: true}
+	tests := []struct {
+		name  string
+		in    MaturityInput
+		score int
+		level string
+		// notEvaluated lists the components left out of the weighting
+		notEvaluated []string
+	}{
+		{
+			name: "established library",
+			in: MaturityInput{
+				Repo:    &github.Repo{Description: "A library", CreatedAt: now.AddDate(-5, 0, 0), PushedAt: now},
+				Commits: 300,
+				Releases: ReleaseStats{Count: 30, LatestTag: "v3.2.0", LatestDate: now.AddDate(0, 0, -18),
+					MedianDaysBetween: 35, Semver: true, Source: VersionsFromReleases},
+				Readme:            fullReadme,
+				Tree:              blobs("CHANGELOG.md", ".github/workflows/ci.yml", "lib.go"),
+				License:           LicenseInfo{SPDXID: "MIT", Class: LicensePermissive},
+				ReleaseAutomation: ReleaseAutomation{Evaluated: true, Tools: []string{GoReleaser}},
+			},
+			score: 100,
+			level: MaturityMature,
+		},
+		{
+			// Age 0.2 of 3 years is the only credit: 1 of the 85 points evaluated
+			name: "new experiment",
+			in: MaturityInput{
+				Repo:     &github.Repo{CreatedAt: now.AddDate(0, 0, -73), PushedAt: now},
+				Commits:  20,
+				Releases: ReleaseStats{Source: NoVersioning},
+				Tree:     blobs("main.go"),
+				License:  LicenseInfo{Class: LicenseNone},
+			},
+			score:        1,
+			level:        MaturityExperimental,
+			notEvaluated: []string{"semver", "release_automation"},
+		},
+		{
+			// Age 10, continuous releases 10, docs 7.5, CI 10 and the
+			// license 15 make 52.5 of 85 points
+			name: "continuously deployed service",
+			in: MaturityInput{
+				Repo:     &github.Repo{Description: "A service", CreatedAt: now.AddDate(-2, 0, 0), PushedAt: now},
+				Commits:  400,
+				Releases: ReleaseStats{Source: NoVersioning},
+				Readme:   ReadmeInfo{Exists: true},
+				Tree:     blobs(".gitlab-ci.yml", "main.go"),
+				License:  LicenseInfo{SPDXID: "Apache-2.0", Class: LicensePermissive},
+			},
+			score:        62,
+			level:        MaturityDeveloping,
+			notEvaluated: []string{"semver", "release_automation"},
+		},
+		{
+			// Stale releases 5 and Travis-only CI 5 lose 20 of 100 points,
+			// and two idle years make it legacy whatever the score
+			name: "legacy",
+			in: MaturityInput{
+				Repo:    &github.Repo{Description: "Old faithful", CreatedAt: now.AddDate(-6, 0, 0), PushedAt: now.AddDate(-2, 0, 0)},
+				Commits: 0,
+				Releases: ReleaseStats{Count: 12, LatestTag: "v1.9.3", LatestDate: now.AddDate(0, 0, -800),
+					MedianDaysBetween: 60, Semver: true, Source: VersionsFromReleases},
+				Readme:  fullReadme,
+				Tree:    blobs("HISTORY.md", ".travis.yml"),
+				License: LicenseInfo{SPDXID: "GPL-3.0", Class: LicenseStrongCopyleft},
+			},
+			score:        80,
+			level:        MaturityLegacy,
+			notEvaluated: []string{"release_automation"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.in.Now = now
+			tt.in.CI = DetectCI(tt.in.Tree)
+			got := ScoreMaturity(tt.in)
+			if got.Score != tt.score || got.Grade != GradeFor(tt.score) || got.Level != tt.level {
+				t.Errorf("ScoreMaturity() = %d (%s, %s), want %d (%s)", got.Score, got.Grade, got.Level, tt.score, tt.level)
+				for _, c := range got.Components {
+					t.Logf("%s: %s, score %.2f, weight %d, %.1f points", c.Name, c.Detail, c.Score, c.Weight, c.Contribution)
+				}
+			}
+			var notEvaluated []string
+			for _, c := range got.Components {
+				if !c.Evaluated {
+					notEvaluated = append(notEvaluated, c.Name)
+				}
+			}
+			if !reflect.DeepEqual(notEvaluated, tt.notEvaluated) {
+				t.Errorf("not evaluated = %v, want %v", notEvaluated, tt.notEvaluated)
+			}
+		})
+	}
+}
@@ -0,0 +1,260 @@
+package analyzer
+
+import (
+	"encoding/json"
+	"maps"
+	"path"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/agnivo988/Repo-lyzer/internal/github"
+)
+
+// MaxMonorepoPackages is how many packages get their manifest fetched to
+// count dependencies
+const MaxMonorepoPackages = 25
+
+// monorepoTools are root files that only workspace setups have
+var monorepoTools = map[string]string{
+	"lerna.json":          "Lerna",
+	"nx.json":             "Nx",
+	"turbo.json":          "Turborepo",
+	"pnpm-workspace.yaml": "pnpm workspaces",
+	"go.work":             "Go workspace",
+	"rush.json":           "Rush",
+}
+
+// monorepoLayoutDirs are top-level directories conventionally holding one
+// package each
+var monorepoLayoutDirs = map[string]bool{"packages": true, "apps": true, "libs": true, "services": true}
+
+// exampleDirs hold samples and fixtures whose manifests aren't packages
+var exampleDirs = map[string]bool{
+	"example": true, "examples": true, "testdata": true, "fixtures": true, "docs": true, "samples": true,
+}
+
+// MonorepoPackage is one package of a monorepo
+type MonorepoPackage struct {
+	Path      string `json:"path"`
+	Ecosystem string `json:"ecosystem"`
+	Manifest  string `json:"manifest"` // the manifest's path from the root
+	Files     int    `json:"files"`
+	Language  string `json:"language"` // "" when no file is in a known language
+	// Dependencies counted from the manifest, -1 when it wasn't read
+	Dependencies int `json:"dependencies"`
+}
+
+// Monorepo describes a repository holding several packages
+type Monorepo struct {
+	Detected bool              `json:"detected"`
+	Signals  []string          `json:"signals,omitempty"` // why it looks like a monorepo
+	Packages []MonorepoPackage `json:"packages,omitempty"`
+}
+
+// DetectMonorepo looks for workspace tooling, a packages/ or apps/ layout,
+// or several manifests of one ecosystem in different directories. Each
+// directory with a manifest other than the root becomes a package; a
+// single-package repo gets a zero Monorepo.
+func DetectMonorepo(tree []github.TreeEntry) Monorepo {
+	manifests := make(map[string]string) // manifest path → ecosystem
+	perEcosystem := make(map[string]int)
+	var signals []string
+	for _, entry := range tree {
+		if entry.Type != "blob" || skippedPath(entry.Path) || inExampleDir(entry.Path) {
+			continue
+		}
+		if tool, ok := monorepoTools[entry.Path]; ok {
+			signals = append(signals, tool)
+		}
+		name := path.Base(entry.Path)
+		for _, eco := range ecosystems {
+			if slices.Contains(eco.Manifests, name) {
+				manifests[entry.Path] = eco.Name
+				if path.Dir(entry.Path) != "." {
+					perEcosystem[eco.Name]++
+				}
+				break
+			}
+		}
+	}
+
+	layout := false
+	for manifest := range manifests {
+		if top, _, nested := strings.Cut(manifest, "/"); nested && monorepoLayoutDirs[top] {
+			layout = true
+		}
+	}
+	if layout {
+		signals = append(signals, "packages/ or apps/ layout")
+	}
+	for _, eco := range ecosystems {
+		if perEcosystem[eco.Name] >= 2 {
+			signals = append(signals, "several "+eco.Name+" manifests")
+		}
+	}
+	if len(signals) == 0 {
+		return Monorepo{}
+	}
+	sort.Strings(signals)
+
+	// A directory with several manifests keeps the one ranked first, not
+	// whichever map order reaches first
+	byDir := make(map[string]*MonorepoPackage)
+	for _, manifest := range slices.Sorted(maps.Keys(manifests)) {
+		dir := path.Dir(manifest)
+		if dir == "." {
+			continue
+		}
+		if pkg := byDir[dir]; pkg != nil && manifestRank(pkg.Manifest) <= manifestRank(manifest) {
+			continue
+		}
+		byDir[dir] = &MonorepoPackage{Path: dir, Ecosystem: manifests[manifest], Manifest: manifest, Dependencies: -1}
+	}
+	packageLanguages(tree, byDir)
+
+	mono := Monorepo{Detected: len(byDir) > 0, Signals: signals}
+	if !mono.Detected {
+		return Monorepo{}
+	}
+	for _, pkg := range byDir {
+		mono.Packages = append(mono.Packages, *pkg)
+	}
+	sort.Slice(mono.Packages, func(i, j int) bool { return mono.Packages[i].Path < mono.Packages[j].Path })
+	return mono
+}
+
+// manifestRank orders manifest names as ecosystems lists them
+func manifestRank(manifest string) int {
+	rank := 0
+	for _, eco := range ecosystems {
+		for _, name := range eco.Manifests {
+			if name == path.Base(manifest) {
+				return rank
+			}
+			rank++
+		}
+	}
+	return rank
+}
+
+// packageLanguages counts each file towards the innermost package holding
+// it, and sets each package's language to the one with the most bytes
+func packageLanguages(tree []github.TreeEntry, packages map[string]*MonorepoPackage) {
+	bytes := make(map[string]map[string]int)
+	for _, entry := range tree {
+		if entry.Type != "blob" || skippedPath(entry.Path) {
+			continue
+		}
+		pkg := innermostPackage(entry.Path, packages)
+		if pkg == nil {
+			continue
+		}
+		pkg.Files++
+		if language, ok := extensionLanguages[fileExtension(entry.Path)]; ok {
+			if bytes[pkg.Path] == nil {
+				bytes[pkg.Path] = make(map[string]int)
+			}
+			bytes[pkg.Path][language] += entry.Size
+		}
+	}
+	for dir, languages := range bytes {
+		best := 0
+		for language, n := range languages {
+			if n > best || n == best && language < packages[dir].Language {
+				best, packages[dir].Language = n, language
+			}
+		}
+	}
+}
+
+// innermostPackage returns the deepest package directory above file
+func innermostPackage(file string, packages map[string]*MonorepoPackage) *MonorepoPackage {
+	for dir := path.Dir(file); dir != "."; dir = path.Dir(dir) {
+		if pkg := packages[dir]; pkg != nil {
+			return pkg
+		}
+	}
+	return nil
+}
+
+// inExampleDir reports whether a file is under a samples or fixtures directory
+func inExampleDir(p string) bool {
+	for _, dir := range strings.Split(path.Dir(p), "/") {
+		if exampleDirs[dir] {
+			return true
+		}
+	}
+	return false
+}
+
+// CountDependencies counts the dependencies declared in a manifest, or
+// returns -1 for formats it can't read
+func CountDependencies(manifest, content string) int {
+	switch path.Base(manifest) {
+	case "package.json", "composer.json":
+		var m map[string]json.RawMessage
+		if json.Unmarshal([]byte(content), &m) != nil {
+			return -1
+		}
+		count := 0
+		for _, key := range []string{"dependencies", "devDependencies", "peerDependencies", "optionalDependencies", "require", "require-dev"} {
+			var deps map[string]any
+			if json.Unmarshal(m[key], &deps) == nil {
+				count += len(deps)
+			}
+		}
+		return count
+	case "go.mod":
+		return countGoRequires(content)
+	case "Cargo.toml", "Pipfile":
+		return countTOMLTableKeys(content, "dependencies", "dev-dependencies", "build-dependencies", "packages", "dev-packages")
+	case "Gemfile":
+		count := 0
+		for _, line := range strings.Split(content, "\n") {
+			if strings.HasPrefix(strings.TrimSpace(line), "gem ") {
+				count++
+			}
+		}
+		return count
+	}
+	return -1
+}
+
+// countGoRequires counts the modules in go.mod require directives
+func countGoRequires(content string) int {
+	count, inBlock := 0, false
+	for _, line := range strings.Split(content, "\n") {
+		line = strings.TrimSpace(line)
+		switch {
+		case inBlock && line == ")":
+			inBlock = false
+		case inBlock && line != "" && !strings.HasPrefix(line, "//"):
+			count++
+		case line == "require (":
+			inBlock = true
+		case strings.HasPrefix(line, "require "):
+			count++
+		}
+	}
+	return count
+}
+
+// countTOMLTableKeys counts the keys under the named TOML tables
+func countTOMLTableKeys(content string, tables ...string) int {
+	wanted := make(map[string]bool, len(tables))
+	for _, t := range tables {
+		wanted["["+t+"]"] = true
+	}
+	count, inTable := 0, false
+	for _, line := range strings.Split(content, "\n") {
+		line = strings.TrimSpace(line)
+		switch {
+		case strings.HasPrefix(line, "["):
+			inTable = wanted[line]
+		case inTable && strings.Contains(line, "=") && !strings.HasPrefix(line, "#"):
+			count++
+		}
+	}
+	return count
+}
@@ -0,0 +1,38 @@
+package analyzer
+
+import (
+	"testing"
+
+	"github.com/agnivo988/Repo-lyzer/internal/github"
+)
+
+func TestDetectMonorepoManifestPerDirectory(t *testing.T) {
+	blob := func(path string) github.TreeEntry {
+		return github.TreeEntry{Path: path, Type: "blob"}
+	}
+	tree := []github.TreeEntry{
+		blob("package.json"),
+		blob("packages/a/pyproject.toml"),
+		blob("packages/a/package.json"),
+		{Path: "packages/a/index.js", Type: "blob", Size: 100},
+		blob("packages/b/Pipfile"),
+		blob("packages/b/pyproject.toml"),
+	}
+	want := []MonorepoPackage{
+		{Path: "packages/a", Ecosystem: "npm", Manifest: "packages/a/package.json", Files: 3, Language: "JavaScript", Dependencies: -1},
+		{Path: "packages/b", Ecosystem: "Python", Manifest: "packages/b/pyproject.toml", Files: 2, Dependencies: -1},
+	}
+
+	// Map order varies between runs, so check the choice is stable
+	for run := 0; run < 50; run++ {
+		mono := DetectMonorepo(tree)
+		if !mono.Detected || len(mono.Packages) != len(want) {
+			t.Fatalf("run %d: got %+v, want %d packages", run, mono, len(want))
+		}
+		for i, pkg := range mono.Packages {
+			if pkg != want[i] {
+				t.Fatalf("run %d: package %d = %+v, want %+v", run, i, pkg, want[i])
+			}
+		}
+	}
+}
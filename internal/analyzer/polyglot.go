@@ -0,0 +1,100 @@
+package analyzer
+
+import (
+	"fmt"
+	"math"
+)
+
+// SignificantLanguageShare is the share of the code a language needs to
+// count as significant
+const SignificantLanguageShare = 0.05
+
+// LanguageProfile describes how concentrated a codebase is in one language
+type LanguageProfile struct {
+	Evaluated    bool    `json:"evaluated"`
+	Primary      string  `json:"primary"`
+	PrimaryShare float64 `json:"primary_share"`
+	Languages    int     `json:"languages"`
+	// Significant counts the languages with at least
+	// SignificantLanguageShare of the code
+	Significant int `json:"significant"`
+	// Polyglot is the entropy of the language shares over its maximum,
+	// from 0 for a single language to 1 for an even split
+	Polyglot float64 `json:"polyglot"`
+}
+
+// AnalyzeLanguageProfile derives the profile from a language breakdown in
+// bytes. OtherLanguage, files in no known language, is left out.
+func AnalyzeLanguageProfile(languages map[string]int) LanguageProfile {
+	shares := languageShares(languages)
+	if len(shares) == 0 {
+		return LanguageProfile{}
+	}
+	p := LanguageProfile{Evaluated: true, Languages: len(shares)}
+	entropy := 0.0
+	for language, share := range shares {
+		if share > p.PrimaryShare || share == p.PrimaryShare && language < p.Primary {
+			p.Primary, p.PrimaryShare = language, share
+		}
+		if share >= SignificantLanguageShare {
+			p.Significant++
+		}
+		entropy -= share * math.Log(share)
+	}
+	if len(shares) > 1 {
+		p.Polyglot = entropy / math.Log(float64(len(shares)))
+	}
+	return p
+}
+
+// languageShares turns bytes per language into shares of the total
+func languageShares(languages map[string]int) map[string]float64 {
+	total := 0
+	for language, bytes := range languages {
+		if language != OtherLanguage && bytes > 0 {
+			total += bytes
+		}
+	}
+	if total == 0 {
+		return nil
+	}
+	shares := make(map[string]float64, len(languages))
+	for language, bytes := range languages {
+		if language != OtherLanguage && bytes > 0 {
+			shares[language] = float64(bytes) / float64(total)
+		}
+	}
+	return shares
+}
+
+// LanguageSimilarity is the cosine similarity of two language breakdowns,
+// from 0 for no language in common to 1 for the same mix; -1 when either
+// is empty
+func LanguageSimilarity(a, b map[string]int) float64 {
+	sharesA, sharesB := languageShares(a), languageShares(b)
+	if len(sharesA) == 0 || len(sharesB) == 0 {
+		return -1
+	}
+	dot, normA, normB := 0.0, 0.0, 0.0
+	for language, share := range sharesA {
+		dot += share * sharesB[language]
+		normA += share * share
+	}
+	for _, share := range sharesB {
+		normB += share * share
+	}
+	return dot / math.Sqrt(normA*normB)
+}
+
+// Summary renders the profile, e.g. "Primary: Go (82%), 3 significant
+// languages"
+func (p LanguageProfile) Summary() string {
+	if !p.Evaluated {
+		return "unknown"
+	}
+	summary := fmt.Sprintf("Primary: %s (%.0f%%)", p.Primary, p.PrimaryShare*100)
+	if p.Significant == 1 {
+		return summary + ", 1 significant language"
+	}
+	return summary + fmt.Sprintf(", %d significant languages", p.Significant)
+}
@@ -0,0 +1,78 @@
+package analyzer
+
+import (
+	"fmt"
+
+	"github.com/agnivo988/Repo-lyzer/internal/github"
+)
+
+// PullRequestStats summarises a project's pull request throughput
+type PullRequestStats struct {
+	Evaluated         bool    `json:"evaluated"` // false when the PR data couldn't be fetched
+	OpenPRs           int     `json:"open_p_rs"`
+	MergedLast90Days  int     `json:"merged_last_90_days"`
+	MedianDaysToMerge float64 `json:"median_days_to_merge"` // over merged, non-draft PRs in the sample
+	MergeSampleSize   int     `json:"merge_sample_size"`
+	Merged            int     `json:"merged"`          // merged PRs in the sample
+	ClosedUnmerged    int     `json:"closed_unmerged"` // PRs in the sample closed without merging
+	// UnreviewedOpen counts open PRs without any review, -1 when unknown
+	UnreviewedOpen int `json:"unreviewed_open"`
+}
+
+// AnalyzePullRequests fills in the sample-based stats: median time to merge
+// (drafts excluded, since they're not waiting on review) and merge outcomes
+func AnalyzePullRequests(sample []github.PullRequest) PullRequestStats {
+	var stats PullRequestStats
+	var days []float64
+	for _, pr := range sample {
+		switch {
+		case pr.IsMerged():
+			stats.Merged++
+			if !pr.Draft {
+				days = append(days, pr.MergedAt.Sub(pr.CreatedAt).Hours()/24)
+			}
+		case pr.State == "closed":
+			stats.ClosedUnmerged++
+		}
+	}
+	stats.MedianDaysToMerge = median(days)
+	stats.MergeSampleSize = len(days)
+	return stats
+}
+
+// MergeRatio returns merged PRs per PR closed without merging, or -1 when
+// none were closed unmerged
+func (s PullRequestStats) MergeRatio() float64 {
+	if s.ClosedUnmerged == 0 {
+		return -1
+	}
+	return float64(s.Merged) / float64(s.ClosedUnmerged)
+}
+
+// HasMergeTimes reports whether a time-to-merge figure is available
+func (s PullRequestStats) HasMergeTimes() bool {
+	return s.Evaluated && s.MergeSampleSize > 0
+}
+
+// MergeRatioLabel renders the merged:closed-unmerged ratio
+func (s PullRequestStats) MergeRatioLabel() string {
+	if s.Merged+s.ClosedUnmerged == 0 {
+		return "n/a"
+	}
+	if s.ClosedUnmerged == 0 {
+		return fmt.Sprintf("%d merged, none rejected", s.Merged)
+	}
+	return fmt.Sprintf("%.1f:1 (%d merged, %d closed unmerged)", s.MergeRatio(), s.Merged, s.ClosedUnmerged)
+}
+
+// Summary renders the stats for display
+func (s PullRequestStats) Summary() string {
+	if !s.Evaluated {
+		return "Pull request data unavailable"
+	}
+	summary := fmt.Sprintf("%d open, %d merged in the last 90 days", s.OpenPRs, s.MergedLast90Days)
+	if s.MergeSampleSize > 0 {
+		summary += fmt.Sprintf(", median time to merge %s", formatDays(s.MedianDaysToMerge))
+	}
+	return summary
+}
@@ -0,0 +1,181 @@
+package analyzer
+
+import (
+	"fmt"
+	"path"
+	"regexp"
+	"strings"
+)
+
+// README formats
+const (
+	ReadmeMarkdown = "markdown"
+	ReadmeOther    = "other" // rst, txt, ... (word count only)
+)
+
+var (
+	markdownHeading = regexp.MustCompile(`(?m)^#{1,6}\s+(.+)$`)
+	htmlHeading     = regexp.MustCompile(`(?i)<h[1-6][^>]*>([^<]*)`)
+	markdownImage   = regexp.MustCompile(`!\[[^\]]*\]\(\s*<?([^)\s>]+)`)
+	htmlImage       = regexp.MustCompile(`(?i)<img[^>]+src\s*=\s*["']([^"']+)`)
+	markdownLink    = regexp.MustCompile(`\]\(\s*<?([^)\s>]+)`)
+	htmlLink        = regexp.MustCompile(`(?i)<a[^>]+href\s*=\s*["']([^"']+)`)
+	anchorLink      = regexp.MustCompile(`\]\(#[^)]+\)`)
+	badgeURL        = regexp.MustCompile(`(?i)shields\.io|badge|/workflows/|travis-ci|codecov|goreportcard`)
+)
+
+// Heading keywords of the README sections, lowercase, in the languages
+// READMEs are most often written in, so that non-English READMEs aren't
+// flagged as missing everything
+var (
+	installKeywords = []string{"install", "getting started", "setup", "set up", "instalación", "instalacion",
+		"instalação", "instalacao", "installieren", "einrichtung", "安装", "インストール", "установка", "설치"}
+	usageKeywords = []string{"usage", "example", "quick start", "quickstart", "how to use", "uso", "utilisation",
+		"exemple", "ejemplo", "exemplo", "verwendung", "benutzung", "beispiel", "使用", "用法", "示例", "使い方",
+		"использование", "пример", "사용"}
+	contributingKeywords = []string{"contribut", "contribuir", "contribuer", "mitwirken", "beitragen", "mitmachen",
+		"贡献", "貢献", "участие", "вклад", "기여"}
+	licenseKeywords = []string{"license", "licence", "licencia", "licença", "licenca", "lizenz", "许可", "授权",
+		"ライセンス", "лицензия", "라이선스"}
+	tocKeywords = []string{"table of contents", "contents", "toc", "índice", "indice", "sommaire", "inhalt",
+		"目录", "目次", "содержание", "оглавление", "목차"}
+)
+
+// ReadmeInfo records basic facts about a repo's README
+type ReadmeInfo struct {
+	Exists          bool   `json:"exists"`
+	Path            string `json:"path"`
+	Format          string `json:"format"`
+	WordCount       int    `json:"word_count"`
+	Headings        int    `json:"headings"`
+	HasBadges       bool   `json:"has_badges"`
+	Badges          int    `json:"badges"`
+	Images          int    `json:"images"` // images other than badges, e.g. screenshots
+	HasCodeBlocks   bool   `json:"has_code_blocks"`
+	HasInstall      bool   `json:"has_install"`
+	HasUsage        bool   `json:"has_usage"`
+	HasContributing bool   `json:"has_contributing"`
+	HasLicense      bool   `json:"has_license"` // a license section, rather than a mention
+	HasTOC          bool   `json:"has_toc"`
+	MentionsLicense bool   `json:"mentions_license"`
+	// RelativeLinks are the link and image targets within the repo, as
+	// written
+	RelativeLinks []string `json:"relative_links"`
+}
+
+// AnalyzeReadme computes ReadmeInfo from a README's path and decoded text.
+// Section detection only runs on Markdown; other formats get a word count.
+func AnalyzeReadme(filePath, text string) ReadmeInfo {
+	info := ReadmeInfo{
+		Exists:    true,
+		Path:      filePath,
+		Format:    ReadmeOther,
+		WordCount: len(strings.Fields(text)),
+	}
+
+	switch strings.ToLower(path.Ext(filePath)) {
+	case ".md", ".markdown", ".mdown", ".mkd":
+		info.Format = ReadmeMarkdown
+	default:
+		return info
+	}
+
+	var headings []string
+	for _, m := range markdownHeading.FindAllStringSubmatch(text, -1) {
+		headings = append(headings, strings.ToLower(m[1]))
+	}
+	for _, m := range htmlHeading.FindAllStringSubmatch(text, -1) {
+		headings = append(headings, strings.ToLower(m[1]))
+	}
+	info.Headings = len(headings)
+
+	for _, h := range headings {
+		switch {
+		case containsAnyOf(h, installKeywords):
+			info.HasInstall = true
+		case containsAnyOf(h, usageKeywords):
+			info.HasUsage = true
+		case containsAnyOf(h, contributingKeywords):
+			info.HasContributing = true
+		case containsAnyOf(h, licenseKeywords):
+			info.HasLicense = true
+		case containsAnyOf(h, tocKeywords):
+			info.HasTOC = true
+		}
+	}
+	if len(anchorLink.FindAllString(text, 3)) == 3 {
+		info.HasTOC = true
+	}
+
+	for _, pattern := range []*regexp.Regexp{markdownImage, htmlImage} {
+		for _, m := range pattern.FindAllStringSubmatch(text, -1) {
+			if badgeURL.MatchString(m[1]) {
+				info.Badges++
+			} else {
+				info.Images++
+			}
+		}
+	}
+	seen := make(map[string]bool)
+	for _, pattern := range []*regexp.Regexp{markdownLink, htmlLink, htmlImage} {
+		for _, m := range pattern.FindAllStringSubmatch(text, -1) {
+			if target := m[1]; isRelativeLink(target) && !seen[target] {
+				seen[target] = true
+				info.RelativeLinks = append(info.RelativeLinks, target)
+			}
+		}
+	}
+
+	lower := strings.ToLower(text)
+	info.HasBadges = info.Badges > 0
+	info.HasCodeBlocks = strings.Contains(text, "```") || strings.Contains(text, "~~~")
+	info.MentionsLicense = info.HasLicense || containsAnyOf(lower, licenseKeywords)
+	return info
+}
+
+// isRelativeLink reports whether a link target points into the repo
+// rather than at a URL, an anchor or an email address
+func isRelativeLink(target string) bool {
+	if target == "" || strings.HasPrefix(target, "#") || strings.HasPrefix(target, "//") {
+		return false
+	}
+	// A colon before any slash starts a scheme: https:, mailto:, data:...
+	i := strings.IndexAny(target, ":/")
+	return i < 0 || target[i] == '/'
+}
+
+// Summary renders the README facts for display, e.g.
+// "1,240 words, has install + usage sections"
+func (r ReadmeInfo) Summary() string {
+	if !r.Exists {
+		return "No README"
+	}
+
+	summary := fmt.Sprintf("%s words", formatThousands(r.WordCount))
+	if r.Format != ReadmeMarkdown {
+		return summary
+	}
+
+	var sections []string
+	if r.HasInstall {
+		sections = append(sections, "install")
+	}
+	if r.HasUsage {
+		sections = append(sections, "usage")
+	}
+	if len(sections) > 0 {
+		summary += ", has " + strings.Join(sections, " + ") + " sections"
+	} else {
+		summary += ", no install or usage section"
+	}
+	return summary
+}
+
+// formatThousands renders n with comma separators
+func formatThousands(n int) string {
+	s := fmt.Sprint(n)
+	for i := len(s) - 3; i > 0; i -= 3 {
+		s = s[:i] + "," + s[i:]
+	}
+	return s
+}
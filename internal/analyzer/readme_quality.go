@@ -0,0 +1,192 @@
+package analyzer
+
+import (
+	"fmt"
+	"net/url"
+	"path"
+	"strings"
+
+	"github.com/agnivo988/Repo-lyzer/internal/github"
+)
+
+const (
+	// substantialReadmeWords is the length at which a README whose
+	// headings match no section keyword, likely one in another language,
+	// is taken to cover the sections anyway
+	substantialReadmeWords = 300
+	// tocHeadings is the number of headings from which a README is long
+	// enough to want a table of contents
+	tocHeadings = 6
+)
+
+// ReadmeCheck is one item of the README quality checklist
+type ReadmeCheck struct {
+	Name   string `json:"name"`
+	Passed bool   `json:"passed"`
+	Weight int    `json:"weight"`
+	// Skipped checks couldn't be made, e.g. links without a file tree, and
+	// don't count toward the score
+	Skipped bool   `json:"skipped,omitempty"`
+	Detail  string `json:"detail,omitempty"`
+}
+
+// ReadmeQuality scores how well a README is put together
+type ReadmeQuality struct {
+	Evaluated bool          `json:"evaluated"`
+	Checks    []ReadmeCheck `json:"checks"`
+	// BrokenLinks are relative links to files not in the tree
+	BrokenLinks []string `json:"broken_links,omitempty"`
+	// LengthBased is set when no section heading could be recognized and
+	// the sections were judged by the README's length instead
+	LengthBased bool `json:"length_based"`
+	Score       int  `json:"score"` // from 0 to 100
+}
+
+// ScoreReadme checks a README for installation, usage, contributing and
+// license sections (15, 15, 10 and 10 points), badges, a code block, an
+// image and a table of contents (10 each), and relative links that resolve
+// in the file tree (10). Headings are matched in several languages; when
+// none match, a README of substantialReadmeWords or more passes the
+// section checks on length alone. The table of contents is only expected
+// from tocHeadings headings, and links aren't checked without the tree.
+func ScoreReadme(readme ReadmeInfo, tree []github.TreeEntry, treeUnavailable bool) ReadmeQuality {
+	if !readme.Exists {
+		return ReadmeQuality{}
+	}
+	q := ReadmeQuality{Evaluated: true}
+	markdown := readme.Format == ReadmeMarkdown
+
+	sections := []ReadmeCheck{
+		{Name: "Installation", Passed: readme.HasInstall, Weight: 15},
+		{Name: "Usage", Passed: readme.HasUsage, Weight: 15},
+		{Name: "Contributing", Passed: readme.HasContributing, Weight: 10},
+		{Name: "License", Passed: readme.HasLicense, Weight: 10},
+	}
+	if !readme.HasInstall && !readme.HasUsage && !readme.HasContributing && !readme.HasLicense {
+		q.LengthBased = true
+		for i := range sections {
+			sections[i].Passed = readme.WordCount >= substantialReadmeWords
+			sections[i].Detail = "judged by length"
+		}
+	}
+	q.Checks = append(q.Checks, sections...)
+
+	links := ReadmeCheck{Name: "Working links", Passed: true, Weight: 10}
+	if treeUnavailable || !markdown {
+		links.Skipped, links.Detail = true, "not checked"
+	} else {
+		q.BrokenLinks = brokenLinks(readme, tree)
+		links.Passed = len(q.BrokenLinks) == 0
+		if !links.Passed {
+			links.Detail = fmt.Sprintf("%d broken", len(q.BrokenLinks))
+		}
+	}
+	toc := ReadmeCheck{Name: "Table of contents", Passed: readme.HasTOC, Weight: 10}
+	if !readme.HasTOC && readme.Headings < tocHeadings {
+		toc.Skipped, toc.Detail = true, "short enough without one"
+	}
+	q.Checks = append(q.Checks,
+		ReadmeCheck{Name: "Badges", Passed: readme.Badges > 0, Weight: 10, Detail: countDetail(readme.Badges)},
+		ReadmeCheck{Name: "Code example", Passed: readme.HasCodeBlocks, Weight: 10},
+		ReadmeCheck{Name: "Image or screenshot", Passed: readme.Images > 0, Weight: 10, Detail: countDetail(readme.Images)},
+		toc,
+		links,
+	)
+	if !markdown {
+		// Only Markdown is parsed for these
+		for i := len(sections); i < len(q.Checks); i++ {
+			q.Checks[i].Skipped, q.Checks[i].Detail = true, "not checked"
+		}
+	}
+
+	passed, total := 0, 0
+	for _, c := range q.Checks {
+		if c.Skipped {
+			continue
+		}
+		total += c.Weight
+		if c.Passed {
+			passed += c.Weight
+		}
+	}
+	if total > 0 {
+		q.Score = NormalizeScore(float64(passed) * 100 / float64(total))
+	}
+	return q
+}
+
+func countDetail(n int) string {
+	if n == 0 {
+		return ""
+	}
+	return fmt.Sprint(n)
+}
+
+// brokenLinks returns the README's relative links whose file isn't in the
+// tree. Links are resolved from the README's directory, or from the root
+// when they start with a slash; ones climbing out of the repo are left
+// alone, as GitHub resolves them to other pages of the site.
+func brokenLinks(readme ReadmeInfo, tree []github.TreeEntry) []string {
+	paths := make(map[string]bool, len(tree))
+	for _, entry := range tree {
+		paths[entry.Path] = true
+	}
+	var broken []string
+	for _, link := range readme.RelativeLinks {
+		target, _, _ := strings.Cut(link, "#")
+		target, _, _ = strings.Cut(target, "?")
+		if unescaped, err := url.PathUnescape(target); err == nil {
+			target = unescaped
+		}
+		if strings.HasPrefix(target, "/") {
+			target = path.Clean(strings.TrimPrefix(target, "/"))
+		} else {
+			target = path.Join(path.Dir(readme.Path), target)
+		}
+		if target == "." || target == "" || strings.HasPrefix(target, "..") {
+			continue
+		}
+		if !paths[target] {
+			broken = append(broken, link)
+		}
+	}
+	return broken
+}
+
+// Mark is ✓ for a passed check, ✗ for a failed one and – for one skipped
+func (c ReadmeCheck) Mark() string {
+	switch {
+	case c.Skipped:
+		return "–"
+	case c.Passed:
+		return "✓"
+	}
+	return "✗"
+}
+
+// Missing names the checks that failed
+func (q ReadmeQuality) Missing() []string {
+	var missing []string
+	for _, c := range q.Checks {
+		if !c.Skipped && !c.Passed {
+			missing = append(missing, strings.ToLower(c.Name))
+		}
+	}
+	return missing
+}
+
+// Summary renders the score and what's missing, e.g.
+// "70/100; missing: contributing, image or screenshot"
+func (q ReadmeQuality) Summary() string {
+	if !q.Evaluated {
+		return "not evaluated"
+	}
+	summary := fmt.Sprintf("%d/100", q.Score)
+	if missing := q.Missing(); len(missing) > 0 {
+		summary += "; missing: " + strings.Join(missing, ", ")
+	}
+	if q.LengthBased {
+		summary += " (sections judged by length)"
+	}
+	return summary
+}
@@ -0,0 +1,136 @@
+package analyzer
+
+import (
+	"path"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/agnivo988/Repo-lyzer/internal/github"
+)
+
+// Release automation tools
+const (
+	SemanticRelease = "semantic-release"
+	ReleasePlease   = "release-please"
+	GoReleaser      = "goreleaser"
+	Changesets      = "changesets"
+)
+
+// releaseTools is the display order of the tools
+var releaseTools = []string{GoReleaser, ReleasePlease, SemanticRelease, Changesets}
+
+// MaxReleaseWorkflows caps how many workflow files are read for release
+// steps
+const MaxReleaseWorkflows = 5
+
+// releaseConfigFiles are the root config files of each tool, lowercased
+var releaseConfigFiles = map[string]string{
+	".releaserc":                    SemanticRelease,
+	".releaserc.json":               SemanticRelease,
+	".releaserc.yml":                SemanticRelease,
+	".releaserc.yaml":               SemanticRelease,
+	".releaserc.js":                 SemanticRelease,
+	".releaserc.cjs":                SemanticRelease,
+	"release.config.js":             SemanticRelease,
+	"release.config.cjs":            SemanticRelease,
+	"release-please-config.json":    ReleasePlease,
+	".release-please-manifest.json": ReleasePlease,
+	".goreleaser.yml":               GoReleaser,
+	".goreleaser.yaml":              GoReleaser,
+	"goreleaser.yml":                GoReleaser,
+	"goreleaser.yaml":               GoReleaser,
+	".changeset/config.json":        Changesets,
+}
+
+// workflowStep is a workflow's uses: or run: line; other lines, comments
+// and step names included, can mention a tool without running it
+var workflowStep = regexp.MustCompile(`(?m)^[\s-]*(?:uses|run)\s*:\s*(.+)$`)
+
+// releaseStepMarkers are what a uses: or run: line contains when it runs
+// a tool
+var releaseStepMarkers = map[string][]string{
+	GoReleaser:      {"goreleaser"},
+	ReleasePlease:   {"release-please"},
+	SemanticRelease: {"semantic-release"},
+	Changesets:      {"changesets/action", "changeset publish", "changeset version"},
+}
+
+// ReleaseAutomation records the tools that cut releases automatically
+type ReleaseAutomation struct {
+	Evaluated bool     `json:"evaluated"`
+	Tools     []string `json:"tools,omitempty"`
+	// Workflows are the workflow files read for release steps
+	Workflows []string `json:"workflows,omitempty"`
+}
+
+// DetectReleaseAutomation finds the tools' config files in the tree, and
+// picks the workflows to read for release steps: those named for releasing
+// first, up to MaxReleaseWorkflows
+func DetectReleaseAutomation(tree []github.TreeEntry) ReleaseAutomation {
+	if len(tree) == 0 {
+		return ReleaseAutomation{}
+	}
+	a := ReleaseAutomation{Evaluated: true}
+	var named, other []string
+	for _, entry := range tree {
+		if entry.Type != "blob" {
+			continue
+		}
+		lower := strings.ToLower(entry.Path)
+		if tool, ok := releaseConfigFiles[lower]; ok {
+			a.add(tool)
+		}
+		dir, file := path.Split(lower)
+		if dir != ".github/workflows/" || !isTemplateFile(file) || path.Ext(file) == ".md" {
+			continue
+		}
+		if containsAnyOf(file, []string{"release", "publish", "deploy"}) || strings.HasPrefix(file, "cd.") {
+			named = append(named, entry.Path)
+		} else {
+			other = append(other, entry.Path)
+		}
+	}
+	workflows := append(named, other...)
+	a.Workflows = workflows[:min(len(workflows), MaxReleaseWorkflows)]
+	return a
+}
+
+// AddWorkflow records the tools a workflow's uses: and run: lines run
+func (a *ReleaseAutomation) AddWorkflow(text string) {
+	for _, m := range workflowStep.FindAllStringSubmatch(text, -1) {
+		step := strings.ToLower(m[1])
+		for tool, markers := range releaseStepMarkers {
+			if containsAnyOf(step, markers) {
+				a.add(tool)
+			}
+		}
+	}
+}
+
+// add records a tool, keeping Tools in display order
+func (a *ReleaseAutomation) add(tool string) {
+	if slices.Contains(a.Tools, tool) {
+		return
+	}
+	a.Tools = append(a.Tools, tool)
+	slices.SortFunc(a.Tools, func(x, y string) int {
+		return slices.Index(releaseTools, x) - slices.Index(releaseTools, y)
+	})
+}
+
+// Automated reports whether any tool was found
+func (a ReleaseAutomation) Automated() bool {
+	return len(a.Tools) > 0
+}
+
+// Summary renders the tools, e.g. "goreleaser + release-please"
+func (a ReleaseAutomation) Summary() string {
+	switch {
+	case !a.Evaluated:
+		return "not evaluated"
+	case !a.Automated():
+		return "none found"
+	}
+	return strings.Join(a.Tools, " + ")
+}
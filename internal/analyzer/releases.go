@@ -0,0 +1,138 @@
+package analyzer
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"time"
+
+	"github.com/agnivo988/Repo-lyzer/internal/github"
+)
+
+// cadenceWindow is how many recent releases the release interval is measured over
+const cadenceWindow = 10
+
+var semverTag = regexp.MustCompile(`^v?\d+\.\d+\.\d+`)
+
+// VersionSource records where a repository's version history comes from
+type VersionSource string
+
+const (
+	VersionsFromReleases VersionSource = "releases"
+	VersionsFromTags     VersionSource = "tags only"
+	NoVersioning         VersionSource = "none"
+)
+
+// ReleaseStats summarises a repository's release history
+type ReleaseStats struct {
+	Count             int           `json:"count"`
+	LatestTag         string        `json:"latest_tag"`
+	LatestDate        time.Time     `json:"latest_date"`         // zero when unknown
+	MedianDaysBetween float64       `json:"median_days_between"` // over the last cadenceWindow releases; 0 with fewer than two
+	Semver            bool          `json:"semver"`              // at least 80% of tags look like semantic versions
+	Source            VersionSource `json:"source"`              // releases, version tags, or neither
+}
+
+// ReleaseCadence computes release statistics, ignoring drafts
+func ReleaseCadence(releases []github.Release) ReleaseStats {
+	var published []github.Release
+	for _, r := range releases {
+		if !r.Draft {
+			published = append(published, r)
+		}
+	}
+
+	stats := ReleaseStats{Count: len(published), Source: VersionsFromReleases}
+	if len(published) == 0 {
+		stats.Source = NoVersioning
+		return stats
+	}
+
+	sort.Slice(published, func(i, j int) bool {
+		return releaseDate(published[i]).After(releaseDate(published[j]))
+	})
+	stats.LatestTag = published[0].TagName
+	stats.LatestDate = releaseDate(published[0])
+
+	semverCount := 0
+	for _, r := range published {
+		if semverTag.MatchString(r.TagName) {
+			semverCount++
+		}
+	}
+	stats.Semver = float64(semverCount)/float64(len(published)) >= 0.8
+
+	recent := published
+	if len(recent) > cadenceWindow {
+		recent = recent[:cadenceWindow]
+	}
+	var gaps []float64
+	for i := 1; i < len(recent); i++ {
+		gaps = append(gaps, releaseDate(recent[i-1]).Sub(releaseDate(recent[i])).Hours()/24)
+	}
+	stats.MedianDaysBetween = median(gaps)
+
+	return stats
+}
+
+// DaysSinceLatest returns the age of the latest release in days, or -1 when unknown
+func (s ReleaseStats) DaysSinceLatest() int {
+	if s.Count == 0 || s.LatestDate.IsZero() {
+		return -1
+	}
+	return int(time.Since(s.LatestDate).Hours() / 24)
+}
+
+// Summary renders the stats as e.g. "42 releases, latest v2.3.1 on 2024-11-02, ~6 weeks apart"
+func (s ReleaseStats) Summary() string {
+	if s.Count == 0 {
+		return "No releases or version tags"
+	}
+
+	noun := "releases"
+	if s.Source == VersionsFromTags {
+		noun = "version tags (no GitHub Releases)"
+	} else if s.Count == 1 {
+		noun = "release"
+	}
+	summary := fmt.Sprintf("%d %s, latest %s", s.Count, noun, s.LatestTag)
+	if !s.LatestDate.IsZero() {
+		summary += " on " + s.LatestDate.Format("2006-01-02")
+	}
+	if s.MedianDaysBetween > 0 {
+		summary += ", " + approxInterval(s.MedianDaysBetween) + " apart"
+	}
+	return summary
+}
+
+func releaseDate(r github.Release) time.Time {
+	if r.PublishedAt.IsZero() {
+		return r.CreatedAt
+	}
+	return r.PublishedAt
+}
+
+// approxInterval renders a day count as "~3 days", "~6 weeks" or "~4 months"
+func approxInterval(days float64) string {
+	switch {
+	case days < 14:
+		return fmt.Sprintf("~%.0f days", days)
+	case days < 60:
+		return fmt.Sprintf("~%.0f weeks", days/7)
+	default:
+		return fmt.Sprintf("~%.0f months", days/30)
+	}
+}
+
+func median(values []float64) float64 {
+	if len(values) == 0 {
+		return 0
+	}
+	sorted := append([]float64(nil), values...)
+	sort.Float64s(sorted)
+	mid := len(sorted) / 2
+	if len(sorted)%2 == 0 {
+		return (sorted[mid-1] + sorted[mid]) / 2
+	}
+	return sorted[mid]
+}
@@ -0,0 +1,86 @@
+package analyzer
+
+import "fmt"
+
+// Issue responsiveness thresholds
+const (
+	// StaleIssueDays is the age after which an open issue counts as stale
+	StaleIssueDays = 180
+	// MinResponsivenessSample is the fewest closed issues in the sample
+	// needed to judge responsiveness
+	MinResponsivenessSample = 5
+	// responsiveScore and slowScore are the lowest scores labelled
+	// responsive and slow; anything lower is unresponsive
+	responsiveScore = 0.7
+	slowScore       = 0.4
+)
+
+// Responsiveness labels
+const (
+	Responsive            = "responsive"
+	SlowToRespond         = "slow"
+	Unresponsive          = "unresponsive"
+	InsufficientIssueData = "insufficient data"
+)
+
+// IssueResponsiveness rates how well maintainers keep up with issues
+type IssueResponsiveness struct {
+	Evaluated bool    `json:"evaluated"`
+	Label     string  `json:"label"`
+	Score     float64 `json:"score"` // from 0 to 1
+	// The three signals averaged into Score
+	MedianDaysToClose float64 `json:"median_days_to_close"`
+	StaleShare        float64 `json:"stale_share"`       // of open issues, older than StaleIssueDays
+	OpenedPerClosed   float64 `json:"opened_per_closed"` // over the last 90 days; -1 if none closed
+}
+
+// RateIssueResponsiveness averages three scores from 0 to 1: the median
+// time to close (full marks within a week, none past 90 days), the share
+// of open issues that aren't stale, and issues closed keeping up with
+// those opened over the last 90 days (full marks at parity, none at 3:1).
+func RateIssueResponsiveness(stats IssueStats) IssueResponsiveness {
+	if !stats.Enabled || !stats.Evaluated || stats.SampleSize < MinResponsivenessSample {
+		return IssueResponsiveness{Label: InsufficientIssueData}
+	}
+
+	r := IssueResponsiveness{Evaluated: true, MedianDaysToClose: stats.MedianDaysToClose}
+	if stats.OpenIssues > 0 {
+		r.StaleShare = float64(stats.StaleOpen) / float64(stats.OpenIssues)
+	}
+
+	flow := 1.0
+	switch {
+	case stats.ClosedLast90Days > 0:
+		r.OpenedPerClosed = float64(stats.OpenedLast90Days) / float64(stats.ClosedLast90Days)
+		flow = decline(r.OpenedPerClosed, 1, 3)
+	case stats.OpenedLast90Days > 0:
+		r.OpenedPerClosed = -1
+		flow = 0
+	}
+
+	// Clamped, as inconsistent counts (more stale issues than open ones)
+	// would otherwise push it out of range
+	r.Score = clampUnit((decline(r.MedianDaysToClose, 7, 90) + (1 - r.StaleShare) + flow) / 3)
+	switch {
+	case r.Score >= responsiveScore:
+		r.Label = Responsive
+	case r.Score >= slowScore:
+		r.Label = SlowToRespond
+	default:
+		r.Label = Unresponsive
+	}
+	return r
+}
+
+// Summary renders the rating with its signals
+func (r IssueResponsiveness) Summary() string {
+	if !r.Evaluated {
+		return r.Label
+	}
+	flow := "none closed"
+	if r.OpenedPerClosed >= 0 {
+		flow = fmt.Sprintf("%.1f opened per closed", r.OpenedPerClosed)
+	}
+	return fmt.Sprintf("%s (median close %s, %.0f%% of open issues stale, %s in 90 days)",
+		r.Label, formatDays(r.MedianDaysToClose), r.StaleShare*100, flow)
+}
@@ -0,0 +1,68 @@
+package analyzer
+
+import (
+	"math"
+	"testing"
+)
+
+func TestRateIssueResponsiveness(t *testing.T) {
+	// Sampled enough, nothing stale and closing keeps up with opening,
+	// so the median time to close decides
+	kept := func(medianDays float64) IssueStats {
+		return IssueStats{Enabled: true, Evaluated: true, SampleSize: 20, OpenIssues: 10,
+			OpenedLast90Days: 5, ClosedLast90Days: 5, MedianDaysToClose: medianDays}
+	}
+	// Nothing closed in 90 days, so only the close time and staleness count
+	stalled := func(medianDays float64) IssueStats {
+		s := kept(medianDays)
+		s.ClosedLast90Days = 0
+		return s
+	}
+	tests := []struct {
+		name  string
+		stats IssueStats
+		label string
+	}{
+		{"issues disabled", IssueStats{Evaluated: true, SampleSize: 20}, InsufficientIssueData},
+		{"not fetched", IssueStats{Enabled: true, SampleSize: 20}, InsufficientIssueData},
+		{"sample below the minimum", IssueStats{Enabled: true, Evaluated: true, SampleSize: MinResponsivenessSample - 1}, InsufficientIssueData},
+		{"sample at the minimum", IssueStats{Enabled: true, Evaluated: true, SampleSize: MinResponsivenessSample}, Responsive},
+
+		// (decline + 2) / 3 >= 0.7 while the median is within 81.7 days
+		{"responsive", kept(2), Responsive},
+		{"just responsive", kept(81), Responsive},
+		{"just slow", kept(82), SlowToRespond},
+		// (decline + 1) / 3 >= 0.4 while the median is within 73.4 days
+		{"just slow without closes", stalled(73), SlowToRespond},
+		{"unresponsive without closes", stalled(74), Unresponsive},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := RateIssueResponsiveness(tt.stats)
+			if got.Label != tt.label {
+				t.Errorf("label = %s (score %.3f), want %s", got.Label, got.Score, tt.label)
+			}
+			if got.Evaluated != (tt.label != InsufficientIssueData) {
+				t.Errorf("Evaluated = %t for %s", got.Evaluated, got.Label)
+			}
+		})
+	}
+}
+
+func TestIssueResponsivenessSignals(t *testing.T) {
+	got := RateIssueResponsiveness(IssueStats{
+		Enabled: true, Evaluated: true, SampleSize: 10,
+		OpenIssues: 20, StaleOpen: 5,
+		OpenedLast90Days: 12, ClosedLast90Days: 6,
+		MedianDaysToClose: 7,
+	})
+	// Close time 1, a quarter stale 0.75, two opened per closed 0.5
+	if got.StaleShare != 0.25 || got.OpenedPerClosed != 2 || math.Abs(got.Score-0.75) > 1e-9 {
+		t.Errorf("got stale %.2f, %.1f opened per closed, score %.3f; want 0.25, 2.0, 0.750", got.StaleShare, got.OpenedPerClosed, got.Score)
+	}
+
+	none := RateIssueResponsiveness(IssueStats{Enabled: true, Evaluated: true, SampleSize: 10, OpenIssues: 3, OpenedLast90Days: 3})
+	if none.OpenedPerClosed != -1 {
+		t.Errorf("with none closed, OpenedPerClosed = %.1f, want -1", none.OpenedPerClosed)
+	}
+}
@@ -0,0 +1,181 @@
+package analyzer
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/agnivo988/Repo-lyzer/internal/github"
+)
+
+// trendQuarters is how many quarters ContributorTrend buckets, covering
+// the two years of history it's computed from
+const trendQuarters = 8
+
+// ContributorQuarter counts contributors in one quarter of history
+type ContributorQuarter struct {
+	Start     time.Time `json:"start"`
+	Active    int       `json:"active"`
+	FirstSeen int       `json:"first_seen"` // first commit in the window falls in this quarter
+	LastSeen  int       `json:"last_seen"`  // last commit in the window falls in this quarter
+}
+
+// ContributorTrend tracks contributors joining and leaving over the last
+// two years. "New" means not seen earlier in those two years.
+type ContributorTrend struct {
+	Evaluated bool `json:"evaluated"`
+	ThisYear  int  `json:"this_year"` // active in the last 365 days
+	LastYear  int  `json:"last_year"` // active in the 365 days before that
+	New90     int  `json:"new_90_days"`
+	New365    int  `json:"new_365_days"`
+	Retained  int  `json:"retained"` // active both years
+	Churned   int  `json:"churned"`  // active last year but not this year
+	// Quarters buckets the two years, oldest first
+	Quarters []ContributorQuarter `json:"quarters"`
+	// Truncated is set when either year's history hit the fetch cap, so
+	// its contributors may be undercounted
+	Truncated bool `json:"truncated"`
+}
+
+// AnalyzeContributorTrend computes the trend from the last year's commits
+// and the year before's. Authors are identified by login, falling back to
+// email for commits not linked to an account; bots are left out.
+func AnalyzeContributorTrend(recent, prior []github.Commit, now time.Time, truncated bool) ContributorTrend {
+	commits := append(append([]github.Commit(nil), prior...), recent...)
+	if len(commits) == 0 {
+		return ContributorTrend{}
+	}
+
+	// Emails seen with a login let unlinked commits be merged into it
+	emailLogins := make(map[string]string)
+	for _, c := range commits {
+		if login, email := c.AuthorLogin(), strings.ToLower(c.Commit.Author.Email); login != "" && email != "" {
+			emailLogins[email] = login
+		}
+	}
+
+	first := make(map[string]time.Time)
+	last := make(map[string]time.Time)
+	// The quarters split the two years evenly, leap day or not, and the
+	// last one includes now itself
+	start := now.AddDate(-2, 0, 0)
+	quarterLength := now.Sub(start) / trendQuarters
+	quarter := func(date time.Time) int {
+		if date.Before(start) || date.After(now) {
+			return -1
+		}
+		return min(int(date.Sub(start)/quarterLength), trendQuarters-1)
+	}
+	active := make([]map[string]bool, trendQuarters)
+	for i := range active {
+		active[i] = make(map[string]bool)
+	}
+
+	for _, c := range commits {
+		id := commitIdentity(c, emailLogins)
+		if id == "" {
+			continue
+		}
+		date := c.Commit.Author.Date
+		if t, ok := first[id]; !ok || date.Before(t) {
+			first[id] = date
+		}
+		if date.After(last[id]) {
+			last[id] = date
+		}
+		if q := quarter(date); q >= 0 {
+			active[q][id] = true
+		}
+	}
+
+	trend := ContributorTrend{Evaluated: true, Truncated: truncated}
+	for i := range active {
+		trend.Quarters = append(trend.Quarters, ContributorQuarter{
+			Start:  start.Add(time.Duration(i) * quarterLength),
+			Active: len(active[i]),
+		})
+	}
+
+	yearAgo := now.AddDate(-1, 0, 0)
+	for id, firstSeen := range first {
+		lastSeen := last[id]
+		thisYear := !lastSeen.Before(yearAgo)
+		lastYear := firstSeen.Before(yearAgo)
+		if thisYear {
+			trend.ThisYear++
+		}
+		if lastYear {
+			trend.LastYear++
+		}
+		switch {
+		case thisYear && lastYear:
+			trend.Retained++
+		case lastYear:
+			trend.Churned++
+		}
+		if now.Sub(firstSeen) <= 90*24*time.Hour {
+			trend.New90++
+		}
+		if !lastYear {
+			trend.New365++
+		}
+
+		if q := quarter(firstSeen); q >= 0 {
+			trend.Quarters[q].FirstSeen++
+		}
+		if q := quarter(lastSeen); q >= 0 {
+			trend.Quarters[q].LastSeen++
+		}
+	}
+	return trend
+}
+
+// commitIdentity names a commit's author: the login, else the login linked
+// to the email elsewhere, else the email or name. Bots yield "".
+func commitIdentity(c github.Commit, emailLogins map[string]string) string {
+	login := c.AuthorLogin()
+	email := strings.ToLower(c.Commit.Author.Email)
+	name := c.Commit.Author.Name
+	for _, s := range []string{login, email, name} {
+		if strings.Contains(s, "[bot]") {
+			return ""
+		}
+	}
+
+	switch {
+	case login != "":
+		return login
+	case emailLogins[email] != "":
+		return emailLogins[email]
+	case email != "":
+		return "email:" + email
+	case name != "":
+		return "name:" + name
+	default:
+		return ""
+	}
+}
+
+// ActiveByQuarter returns the active contributor counts, oldest first
+func (t ContributorTrend) ActiveByQuarter() []int {
+	counts := make([]int, len(t.Quarters))
+	for i, q := range t.Quarters {
+		counts[i] = q.Active
+	}
+	return counts
+}
+
+// Summary renders the trend for display
+func (t ContributorTrend) Summary() string {
+	if !t.Evaluated {
+		return "Contributor trend unavailable"
+	}
+	summary := fmt.Sprintf(
+		"%d active this year (%d last year): %d retained, %d churned, %d new (%d in the last 90 days)",
+		t.ThisYear, t.LastYear, t.Retained, t.Churned, t.New365, t.New90,
+	)
+	if t.Truncated {
+		summary += ", from capped history"
+	}
+	return summary
+}
@@ -0,0 +1,112 @@
+package analyzer
+
+import (
+	"testing"
+	"time"
+
+	"github.com/agnivo988/Repo-lyzer/internal/github"
+)
+
+func TestAnalyzeContributorTrend(t *testing.T) {
+	// The two years before now span the 2024 leap day
+	now := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	yearAgo := now.AddDate(-1, 0, 0)
+	daysAgo := func(days int) time.Time { return now.AddDate(0, 0, -days) }
+	commit := func(login, email string, date time.Time) github.Commit {
+		var c github.Commit
+		c.Commit.Author.Email = email
+		c.Commit.Author.Date = date
+		if login != "" {
+			c.Author = &github.CommitAuthor{Login: login}
+		}
+		return c
+	}
+
+	tests := []struct {
+		name               string
+		commits            []github.Commit
+		thisYear, lastYear int
+		retained, churned  int
+		new90, new365      int
+	}{
+		{"unlinked commit merged by email", []github.Commit{
+			commit("ann", "ann@example.com", daysAgo(400)),
+			commit("", "Ann@Example.com", daysAgo(10)),
+		}, 1, 1, 1, 0, 0, 0},
+		{"unlinked commit without a known email", []github.Commit{
+			commit("ann", "ann@example.com", daysAgo(400)),
+			commit("", "someone@example.com", daysAgo(10)),
+		}, 1, 1, 0, 1, 1, 1},
+		{"bots left out", []github.Commit{
+			commit("dependabot[bot]", "", daysAgo(400)),
+			commit("dependabot[bot]", "", daysAgo(5)),
+			commit("", "49699333+dependabot[bot]@users.noreply.github.com", daysAgo(5)),
+			commit("ann", "", daysAgo(5)),
+		}, 1, 0, 0, 0, 1, 1},
+		{"last commit exactly a year ago is retained", []github.Commit{
+			commit("bob", "", daysAgo(600)),
+			commit("bob", "", yearAgo),
+		}, 1, 1, 1, 0, 0, 0},
+		{"last commit just before a year ago churned", []github.Commit{
+			commit("cy", "", daysAgo(600)),
+			commit("cy", "", yearAgo.Add(-time.Second)),
+		}, 0, 1, 0, 1, 0, 0},
+		{"first commit exactly a year ago is new", []github.Commit{
+			commit("dee", "", yearAgo),
+		}, 1, 0, 0, 0, 0, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := AnalyzeContributorTrend(tt.commits, nil, now, false)
+			if !got.Evaluated {
+				t.Fatal("not evaluated")
+			}
+			if got.ThisYear != tt.thisYear || got.LastYear != tt.lastYear {
+				t.Errorf("this year %d, last year %d, want %d and %d", got.ThisYear, got.LastYear, tt.thisYear, tt.lastYear)
+			}
+			if got.Retained != tt.retained || got.Churned != tt.churned {
+				t.Errorf("retained %d, churned %d, want %d and %d", got.Retained, got.Churned, tt.retained, tt.churned)
+			}
+			if got.New90 != tt.new90 || got.New365 != tt.new365 {
+				t.Errorf("new %d (90 days), %d (365 days), want %d and %d", got.New90, got.New365, tt.new90, tt.new365)
+			}
+		})
+	}
+}
+
+func TestContributorTrendQuarters(t *testing.T) {
+	now := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	commit := func(login string, date time.Time) github.Commit {
+		var c github.Commit
+		c.Commit.Author.Date = date
+		c.Author = &github.CommitAuthor{Login: login}
+		return c
+	}
+	trend := AnalyzeContributorTrend([]github.Commit{
+		commit("ann", now),                   // the very end of the window
+		commit("bob", now.AddDate(-2, 0, 0)), // its very start
+		commit("cy", now.AddDate(-2, 0, -1)), // before it
+		commit("dee", now.Add(time.Hour)),    // after it
+	}, nil, now, false)
+
+	if len(trend.Quarters) != trendQuarters {
+		t.Fatalf("%d quarters, want %d", len(trend.Quarters), trendQuarters)
+	}
+	last, first := trend.Quarters[trendQuarters-1], trend.Quarters[0]
+	if last.Active != 1 || last.FirstSeen != 1 || last.LastSeen != 1 {
+		t.Errorf("last quarter = %+v, want the commit dated now in it", last)
+	}
+	if first.Active != 1 || first.FirstSeen != 1 || first.LastSeen != 1 {
+		t.Errorf("first quarter = %+v, want only the commit at the start in it", first)
+	}
+	if !first.Start.Equal(now.AddDate(-2, 0, 0)) {
+		t.Errorf("first quarter starts %v, want two years before now", first.Start)
+	}
+	total := 0
+	for _, q := range trend.Quarters {
+		total += q.Active
+	}
+	if total != 2 {
+		t.Errorf("%d active across quarters, want 2: commits outside the window don't count", total)
+	}
+}
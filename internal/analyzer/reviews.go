@@ -0,0 +1,99 @@
+package analyzer
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/agnivo988/Repo-lyzer/internal/github"
+)
+
+// ReviewCoverage rates how consistently merged pull requests get reviewed
+type ReviewCoverage struct {
+	Evaluated  bool `json:"evaluated"`
+	SampleSize int  `json:"sample_size"` // merged pull requests looked at
+	// ApprovedShare is the share with at least one approving review
+	ApprovedShare float64 `json:"approved_share"`
+	// IndependentMergeShare is the share merged by someone other than the author
+	IndependentMergeShare float64 `json:"independent_merge_share"`
+	// MedianHoursToReview is from opening to the first review, over
+	// reviewed pull requests; -1 when none were reviewed
+	MedianHoursToReview float64 `json:"median_hours_to_review"`
+	Score               float64 `json:"score"` // from 0 to 1
+	// SingleMaintainer is set when there is one core contributor, who
+	// has no one to review or merge their changes
+	SingleMaintainer bool `json:"single_maintainer"`
+}
+
+// AnalyzeReviewCoverage averages three scores from 0 to 1: the approved
+// share, the independently merged share, and the review turnaround (full
+// marks within a day, none past two weeks). With a single core contributor
+// self-merging is expected, so the coverage is reported but not scored.
+func AnalyzeReviewCoverage(pulls []github.ReviewedPullRequest, coreContributors int) ReviewCoverage {
+	if len(pulls) == 0 {
+		return ReviewCoverage{}
+	}
+
+	coverage := ReviewCoverage{
+		Evaluated:           true,
+		SampleSize:          len(pulls),
+		MedianHoursToReview: -1,
+		SingleMaintainer:    coreContributors == 1,
+	}
+	approved, independent := 0, 0
+	var turnaround []float64
+	for _, pr := range pulls {
+		var first time.Time
+		hasApproval := false
+		for _, r := range pr.Reviews {
+			if r.User.Login == pr.User.Login || r.SubmittedAt.IsZero() {
+				continue // authors can't review their own changes
+			}
+			if first.IsZero() || r.SubmittedAt.Before(first) {
+				first = r.SubmittedAt
+			}
+			hasApproval = hasApproval || r.State == "APPROVED"
+		}
+		if hasApproval {
+			approved++
+		}
+		if !first.IsZero() {
+			turnaround = append(turnaround, first.Sub(pr.CreatedAt).Hours())
+		}
+		if pr.MergedBy != nil && pr.MergedBy.Login != pr.User.Login {
+			independent++
+		}
+	}
+
+	n := float64(len(pulls))
+	coverage.ApprovedShare = float64(approved) / n
+	coverage.IndependentMergeShare = float64(independent) / n
+	turnaroundScore := 0.0
+	if len(turnaround) > 0 {
+		coverage.MedianHoursToReview = median(turnaround)
+		turnaroundScore = decline(coverage.MedianHoursToReview, 24, 14*24)
+	}
+	coverage.Score = (coverage.ApprovedShare + coverage.IndependentMergeShare + turnaroundScore) / 3
+	return coverage
+}
+
+// Scored reports whether the coverage should count towards health
+func (r ReviewCoverage) Scored() bool {
+	return r.Evaluated && !r.SingleMaintainer
+}
+
+// Summary renders the coverage for display
+func (r ReviewCoverage) Summary() string {
+	if !r.Evaluated {
+		return "Review data unavailable"
+	}
+	summary := fmt.Sprintf("%.0f%% approved, %.0f%% merged by someone else",
+		r.ApprovedShare*100, r.IndependentMergeShare*100)
+	if r.MedianHoursToReview >= 0 {
+		summary += ", first review after " + formatDays(r.MedianHoursToReview/24)
+	}
+	summary += fmt.Sprintf(" (%d merged PRs)", r.SampleSize)
+	if r.SingleMaintainer {
+		summary += "; single maintainer, so self-merges are expected and not scored"
+	}
+	return summary
+}
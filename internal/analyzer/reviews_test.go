@@ -0,0 +1,97 @@
+package analyzer
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/agnivo988/Repo-lyzer/internal/github"
+)
+
+func TestAnalyzeReviewCoverage(t *testing.T) {
+	opened := time.Date(2024, 3, 1, 9, 0, 0, 0, time.UTC)
+	review := func(login, state string, afterHours float64) github.Review {
+		r := github.Review{State: state, SubmittedAt: opened.Add(time.Duration(afterHours * float64(time.Hour)))}
+		r.User.Login = login
+		return r
+	}
+	pull := func(author, merger string, reviews ...github.Review) github.ReviewedPullRequest {
+		pr := github.ReviewedPullRequest{Reviews: reviews}
+		pr.CreatedAt = opened
+		pr.User.Login = author
+		pr.MergedBy = &struct {
+			Login string `json:"login"`
+		}{Login: merger}
+		return pr
+	}
+
+	tests := []struct {
+		name        string
+		pulls       []github.ReviewedPullRequest
+		core        int
+		evaluated   bool
+		approved    float64
+		independent float64
+		medianHours float64
+		single      bool
+		scored      bool
+		score       float64
+	}{
+		{"no pull requests", nil, 3, false, 0, 0, 0, false, false, 0},
+		{"approved and merged by others within a day", []github.ReviewedPullRequest{
+			pull("ann", "bob", review("bob", "APPROVED", 2)),
+			pull("bob", "ann", review("ann", "APPROVED", 4)),
+		}, 2, true, 1, 1, 3, false, true, 1},
+		{"half approved", []github.ReviewedPullRequest{
+			pull("ann", "bob", review("bob", "APPROVED", 2)),
+			pull("ann", "bob", review("bob", "COMMENTED", 2)),
+		}, 2, true, 0.5, 1, 2, false, true, 2.5 / 3},
+		{"self-reviews ignored", []github.ReviewedPullRequest{
+			pull("ann", "bob", review("ann", "APPROVED", 1)),
+		}, 2, true, 0, 1, -1, false, true, 1.0 / 3},
+		{"independent merge share", []github.ReviewedPullRequest{
+			pull("ann", "ann", review("bob", "APPROVED", 2)),
+			pull("ann", "ann", review("bob", "APPROVED", 2)),
+			pull("ann", "ann", review("bob", "APPROVED", 2)),
+			pull("ann", "bob", review("bob", "APPROVED", 2)),
+		}, 2, true, 1, 0.25, 2, false, true, 2.25 / 3},
+		{"median of the first reviews", []github.ReviewedPullRequest{
+			pull("ann", "bob", review("bob", "COMMENTED", 30), review("cy", "APPROVED", 2)),
+			pull("ann", "bob", review("bob", "APPROVED", 10)),
+			pull("ann", "bob", review("bob", "APPROVED", 48)),
+		}, 3, true, 1, 1, 10, false, true, 1},
+		{"single maintainer", []github.ReviewedPullRequest{
+			pull("ann", "ann"),
+			pull("ann", "ann"),
+		}, 1, true, 0, 0, -1, true, false, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := AnalyzeReviewCoverage(tt.pulls, tt.core)
+			if got.Evaluated != tt.evaluated {
+				t.Fatalf("Evaluated = %t, want %t", got.Evaluated, tt.evaluated)
+			}
+			if got.SampleSize != len(tt.pulls) {
+				t.Errorf("SampleSize = %d, want %d", got.SampleSize, len(tt.pulls))
+			}
+			if got.ApprovedShare != tt.approved {
+				t.Errorf("ApprovedShare = %v, want %v", got.ApprovedShare, tt.approved)
+			}
+			if got.IndependentMergeShare != tt.independent {
+				t.Errorf("IndependentMergeShare = %v, want %v", got.IndependentMergeShare, tt.independent)
+			}
+			if got.MedianHoursToReview != tt.medianHours {
+				t.Errorf("MedianHoursToReview = %v, want %v", got.MedianHoursToReview, tt.medianHours)
+			}
+			if got.SingleMaintainer != tt.single {
+				t.Errorf("SingleMaintainer = %t, want %t", got.SingleMaintainer, tt.single)
+			}
+			if got.Scored() != tt.scored {
+				t.Errorf("Scored() = %t, want %t", got.Scored(), tt.scored)
+			}
+			if math.Abs(got.Score-tt.score) > 1e-9 {
+				t.Errorf("Score = %.3f, want %.3f", got.Score, tt.score)
+			}
+		})
+	}
+}
@@ -1 +1,66 @@
-package analyzer
\ No newline at end of file
+package analyzer
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/agnivo988/Repo-lyzer/internal/github"
+)
+
+// ProtectionStatus is the tri-state result of a branch protection check
+type ProtectionStatus string
+
+const (
+	ProtectionProtected   ProtectionStatus = "protected"
+	ProtectionUnprotected ProtectionStatus = "unprotected"
+	ProtectionUnknown     ProtectionStatus = "unknown" // no permission to read the settings
+)
+
+// BranchProtectionInfo describes how the default branch is protected
+type BranchProtectionInfo struct {
+	Branch          string           `json:"branch"`
+	Status          ProtectionStatus `json:"status"`
+	RequiredReviews int              `json:"required_reviews"`
+	StatusChecks    []string         `json:"status_checks"`
+}
+
+// AnalyzeBranchProtection turns a GetBranchProtection result into a
+// BranchProtectionInfo, only reporting "unprotected" when GitHub said so
+func AnalyzeBranchProtection(branch string, protection *github.BranchProtection, err error) BranchProtectionInfo {
+	info := BranchProtectionInfo{Branch: branch, Status: ProtectionUnknown}
+	switch {
+	case errors.Is(err, github.ErrBranchNotProtected):
+		info.Status = ProtectionUnprotected
+	case err != nil || protection == nil:
+		// Couldn't see the settings
+	default:
+		info.Status = ProtectionProtected
+		if reviews := protection.RequiredPullRequestReviews; reviews != nil {
+			info.RequiredReviews = reviews.RequiredApprovingReviewCount
+		}
+		if checks := protection.RequiredStatusChecks; checks != nil {
+			info.StatusChecks = checks.Contexts
+		}
+	}
+	return info
+}
+
+// Summary renders the protection status for display
+func (b BranchProtectionInfo) Summary() string {
+	switch b.Status {
+	case ProtectionProtected:
+		summary := fmt.Sprintf("%s is protected", b.Branch)
+		if b.RequiredReviews > 0 {
+			summary += fmt.Sprintf(", %d required review(s)", b.RequiredReviews)
+		}
+		if len(b.StatusChecks) > 0 {
+			summary += ", required checks: " + strings.Join(b.StatusChecks, ", ")
+		}
+		return summary
+	case ProtectionUnprotected:
+		return b.Branch + " is not protected"
+	default:
+		return "Unknown (needs a token with admin access to the repo)"
+	}
+}
@@ -0,0 +1,235 @@
+package analyzer
+
+import (
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/agnivo988/Repo-lyzer/internal/github"
+)
+
+// Security score component weights, out of 100
+const (
+	securityPolicyWeight     = 20
+	dependencyUpdatesWeight  = 20
+	codeScanningWeight       = 15
+	securityProtectionWeight = 15
+	lockfilesWeight          = 15
+	vulnerabilitiesWeight    = 15
+)
+
+// dependencyUpdateConfigs are the Dependabot and Renovate config files
+var dependencyUpdateConfigs = map[string]string{
+	".github/dependabot.yml":  "Dependabot",
+	".github/dependabot.yaml": "Dependabot",
+	"renovate.json":           "Renovate",
+	"renovate.json5":          "Renovate",
+	".renovaterc":             "Renovate",
+	".renovaterc.json":        "Renovate",
+	".github/renovate.json":   "Renovate",
+	".github/renovate.json5":  "Renovate",
+}
+
+// ecosystem pairs a package manifest with the lockfiles that pin it
+type ecosystem struct {
+	Name      string
+	Manifests []string
+	Lockfiles []string
+}
+
+// ecosystems are checked for a lockfile wherever their manifest appears
+var ecosystems = []ecosystem{
+	{"Go", []string{"go.mod"}, []string{"go.sum"}},
+	{"npm", []string{"package.json"}, []string{"package-lock.json", "npm-shrinkwrap.json", "yarn.lock", "pnpm-lock.yaml", "bun.lockb", "bun.lock"}},
+	{"Cargo", []string{"Cargo.toml"}, []string{"Cargo.lock"}},
+	{"Bundler", []string{"Gemfile"}, []string{"Gemfile.lock"}},
+	{"Python", []string{"pyproject.toml", "Pipfile"}, []string{"poetry.lock", "uv.lock", "pdm.lock", "Pipfile.lock"}},
+	{"Composer", []string{"composer.json"}, []string{"composer.lock"}},
+}
+
+// SecurityInput holds the signals the security score is computed from
+type SecurityInput struct {
+	Tree             []github.TreeEntry
+	CI               CIInfo
+	BranchProtection BranchProtectionInfo
+	// Vulnerabilities is the count of known-vulnerable dependencies, nil
+	// when no dependency scan ran
+	Vulnerabilities *int
+
+	FileTreeUnavailable bool
+}
+
+// SecurityScore is a 0-100 rating of a repository's security posture.
+// Components that need a token or a scan aren't evaluated without one,
+// and the others' weights grow to cover for them.
+type SecurityScore struct {
+	Score      int              `json:"score"`
+	Grade      Grade            `json:"grade"`
+	Components []ScoreComponent `json:"components"`
+}
+
+// ScoreSecurity aggregates the security-relevant signals into a score
+func ScoreSecurity(in SecurityInput) SecurityScore {
+	files := make(map[string]bool)
+	for _, entry := range in.Tree {
+		if entry.Type == "blob" {
+			files[entry.Path] = true
+		}
+	}
+	components := []ScoreComponent{
+		securityPolicyComponent(in, files),
+		dependencyUpdatesComponent(in, files),
+		codeScanningComponent(in),
+		securityProtectionComponent(in),
+		lockfilesComponent(in),
+		vulnerabilitiesComponent(in),
+	}
+	shareWeights(components)
+	score := ComponentsScore(components)
+	return SecurityScore{Score: score, Grade: GradeFor(score), Components: components}
+}
+
+// Evaluated reports whether any component could be scored
+func (s SecurityScore) Evaluated() bool {
+	for _, c := range s.Components {
+		if c.Evaluated {
+			return true
+		}
+	}
+	return false
+}
+
+func securityPolicyComponent(in SecurityInput, files map[string]bool) ScoreComponent {
+	if in.FileTreeUnavailable {
+		return notEvaluated("security_policy", securityPolicyWeight)
+	}
+	c := ScoreComponent{Name: "security_policy", Detail: "no SECURITY.md", Weight: securityPolicyWeight, Evaluated: true}
+	for file := range files {
+		dir, name := path.Split(file)
+		if strings.EqualFold(name, "security.md") && (dir == "" || dir == ".github/" || dir == "docs/") {
+			c.Raw, c.Score, c.Detail = 1, 1, file
+			break
+		}
+	}
+	return c
+}
+
+func dependencyUpdatesComponent(in SecurityInput, files map[string]bool) ScoreComponent {
+	if in.FileTreeUnavailable {
+		return notEvaluated("dependency_updates", dependencyUpdatesWeight)
+	}
+	found := make(map[string]bool)
+	for file, tool := range dependencyUpdateConfigs {
+		if files[file] {
+			found[tool] = true
+		}
+	}
+	c := ScoreComponent{Name: "dependency_updates", Detail: "no Dependabot or Renovate config", Weight: dependencyUpdatesWeight, Evaluated: true}
+	if len(found) > 0 {
+		c.Raw, c.Score, c.Detail = float64(len(found)), 1, strings.Join(sortedSet(found), " and ")
+	}
+	return c
+}
+
+func codeScanningComponent(in SecurityInput) ScoreComponent {
+	if in.FileTreeUnavailable {
+		return notEvaluated("code_scanning", codeScanningWeight)
+	}
+	var scanners []string
+	for _, workflow := range in.CI.WorkflowFiles() {
+		name := strings.ToLower(workflow)
+		if strings.Contains(name, "codeql") || strings.Contains(name, "scorecard") {
+			scanners = append(scanners, workflow)
+		}
+	}
+	c := ScoreComponent{Name: "code_scanning", Detail: "no CodeQL or Scorecard workflow", Weight: codeScanningWeight, Evaluated: true}
+	if len(scanners) > 0 {
+		c.Raw, c.Score, c.Detail = float64(len(scanners)), 1, strings.Join(scanners, ", ")
+	}
+	return c
+}
+
+func securityProtectionComponent(in SecurityInput) ScoreComponent {
+	p := in.BranchProtection
+	switch p.Status {
+	case ProtectionProtected:
+		c := ScoreComponent{Name: "branch_protection", Raw: float64(p.RequiredReviews), Detail: p.Summary(), Score: 0.5, Weight: securityProtectionWeight, Evaluated: true}
+		if p.RequiredReviews > 0 {
+			c.Score = 1
+		}
+		return c
+	case ProtectionUnprotected:
+		return ScoreComponent{Name: "branch_protection", Detail: p.Summary(), Weight: securityProtectionWeight, Evaluated: true}
+	}
+	c := notEvaluated("branch_protection", securityProtectionWeight)
+	c.Detail = "not evaluated (needs a token with admin access)"
+	return c
+}
+
+func lockfilesComponent(in SecurityInput) ScoreComponent {
+	if in.FileTreeUnavailable {
+		return notEvaluated("lockfiles", lockfilesWeight)
+	}
+	names := make(map[string]bool)
+	for _, entry := range in.Tree {
+		if entry.Type == "blob" && !skippedPath(entry.Path) {
+			names[path.Base(entry.Path)] = true
+		}
+	}
+	var used, locked, unlocked []string
+	for _, eco := range ecosystems {
+		if !containsAny(names, eco.Manifests) {
+			continue
+		}
+		used = append(used, eco.Name)
+		if containsAny(names, eco.Lockfiles) {
+			locked = append(locked, eco.Name)
+		} else {
+			unlocked = append(unlocked, eco.Name)
+		}
+	}
+	if len(used) == 0 {
+		c := notEvaluated("lockfiles", lockfilesWeight)
+		c.Detail = "not evaluated (no package manifests)"
+		return c
+	}
+	c := ScoreComponent{
+		Name:      "lockfiles",
+		Raw:       float64(len(locked)),
+		Detail:    fmt.Sprintf("%d/%d ecosystems locked", len(locked), len(used)),
+		Score:     float64(len(locked)) / float64(len(used)),
+		Weight:    lockfilesWeight,
+		Evaluated: true,
+	}
+	if len(unlocked) > 0 {
+		c.Detail += " (missing: " + strings.Join(unlocked, ", ") + ")"
+	}
+	return c
+}
+
+func vulnerabilitiesComponent(in SecurityInput) ScoreComponent {
+	if in.Vulnerabilities == nil {
+		c := notEvaluated("vulnerabilities", vulnerabilitiesWeight)
+		c.Detail = "not evaluated (no dependency scan)"
+		return c
+	}
+	n := *in.Vulnerabilities
+	return ScoreComponent{
+		Name:      "vulnerabilities",
+		Raw:       float64(n),
+		Detail:    fmt.Sprintf("%d vulnerable dependencies", n),
+		Score:     decline(float64(n), 0, 10),
+		Weight:    vulnerabilitiesWeight,
+		Evaluated: true,
+	}
+}
+
+// containsAny reports whether any of names is in set
+func containsAny(set map[string]bool, names []string) bool {
+	for _, name := range names {
+		if set[name] {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,87 @@
+package analyzer
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestScoreSecurity(t *testing.T) {
+	zero, many := 0, 12
+	tests := []struct {
+		name         string
+		in           SecurityInput
+		score        int
+		notEvaluated []string
+	}{
+		{
+			name: "locked down",
+			in: SecurityInput{
+				Tree: blobs("SECURITY.md", ".github/dependabot.yml", ".github/workflows/codeql.yml",
+					"go.mod", "go.sum", "web/package.json", "web/package-lock.json"),
+				BranchProtection: BranchProtectionInfo{Branch: "main", Status: ProtectionProtected, RequiredReviews: 2},
+				Vulnerabilities:  &zero,
+			},
+			score: 100,
+		},
+		{
+			// Nothing set up; with no manifests the lockfiles can't be judged
+			name: "bare",
+			in: SecurityInput{
+				Tree:             blobs("README.md", "main.c"),
+				BranchProtection: BranchProtectionInfo{Branch: "main", Status: ProtectionUnprotected},
+			},
+			score:        0,
+			notEvaluated: []string{"lockfiles", "vulnerabilities"},
+		},
+		{
+			// Without a token or a scan, protection and vulnerabilities are
+			// left out rather than scored 0: (20 + 15*0.5) / 70 of the points
+			name: "partially evaluated",
+			in: SecurityInput{
+				Tree:             blobs(".github/SECURITY.md", "go.mod", "go.sum", "package.json"),
+				BranchProtection: BranchProtectionInfo{Branch: "main", Status: ProtectionUnknown},
+			},
+			score:        39,
+			notEvaluated: []string{"branch_protection", "vulnerabilities"},
+		},
+		{
+			// Protected without required reviews earns half: (7.5 + 0) / 30
+			name: "no file tree",
+			in: SecurityInput{
+				BranchProtection:    BranchProtectionInfo{Branch: "main", Status: ProtectionProtected},
+				Vulnerabilities:     &many,
+				FileTreeUnavailable: true,
+			},
+			score:        25,
+			notEvaluated: []string{"security_policy", "dependency_updates", "code_scanning", "lockfiles"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.in.CI = DetectCI(tt.in.Tree)
+			got := ScoreSecurity(tt.in)
+			if got.Score != tt.score || got.Grade != GradeFor(tt.score) {
+				t.Errorf("score = %d (%s), want %d", got.Score, got.Grade, tt.score)
+			}
+			var notEvaluated []string
+			for _, c := range got.Components {
+				if !c.Evaluated {
+					notEvaluated = append(notEvaluated, c.Name)
+				}
+			}
+			if !reflect.DeepEqual(notEvaluated, tt.notEvaluated) {
+				t.Errorf("not evaluated = %v, want %v", notEvaluated, tt.notEvaluated)
+			}
+			if !got.Evaluated() {
+				t.Error("Evaluated() = false with components scored")
+			}
+		})
+	}
+}
+
+func TestScoreSecurityNothingEvaluated(t *testing.T) {
+	got := ScoreSecurity(SecurityInput{FileTreeUnavailable: true, BranchProtection: BranchProtectionInfo{Status: ProtectionUnknown}})
+	if got.Evaluated() || got.Score != 0 {
+		t.Errorf("Evaluated() = %t, score %d; want false, 0", got.Evaluated(), got.Score)
+	}
+}
@@ -0,0 +1,104 @@
+package analyzer
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/agnivo988/Repo-lyzer/internal/github"
+)
+
+// SignedCommitRatio is the share of commits with a verified signature
+type SignedCommitRatio struct {
+	// Evaluated is false when the server reported no verification data,
+	// as older GitHub Enterprise Servers and other hosts don't
+	Evaluated bool    `json:"evaluated"`
+	Sampled   int     `json:"sampled"`  // commits with verification data
+	Verified  int     `json:"verified"` // of them, with a verified signature
+	Ratio     float64 `json:"ratio"`
+	// Reasons counts the commits by verification reason, e.g. "valid",
+	// "unsigned" or "unknown_key"
+	Reasons map[string]int `json:"reasons"`
+	// WebUI counts the commits made in GitHub's web UI, which GitHub signs
+	// itself; WebUIVerified those of them verified
+	WebUI         int `json:"web_ui"`
+	WebUIVerified int `json:"web_ui_verified"`
+}
+
+// AnalyzeSignedCommits counts the commits whose signature GitHub verified.
+// Commits without verification data are left out.
+func AnalyzeSignedCommits(commits []github.Commit) SignedCommitRatio {
+	s := SignedCommitRatio{Reasons: make(map[string]int)}
+	for _, c := range commits {
+		v := c.Commit.Verification
+		if v == nil {
+			continue
+		}
+		s.Sampled++
+		s.Reasons[v.Reason]++
+		if v.Verified {
+			s.Verified++
+		}
+		if c.IsWebFlow() {
+			s.WebUI++
+			if v.Verified {
+				s.WebUIVerified++
+			}
+		}
+	}
+	if s.Sampled == 0 {
+		return SignedCommitRatio{}
+	}
+	s.Evaluated = true
+	s.Ratio = float64(s.Verified) / float64(s.Sampled)
+	return s
+}
+
+// ContributorRatio is the share of verified commits among those not made
+// in the web UI, which says more about contributors' own practice; -1
+// when every commit came from the web UI
+func (s SignedCommitRatio) ContributorRatio() float64 {
+	n := s.Sampled - s.WebUI
+	if n == 0 {
+		return -1
+	}
+	return float64(s.Verified-s.WebUIVerified) / float64(n)
+}
+
+// Label renders the ratio, e.g. "64%", or "unknown" without data
+func (s SignedCommitRatio) Label() string {
+	if !s.Evaluated {
+		return "unknown"
+	}
+	return fmt.Sprintf("%.0f%%", s.Ratio*100)
+}
+
+// Summary renders the ratio with its reasons, most common first, e.g.
+// "64% of 120 commits (valid 77, unsigned 40, unknown_key 3); 20 made in
+// the web UI, 57% signed without them"
+func (s SignedCommitRatio) Summary() string {
+	if !s.Evaluated {
+		return "unknown (no verification data)"
+	}
+	reasons := make([]string, 0, len(s.Reasons))
+	for reason := range s.Reasons {
+		reasons = append(reasons, reason)
+	}
+	sort.Slice(reasons, func(i, j int) bool {
+		if s.Reasons[reasons[i]] != s.Reasons[reasons[j]] {
+			return s.Reasons[reasons[i]] > s.Reasons[reasons[j]]
+		}
+		return reasons[i] < reasons[j]
+	})
+	for i, reason := range reasons {
+		reasons[i] = fmt.Sprintf("%s %d", reason, s.Reasons[reason])
+	}
+	summary := fmt.Sprintf("%s of %d commits (%s)", s.Label(), s.Sampled, strings.Join(reasons, ", "))
+	if s.WebUI > 0 {
+		summary += fmt.Sprintf("; %d made in the web UI", s.WebUI)
+		if ratio := s.ContributorRatio(); ratio >= 0 {
+			summary += fmt.Sprintf(", %.0f%% signed without them", ratio*100)
+		}
+	}
+	return summary
+}
@@ -0,0 +1,127 @@
+package analyzer
+
+import (
+	"fmt"
+	"sort"
+	"time"
+)
+
+// StarTrend classifies how star growth is changing
+type StarTrend string
+
+const (
+	StarsAccelerating StarTrend = "accelerating"
+	StarsSteady       StarTrend = "steady"
+	StarsDeclining    StarTrend = "declining"
+)
+
+// StarSample is one page of stargazer timestamps, oldest first. Offset is
+// the number of stars given before the first one on the page.
+type StarSample struct {
+	Offset int
+	Times  []time.Time
+}
+
+// StarHistory is the recent growth in stars
+type StarHistory struct {
+	Evaluated bool `json:"evaluated"`
+	Total     int  `json:"total"`
+	Last30    int  `json:"last_30_days"`
+	Last90    int  `json:"last_90_days"`
+	Last365   int  `json:"last_365_days"`
+	// Monthly is stars gained in each of the last 12 months, oldest first
+	Monthly []int `json:"monthly"`
+	// Sampled is set when only some pages of stargazers were fetched, so the
+	// counts are interpolated between them
+	Sampled bool      `json:"sampled"`
+	Trend   StarTrend `json:"trend"`
+}
+
+type starPoint struct {
+	count int
+	at    time.Time
+}
+
+// AnalyzeStarHistory reconstructs the cumulative star curve from the
+// samples and reads growth off it. Between samples (and between the last
+// sample and now, where the count is total) the curve is interpolated
+// linearly; complete reports whether the samples cover every star.
+func AnalyzeStarHistory(samples []StarSample, total int, complete bool, now time.Time) StarHistory {
+	var points []starPoint
+	fromFirstStar := false
+	for _, sample := range samples {
+		fromFirstStar = fromFirstStar || sample.Offset == 0
+		for i, t := range sample.Times {
+			points = append(points, starPoint{count: sample.Offset + i + 1, at: t})
+		}
+	}
+	points = append(points, starPoint{count: total, at: now})
+	sort.Slice(points, func(i, j int) bool { return points[i].at.Before(points[j].at) })
+
+	starsAt := func(t time.Time) float64 {
+		if !t.After(points[0].at) {
+			if fromFirstStar {
+				return 0 // before the first star
+			}
+			return float64(points[0].count)
+		}
+		for i := 1; i < len(points); i++ {
+			a, b := points[i-1], points[i]
+			if t.After(b.at) {
+				continue
+			}
+			span := b.at.Sub(a.at)
+			if span <= 0 {
+				return float64(b.count)
+			}
+			return float64(a.count) + float64(b.count-a.count)*float64(t.Sub(a.at))/float64(span)
+		}
+		return float64(total)
+	}
+	gainedSince := func(t time.Time) int {
+		gained := float64(total) - starsAt(t)
+		if gained < 0 {
+			return 0
+		}
+		return int(gained + 0.5)
+	}
+
+	history := StarHistory{
+		Evaluated: true,
+		Total:     total,
+		Last30:    gainedSince(now.AddDate(0, 0, -30)),
+		Last90:    gainedSince(now.AddDate(0, 0, -90)),
+		Last365:   gainedSince(now.AddDate(0, 0, -365)),
+		Sampled:   !complete,
+	}
+	for m := 12; m > 0; m-- {
+		history.Monthly = append(history.Monthly, gainedSince(now.AddDate(0, -m, 0))-gainedSince(now.AddDate(0, -m+1, 0)))
+	}
+
+	// Compare the last quarter's daily rate with the rest of the year's
+	recentRate := float64(history.Last90) / 90
+	earlierRate := float64(history.Last365-history.Last90) / 275
+	switch {
+	case earlierRate == 0 && recentRate == 0:
+		history.Trend = StarsSteady
+	case recentRate >= earlierRate*1.25:
+		history.Trend = StarsAccelerating
+	case recentRate <= earlierRate*0.75:
+		history.Trend = StarsDeclining
+	default:
+		history.Trend = StarsSteady
+	}
+	return history
+}
+
+// Summary renders the star growth for display
+func (s StarHistory) Summary() string {
+	if !s.Evaluated {
+		return "Not fetched (enable with --star-history)"
+	}
+	summary := fmt.Sprintf("+%d in 30 days, +%d in 90 days, +%d in a year (%s)", s.Last30, s.Last90, s.Last365, s.Trend)
+	if s.Sampled {
+		summary += ", estimated from sampled pages"
+	}
+	return summary
+}
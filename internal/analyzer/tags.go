@@ -0,0 +1,87 @@
+package analyzer
+
+import (
+	"regexp"
+	"strconv"
+	"time"
+
+	"github.com/agnivo988/Repo-lyzer/internal/github"
+)
+
+// versionTag matches version-like tags (v1.2, 1.2.3, release-2.0.0-rc1) and
+// rejects others such as "deploy-2023-01-01"
+var versionTag = regexp.MustCompile(`^(?:v|release-|version-)?(\d+)\.(\d+)(?:\.(\d+))?(?:[-+][0-9A-Za-z.-]+)?$`)
+
+// sparseReleases is the release count below which tags are consulted too
+const sparseReleases = 3
+
+// TagStats summarises the version-like tags of a repository
+type TagStats struct {
+	Total         int
+	VersionCount  int
+	LatestVersion string
+	LatestSHA     string
+}
+
+// AnalyzeTags counts version-like tags and finds the highest version
+func AnalyzeTags(tags []github.Tag) TagStats {
+	stats := TagStats{Total: len(tags)}
+
+	var latest [3]int
+	for _, t := range tags {
+		v, ok := parseVersion(t.Name)
+		if !ok {
+			continue
+		}
+		stats.VersionCount++
+		if stats.LatestVersion == "" || versionLess(latest, v) {
+			latest = v
+			stats.LatestVersion = t.Name
+			stats.LatestSHA = t.Commit.SHA
+		}
+	}
+
+	return stats
+}
+
+// NeedsTagFallback reports whether releases are too sparse to judge versioning
+func NeedsTagFallback(releases ReleaseStats) bool {
+	return releases.Count < sparseReleases
+}
+
+// TagReleaseStats builds release statistics from version tags, for projects
+// that tag versions without publishing GitHub Releases. latestDate is the
+// commit date of the latest version tag.
+func TagReleaseStats(tags TagStats, latestDate time.Time) ReleaseStats {
+	if tags.VersionCount == 0 {
+		return ReleaseStats{Source: NoVersioning}
+	}
+	return ReleaseStats{
+		Count:      tags.VersionCount,
+		LatestTag:  tags.LatestVersion,
+		LatestDate: latestDate,
+		Semver:     true,
+		Source:     VersionsFromTags,
+	}
+}
+
+func parseVersion(name string) ([3]int, bool) {
+	var v [3]int
+	m := versionTag.FindStringSubmatch(name)
+	if m == nil {
+		return v, false
+	}
+	for i := 0; i < 3; i++ {
+		v[i], _ = strconv.Atoi(m[i+1])
+	}
+	return v, true
+}
+
+func versionLess(a, b [3]int) bool {
+	for i := 0; i < 3; i++ {
+		if a[i] != b[i] {
+			return a[i] < b[i]
+		}
+	}
+	return false
+}
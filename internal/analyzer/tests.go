@@ -0,0 +1,179 @@
+package analyzer
+
+import (
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/agnivo988/Repo-lyzer/internal/github"
+)
+
+// testLanguages maps source file extensions to their language
+var testLanguages = map[string]string{
+	".go":   "Go",
+	".js":   "JavaScript",
+	".jsx":  "JavaScript",
+	".mjs":  "JavaScript",
+	".cjs":  "JavaScript",
+	".ts":   "TypeScript",
+	".tsx":  "TypeScript",
+	".py":   "Python",
+	".java": "Java",
+	".kt":   "Kotlin",
+	".rb":   "Ruby",
+	".rs":   "Rust",
+	".cs":   "C#",
+	".php":  "PHP",
+}
+
+// testDirs are directories whose source files are tests in any language
+var testDirs = map[string]bool{
+	"test":      true,
+	"tests":     true,
+	"spec":      true,
+	"__tests__": true,
+}
+
+// skippedDirs hold vendored, built or generated code that isn't the
+// project's own
+var skippedDirs = map[string]bool{
+	"vendor":       true,
+	"node_modules": true,
+	"third_party":  true,
+	"dist":         true,
+	"build":        true,
+	"generated":    true,
+}
+
+// LanguageTests counts test and source files in one language
+type LanguageTests struct {
+	Language    string `json:"language"`
+	TestFiles   int    `json:"test_files"`
+	SourceFiles int    `json:"source_files"`
+}
+
+// Ratio is test files per source file, 0 without source files
+func (l LanguageTests) Ratio() float64 {
+	if l.SourceFiles == 0 {
+		return 0
+	}
+	return float64(l.TestFiles) / float64(l.SourceFiles)
+}
+
+// TestStats estimates how well tested a repository is from its file tree
+type TestStats struct {
+	// Evaluated is false when there was no tree or no recognised source
+	Evaluated   bool            `json:"evaluated"`
+	TestFiles   int             `json:"test_files"`
+	SourceFiles int             `json:"source_files"`
+	Languages   []LanguageTests `json:"languages"` // most source files first
+}
+
+// DetectTests classifies the files in a tree as tests or source using
+// each language's conventions, ignoring vendored and generated code
+func DetectTests(tree []github.TreeEntry) TestStats {
+	byLanguage := make(map[string]*LanguageTests)
+	for _, entry := range tree {
+		if entry.Type != "blob" || skippedPath(entry.Path) {
+			continue
+		}
+		language, ok := testLanguages[strings.ToLower(path.Ext(entry.Path))]
+		if !ok {
+			continue
+		}
+		counts := byLanguage[language]
+		if counts == nil {
+			counts = &LanguageTests{Language: language}
+			byLanguage[language] = counts
+		}
+		if isTestFile(entry.Path) {
+			counts.TestFiles++
+		} else {
+			counts.SourceFiles++
+		}
+	}
+
+	var stats TestStats
+	for _, counts := range byLanguage {
+		stats.Languages = append(stats.Languages, *counts)
+		stats.TestFiles += counts.TestFiles
+		stats.SourceFiles += counts.SourceFiles
+	}
+	sort.Slice(stats.Languages, func(i, j int) bool {
+		a, b := stats.Languages[i], stats.Languages[j]
+		if a.SourceFiles != b.SourceFiles {
+			return a.SourceFiles > b.SourceFiles
+		}
+		return a.Language < b.Language
+	})
+	stats.Evaluated = stats.SourceFiles > 0
+	return stats
+}
+
+// skippedPath reports whether a file is vendored or generated
+func skippedPath(p string) bool {
+	dirs := strings.Split(path.Dir(p), "/")
+	for _, dir := range dirs {
+		if skippedDirs[dir] {
+			return true
+		}
+	}
+	file := strings.ToLower(path.Base(p))
+	return strings.HasSuffix(file, ".pb.go") ||
+		strings.HasSuffix(file, "_gen.go") ||
+		strings.HasSuffix(file, ".min.js") ||
+		strings.Contains(file, ".generated.")
+}
+
+// isTestFile applies the naming conventions of each language: _test.go,
+// *.test.js and *.spec.ts, test_*.py, *Test.java, *_spec.rb, or any
+// source file under a test/, tests/, spec/ or __tests__/ directory
+func isTestFile(p string) bool {
+	for _, dir := range strings.Split(path.Dir(p), "/") {
+		if testDirs[strings.ToLower(dir)] {
+			return true
+		}
+	}
+	// Maven and Gradle keep tests in src/test
+	if strings.Contains("/"+p, "/src/test/") {
+		return true
+	}
+
+	file := path.Base(p)
+	ext := path.Ext(file)
+	name := strings.TrimSuffix(file, ext)
+	switch strings.ToLower(ext) {
+	case ".go":
+		return strings.HasSuffix(name, "_test")
+	case ".js", ".jsx", ".mjs", ".cjs", ".ts", ".tsx":
+		return strings.HasSuffix(name, ".test") || strings.HasSuffix(name, ".spec")
+	case ".py":
+		return strings.HasPrefix(name, "test_") || strings.HasSuffix(name, "_test")
+	case ".java", ".kt", ".cs", ".php":
+		return strings.HasSuffix(name, "Test") || strings.HasSuffix(name, "Tests")
+	case ".rb":
+		return strings.HasSuffix(name, "_spec") || strings.HasSuffix(name, "_test")
+	}
+	return false
+}
+
+// Ratio is test files per source file across all languages
+func (t TestStats) Ratio() float64 {
+	if t.SourceFiles == 0 {
+		return 0
+	}
+	return float64(t.TestFiles) / float64(t.SourceFiles)
+}
+
+// Summary renders the test facts for display, e.g.
+// "214 files (~28% of source files)"
+func (t TestStats) Summary() string {
+	switch {
+	case !t.Evaluated:
+		return "no source files recognised"
+	case t.TestFiles == 0:
+		return "none detected"
+	}
+	return fmt.Sprintf("%d files (~%.0f%% of source files)", t.TestFiles, t.Ratio()*100)
+}
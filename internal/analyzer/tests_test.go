@@ -0,0 +1,108 @@
+package analyzer
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/agnivo988/Repo-lyzer/internal/github"
+)
+
+// blobs builds a tree of files at paths
+func blobs(paths ...string) []github.TreeEntry {
+	tree := make([]github.TreeEntry, len(paths))
+	for i, p := range paths {
+		tree[i] = github.TreeEntry{Path: p, Type: "blob"}
+	}
+	return tree
+}
+
+func TestIsTestFile(t *testing.T) {
+	tests := []struct {
+		path string
+		want bool
+	}{
+		{"pkg/client_test.go", true},
+		{"pkg/client.go", false},
+		{"pkg/testing.go", false},
+		{"src/app.test.js", true},
+		{"src/app.spec.ts", true},
+		{"src/Button.test.tsx", true},
+		{"src/test-utils.js", false},
+		{"lib/test_parser.py", true},
+		{"lib/parser_test.py", true},
+		{"lib/testing.py", false},
+		{"src/main/java/org/ParserTest.java", true},
+		{"src/test/java/org/Helpers.java", true},
+		{"src/main/java/org/Parser.java", false},
+		{"app/src/FooTests.kt", true},
+		{"Lib/ParserTests.cs", true},
+		{"lib/parser_spec.rb", true},
+		{"spec/support/helpers.rb", true},
+		{"tests/conftest.py", true},
+		{"Tests/Helpers.cs", true},
+		{"web/__tests__/render.js", true},
+		{"contest/entry.go", false},
+	}
+	for _, tt := range tests {
+		if got := isTestFile(tt.path); got != tt.want {
+			t.Errorf("isTestFile(%q) = %t, want %t", tt.path, got, tt.want)
+		}
+	}
+}
+
+func TestDetectTests(t *testing.T) {
+	tree := append(blobs(
+		"main.go", "server.go", "server_test.go", "store.go",
+		"web/app.ts", "web/app.test.ts",
+		"scripts/build.py",
+		"README.md",
+		// Vendored and generated code isn't the project's own
+		"vendor/github.com/x/y/y.go", "vendor/github.com/x/y/y_test.go",
+		"node_modules/lib/index.js", "node_modules/lib/index.test.js",
+		"api/service.pb.go", "web/dist/bundle.min.js",
+	), github.TreeEntry{Path: "internal", Type: "tree"})
+
+	got := DetectTests(tree)
+	want := TestStats{
+		Evaluated:   true,
+		TestFiles:   2,
+		SourceFiles: 5,
+		Languages: []LanguageTests{
+			{Language: "Go", TestFiles: 1, SourceFiles: 3},
+			{Language: "Python", SourceFiles: 1},
+			{Language: "TypeScript", TestFiles: 1, SourceFiles: 1},
+		},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("DetectTests() = %+v, want %+v", got, want)
+	}
+	if got := got.Summary(); got != "2 files (~40% of source files)" {
+		t.Errorf("Summary() = %q", got)
+	}
+}
+
+func TestDetectTestsWithoutTests(t *testing.T) {
+	tests := []struct {
+		name      string
+		tree      []github.TreeEntry
+		evaluated bool
+		summary   string
+	}{
+		{"no tree", nil, false, "no source files recognised"},
+		{"docs only", blobs("README.md", "docs/index.md"), false, "no source files recognised"},
+		{"only tests", blobs("tests/test_smoke.py"), false, "no source files recognised"},
+		{"no tests", blobs("main.go", "util.go"), true, "none detected"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := DetectTests(tt.tree)
+			if got.Evaluated != tt.evaluated || got.Summary() != tt.summary {
+				t.Errorf("Evaluated = %t, Summary() = %q; want %t, %q", got.Evaluated, got.Summary(), tt.evaluated, tt.summary)
+			}
+			// No source files mustn't divide by zero
+			if r := got.Ratio(); r != 0 {
+				t.Errorf("Ratio() = %f, want 0", r)
+			}
+		})
+	}
+}
@@ -0,0 +1,100 @@
+package analyzer
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/agnivo988/Repo-lyzer/internal/github"
+)
+
+// Contributor tier thresholds
+const (
+	// CoreCommitShare is the percent of all commits the top contributors
+	// must cover to all count as core
+	CoreCommitShare = 80
+	// CoreMinRecentCommits makes anyone with this many commits in the last
+	// year core, even outside the top share
+	CoreMinRecentCommits = 12
+	// DriveByMaxCommits is the most commits ever a drive-by contributor has
+	DriveByMaxCommits = 2
+)
+
+// ContributorTier classifies how involved a contributor is
+type ContributorTier string
+
+const (
+	TierCore    ContributorTier = "core"
+	TierRegular ContributorTier = "regular"
+	TierDriveBy ContributorTier = "drive-by"
+)
+
+// ContributorTiers counts contributors in each tier, bots excluded
+type ContributorTiers struct {
+	Core    int `json:"core"`
+	Regular int `json:"regular"`
+	DriveBy int `json:"drive_by"`
+	// ByLogin maps each classified contributor to their tier
+	ByLogin map[string]ContributorTier `json:"-"`
+}
+
+// ClassifyContributors sorts contributors into tiers from their all-time
+// commit counts and the recent commits (the last year's history): core
+// are the top contributors covering CoreCommitShare of commits plus anyone
+// with CoreMinRecentCommits recent commits, drive-by have at most
+// DriveByMaxCommits commits, and everyone else is regular.
+func ClassifyContributors(contributors []github.Contributor, recent []github.Commit) ContributorTiers {
+	recentCommits := make(map[string]int)
+	for _, c := range recent {
+		if login := c.AuthorLogin(); login != "" {
+			recentCommits[login]++
+		}
+	}
+
+	var humans []github.Contributor
+	for _, c := range contributors {
+		if !c.IsBot() && c.Commits > 0 {
+			humans = append(humans, c)
+		}
+	}
+	sort.SliceStable(humans, func(i, j int) bool {
+		return humans[i].Commits > humans[j].Commits
+	})
+	commits := make([]int, len(humans))
+	for i, c := range humans {
+		commits[i] = c.Commits
+	}
+	top := coveringContributors(commits, CoreCommitShare)
+
+	tiers := ContributorTiers{ByLogin: make(map[string]ContributorTier)}
+	for i, c := range humans {
+		tier := TierRegular
+		switch {
+		case i < top || recentCommits[c.Login] >= CoreMinRecentCommits:
+			tier = TierCore
+			tiers.Core++
+		case c.Commits <= DriveByMaxCommits:
+			tier = TierDriveBy
+			tiers.DriveBy++
+		default:
+			tiers.Regular++
+		}
+		tiers.ByLogin[c.Login] = tier
+	}
+	return tiers
+}
+
+// CoreContributors returns the contributors classified as core
+func (t ContributorTiers) CoreContributors(contributors []github.Contributor) []github.Contributor {
+	var core []github.Contributor
+	for _, c := range contributors {
+		if t.ByLogin[c.Login] == TierCore {
+			core = append(core, c)
+		}
+	}
+	return core
+}
+
+// Summary renders the counts, e.g. "5 core, 20 regular, 125 drive-by"
+func (t ContributorTiers) Summary() string {
+	return fmt.Sprintf("%d core, %d regular, %d drive-by", t.Core, t.Regular, t.DriveBy)
+}
@@ -0,0 +1,66 @@
+package analyzer
+
+import (
+	"testing"
+
+	"github.com/agnivo988/Repo-lyzer/internal/github"
+)
+
+// recentCommits builds n commits in the last year by login
+func recentCommits(login string, n int) []github.Commit {
+	commits := make([]github.Commit, n)
+	for i := range commits {
+		commits[i].Author = &github.CommitAuthor{Login: login}
+	}
+	return commits
+}
+
+func TestClassifyContributorsBoundaries(t *testing.T) {
+	tests := []struct {
+		name         string
+		contributors []github.Contributor
+		recent       []github.Commit
+		tier         ContributorTier // of contributor "b"
+	}{
+		// a alone covers the core share, so b's tier comes from its own count
+		{"one commit", people(1000, 1), nil, TierDriveBy},
+		{"at the drive-by limit", people(1000, DriveByMaxCommits), nil, TierDriveBy},
+		{"just above the drive-by limit", people(1000, DriveByMaxCommits+1), nil, TierRegular},
+
+		{"just below the recent core minimum", people(1000, 50), recentCommits("b", CoreMinRecentCommits-1), TierRegular},
+		{"at the recent core minimum", people(1000, 50), recentCommits("b", CoreMinRecentCommits), TierCore},
+		{"just above the recent core minimum", people(1000, 50), recentCommits("b", CoreMinRecentCommits+1), TierCore},
+		// Recent commits make even a drive-by contributor core
+		{"drive-by with recent commits", people(1000, 2), recentCommits("b", CoreMinRecentCommits), TierCore},
+
+		// a's share of commits against CoreCommitShare
+		{"top share just below the core share", people(CoreCommitShare-1, 101-CoreCommitShare), nil, TierCore},
+		{"top share at the core share", people(CoreCommitShare, 100-CoreCommitShare), nil, TierRegular},
+		{"top share just above the core share", people(CoreCommitShare+1, 99-CoreCommitShare), nil, TierRegular},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tiers := ClassifyContributors(tt.contributors, tt.recent)
+			if tiers.ByLogin["a"] != TierCore {
+				t.Errorf("top contributor is %s, want core", tiers.ByLogin["a"])
+			}
+			if got := tiers.ByLogin["b"]; got != tt.tier {
+				t.Errorf("b is %s, want %s", got, tt.tier)
+			}
+			if tiers.Core+tiers.Regular+tiers.DriveBy != len(tt.contributors) {
+				t.Errorf("counts %s don't cover the %d contributors", tiers.Summary(), len(tt.contributors))
+			}
+		})
+	}
+}
+
+func TestClassifyContributorsSkipsBots(t *testing.T) {
+	contributors := append(people(40, 30, 1), github.Contributor{Login: "dependabot[bot]", Commits: 500})
+	tiers := ClassifyContributors(contributors, recentCommits("dependabot[bot]", 50))
+	if got := tiers.Summary(); got != "2 core, 0 regular, 1 drive-by" {
+		t.Errorf("Summary() = %s, want 2 core, 0 regular, 1 drive-by", got)
+	}
+	if _, ok := tiers.ByLogin["dependabot[bot]"]; ok {
+		t.Error("the bot was classified")
+	}
+}
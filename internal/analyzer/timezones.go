@@ -0,0 +1,128 @@
+package analyzer
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/agnivo988/Repo-lyzer/internal/github"
+)
+
+// timezoneBand is a range of UTC offsets, in seconds, from Min up to but
+// not including Max
+type timezoneBand struct {
+	Label    string
+	Min, Max int
+}
+
+// timezoneBands split the world into broad regions. Bounds are in
+// seconds so half-hour and 45-minute offsets such as +05:30 and +05:45
+// fall in a band like any other.
+var timezoneBands = []timezoneBand{
+	{"UTC−12 to −6", -12 * 3600, -5 * 3600},
+	{"UTC−5 to −1", -5 * 3600, 0},
+	{"UTC+0 to +3", 0, 3*3600 + 1},
+	{"UTC+3:30 to +6:30", 3*3600 + 1, 7 * 3600},
+	{"UTC+7 to +14", 7 * 3600, 14*3600 + 1},
+}
+
+// TimezoneBand is the share of commits authored in one band of offsets
+type TimezoneBand struct {
+	Label   string  `json:"label"`
+	Commits int     `json:"commits"`
+	Share   float64 `json:"share"`
+}
+
+// TimezoneSpread is where in the world commits are authored, going by the
+// UTC offsets of author dates
+type TimezoneSpread struct {
+	Evaluated bool           `json:"evaluated"`
+	Commits   int            `json:"commits"` // counted, after exclusions
+	Bands     []TimezoneBand `json:"bands"`
+	Offsets   int            `json:"offsets"` // distinct offsets seen
+	// CoreOffsets is the distinct offsets among core contributors' commits
+	CoreOffsets int `json:"core_offsets"`
+	// Bots and WebUI count the commits left out: bots run anywhere, and the
+	// web UI doesn't record the author's offset
+	Bots  int `json:"bots"`
+	WebUI int `json:"web_ui"`
+	// OnlyUTC is set when every date was in UTC, as when the provider
+	// doesn't keep offsets, so the spread says nothing
+	OnlyUTC bool `json:"only_utc"`
+}
+
+// AnalyzeTimezones buckets the author date offsets of commits into bands.
+// core maps the logins of core contributors, as from ContributorTiers.
+func AnalyzeTimezones(commits []github.Commit, core map[string]ContributorTier) TimezoneSpread {
+	spread := TimezoneSpread{}
+	counts := make([]int, len(timezoneBands))
+	offsets := make(map[int]bool)
+	coreOffsets := make(map[int]bool)
+	for _, c := range commits {
+		id := commitIdentity(c, nil)
+		switch {
+		case id == "":
+			spread.Bots++
+			continue
+		case c.IsWebFlow():
+			spread.WebUI++
+			continue
+		}
+		_, offset := c.Commit.Author.Date.Zone()
+		for i, band := range timezoneBands {
+			if offset >= band.Min && offset < band.Max {
+				counts[i]++
+				break
+			}
+		}
+		spread.Commits++
+		offsets[offset] = true
+		if core[id] == TierCore {
+			coreOffsets[offset] = true
+		}
+	}
+	if spread.Commits == 0 {
+		return spread
+	}
+	if len(offsets) == 1 && offsets[0] {
+		spread.OnlyUTC = true
+		return spread
+	}
+
+	spread.Evaluated = true
+	spread.Offsets = len(offsets)
+	spread.CoreOffsets = len(coreOffsets)
+	for i, band := range timezoneBands {
+		spread.Bands = append(spread.Bands, TimezoneBand{
+			Label:   band.Label,
+			Commits: counts[i],
+			Share:   float64(counts[i]) / float64(spread.Commits),
+		})
+	}
+	return spread
+}
+
+// Summary renders the bands with commits, largest first, e.g.
+// "45% UTC−5 to −1, 30% UTC+0 to +3, 25% UTC+7 to +14; 4 offsets among core contributors"
+func (t TimezoneSpread) Summary() string {
+	switch {
+	case t.OnlyUTC:
+		return "not evaluated (commit dates carry no timezone)"
+	case !t.Evaluated:
+		return "not evaluated"
+	}
+	bands := append([]TimezoneBand(nil), t.Bands...)
+	sort.SliceStable(bands, func(i, j int) bool { return bands[i].Commits > bands[j].Commits })
+	var parts []string
+	for _, b := range bands {
+		if b.Commits > 0 {
+			parts = append(parts, fmt.Sprintf("%.0f%% %s", b.Share*100, b.Label))
+		}
+	}
+	summary := strings.Join(parts, ", ")
+	summary += fmt.Sprintf("; %d offsets among core contributors", t.CoreOffsets)
+	if excluded := t.Bots + t.WebUI; excluded > 0 {
+		summary += fmt.Sprintf(" (%d bot and web UI commits left out)", excluded)
+	}
+	return summary
+}
@@ -0,0 +1,97 @@
+package analyzer
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/agnivo988/Repo-lyzer/internal/github"
+)
+
+func TestAnalyzeTimezones(t *testing.T) {
+	// commit is authored by login at 12:00 in the offset, e.g. "+05:30"
+	commit := func(login, offset string) github.Commit {
+		var c github.Commit
+		date, err := time.Parse(time.RFC3339, "2024-05-01T12:00:00"+offset)
+		if err != nil {
+			t.Fatal(err)
+		}
+		c.Commit.Author.Date = date
+		c.Author = &github.CommitAuthor{Login: login}
+		return c
+	}
+	webUI := func(login, offset string) github.Commit {
+		c := commit(login, offset)
+		c.Commit.Committer.Email = github.WebFlowEmail
+		return c
+	}
+	core := map[string]ContributorTier{"ann": TierCore, "bob": TierCore}
+
+	tests := []struct {
+		name        string
+		commits     []github.Commit
+		bands       [5]int
+		offsets     int
+		coreOffsets int
+		bots, webUI int
+	}{
+		{"half-hour offsets", []github.Commit{
+			commit("ann", "+05:30"), commit("bob", "+05:45"), commit("cy", "+01:00"),
+		}, [5]int{0, 0, 1, 2, 0}, 3, 2, 0, 0},
+		{"band edges at +3", []github.Commit{
+			commit("ann", "+03:00"), commit("bob", "+03:30"), commit("cy", "+06:30"), commit("dee", "+07:00"),
+		}, [5]int{0, 0, 1, 2, 1}, 4, 2, 0, 0},
+		{"band edges around UTC", []github.Commit{
+			commit("ann", "Z"), commit("bob", "-01:00"), commit("cy", "-05:00"), commit("dee", "-06:00"),
+		}, [5]int{1, 2, 1, 0, 0}, 4, 2, 0, 0},
+		{"bots and web UI left out", []github.Commit{
+			commit("ann", "+02:00"), commit("bob", "-08:00"),
+			commit("dependabot[bot]", "+09:00"), commit("renovate[bot]", "+09:00"),
+			webUI("ann", "Z"),
+		}, [5]int{1, 0, 1, 0, 0}, 2, 2, 2, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := AnalyzeTimezones(tt.commits, core)
+			if !got.Evaluated {
+				t.Fatalf("not evaluated: %+v", got)
+			}
+			var bands [5]int
+			for i, b := range got.Bands {
+				bands[i] = b.Commits
+			}
+			if bands != tt.bands {
+				t.Errorf("bands = %v, want %v", bands, tt.bands)
+			}
+			if got.Offsets != tt.offsets || got.CoreOffsets != tt.coreOffsets {
+				t.Errorf("offsets = %d (%d core), want %d (%d)", got.Offsets, got.CoreOffsets, tt.offsets, tt.coreOffsets)
+			}
+			if got.Bots != tt.bots || got.WebUI != tt.webUI {
+				t.Errorf("left out %d bot and %d web UI commits, want %d and %d", got.Bots, got.WebUI, tt.bots, tt.webUI)
+			}
+			if want := len(tt.commits) - tt.bots - tt.webUI; got.Commits != want {
+				t.Errorf("Commits = %d, want %d", got.Commits, want)
+			}
+		})
+	}
+}
+
+func TestAnalyzeTimezonesOnlyUTC(t *testing.T) {
+	// The REST API normalizes author dates to Z
+	var commits []github.Commit
+	payload := `[
+		{"commit": {"author": {"name": "Ann", "date": "2024-05-01T06:30:00Z"}}, "author": {"login": "ann"}},
+		{"commit": {"author": {"name": "Bob", "date": "2024-05-01T22:15:00Z"}}, "author": {"login": "bob"}}
+	]`
+	if err := json.Unmarshal([]byte(payload), &commits); err != nil {
+		t.Fatal(err)
+	}
+
+	got := AnalyzeTimezones(commits, nil)
+	if !got.OnlyUTC || got.Evaluated || len(got.Bands) != 0 {
+		t.Errorf("spread = %+v, want OnlyUTC and not evaluated", got)
+	}
+	if got.Summary() != "not evaluated (commit dates carry no timezone)" {
+		t.Errorf("Summary = %q", got.Summary())
+	}
+}
@@ -0,0 +1,135 @@
+package analyzer
+
+import (
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/agnivo988/Repo-lyzer/internal/github"
+)
+
+// largestFiles is how many of the biggest files TreeStats keeps
+const largestFiles = 10
+
+// NoExtension groups files without an extension, like Makefile or .gitignore
+const NoExtension = "(none)"
+
+// ExtensionStats counts the files sharing an extension
+type ExtensionStats struct {
+	Extension string `json:"extension"` // lowercase with the dot, or NoExtension
+	Files     int    `json:"files"`
+	Bytes     int64  `json:"bytes"`
+}
+
+// FileSize is one file and its size
+type FileSize struct {
+	Path  string `json:"path"`
+	Bytes int64  `json:"bytes"`
+}
+
+// TreeStats summarizes the files in a repository tree
+type TreeStats struct {
+	Files int   `json:"files"`
+	Bytes int64 `json:"bytes"`
+	// Extensions are ordered by total size, largest first
+	Extensions []ExtensionStats `json:"extensions"`
+	// DeepestPath is the file nested in the most directories
+	DeepestPath string     `json:"deepest_path"`
+	MaxDepth    int        `json:"max_depth"`
+	Largest     []FileSize `json:"largest"` // up to ten, largest first
+	// Excluded counts the vendored and generated files left out
+	Excluded int `json:"excluded"`
+}
+
+// ComputeTreeStats counts the files in a tree by extension. Only blobs are
+// counted, as directories and submodules have no size, and vendored and
+// generated paths are left out when excludeVendored is set.
+func ComputeTreeStats(tree []github.TreeEntry, excludeVendored bool) TreeStats {
+	var stats TreeStats
+	byExt := make(map[string]*ExtensionStats)
+	var files []FileSize
+	for _, entry := range tree {
+		if entry.Type != "blob" {
+			continue
+		}
+		if excludeVendored && skippedPath(entry.Path) {
+			stats.Excluded++
+			continue
+		}
+		size := int64(entry.Size)
+		stats.Files++
+		stats.Bytes += size
+		files = append(files, FileSize{Path: entry.Path, Bytes: size})
+
+		ext := fileExtension(entry.Path)
+		s := byExt[ext]
+		if s == nil {
+			s = &ExtensionStats{Extension: ext}
+			byExt[ext] = s
+		}
+		s.Files++
+		s.Bytes += size
+
+		if depth := strings.Count(entry.Path, "/"); depth > stats.MaxDepth || stats.DeepestPath == "" {
+			stats.MaxDepth, stats.DeepestPath = depth, entry.Path
+		}
+	}
+
+	for _, s := range byExt {
+		stats.Extensions = append(stats.Extensions, *s)
+	}
+	sort.Slice(stats.Extensions, func(i, j int) bool {
+		a, b := stats.Extensions[i], stats.Extensions[j]
+		if a.Bytes != b.Bytes {
+			return a.Bytes > b.Bytes
+		}
+		return a.Extension < b.Extension
+	})
+
+	sort.SliceStable(files, func(i, j int) bool { return files[i].Bytes > files[j].Bytes })
+	stats.Largest = files[:min(len(files), largestFiles)]
+	return stats
+}
+
+// fileExtension returns a path's lowercase extension, or NoExtension. A
+// leading dot, as in .gitignore, doesn't start an extension.
+func fileExtension(p string) string {
+	ext := path.Ext(strings.TrimPrefix(path.Base(p), "."))
+	if ext == "" {
+		return NoExtension
+	}
+	return strings.ToLower(ext)
+}
+
+// AverageSize is the mean file size in bytes, 0 for an empty tree
+func (t TreeStats) AverageSize() float64 {
+	if t.Files == 0 {
+		return 0
+	}
+	return float64(t.Bytes) / float64(t.Files)
+}
+
+// Summary renders the totals for display, e.g.
+// "1,204 files, 18.3 MB (15.6 KB on average)"
+func (t TreeStats) Summary() string {
+	if t.Files == 0 {
+		return "no files"
+	}
+	return fmt.Sprintf("%s files, %s (%s on average)",
+		formatThousands(t.Files), FormatBytes(t.Bytes), FormatBytes(int64(t.AverageSize())))
+}
+
+// FormatBytes renders a size with a binary unit, e.g. "1.5 KB"
+func FormatBytes(n int64) string {
+	const unit = 1024
+	if n < unit {
+		return fmt.Sprintf("%d B", n)
+	}
+	value, exp := float64(n)/unit, 0
+	for value >= unit && exp < 3 {
+		value /= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %cB", value, "KMGT"[exp])
+}
@@ -0,0 +1,113 @@
+package analyzer
+
+import (
+	"fmt"
+	"regexp"
+
+	"github.com/agnivo988/Repo-lyzer/internal/github"
+)
+
+// Development workflows
+const (
+	WorkflowSquash = "squash-merge via PRs"
+	WorkflowMerge  = "merge commits via PRs"
+	WorkflowDirect = "direct pushes"
+)
+
+// prWorkflowShare is the share of default-branch commits that must come
+// from pull requests for the workflow to count as PR-based
+const prWorkflowShare = 0.5
+
+var (
+	// squashSubject ends a squash-merged subject: "Fix crash (#123)"
+	squashSubject = regexp.MustCompile(`\(#\d+\)\s*$`)
+	// mergeSubject is GitHub's merge commit subject, e.g. "Merge pull
+	// request #123 from alice/fix", or Bitbucket's "Merged in fix (pull
+	// request #123)"
+	mergeSubject = regexp.MustCompile(`^Merge pull request #\d+\b|\(pull request #\d+\)`)
+	// mergeRequestBody is GitLab's merge commit trailer, e.g. "See merge
+	// request group/project!123"
+	mergeRequestBody = regexp.MustCompile(`(?m)^See merge request \S*!\d+`)
+)
+
+// DevelopmentWorkflow is how changes land on the default branch
+type DevelopmentWorkflow struct {
+	Evaluated bool `json:"evaluated"`
+	// Commits counts the first-parent history: the commits made on the
+	// branch itself rather than brought in by merges
+	Commits    int    `json:"commits"`
+	PRMerges   int    `json:"pr_merges"`    // merge commits of pull requests
+	OtherMerge int    `json:"other_merges"` // merge commits without a PR reference
+	Squashed   int    `json:"squashed"`     // single commits referencing a PR
+	Direct     int    `json:"direct"`       // single commits without a PR reference
+	Strategy   string `json:"strategy"`
+}
+
+// PRShare is the share of default-branch commits that came from a PR
+func (w DevelopmentWorkflow) PRShare() float64 {
+	if w.Commits == 0 {
+		return 0
+	}
+	return float64(w.PRMerges+w.Squashed) / float64(w.Commits)
+}
+
+// AnalyzeWorkflow classifies the commits of the default branch, newest
+// first. Commits merged in from other branches aren't the branch's own, so
+// only the first-parent chain from the newest commit is counted; it ends
+// where a parent falls outside the window.
+func AnalyzeWorkflow(commits []github.Commit) DevelopmentWorkflow {
+	var w DevelopmentWorkflow
+	if len(commits) == 0 {
+		return w
+	}
+	bySHA := make(map[string]github.Commit, len(commits))
+	for _, c := range commits {
+		bySHA[c.SHA] = c
+	}
+	for c, ok := commits[0], true; ok; {
+		w.Commits++
+		referencesPR := HasPRReference(c)
+		switch {
+		case c.IsMerge() && referencesPR:
+			w.PRMerges++
+		case c.IsMerge():
+			w.OtherMerge++
+		case referencesPR:
+			w.Squashed++
+		default:
+			w.Direct++
+		}
+		if len(c.Parents) == 0 {
+			break
+		}
+		c, ok = bySHA[c.Parents[0].SHA]
+	}
+
+	w.Evaluated = true
+	switch {
+	case w.PRShare() < prWorkflowShare:
+		w.Strategy = WorkflowDirect
+	case w.Squashed >= w.PRMerges:
+		w.Strategy = WorkflowSquash
+	default:
+		w.Strategy = WorkflowMerge
+	}
+	return w
+}
+
+// HasPRReference reports whether a commit landed through a pull or merge
+// request, going by the subjects and trailers GitHub, GitLab and Bitbucket
+// write
+func HasPRReference(c github.Commit) bool {
+	subject := c.Subject()
+	return squashSubject.MatchString(subject) || mergeSubject.MatchString(subject) ||
+		mergeRequestBody.MatchString(c.Commit.Message)
+}
+
+// Summary renders the workflow, e.g. "squash-merge via PRs, ~92% of commits"
+func (w DevelopmentWorkflow) Summary() string {
+	if !w.Evaluated {
+		return "not evaluated"
+	}
+	return fmt.Sprintf("%s, ~%.0f%% of commits via PRs", w.Strategy, w.PRShare()*100)
+}
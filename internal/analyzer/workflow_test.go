@@ -0,0 +1,88 @@
+package analyzer
+
+import (
+	"testing"
+
+	"github.com/agnivo988/Repo-lyzer/internal/github"
+)
+
+// chained builds a commit with message and parent SHAs
+func chained(sha, message string, parents ...string) github.Commit {
+	c := github.Commit{SHA: sha}
+	c.Commit.Message = message
+	for _, p := range parents {
+		c.Parents = append(c.Parents, github.CommitParent{SHA: p})
+	}
+	return c
+}
+
+func TestHasPRReference(t *testing.T) {
+	tests := []struct {
+		message string
+		want    bool
+	}{
+		{"Merge pull request #12 from alice/fix-crash\n\nFix the crash", true},
+		{"Merge pull request #12", true},
+		{"Fix crash on empty input (#34)", true},
+		{"feat(api): add pagination (#1234)  ", true},
+		{"Merged in feature/login (pull request #56)", true},
+		{"Add login\n\nSee merge request group/project!78", true},
+		{"Add login\n\nSee merge request !78", true},
+		// Mentions of issues and PRs aren't merges of them
+		{"Fix issue #5", false},
+		{"Fixes #5", false},
+		{"Revert (#34) change in the middle", false},
+		{"Merge branch 'main' into feature", false},
+		{"Merge pull request from nowhere", false},
+		{"Bump version (#abc)", false},
+		{"Update docs\n\nPart of merge request !78 discussion", false},
+		{"Close #34)", false},
+	}
+	for _, tt := range tests {
+		if got := HasPRReference(chained("a", tt.message)); got != tt.want {
+			t.Errorf("HasPRReference(%q) = %t, want %t", tt.message, got, tt.want)
+		}
+	}
+}
+
+func TestAnalyzeWorkflow(t *testing.T) {
+	tests := []struct {
+		name     string
+		commits  []github.Commit
+		strategy string
+		share    float64
+	}{
+		{"squash merges", []github.Commit{
+			chained("c4", "Add export (#4)", "c3"),
+			chained("c3", "Fix typo", "c2"),
+			chained("c2", "Fix crash (#2)", "c1"),
+			chained("c1", "Initial commit (#1)"),
+		}, WorkflowSquash, 0.75},
+		{"merge commits", []github.Commit{
+			chained("m2", "Merge pull request #9 from bob/docs", "m1", "b1"),
+			// Commits brought in by the merges aren't the branch's own
+			chained("b1", "Write docs", "m1"),
+			chained("m1", "Merge pull request #8 from alice/feat", "c1", "a1"),
+			chained("a1", "Add feature", "c1"),
+			chained("c1", "Initial commit"),
+		}, WorkflowMerge, 2.0 / 3},
+		{"direct pushes", []github.Commit{
+			chained("c3", "Fix issue #5", "c2"),
+			chained("c2", "Merge branch 'dev'", "c1", "d1"),
+			chained("c1", "Add feature (#1)", "c0"),
+			// c0 is outside the window, which ends the chain
+		}, WorkflowDirect, 1.0 / 3},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := AnalyzeWorkflow(tt.commits)
+			if got.Strategy != tt.strategy || got.PRShare() != tt.share {
+				t.Errorf("AnalyzeWorkflow() = %s at %.2f, want %s at %.2f (%+v)", got.Strategy, got.PRShare(), tt.strategy, tt.share, got)
+			}
+		})
+	}
+
+	if got := AnalyzeWorkflow(nil); got.Evaluated || got.Summary() != "not evaluated" {
+		t.Errorf("no commits: %+v", got)
+	}
+}
@@ -0,0 +1,37 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"time"
+)
+
+// WorkflowRun is one GitHub Actions workflow run
+type WorkflowRun struct {
+	ID         int64     `json:"id"`
+	Name       string    `json:"name"`
+	WorkflowID int64     `json:"workflow_id"`
+	HeadBranch string    `json:"head_branch"`
+	Status     string    `json:"status"`     // queued, in_progress, completed
+	Conclusion string    `json:"conclusion"` // success, failure, cancelled... once completed
+	HTMLURL    string    `json:"html_url"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+// GetWorkflowRuns lists the most recent workflow runs on a branch, newest first
+func (c *Client) GetWorkflowRuns(ctx context.Context, owner, repo, branch string) ([]WorkflowRun, error) {
+	endpoint := fmt.Sprintf(
+		"%s/repos/%s/%s/actions/runs?branch=%s&per_page=100",
+		c.baseURL, owner, repo, url.QueryEscape(branch),
+	)
+
+	var resp struct {
+		TotalCount   int           `json:"total_count"`
+		WorkflowRuns []WorkflowRun `json:"workflow_runs"`
+	}
+	if err := c.get(ctx, endpoint, &resp); err != nil {
+		return nil, err
+	}
+	return resp.WorkflowRuns, nil
+}
@@ -0,0 +1,171 @@
+package github
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// TokenSourceApp is reported when authenticating as a GitHub App installation
+const TokenSourceApp TokenSource = "GitHub App installation"
+
+// ErrNoInstallationAccess is matched by errors.Is when the GitHub App
+// installation can't see the requested repository
+var ErrNoInstallationAccess = errors.New("GitHub App installation has no access to the repository")
+
+// InstallationAccessError names the repository an installation can't see
+type InstallationAccessError struct {
+	Repo string
+}
+
+func (e *InstallationAccessError) Error() string {
+	return fmt.Sprintf("%s is not accessible to the GitHub App installation (add it to the installation's repositories)", e.Repo)
+}
+
+func (e *InstallationAccessError) Is(target error) bool {
+	return target == ErrNoInstallationAccess
+}
+
+// AppCredentials identify a GitHub App installation. The app needs the
+// read-only "Contents" and "Metadata" repository permissions.
+type AppCredentials struct {
+	AppID          int64
+	InstallationID int64
+	PrivateKey     *rsa.PrivateKey
+}
+
+// installation tokens are renewed this long before they expire
+const tokenRefreshMargin = 5 * time.Minute
+
+// appAuth mints and caches installation tokens
+type appAuth struct {
+	creds AppCredentials
+
+	mu      sync.Mutex
+	token   string
+	expires time.Time
+}
+
+// WithAppAuth authenticates as a GitHub App installation instead of with a
+// token. Installation tokens are minted on first use and renewed before
+// their one-hour expiry.
+func WithAppAuth(creds AppCredentials) Option {
+	return func(c *Client) {
+		c.app = &appAuth{creds: creds}
+		c.token = ""
+		c.tokenSource = TokenSourceApp
+	}
+}
+
+// ParsePrivateKey parses a GitHub App private key in PEM form (PKCS#1, as
+// GitHub issues them, or PKCS#8)
+func ParsePrivateKey(data []byte) (*rsa.PrivateKey, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, errors.New("private key is not PEM encoded")
+	}
+	if key, err := x509.ParsePKCS1PrivateKey(block.Bytes); err == nil {
+		return key, nil
+	}
+	parsed, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("parsing private key: %w", err)
+	}
+	key, ok := parsed.(*rsa.PrivateKey)
+	if !ok {
+		return nil, errors.New("private key is not an RSA key")
+	}
+	return key, nil
+}
+
+// authToken returns the token to send, minting or renewing the installation
+// token when authenticating as an app
+func (c *Client) authToken(ctx context.Context) (string, error) {
+	if c.app == nil {
+		return c.token, nil
+	}
+
+	a := c.app
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if a.token != "" && time.Until(a.expires) > tokenRefreshMargin {
+		return a.token, nil
+	}
+
+	token, expires, err := c.installationToken(ctx)
+	if err != nil {
+		return "", err
+	}
+	a.token, a.expires = token, expires
+	return token, nil
+}
+
+// installationToken exchanges a freshly signed app JWT for an installation token
+func (c *Client) installationToken(ctx context.Context) (string, time.Time, error) {
+	jwt, err := appJWT(c.app.creds, time.Now())
+	if err != nil {
+		return "", time.Time{}, err
+	}
+
+	url := fmt.Sprintf("%s/app/installations/%d/access_tokens", c.baseURL, c.app.creds.InstallationID)
+	req, err := http.NewRequestWithContext(ctx, "POST", url, nil)
+	if err != nil {
+		return "", time.Time{}, err
+	}
+	req.Header.Set("Accept", defaultAccept)
+	req.Header.Set("User-Agent", c.userAgent)
+	req.Header.Set("Authorization", "Bearer "+jwt)
+
+	resp, err := c.send(req)
+	if err != nil {
+		return "", time.Time{}, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusCreated {
+		return "", time.Time{}, fmt.Errorf("GitHub App installation token: %s (check the app ID, installation ID and private key)", resp.Status)
+	}
+
+	var body struct {
+		Token     string    `json:"token"`
+		ExpiresAt time.Time `json:"expires_at"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return "", time.Time{}, err
+	}
+	return body.Token, body.ExpiresAt, nil
+}
+
+// appJWT signs the short-lived RS256 JWT that identifies the app itself.
+// iat is backdated a minute to allow for clock drift, as GitHub advises.
+func appJWT(creds AppCredentials, now time.Time) (string, error) {
+	header := base64.RawURLEncoding.EncodeToString([]byte(`{"alg":"RS256","typ":"JWT"}`))
+	claims, err := json.Marshal(map[string]interface{}{
+		"iat": now.Add(-time.Minute).Unix(),
+		"exp": now.Add(9 * time.Minute).Unix(),
+		"iss": strconv.FormatInt(creds.AppID, 10),
+	})
+	if err != nil {
+		return "", err
+	}
+
+	signed := header + "." + base64.RawURLEncoding.EncodeToString(claims)
+	digest := sha256.Sum256([]byte(signed))
+	signature, err := rsa.SignPKCS1v15(rand.Reader, creds.PrivateKey, crypto.SHA256, digest[:])
+	if err != nil {
+		return "", err
+	}
+	return signed + "." + base64.RawURLEncoding.EncodeToString(signature), nil
+}
@@ -0,0 +1,190 @@
+package github
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// testAppCreds returns credentials with a freshly generated key
+func testAppCreds(t *testing.T) AppCredentials {
+	t.Helper()
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return AppCredentials{AppID: 42, InstallationID: 7, PrivateKey: key}
+}
+
+// fakeTokenExchange serves installation tokens that expire after lifetime,
+// numbered by how many have been minted, and answers every other request
+// with the languages payload when it carries the latest token
+func fakeTokenExchange(t *testing.T, lifetime time.Duration, minted *atomic.Int32) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/api/v3/app/installations/7/access_tokens" {
+			if r.Method != http.MethodPost {
+				t.Errorf("token exchange method = %s, want POST", r.Method)
+			}
+			if !strings.HasPrefix(r.Header.Get("Authorization"), "Bearer ") {
+				t.Errorf("token exchange Authorization = %q, want a bearer JWT", r.Header.Get("Authorization"))
+			}
+			n := minted.Add(1)
+			w.WriteHeader(http.StatusCreated)
+			fmt.Fprintf(w, `{"token":"inst-%d","expires_at":%q}`, n, time.Now().Add(lifetime).Format(time.RFC3339))
+			return
+		}
+		want := fmt.Sprintf("Bearer inst-%d", minted.Load())
+		if got := r.Header.Get("Authorization"); got != want {
+			t.Errorf("Authorization = %q, want %q", got, want)
+		}
+		w.Write([]byte(`{"Go":100}`))
+	}
+}
+
+func TestAppJWT(t *testing.T) {
+	creds := testAppCreds(t)
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+
+	jwt, err := appJWT(creds, now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	parts := strings.Split(jwt, ".")
+	if len(parts) != 3 {
+		t.Fatalf("JWT has %d parts, want 3", len(parts))
+	}
+
+	var header struct {
+		Alg string `json:"alg"`
+		Typ string `json:"typ"`
+	}
+	decodeSegment(t, parts[0], &header)
+	if header.Alg != "RS256" || header.Typ != "JWT" {
+		t.Errorf("header = %+v, want RS256 JWT", header)
+	}
+
+	var claims struct {
+		Iss string `json:"iss"`
+		Iat int64  `json:"iat"`
+		Exp int64  `json:"exp"`
+	}
+	decodeSegment(t, parts[1], &claims)
+	if claims.Iss != "42" {
+		t.Errorf("iss = %q, want the app ID", claims.Iss)
+	}
+	if want := now.Add(-time.Minute).Unix(); claims.Iat != want {
+		t.Errorf("iat = %d, want %d (backdated a minute)", claims.Iat, want)
+	}
+	if want := now.Add(9 * time.Minute).Unix(); claims.Exp != want {
+		t.Errorf("exp = %d, want %d", claims.Exp, want)
+	}
+
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		t.Fatal(err)
+	}
+	digest := sha256.Sum256([]byte(parts[0] + "." + parts[1]))
+	if err := rsa.VerifyPKCS1v15(&creds.PrivateKey.PublicKey, crypto.SHA256, digest[:], signature); err != nil {
+		t.Errorf("signature doesn't verify: %v", err)
+	}
+}
+
+func decodeSegment(t *testing.T, segment string, target interface{}) {
+	t.Helper()
+	data, err := base64.RawURLEncoding.DecodeString(segment)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := json.Unmarshal(data, target); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestInstallationTokenExchange(t *testing.T) {
+	creds := testAppCreds(t)
+	var jwt string
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/api/v3/app/installations/7/access_tokens" {
+			t.Errorf("request = %s %s, want POST to the installation's access_tokens", r.Method, r.URL.Path)
+		}
+		jwt = strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte(`{"token":"inst-1","expires_at":"2030-01-01T00:00:00Z"}`))
+	}, WithAppAuth(creds))
+
+	token, err := client.authToken(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if token != "inst-1" {
+		t.Errorf("token = %q, want inst-1", token)
+	}
+	if strings.Count(jwt, ".") != 2 {
+		t.Errorf("exchange was authorized with %q, want the app JWT", jwt)
+	}
+	if client.TokenSource() != TokenSourceApp {
+		t.Errorf("TokenSource = %q, want %q", client.TokenSource(), TokenSourceApp)
+	}
+}
+
+func TestInstallationTokenRenewal(t *testing.T) {
+	tests := []struct {
+		name       string
+		lifetime   time.Duration
+		wantMinted int32
+	}{
+		{"cached token is reused", time.Hour, 1},
+		{"token inside the refresh margin is renewed", tokenRefreshMargin - time.Minute, 3},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var minted atomic.Int32
+			client := newTestClient(t, fakeTokenExchange(t, tt.lifetime, &minted), WithAppAuth(testAppCreds(t)))
+
+			for i := 0; i < 3; i++ {
+				if _, err := client.GetLanguages(context.Background(), "o", "r"); err != nil {
+					t.Fatal(err)
+				}
+			}
+			if got := minted.Load(); got != tt.wantMinted {
+				t.Errorf("minted %d tokens, want %d", got, tt.wantMinted)
+			}
+		})
+	}
+}
+
+func TestInstallationAccessError(t *testing.T) {
+	var minted atomic.Int32
+	exchange := fakeTokenExchange(t, time.Hour, &minted)
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/api/v3/repos/o/private" {
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"message":"Not Found"}`))
+			return
+		}
+		exchange(w, r)
+	}, WithAppAuth(testAppCreds(t)))
+
+	_, err := client.GetRepo(context.Background(), "o", "private")
+	var accessErr *InstallationAccessError
+	if !errors.As(err, &accessErr) {
+		t.Fatalf("err = %v, want an InstallationAccessError", err)
+	}
+	if accessErr.Repo != "o/private" {
+		t.Errorf("Repo = %q, want o/private", accessErr.Repo)
+	}
+	if !errors.Is(err, ErrNoInstallationAccess) {
+		t.Error("errors.Is(err, ErrNoInstallationAccess) = false")
+	}
+}
@@ -0,0 +1,144 @@
+package github
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// AuthKind is the kind of credential the client authenticates with
+type AuthKind string
+
+const (
+	AuthNone        AuthKind = "none"
+	AuthClassic     AuthKind = "classic token"
+	AuthFineGrained AuthKind = "fine-grained token"
+	AuthApp         AuthKind = "GitHub App"
+	AuthInvalid     AuthKind = "invalid token" // rejected by GitHub
+	AuthUnknown     AuthKind = "unknown"       // not checked, or the check failed
+)
+
+// AuthCapability is what CheckAuth learned about the client's credential
+type AuthCapability struct {
+	Kind   AuthKind
+	Scopes []string // OAuth scopes of a classic token
+}
+
+// HasScope reports whether a classic token was granted scope
+func (a AuthCapability) HasScope(scope string) bool {
+	for _, s := range a.Scopes {
+		if s == scope {
+			return true
+		}
+	}
+	return false
+}
+
+// CanReadPrivateRepos reports whether the credential can see private
+// repositories, as far as can be told up front. Fine-grained tokens and
+// apps are limited to the repositories they were granted, so only a
+// missing "repo" scope on a classic token is a definite no.
+func (a AuthCapability) CanReadPrivateRepos() bool {
+	switch a.Kind {
+	case AuthNone:
+		return false
+	case AuthClassic:
+		return a.HasScope("repo")
+	default:
+		return true
+	}
+}
+
+// Label describes the credential for status displays
+func (a AuthCapability) Label() string {
+	switch a.Kind {
+	case AuthNone:
+		return "unauthenticated"
+	case AuthClassic:
+		if len(a.Scopes) == 0 {
+			return "classic token (no scopes: public data only)"
+		}
+		return "classic token (" + strings.Join(a.Scopes, ", ") + ")"
+	case AuthInvalid:
+		return "invalid token (rejected by GitHub)"
+	case AuthUnknown:
+		return "token (not verified)"
+	default:
+		return string(a.Kind)
+	}
+}
+
+// CheckAuth makes one cheap call to find out what kind of credential the
+// client has and, for classic tokens, its scopes. The result is kept and
+// returned by AuthCapability. A rejected token yields a *StatusError
+// matching ErrUnauthorized.
+func (c *Client) CheckAuth(ctx context.Context) (AuthCapability, error) {
+	capability, err := c.checkAuth(ctx)
+	c.mu.Lock()
+	c.auth = capability
+	c.mu.Unlock()
+	return capability, err
+}
+
+func (c *Client) checkAuth(ctx context.Context) (AuthCapability, error) {
+	switch {
+	case c.app != nil:
+		// Installation tokens carry permissions, not scopes, and can't read /user
+		return AuthCapability{Kind: AuthApp}, nil
+	case c.token == "":
+		return AuthCapability{Kind: AuthNone}, nil
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "GET", c.baseURL+"/user", nil)
+	if err != nil {
+		return AuthCapability{Kind: AuthUnknown}, err
+	}
+	req.Header.Set("Accept", defaultAccept)
+	req.Header.Set("User-Agent", c.userAgent)
+	req.Header.Set("Authorization", "Bearer "+c.token)
+
+	resp, err := c.do(req)
+	if err != nil {
+		return AuthCapability{Kind: AuthUnknown}, err
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, resp.Body)
+	c.recordRateLimit(resp)
+
+	if resp.StatusCode == http.StatusUnauthorized {
+		return AuthCapability{Kind: AuthInvalid}, &StatusError{StatusCode: resp.StatusCode, Status: resp.Status, Message: "Bad credentials"}
+	}
+
+	// Classic tokens (and gh CLI OAuth tokens) always send the scopes
+	// header, if empty; fine-grained tokens never do
+	if _, ok := resp.Header["X-Oauth-Scopes"]; ok {
+		capability := AuthCapability{Kind: AuthClassic}
+		for _, scope := range strings.Split(resp.Header.Get("X-OAuth-Scopes"), ",") {
+			if scope = strings.TrimSpace(scope); scope != "" {
+				capability.Scopes = append(capability.Scopes, scope)
+			}
+		}
+		return capability, nil
+	}
+	if strings.HasPrefix(c.token, "github_pat_") {
+		return AuthCapability{Kind: AuthFineGrained}, nil
+	}
+	return AuthCapability{Kind: AuthUnknown}, nil
+}
+
+// AuthCapability returns what the last CheckAuth found. Before any check,
+// only the absence of a token or the use of an app is known.
+func (c *Client) AuthCapability() AuthCapability {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	switch {
+	case c.auth.Kind != "":
+		return c.auth
+	case c.app != nil:
+		return AuthCapability{Kind: AuthApp}
+	case c.token == "":
+		return AuthCapability{Kind: AuthNone}
+	}
+	return AuthCapability{Kind: AuthUnknown}
+}
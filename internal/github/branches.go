@@ -0,0 +1,174 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// DefaultMaxBranches caps how many branches are listed for a repo
+const DefaultMaxBranches = 1000
+
+// DefaultBranchDateSample caps how many branches get their head commit
+// looked up, at one request each, when the listing has no dates
+const DefaultBranchDateSample = 30
+
+// Branch is a branch and its head commit
+type Branch struct {
+	Name   string `json:"name"`
+	Commit struct {
+		SHA string `json:"sha"`
+	} `json:"commit"`
+	// CommittedAt is the head commit's date; zero when it wasn't looked up
+	CommittedAt time.Time `json:"committed_at,omitempty"`
+}
+
+// BranchList is a repo's branches, up to a cap
+type BranchList struct {
+	Branches []Branch
+	// TotalCount is every branch of the repo, which may be more than
+	// Branches holds; without a token it's only known up to the cap
+	TotalCount int
+	Truncated  bool // the cap was hit
+}
+
+// GetBranches lists up to max branches (0 means no cap). With a token a
+// GraphQL query returns each branch's head commit date, 100 branches per
+// request; otherwise, or if GraphQL fails, REST lists them without dates.
+func (c *Client) GetBranches(ctx context.Context, owner, repo string, max int) (*BranchList, error) {
+	if c.tokenSource != TokenSourceNone {
+		if list, err := c.getBranchesGraphQL(ctx, owner, repo, max); err == nil {
+			return list, nil
+		} else if ctx.Err() != nil {
+			return nil, err
+		}
+	}
+	return c.getBranchesREST(ctx, owner, repo, max)
+}
+
+func (c *Client) getBranchesREST(ctx context.Context, owner, repo string, max int) (*BranchList, error) {
+	list := &BranchList{}
+
+	page := 1
+	perPage := 100
+
+	for {
+		url := fmt.Sprintf(
+			"%s/repos/%s/%s/branches?per_page=%d&page=%d",
+			c.baseURL, owner, repo, perPage, page,
+		)
+
+		var branches []Branch
+		if err := c.get(ctx, url, &branches); err != nil {
+			return nil, err
+		}
+
+		list.Branches = append(list.Branches, branches...)
+
+		if max > 0 && len(list.Branches) >= max {
+			list.Truncated = len(list.Branches) > max || len(branches) == perPage
+			list.Branches = list.Branches[:max]
+			break
+		}
+		if len(branches) < perPage {
+			break
+		}
+
+		page++
+	}
+
+	list.TotalCount = len(list.Branches)
+	return list, nil
+}
+
+const branchesQuery = `query($owner: String!, $name: String!, $after: String) {
+  repository(owner: $owner, name: $name) {
+    refs(refPrefix: "refs/heads/", first: 100, after: $after) {
+      totalCount
+      pageInfo { hasNextPage endCursor }
+      nodes { name target { oid ... on Commit { committedDate } } }
+    }
+  }
+}`
+
+type branchesResponse struct {
+	Repository *struct {
+		Refs struct {
+			TotalCount int `json:"totalCount"`
+			PageInfo   struct {
+				HasNextPage bool   `json:"hasNextPage"`
+				EndCursor   string `json:"endCursor"`
+			} `json:"pageInfo"`
+			Nodes []struct {
+				Name   string `json:"name"`
+				Target struct {
+					Oid           string    `json:"oid"`
+					CommittedDate time.Time `json:"committedDate"`
+				} `json:"target"`
+			} `json:"nodes"`
+		} `json:"refs"`
+	} `json:"repository"`
+}
+
+func (c *Client) getBranchesGraphQL(ctx context.Context, owner, repo string, max int) (*BranchList, error) {
+	list := &BranchList{}
+	vars := map[string]interface{}{"owner": owner, "name": repo}
+	for {
+		var resp branchesResponse
+		if err := c.graphql(ctx, branchesQuery, vars, &resp); err != nil {
+			return nil, err
+		}
+		if resp.Repository == nil {
+			return nil, fmt.Errorf("repository %s/%s not found", owner, repo)
+		}
+		refs := resp.Repository.Refs
+		list.TotalCount = refs.TotalCount
+		for _, n := range refs.Nodes {
+			branch := Branch{Name: n.Name, CommittedAt: n.Target.CommittedDate}
+			branch.Commit.SHA = n.Target.Oid
+			list.Branches = append(list.Branches, branch)
+		}
+
+		if max > 0 && len(list.Branches) >= max {
+			list.Truncated = list.TotalCount > max
+			list.Branches = list.Branches[:max]
+			return list, nil
+		}
+		if !refs.PageInfo.HasNextPage {
+			return list, nil
+		}
+		vars["after"] = refs.PageInfo.EndCursor
+	}
+}
+
+// DateBranches looks up the head commit date of up to sample undated
+// branches, spread evenly over the list so the sample isn't skewed by
+// naming. Branches whose commit can't be fetched stay undated.
+func (c *Client) DateBranches(ctx context.Context, owner, repo string, branches []Branch, sample int) {
+	var undated []int
+	for i, b := range branches {
+		if b.CommittedAt.IsZero() {
+			undated = append(undated, i)
+		}
+	}
+	if len(undated) > sample {
+		picked := make([]int, sample)
+		for i := range picked {
+			picked[i] = undated[i*len(undated)/sample]
+		}
+		undated = picked
+	}
+
+	var wg sync.WaitGroup
+	for _, i := range undated {
+		wg.Add(1)
+		go func(b *Branch) {
+			defer wg.Done()
+			if commit, err := c.GetCommit(ctx, owner, repo, b.Commit.SHA); err == nil {
+				b.CommittedAt = commit.Commit.Author.Date
+			}
+		}(&branches[i])
+	}
+	wg.Wait()
+}
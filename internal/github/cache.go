@@ -0,0 +1,61 @@
+package github
+
+import (
+	"sync"
+	"time"
+)
+
+// CacheEntry is a cached response body with the ETag it was served with
+type CacheEntry struct {
+	ETag      string
+	Body      []byte
+	FetchedAt time.Time // when the body was last confirmed current
+}
+
+// ResponseCache stores responses for conditional requests, keyed by URL
+// and the identity requesting it
+type ResponseCache interface {
+	Get(url string) (CacheEntry, bool)
+	Set(url string, entry CacheEntry)
+}
+
+// DefaultCacheSize is the number of responses kept by the in-memory cache
+const DefaultCacheSize = 500
+
+// MemoryCache is an in-memory ResponseCache that evicts the oldest entry once full
+type MemoryCache struct {
+	mu      sync.Mutex
+	max     int
+	entries map[string]CacheEntry
+	order   []string
+}
+
+// NewMemoryCache creates a cache holding at most max entries
+func NewMemoryCache(max int) *MemoryCache {
+	return &MemoryCache{
+		max:     max,
+		entries: make(map[string]CacheEntry),
+	}
+}
+
+func (m *MemoryCache) Get(url string) (CacheEntry, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	e, ok := m.entries[url]
+	return e, ok
+}
+
+func (m *MemoryCache) Set(url string, entry CacheEntry) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, exists := m.entries[url]; !exists {
+		m.order = append(m.order, url)
+	}
+	m.entries[url] = entry
+
+	for len(m.order) > m.max {
+		delete(m.entries, m.order[0])
+		m.order = m.order[1:]
+	}
+}
@@ -0,0 +1,158 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestConditionalRequests(t *testing.T) {
+	var requests atomic.Int32
+	var ifNoneMatch []string
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		ifNoneMatch = append(ifNoneMatch, r.Header.Get("If-None-Match"))
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("ETag", `"v1"`)
+		w.Write([]byte(`{"name":"r","stargazers_count":7}`))
+	})
+	client.SetCache(NewMemoryCache(10))
+
+	for i := 0; i < 2; i++ {
+		repo, err := client.GetRepo(context.Background(), "o", "r")
+		if err != nil {
+			t.Fatalf("request %d: %v", i+1, err)
+		}
+		if repo.Name != "r" || repo.Stars != 7 {
+			t.Errorf("request %d: repo = %+v, want the cached body", i+1, repo)
+		}
+	}
+
+	if requests.Load() != 2 {
+		t.Fatalf("%d requests, want 2", requests.Load())
+	}
+	if ifNoneMatch[0] != "" || ifNoneMatch[1] != `"v1"` {
+		t.Errorf("If-None-Match = %q, want none then the cached ETag", ifNoneMatch)
+	}
+}
+
+func TestConditionalRequestChangedBody(t *testing.T) {
+	version := "v1"
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		etag := `"` + version + `"`
+		if r.Header.Get("If-None-Match") == etag {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("ETag", etag)
+		w.Write([]byte(`{"name":"` + version + `"}`))
+	})
+	client.SetCache(NewMemoryCache(10))
+
+	for _, v := range []string{"v1", "v2", "v2"} {
+		version = v
+		repo, err := client.GetRepo(context.Background(), "o", "r")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if repo.Name != v {
+			t.Errorf("repo name = %q, want %q", repo.Name, v)
+		}
+	}
+}
+
+func TestMemoryCacheEvictsOldest(t *testing.T) {
+	cache := NewMemoryCache(2)
+	cache.Set("a", CacheEntry{ETag: "a"})
+	cache.Set("b", CacheEntry{ETag: "b"})
+	cache.Set("a", CacheEntry{ETag: "a2"}) // updating keeps a's place
+	cache.Set("c", CacheEntry{ETag: "c"})
+
+	tests := []struct {
+		url  string
+		etag string
+		ok   bool
+	}{
+		{"a", "", false},
+		{"b", "b", true},
+		{"c", "c", true},
+	}
+	for _, tt := range tests {
+		e, ok := cache.Get(tt.url)
+		if ok != tt.ok || e.ETag != tt.etag {
+			t.Errorf("Get(%q) = %q, %v, want %q, %v", tt.url, e.ETag, ok, tt.etag, tt.ok)
+		}
+	}
+}
+
+func TestCacheKeyedByIdentity(t *testing.T) {
+	var requests atomic.Int32
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		w.Header().Set("ETag", `"`+r.Header.Get("Authorization")+`"`)
+		w.Write([]byte(`{"name":"` + r.Header.Get("Authorization") + `"}`))
+	}
+	cache := NewMemoryCache(10)
+	anonymous := newTestClient(t, handler)
+	anonymous.token = ""
+	alice := newTestClient(t, handler, WithToken("alice"))
+	bob := newTestClient(t, handler, WithToken("bob"))
+	for _, c := range []*Client{anonymous, alice, bob} {
+		c.SetCache(cache)
+		c.SetCacheTTL(time.Hour)
+	}
+
+	tests := []struct {
+		client   *Client
+		want     string
+		requests int32
+	}{
+		{anonymous, "", 1},
+		{alice, "Bearer alice", 2},
+		{bob, "Bearer bob", 3},
+		{alice, "Bearer alice", 3}, // from alice's entry
+		{anonymous, "", 3},
+	}
+	for i, tt := range tests {
+		repo, err := tt.client.GetRepo(context.Background(), "o", "r")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if repo.Name != tt.want || requests.Load() != tt.requests {
+			t.Errorf("fetch %d: got %q after %d requests, want %q after %d", i+1, repo.Name, requests.Load(), tt.want, tt.requests)
+		}
+	}
+	for key := range cache.entries {
+		if strings.Contains(key, "alice") || strings.Contains(key, "bob") {
+			t.Errorf("cache key %q contains a token", key)
+		}
+	}
+}
+
+func TestRateLimitIsNeverCached(t *testing.T) {
+	var requests atomic.Int32
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		n := requests.Add(1)
+		w.Header().Set("ETag", `"same"`)
+		fmt.Fprintf(w, `{"resources":{"core":{"limit":5000,"remaining":%d}}}`, 5000-n)
+	})
+	client.SetCache(NewMemoryCache(10))
+	client.SetCacheTTL(time.Hour)
+
+	for want := 4999; want >= 4998; want-- {
+		limit, err := client.GetRateLimit(context.Background())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := limit.Resources.Core.Remaining; got != want {
+			t.Errorf("remaining = %d, want the live %d", got, want)
+		}
+	}
+}
@@ -1,48 +1,331 @@
 package github
 
 import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
+	"io"
 	"net/http"
 	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
 )
 
+// DefaultBaseURL is the REST API root for github.com
+const DefaultBaseURL = "https://api.github.com"
+
 type Client struct {
-	http *http.Client
-	token string
+	http        *http.Client
+	token       string
+	tokenSource TokenSource
+	cache       ResponseCache
+	baseURL     string
+	retry       RetryPolicy
+	cacheTTL    time.Duration
+	userAgent   string
+	limiter     chan struct{} // bounds concurrent requests; nil for no bound
+	app         *appAuth      // set when authenticating as a GitHub App
+
+	mu            sync.Mutex
+	rateLimit     RateLimitStatus
+	rateLimitWait time.Duration
+	servedStale   bool
+	auth          AuthCapability
+}
+
+// Option configures a Client
+type Option func(*Client)
+
+// WithBaseURL points the client at a GitHub Enterprise Server. Either the
+// API root (https://host/api/v3) or just the host (https://host) is accepted.
+func WithBaseURL(baseURL string) Option {
+	return func(c *Client) {
+		c.baseURL = normalizeBaseURL(baseURL)
+	}
+}
+
+// WithToken authenticates with an explicit token instead of resolving one
+// from the environment or the gh CLI
+func WithToken(token string) Option {
+	return func(c *Client) {
+		if token != "" {
+			c.token = token
+			c.tokenSource = TokenSourceFlag
+		}
+	}
+}
+
+// WithHTTPClient replaces the underlying http.Client entirely, e.g. to
+// point the client at an httptest server in tests
+func WithHTTPClient(hc *http.Client) Option {
+	return func(c *Client) {
+		c.http = hc
+	}
+}
+
+// NewClient creates a client for github.com, or for the API root in the
+// GITHUB_API_URL environment variable when set. Unless WithToken is given,
+// the token is discovered with ResolveToken.
+func NewClient(opts ...Option) *Client {
+	c := &Client{
+		http:      newHTTPClient(),
+		cache:     NewMemoryCache(DefaultCacheSize),
+		baseURL:   DefaultBaseURL,
+		retry:     DefaultRetryPolicy,
+		userAgent: DefaultUserAgent,
+		limiter:   make(chan struct{}, DefaultMaxConcurrency),
+	}
+	if env := os.Getenv("GITHUB_API_URL"); env != "" {
+		c.baseURL = normalizeBaseURL(env)
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	if c.token == "" && c.app == nil {
+		c.token, c.tokenSource = ResolveToken("", c.Host())
+	}
+	return c
+}
+
+// Host returns the web host of the configured GitHub instance
+func (c *Client) Host() string {
+	if c.baseURL == DefaultBaseURL {
+		return "github.com"
+	}
+	host := strings.TrimSuffix(c.baseURL, "/api/v3")
+	if i := strings.Index(host, "://"); i >= 0 {
+		host = host[i+3:]
+	}
+	return host
+}
+
+// TokenSource reports where the client's token came from
+func (c *Client) TokenSource() TokenSource {
+	return c.tokenSource
+}
+
+// AuthDescription summarises the authentication mode for status displays
+func (c *Client) AuthDescription() string {
+	switch c.tokenSource {
+	case TokenSourceNone:
+		return "unauthenticated (60 req/h)"
+	case TokenSourceFlag:
+		return "authenticated via --token"
+	default:
+		return "authenticated via " + string(c.tokenSource)
+	}
+}
+
+// BaseURL returns the REST API root the client talks to
+func (c *Client) BaseURL() string {
+	return c.baseURL
+}
+
+// WebURL returns the browser URL for owner/repo on the configured host
+func (c *Client) WebURL(owner, repo string) string {
+	if c.baseURL == DefaultBaseURL {
+		return "https://github.com/" + owner + "/" + repo
+	}
+	return strings.TrimSuffix(c.baseURL, "/api/v3") + "/" + owner + "/" + repo
 }
 
-func NewClient() *Client {
-	return &Client{
-		http:  &http.Client{},
-		token: os.Getenv("GITHUB_TOKEN"),
+func normalizeBaseURL(baseURL string) string {
+	baseURL = strings.TrimRight(baseURL, "/")
+	if baseURL == "" {
+		return DefaultBaseURL
+	}
+	if !strings.Contains(baseURL, "://") {
+		baseURL = "https://" + baseURL
 	}
+	if baseURL != DefaultBaseURL && !strings.HasSuffix(baseURL, "/api/v3") {
+		baseURL += "/api/v3"
+	}
+	return baseURL
+}
+
+// SetCache replaces the cache used for ETag conditional requests; nil disables it
+func (c *Client) SetCache(cache ResponseCache) {
+	c.cache = cache
+}
+
+// SetCacheTTL makes cached responses younger than ttl be used without asking
+// GitHub at all. Older ones are revalidated with a conditional request.
+func (c *Client) SetCacheTTL(ttl time.Duration) {
+	c.cacheTTL = ttl
+}
+
+// ServedStale reports whether any response since the last ResetServedStale
+// came from the cache because GitHub couldn't be reached
+func (c *Client) ServedStale() bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.servedStale
 }
 
-func (c *Client) get(url string, target interface{}) error {
-	req, err := http.NewRequest("GET", url, nil)
+// ResetServedStale clears the ServedStale flag, e.g. before a new analysis
+func (c *Client) ResetServedStale() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.servedStale = false
+}
+
+// WaitOnRateLimit makes the client sleep until the rate limit resets instead
+// of failing, as long as the reset is no more than max away.
+func (c *Client) WaitOnRateLimit(max time.Duration) {
+	c.rateLimitWait = max
+}
+
+func (c *Client) get(ctx context.Context, url string, target interface{}) error {
+	return c.fetch(ctx, url, defaultAccept, target, c.rateLimitWait > 0)
+}
+
+// defaultAccept is the media type for regular REST responses
+const defaultAccept = "application/vnd.github+json"
+
+// fetch performs a GET request for the accept media type, waiting out the
+// rate limit at most once when canWait is set
+func (c *Client) fetch(ctx context.Context, url, accept string, target interface{}, canWait bool) error {
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
 	if err != nil {
 		return err
 	}
 
-	req.Header.Set("Accept", "application/vnd.github+json")
+	req.Header.Set("Accept", accept)
+	req.Header.Set("User-Agent", c.userAgent)
+
+	token, err := c.authToken(ctx)
+	if err != nil {
+		return err
+	}
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
 
-	if c.token != "" {
-		req.Header.Set("Authorization", "Bearer "+c.token)
+	// 304 responses don't count against the rate limit
+	var cached CacheEntry
+	var hasCached bool
+	cache := c.cache
+	if !cacheable(url) {
+		cache = nil
+	}
+	key := c.cacheKey(url)
+	if cache != nil {
+		if cached, hasCached = cache.Get(key); hasCached {
+			if c.cacheTTL > 0 && time.Since(cached.FetchedAt) < c.cacheTTL {
+				return json.Unmarshal(cached.Body, target)
+			}
+			req.Header.Set("If-None-Match", cached.ETag)
+		}
 	}
 
-	resp, err := c.http.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
+		// Offline: better stale data than none
+		if hasCached && ctx.Err() == nil {
+			c.mu.Lock()
+			c.servedStale = true
+			c.mu.Unlock()
+			return json.Unmarshal(cached.Body, target)
+		}
 		return err
 	}
 	defer resp.Body.Close()
 
+	c.recordRateLimit(resp)
+
+	if isRateLimited(resp) {
+		rlErr := &RateLimitError{Reset: c.RateLimitStatus().Reset}
+		wait := time.Until(rlErr.Reset)
+		if canWait && wait <= c.rateLimitWait {
+			select {
+			case <-time.After(wait + time.Second):
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+			return c.fetch(ctx, url, accept, target, false)
+		}
+		return rlErr
+	}
+	if isSecondaryRateLimited(resp) {
+		return &SecondaryRateLimitError{RetryAfter: secondaryWait(resp)}
+	}
+
+	if resp.StatusCode == http.StatusNotModified && hasCached {
+		cached.FetchedAt = time.Now()
+		cache.Set(key, cached)
+		return json.Unmarshal(cached.Body, target)
+	}
+
 	if resp.StatusCode != http.StatusOK {
-		return fmt.Errorf(
-	    "GitHub API error: %s (tip: set GITHUB_TOKEN env variable)",
-	       resp.Status,
-)
+		statusErr := &StatusError{StatusCode: resp.StatusCode, Status: resp.Status}
+		var body struct {
+			Message string `json:"message"`
+		}
+		if json.NewDecoder(resp.Body).Decode(&body) == nil {
+			statusErr.Message = body.Message
+		}
+		return statusErr
 	}
 
-	return json.NewDecoder(resp.Body).Decode(target)
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	if etag := resp.Header.Get("ETag"); etag != "" && cache != nil {
+		cache.Set(key, CacheEntry{ETag: etag, Body: body, FetchedAt: time.Now()})
+	}
+
+	return json.Unmarshal(body, target)
+}
+
+// cacheable reports whether responses from url may be cached. The rate
+// limit is only useful live, and checking it doesn't count against it.
+func cacheable(url string) bool {
+	path, _, _ := strings.Cut(url, "?")
+	return !strings.HasSuffix(path, "/rate_limit")
+}
+
+// cacheKey keys url's cached response by who's asking too, as what GitHub
+// returns depends on the token (private repos, permissions). The token
+// itself is only hashed, as disk cache entries record their key.
+func (c *Client) cacheKey(url string) string {
+	switch {
+	case c.app != nil:
+		return fmt.Sprintf("app:%d/%d %s", c.app.creds.AppID, c.app.creds.InstallationID, url)
+	case c.token == "":
+		return "anonymous " + url
+	}
+	sum := sha256.Sum256([]byte(c.token))
+	return "token:" + hex.EncodeToString(sum[:8]) + " " + url
+}
+
+// recordRateLimit stores the rate limit headers sent with every API response
+func (c *Client) recordRateLimit(resp *http.Response) {
+	remaining, err := strconv.Atoi(resp.Header.Get("X-RateLimit-Remaining"))
+	if err != nil {
+		return
+	}
+	limit, _ := strconv.Atoi(resp.Header.Get("X-RateLimit-Limit"))
+	reset, _ := strconv.ParseInt(resp.Header.Get("X-RateLimit-Reset"), 10, 64)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.rateLimit = RateLimitStatus{
+		Limit:     limit,
+		Remaining: remaining,
+		Reset:     time.Unix(reset, 0),
+		Known:     true,
+	}
+}
+
+// isRateLimited reports whether resp is a primary rate limit rejection
+func isRateLimited(resp *http.Response) bool {
+	if resp.StatusCode != http.StatusForbidden && resp.StatusCode != http.StatusTooManyRequests {
+		return false
+	}
+	return resp.Header.Get("X-RateLimit-Remaining") == "0"
 }
@@ -0,0 +1,173 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// newTestClient returns a client for an httptest server running handler,
+// without a response cache and with retries disabled unless opts say
+// otherwise
+func newTestClient(t *testing.T, handler http.HandlerFunc, opts ...Option) *Client {
+	t.Helper()
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	opts = append([]Option{
+		WithBaseURL(server.URL),
+		WithToken("test"),
+		WithHTTPClient(server.Client()),
+		WithRetryPolicy(RetryPolicy{MaxAttempts: 1}),
+	}, opts...)
+	client := NewClient(opts...)
+	client.SetCache(nil)
+	return client
+}
+
+// countingTransport counts requests before handing them to the default transport
+type countingTransport struct {
+	requests atomic.Int32
+}
+
+func (t *countingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	t.requests.Add(1)
+	return http.DefaultTransport.RoundTrip(req)
+}
+
+func TestRequestHeaders(t *testing.T) {
+	tests := []struct {
+		name      string
+		opts      []Option
+		userAgent string
+	}{
+		{"default user agent", nil, DefaultUserAgent},
+		{"custom user agent", []Option{WithUserAgent("acme-audit/1.0")}, "acme-audit/1.0"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var header http.Header
+			client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+				header = r.Header.Clone()
+				w.Write([]byte(`{"Go":100}`))
+			}, tt.opts...)
+
+			if _, err := client.GetLanguages(context.Background(), "o", "r"); err != nil {
+				t.Fatal(err)
+			}
+			if got := header.Get("User-Agent"); got != tt.userAgent {
+				t.Errorf("User-Agent = %q, want %q", got, tt.userAgent)
+			}
+			if got := header.Get("Authorization"); got != "Bearer test" {
+				t.Errorf("Authorization = %q, want the token", got)
+			}
+			if got := header.Get("Accept"); got != defaultAccept {
+				t.Errorf("Accept = %q, want %q", got, defaultAccept)
+			}
+		})
+	}
+}
+
+func TestInjectedHTTPClient(t *testing.T) {
+	tests := []struct {
+		name      string
+		available int
+		max       int
+		want      int
+		truncated bool
+		requests  int32
+	}{
+		{"uncapped", 201, 0, 201, false, 3},
+		{"capped at the default", 700, DefaultMaxContributors, DefaultMaxContributors, true, 5},
+		{"under the default cap", 450, DefaultMaxContributors, 450, false, 5},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Pages of 100 contributors ranked by commits, u000 first
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+				var entries []string
+				for i := (page - 1) * 100; i < min(page*100, tt.available); i++ {
+					entries = append(entries, fmt.Sprintf(`{"login":"u%03d","contributions":%d}`, i, 1000-i))
+				}
+				w.Write([]byte(`[` + strings.Join(entries, ",") + `]`))
+			}))
+			defer server.Close()
+
+			transport := &countingTransport{}
+			client := NewClient(WithBaseURL(server.URL), WithToken("test"), WithHTTPClient(&http.Client{Transport: transport}))
+			client.SetCache(nil)
+
+			contributors, truncated, err := client.GetContributors(context.Background(), "o", "r", tt.max)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(contributors) != tt.want || truncated != tt.truncated {
+				t.Errorf("got %d contributors, truncated %v, want %d and %v", len(contributors), truncated, tt.want, tt.truncated)
+			}
+			for i, c := range contributors {
+				if want := fmt.Sprintf("u%03d", i); c.Login != want {
+					t.Fatalf("contributor %d is %s, want %s in page order", i, c.Login, want)
+				}
+			}
+			if got := transport.requests.Load(); got != tt.requests {
+				t.Errorf("injected transport saw %d requests, want %d", got, tt.requests)
+			}
+		})
+	}
+}
+
+func TestWithTimeoutCopiesHTTPClient(t *testing.T) {
+	hc := &http.Client{Timeout: time.Minute}
+	client := NewClient(WithToken("test"), WithHTTPClient(hc), WithTimeout(time.Second))
+	if hc.Timeout != time.Minute {
+		t.Errorf("WithTimeout changed the caller's http.Client to %v", hc.Timeout)
+	}
+	if client.http.Timeout != time.Second {
+		t.Errorf("client timeout = %v, want 1s", client.http.Timeout)
+	}
+}
+
+func TestWithProxy(t *testing.T) {
+	var proxied string
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		proxied = r.URL.String() // proxies see the absolute URL
+		w.Write([]byte(`{"name":"r"}`))
+	}))
+	defer proxy.Close()
+	proxyURL, _ := url.Parse(proxy.URL)
+
+	client := NewClient(WithBaseURL("http://ghe.example"), WithToken("test"), WithProxy(proxyURL), WithRetryPolicy(RetryPolicy{MaxAttempts: 1}))
+	client.SetCache(nil)
+	if _, err := client.GetRepo(context.Background(), "o", "r"); err != nil {
+		t.Fatal(err)
+	}
+	if want := "http://ghe.example/api/v3/repos/o/r"; proxied != want {
+		t.Errorf("proxy saw %q, want %q", proxied, want)
+	}
+}
+
+func TestBaseURL(t *testing.T) {
+	tests := []struct {
+		in, baseURL, host string
+	}{
+		{"", DefaultBaseURL, "github.com"},
+		{"https://api.github.com/", DefaultBaseURL, "github.com"},
+		{"ghe.example", "https://ghe.example/api/v3", "ghe.example"},
+		{"https://ghe.example/api/v3/", "https://ghe.example/api/v3", "ghe.example"},
+		{"http://localhost:8080", "http://localhost:8080/api/v3", "localhost:8080"},
+	}
+	for _, tt := range tests {
+		client := NewClient(WithBaseURL(tt.in), WithToken("test"))
+		if client.BaseURL() != tt.baseURL || client.Host() != tt.host {
+			t.Errorf("WithBaseURL(%q): BaseURL() = %q, Host() = %q, want %q, %q", tt.in, client.BaseURL(), client.Host(), tt.baseURL, tt.host)
+		}
+	}
+}
@@ -0,0 +1,38 @@
+package github
+
+import (
+	"context"
+	"sync"
+)
+
+// DefaultHotspotSample caps how many commits get their changed files
+// fetched, at one request each
+const DefaultHotspotSample = 100
+
+// GetCommitDetails fetches up to max commits one by one, which unlike the
+// commit list includes their changed Files. Commits whose detail can't be
+// fetched are left out; the rest keep the order given.
+func (c *Client) GetCommitDetails(ctx context.Context, owner, repo string, commits []Commit, max int) []Commit {
+	commits = commits[:min(len(commits), max)]
+
+	details := make([]*Commit, len(commits))
+	var wg sync.WaitGroup
+	for i, commit := range commits {
+		wg.Add(1)
+		go func(i int, sha string) {
+			defer wg.Done()
+			if detail, err := c.GetCommit(ctx, owner, repo, sha); err == nil {
+				details[i] = detail
+			}
+		}(i, commit.SHA)
+	}
+	wg.Wait()
+
+	var result []Commit
+	for _, d := range details {
+		if d != nil {
+			result = append(result, *d)
+		}
+	}
+	return result
+}
@@ -1,22 +1,192 @@
 package github
 
-import "time"
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// DefaultMaxCommits caps how many commits are fetched for a repo
+const DefaultMaxCommits = 1000
 
 type Commit struct {
 	SHA    string `json:"sha"`
 	Commit struct {
 		Author struct {
-			Date time.Time `json:"date"`
+			Name  string    `json:"name"`
+			Email string    `json:"email"`
+			Date  time.Time `json:"date"`
 		} `json:"author"`
+		Committer struct {
+			Email string `json:"email"`
+		} `json:"committer"`
+		Message string `json:"message"`
+		// Verification is GitHub's check of the commit's signature; nil
+		// when the server doesn't report it
+		Verification *CommitVerification `json:"verification"`
 	} `json:"commit"`
+	// Parents are the commit's parents; merges have more than one
+	Parents []CommitParent `json:"parents"`
+	// Author is the GitHub account of the commit author; nil when the
+	// author's email isn't linked to an account
+	Author *CommitAuthor `json:"author"`
+	// Files changed by the commit; only GetCommit returns them
+	Files []CommitFile `json:"files,omitempty"`
+	// Stats counts the lines changed; only GetCommit returns them
+	Stats *CommitStats `json:"stats,omitempty"`
+}
+
+// CommitStats counts the lines a commit changed
+type CommitStats struct {
+	Additions int `json:"additions"`
+	Deletions int `json:"deletions"`
+	Total     int `json:"total"`
+}
+
+// CommitFile is one file changed by a commit
+type CommitFile struct {
+	Filename string `json:"filename"`
+	Status   string `json:"status"` // added, modified, removed, renamed...
+	Changes  int    `json:"changes"`
+}
+
+// CommitVerification is whether a commit's signature checked out, and why
+// not when it didn't, e.g. "unsigned" or "unknown_key"
+type CommitVerification struct {
+	Verified bool   `json:"verified"`
+	Reason   string `json:"reason"`
+}
+
+// CommitParent is a parent of a commit
+type CommitParent struct {
+	SHA string `json:"sha"`
+}
+
+// CommitAuthor identifies the account behind a commit
+type CommitAuthor struct {
+	Login string `json:"login"`
+}
+
+// AuthorLogin returns the commit author's login, or "" if unknown
+func (c Commit) AuthorLogin() string {
+	if c.Author == nil {
+		return ""
+	}
+	return c.Author.Login
+}
+
+// WebFlowEmail is the committer of commits made in GitHub's web UI
+const WebFlowEmail = "noreply@github.com"
+
+// IsWebFlow reports whether the commit was made in GitHub's web UI, which
+// records author dates without the author's own timezone
+func (c Commit) IsWebFlow() bool {
+	return strings.EqualFold(c.Commit.Committer.Email, WebFlowEmail)
+}
+
+// IsMerge reports whether the commit merges several parents
+func (c Commit) IsMerge() bool {
+	return len(c.Parents) > 1
+}
+
+// Subject returns the first line of the commit message
+func (c Commit) Subject() string {
+	subject, _, _ := strings.Cut(c.Commit.Message, "\n")
+	return strings.TrimSpace(subject)
+}
+
+// CommitOptions selects the window of history to fetch
+type CommitOptions struct {
+	Since time.Time // zero means from the beginning
+	Until time.Time // zero means up to now
+	Max   int       // <= 0 means no cap
+	Ref   string    // branch, tag or SHA to list history from; "" means the default branch
 }
 
+// LastDays returns options covering the last n days, capped at
+// DefaultMaxCommits. The window starts at midnight UTC, so the request URL,
+// which keys the cache and its ETags, stays the same all day.
+func LastDays(days int) CommitOptions {
+	since := time.Now().UTC().AddDate(0, 0, -days)
+	return CommitOptions{
+		Since: time.Date(since.Year(), since.Month(), since.Day(), 0, 0, 0, 0, time.UTC),
+		Max:   DefaultMaxCommits,
+	}
+}
+
+// GetCommits fetches commits page by page within the window in opts.
+// The returned bool reports whether the list was truncated at opts.Max.
+func (c *Client) GetCommits(ctx context.Context, owner, repo string, opts CommitOptions) ([]Commit, bool, error) {
+	var allCommits []Commit
+
+	page := 1
+	perPage := 100
+
+	for {
+		endpoint := fmt.Sprintf(
+			"%s/repos/%s/%s/commits?per_page=%d&page=%d",
+			c.baseURL, owner, repo, perPage, page,
+		)
+		if !opts.Since.IsZero() {
+			endpoint += "&since=" + opts.Since.UTC().Format(time.RFC3339)
+		}
+		if !opts.Until.IsZero() {
+			endpoint += "&until=" + opts.Until.UTC().Format(time.RFC3339)
+		}
+		if opts.Ref != "" {
+			endpoint += "&sha=" + url.QueryEscape(opts.Ref)
+		}
 
-func (c *Client) GetCommits(owner, repo string, days int) ([]Commit, error) {
-	var commits []Commit
-	since := time.Now().AddDate(0, 0, -days).Format(time.RFC3339)
+		var commits []Commit
+		if err := c.get(ctx, endpoint, &commits); err != nil {
+			return allCommits, false, err
+		}
+
+		allCommits = append(allCommits, commits...)
+
+		if opts.Max > 0 && len(allCommits) >= opts.Max {
+			truncated := len(allCommits) > opts.Max || len(commits) == perPage
+			if len(allCommits) > opts.Max {
+				allCommits = allCommits[:opts.Max]
+			}
+			return allCommits, truncated, nil
+		}
+
+		if len(commits) < perPage {
+			break
+		}
+
+		page++
+	}
+
+	return allCommits, false, nil
+}
+
+// GetCommit fetches a single commit by SHA or ref
+func (c *Client) GetCommit(ctx context.Context, owner, repo, sha string) (*Commit, error) {
+	var commit Commit
+	err := c.get(ctx, c.baseURL+"/repos/"+owner+"/"+repo+"/commits/"+sha, &commit)
+	if err != nil {
+		return nil, err
+	}
+	return &commit, nil
+}
 
-	url := "https://api.github.com/repos/" + owner + "/" + repo + "/commits?since=" + since
-	err := c.get(url, &commits)
-	return commits, err
+// ResolveRef returns the commit SHA a branch, tag or SHA points to, or a
+// *RefNotFoundError if the repo has no such ref
+func (c *Client) ResolveRef(ctx context.Context, owner, repo, ref string) (string, error) {
+	commit, err := c.GetCommit(ctx, owner, repo, url.PathEscape(ref))
+	var statusErr *StatusError
+	if errors.As(err, &statusErr) &&
+		(statusErr.StatusCode == http.StatusNotFound || statusErr.StatusCode == http.StatusUnprocessableEntity) {
+		return "", &RefNotFoundError{Ref: ref, Repo: owner + "/" + repo}
+	}
+	if err != nil {
+		return "", err
+	}
+	return commit.SHA, nil
 }
@@ -0,0 +1,73 @@
+package github
+
+import (
+	"context"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestLastDaysStartsAtMidnightUTC(t *testing.T) {
+	opts := LastDays(30)
+	if opts.Since.Location() != time.UTC || opts.Since.Hour() != 0 || opts.Since.Minute() != 0 || opts.Since.Second() != 0 {
+		t.Errorf("Since = %v, want midnight UTC", opts.Since)
+	}
+	if age := time.Since(opts.Since); age < 30*24*time.Hour || age > 31*24*time.Hour {
+		t.Errorf("Since is %v ago, want 30 to 31 days", age)
+	}
+	if opts.Max != DefaultMaxCommits {
+		t.Errorf("Max = %d, want %d", opts.Max, DefaultMaxCommits)
+	}
+}
+
+func TestLastDaysFetchesAreCached(t *testing.T) {
+	var requests atomic.Int32
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		w.Header().Set("ETag", `"commits"`)
+		w.Write([]byte(`[{"sha":"abc"}]`))
+	})
+	client.SetCache(NewMemoryCache(10))
+	client.SetCacheTTL(time.Hour)
+
+	for i := 0; i < 2; i++ {
+		commits, _, err := client.GetCommits(context.Background(), "o", "r", LastDays(365))
+		if err != nil || len(commits) != 1 {
+			t.Fatalf("fetch %d: %v, %v", i+1, commits, err)
+		}
+		if i == 0 {
+			time.Sleep(1100 * time.Millisecond) // past the second the first URL was built in
+		}
+	}
+	if got := requests.Load(); got != 1 {
+		t.Errorf("%d requests, want 1: the second fetch should come from the cache", got)
+	}
+}
+
+func TestLastDaysFetchesRevalidate(t *testing.T) {
+	var ifNoneMatch []string
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		ifNoneMatch = append(ifNoneMatch, r.Header.Get("If-None-Match"))
+		if r.Header.Get("If-None-Match") == `"commits"` {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("ETag", `"commits"`)
+		w.Write([]byte(`[{"sha":"abc"}]`))
+	})
+	client.SetCache(NewMemoryCache(10))
+
+	for i := 0; i < 2; i++ {
+		commits, _, err := client.GetCommits(context.Background(), "o", "r", LastDays(365))
+		if err != nil || len(commits) != 1 || commits[0].SHA != "abc" {
+			t.Fatalf("fetch %d: %v, %v", i+1, commits, err)
+		}
+		if i == 0 {
+			time.Sleep(1100 * time.Millisecond)
+		}
+	}
+	if len(ifNoneMatch) != 2 || ifNoneMatch[1] != `"commits"` {
+		t.Errorf("If-None-Match = %q, want the ETag on the second fetch", ifNoneMatch)
+	}
+}
@@ -0,0 +1,36 @@
+package github
+
+import (
+	"context"
+	"fmt"
+)
+
+// CommunityFile is a health file reported by the community profile
+type CommunityFile struct {
+	URL     string `json:"url"`
+	HTMLURL string `json:"html_url"`
+}
+
+// CommunityProfile is GitHub's community health summary for a repo
+type CommunityProfile struct {
+	HealthPercentage int `json:"health_percentage"`
+	Files            struct {
+		Readme              *CommunityFile `json:"readme"`
+		Contributing        *CommunityFile `json:"contributing"`
+		CodeOfConduct       *CommunityFile `json:"code_of_conduct"`
+		License             *CommunityFile `json:"license"`
+		IssueTemplate       *CommunityFile `json:"issue_template"`
+		PullRequestTemplate *CommunityFile `json:"pull_request_template"`
+	} `json:"files"`
+}
+
+// GetCommunityProfile fetches which community health files a repo has. Not
+// every GitHub Enterprise Server version serves this endpoint.
+func (c *Client) GetCommunityProfile(ctx context.Context, owner, repo string) (*CommunityProfile, error) {
+	var profile CommunityProfile
+	url := fmt.Sprintf("%s/repos/%s/%s/community/profile", c.baseURL, owner, repo)
+	if err := c.get(ctx, url, &profile); err != nil {
+		return nil, err
+	}
+	return &profile, nil
+}
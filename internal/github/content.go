@@ -0,0 +1,112 @@
+package github
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// MaxBlobSize is the largest blob GetFileContent will download (20MB)
+const MaxBlobSize = 20 * 1024 * 1024
+
+// FileContent represents a file returned by the contents API
+type FileContent struct {
+	Name     string `json:"name"`
+	Path     string `json:"path"`
+	Sha      string `json:"sha"`
+	Size     int    `json:"size"`
+	Type     string `json:"type"`
+	Encoding string `json:"encoding"`
+	Content  string `json:"content"`
+}
+
+// Blob represents a git blob returned by the git data API
+type Blob struct {
+	Sha      string `json:"sha"`
+	Size     int    `json:"size"`
+	Encoding string `json:"encoding"`
+	Content  string `json:"content"`
+}
+
+// GetFileContent fetches a file via the contents API. Files over 1MB come back
+// with an empty content field, so those are fetched through the blob API instead.
+// Content is always returned base64 encoded. An empty ref reads the default branch.
+func (c *Client) GetFileContent(ctx context.Context, owner, repo, path, ref string) (*FileContent, error) {
+	var f FileContent
+	endpoint := c.baseURL + "/repos/" + owner + "/" + repo + "/contents/" + escapePath(path)
+	if ref != "" {
+		endpoint += "?ref=" + url.QueryEscape(ref)
+	}
+	if err := c.get(ctx, endpoint, &f); err != nil {
+		return nil, err
+	}
+
+	if f.Encoding == "none" || (f.Content == "" && f.Size > 0) {
+		if f.Size > MaxBlobSize {
+			return nil, fmt.Errorf("%s is too large to fetch (%d bytes)", path, f.Size)
+		}
+		// The SHA already identifies the blob at the requested ref
+		blob, err := c.GetBlob(ctx, owner, repo, f.Sha, f.Size)
+		if err != nil {
+			return nil, err
+		}
+		f.Encoding = blob.Encoding
+		f.Content = blob.Content
+	}
+
+	return &f, nil
+}
+
+// GetReadme fetches the README GitHub shows on the repo page, whatever its
+// name or format. Content is base64 encoded. An empty ref reads the default branch.
+func (c *Client) GetReadme(ctx context.Context, owner, repo, ref string) (*FileContent, error) {
+	var f FileContent
+	endpoint := c.baseURL + "/repos/" + owner + "/" + repo + "/readme"
+	if ref != "" {
+		endpoint += "?ref=" + url.QueryEscape(ref)
+	}
+	if err := c.get(ctx, endpoint, &f); err != nil {
+		return nil, err
+	}
+	return &f, nil
+}
+
+// escapePath escapes each segment of a repository path, keeping the
+// slashes between them
+func escapePath(path string) string {
+	segments := strings.Split(path, "/")
+	for i, segment := range segments {
+		segments[i] = url.PathEscape(segment)
+	}
+	return strings.Join(segments, "/")
+}
+
+// GetBlob fetches a git blob by SHA (supports files up to 100MB). size is
+// the blob's size from the contents or tree metadata, so blobs over
+// MaxBlobSize are refused before downloading them; 0 means unknown, and the
+// size GitHub reports is checked instead.
+func (c *Client) GetBlob(ctx context.Context, owner, repo, sha string, size int) (*Blob, error) {
+	if size > MaxBlobSize {
+		return nil, fmt.Errorf("blob %s is too large to fetch (%d bytes)", sha, size)
+	}
+	var b Blob
+	err := c.get(ctx, c.baseURL+"/repos/"+owner+"/"+repo+"/git/blobs/"+sha, &b)
+	if err != nil {
+		return nil, err
+	}
+	if b.Size > MaxBlobSize {
+		return nil, fmt.Errorf("blob %s is too large to fetch (%d bytes)", sha, b.Size)
+	}
+	return &b, nil
+}
+
+// Decode returns the decoded file content
+func (f *FileContent) Decode() ([]byte, error) {
+	if f.Encoding != "base64" {
+		return []byte(f.Content), nil
+	}
+	// The API wraps base64 content at 60 columns
+	return base64.StdEncoding.DecodeString(strings.ReplaceAll(f.Content, "\n", ""))
+}
@@ -0,0 +1,106 @@
+package github
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestGetFileContentEscapesPathAndRef(t *testing.T) {
+	tests := []struct {
+		path, ref string
+		want      string
+	}{
+		{"README.md", "", "/api/v3/repos/o/r/contents/README.md"},
+		{"docs/a b.md", "main", "/api/v3/repos/o/r/contents/docs/a%20b.md?ref=main"},
+		{"src/c#.txt", "feature/a&b", "/api/v3/repos/o/r/contents/src/c%23.txt?ref=feature%2Fa%26b"},
+		{"x", "v1.0+build#2", "/api/v3/repos/o/r/contents/x?ref=v1.0%2Bbuild%232"},
+	}
+	for _, tt := range tests {
+		var got string
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			got = r.RequestURI
+			w.Write([]byte(`{"encoding":"base64","content":""}`))
+		}))
+		client := NewClient(WithBaseURL(server.URL), WithToken("test"), WithHTTPClient(server.Client()))
+		client.SetCache(nil)
+		if _, err := client.GetFileContent(context.Background(), "o", "r", tt.path, tt.ref); err != nil {
+			t.Errorf("GetFileContent(%q, %q): %v", tt.path, tt.ref, err)
+		}
+		server.Close()
+		if got != tt.want {
+			t.Errorf("GetFileContent(%q, %q) requested %s, want %s", tt.path, tt.ref, got, tt.want)
+		}
+	}
+}
+
+func TestGetFileContentLargeFile(t *testing.T) {
+	large := bytes.Repeat([]byte("0123456789abcdef"), 96*1024) // 1.5MB
+	tests := []struct {
+		name      string
+		size      int
+		wantErr   bool
+		blobFetch bool
+	}{
+		{"over 1MB comes from the blob API", len(large), false, true},
+		{"over MaxBlobSize isn't downloaded", MaxBlobSize + 1, true, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			blobFetched := false
+			client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+				switch r.URL.Path {
+				case "/api/v3/repos/o/r/contents/data.bin":
+					// The contents API leaves out files over 1MB
+					fmt.Fprintf(w, `{"name":"data.bin","path":"data.bin","sha":"b1","size":%d,"type":"file","encoding":"none","content":""}`, tt.size)
+				case "/api/v3/repos/o/r/git/blobs/b1":
+					blobFetched = true
+					fmt.Fprintf(w, `{"sha":"b1","size":%d,"encoding":"base64","content":%q}`, len(large), base64.StdEncoding.EncodeToString(large))
+				default:
+					http.NotFound(w, r)
+				}
+			})
+
+			file, err := client.GetFileContent(context.Background(), "o", "r", "data.bin", "")
+			if blobFetched != tt.blobFetch {
+				t.Errorf("blob fetched = %v, want %v", blobFetched, tt.blobFetch)
+			}
+			if tt.wantErr {
+				if err == nil {
+					t.Error("no error for a file over MaxBlobSize")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			content, err := file.Decode()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !bytes.Equal(content, large) {
+				t.Errorf("decoded %d bytes, want the %d byte blob", len(content), len(large))
+			}
+		})
+	}
+}
+
+func TestGetBlobChecksSizeFirst(t *testing.T) {
+	requests := 0
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		fmt.Fprintf(w, `{"sha":"b1","size":%d,"encoding":"base64","content":""}`, MaxBlobSize+1)
+	})
+
+	if _, err := client.GetBlob(context.Background(), "o", "r", "b1", MaxBlobSize+1); err == nil || requests != 0 {
+		t.Errorf("known oversized blob: err = %v after %d requests, want an error and none", err, requests)
+	}
+	// Without the size up front, GitHub's reported size still guards
+	if _, err := client.GetBlob(context.Background(), "o", "r", "b1", 0); err == nil || requests != 1 {
+		t.Errorf("unknown size: err = %v after %d requests, want an error after 1", err, requests)
+	}
+}
@@ -1,15 +1,33 @@
 package github
 
-import "fmt"
+import (
+	"context"
+	"fmt"
+	"strings"
+)
 
-// Contributor represents a GitHub contributor
+// DefaultMaxContributors caps how many contributors are fetched for a repo
+const DefaultMaxContributors = 500
+
+// Contributor represents a GitHub contributor. Name and Company are only
+// set for contributors passed through EnrichContributors.
 type Contributor struct {
 	Login   string `json:"login"`
 	Commits int    `json:"contributions"`
+	Type    string `json:"type"` // User, Bot or Organization
+	Name    string `json:"name,omitempty"`
+	Company string `json:"company,omitempty"`
+}
+
+// IsBot reports whether the contributor is an automated account
+func (c Contributor) IsBot() bool {
+	return c.Type == "Bot" || strings.HasSuffix(c.Login, "[bot]")
 }
 
-// GetContributors fetches ALL contributors (paginated)
-func (c *Client) GetContributors(owner, repo string) ([]Contributor, error) {
+// GetContributors fetches contributors page by page (ordered by commit count)
+// until the list is exhausted or max is reached; max <= 0 means no cap.
+// The returned bool reports whether the list was truncated at max.
+func (c *Client) GetContributors(ctx context.Context, owner, repo string, max int) ([]Contributor, bool, error) {
 	var allContributors []Contributor
 
 	page := 1
@@ -17,24 +35,34 @@ func (c *Client) GetContributors(owner, repo string) ([]Contributor, error) {
 
 	for {
 		url := fmt.Sprintf(
-			"https://api.github.com/repos/%s/%s/contributors?per_page=%d&page=%d",
-			owner, repo, perPage, page,
+			"%s/repos/%s/%s/contributors?per_page=%d&page=%d",
+			c.baseURL, owner, repo, perPage, page,
 		)
 
 		var contributors []Contributor
-		err := c.get(url, &contributors)
+		err := c.get(ctx, url, &contributors)
 		if err != nil {
-			return nil, err
+			return nil, false, err
+		}
+
+		allContributors = append(allContributors, contributors...)
+
+		if max > 0 && len(allContributors) >= max {
+			// A full last page means there may be more we didn't fetch
+			truncated := len(allContributors) > max || len(contributors) == perPage
+			if len(allContributors) > max {
+				allContributors = allContributors[:max]
+			}
+			return allContributors, truncated, nil
 		}
 
 		// Stop when no more contributors
-		if len(contributors) == 0 {
+		if len(contributors) < perPage {
 			break
 		}
 
-		allContributors = append(allContributors, contributors...)
 		page++
 	}
 
-	return allContributors, nil
+	return allContributors, false, nil
 }
@@ -0,0 +1,147 @@
+package github
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultDiskCacheEntries caps how many responses the disk cache keeps
+const DefaultDiskCacheEntries = 5000
+
+// DefaultCacheTTL is how long cached responses are used without revalidation
+const DefaultCacheTTL = 15 * time.Minute
+
+// DiskCache is a ResponseCache persisted as one file per URL, so repeated
+// runs can skip the network. Once full, the least recently used entries are
+// evicted, using file modification times as the access record.
+type DiskCache struct {
+	mu  sync.Mutex
+	dir string
+	max int
+}
+
+type diskEntry struct {
+	URL       string    `json:"url"`
+	ETag      string    `json:"etag"`
+	Body      []byte    `json:"body"`
+	FetchedAt time.Time `json:"fetched_at"`
+}
+
+// DefaultCacheDir returns the repolyzer directory under the user cache dir
+func DefaultCacheDir() (string, error) {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "repolyzer"), nil
+}
+
+// NewDiskCache creates (if needed) a cache in dir holding at most max entries
+func NewDiskCache(dir string, max int) (*DiskCache, error) {
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, err
+	}
+	return &DiskCache{dir: dir, max: max}, nil
+}
+
+func (d *DiskCache) path(url string) string {
+	sum := sha256.Sum256([]byte(url))
+	return filepath.Join(d.dir, hex.EncodeToString(sum[:])+".json")
+}
+
+func (d *DiskCache) Get(url string) (CacheEntry, bool) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	p := d.path(url)
+	data, err := os.ReadFile(p)
+	if err != nil {
+		return CacheEntry{}, false
+	}
+	var e diskEntry
+	if json.Unmarshal(data, &e) != nil || e.URL != url {
+		return CacheEntry{}, false
+	}
+
+	now := time.Now()
+	os.Chtimes(p, now, now)
+	return CacheEntry{ETag: e.ETag, Body: e.Body, FetchedAt: e.FetchedAt}, true
+}
+
+func (d *DiskCache) Set(url string, entry CacheEntry) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	data, err := json.Marshal(diskEntry{URL: url, ETag: entry.ETag, Body: entry.Body, FetchedAt: entry.FetchedAt})
+	if err != nil {
+		return
+	}
+
+	// Write then rename so a crash never leaves a half-written entry
+	tmp, err := os.CreateTemp(d.dir, "entry-*.tmp")
+	if err != nil {
+		return
+	}
+	_, err = tmp.Write(data)
+	tmp.Close()
+	if err != nil || os.Rename(tmp.Name(), d.path(url)) != nil {
+		os.Remove(tmp.Name())
+		return
+	}
+
+	d.evict()
+}
+
+// evict removes the least recently used entries beyond the cap
+func (d *DiskCache) evict() {
+	entries, err := os.ReadDir(d.dir)
+	if err != nil {
+		return
+	}
+
+	type cached struct {
+		name string
+		used time.Time
+	}
+	var files []cached
+	for _, e := range entries {
+		if !strings.HasSuffix(e.Name(), ".json") {
+			continue
+		}
+		if info, err := e.Info(); err == nil {
+			files = append(files, cached{e.Name(), info.ModTime()})
+		}
+	}
+	if len(files) <= d.max {
+		return
+	}
+
+	sort.Slice(files, func(i, j int) bool { return files[i].used.Before(files[j].used) })
+	for _, f := range files[:len(files)-d.max] {
+		os.Remove(filepath.Join(d.dir, f.name))
+	}
+}
+
+// Clear deletes every cached response
+func (d *DiskCache) Clear() error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	entries, err := os.ReadDir(d.dir)
+	if err != nil {
+		return err
+	}
+	for _, e := range entries {
+		if err := os.Remove(filepath.Join(d.dir, e.Name())); err != nil {
+			return err
+		}
+	}
+	return nil
+}
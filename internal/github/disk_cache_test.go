@@ -0,0 +1,156 @@
+package github
+
+import (
+	"context"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestDiskCacheRoundTrip(t *testing.T) {
+	dir := t.TempDir()
+	cache, err := NewDiskCache(dir, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	fetched := time.Now().Add(-time.Hour).Truncate(time.Second)
+	cache.Set("https://api/x", CacheEntry{ETag: `"e"`, Body: []byte(`{"a":1}`), FetchedAt: fetched})
+
+	// A fresh cache on the same directory sees the entry, as a later run would
+	reopened, err := NewDiskCache(dir, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	e, ok := reopened.Get("https://api/x")
+	if !ok || e.ETag != `"e"` || string(e.Body) != `{"a":1}` || !e.FetchedAt.Equal(fetched) {
+		t.Errorf("Get = %+v, %v", e, ok)
+	}
+	if _, ok := reopened.Get("https://api/y"); ok {
+		t.Error("Get of an unknown URL succeeded")
+	}
+
+	if err := reopened.Clear(); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := reopened.Get("https://api/x"); ok {
+		t.Error("Get succeeded after Clear")
+	}
+}
+
+func TestDiskCacheEvictsLeastRecentlyUsed(t *testing.T) {
+	dir := t.TempDir()
+	cache, err := NewDiskCache(dir, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Modification times record use; space them out so the order is exact
+	use := func(url string, at time.Time) {
+		p := cache.path(url)
+		if err := os.Chtimes(p, at, at); err != nil {
+			t.Fatal(err)
+		}
+	}
+	now := time.Now()
+	cache.Set("a", CacheEntry{ETag: "a"})
+	use("a", now.Add(-3*time.Minute))
+	cache.Set("b", CacheEntry{ETag: "b"})
+	use("b", now.Add(-2*time.Minute))
+	cache.Get("a") // a is now the most recently used
+	cache.Set("c", CacheEntry{ETag: "c"})
+
+	tests := []struct {
+		url string
+		ok  bool
+	}{
+		{"a", true},
+		{"b", false},
+		{"c", true},
+	}
+	for _, tt := range tests {
+		if _, ok := cache.Get(tt.url); ok != tt.ok {
+			t.Errorf("Get(%q) ok = %v, want %v", tt.url, ok, tt.ok)
+		}
+	}
+	if files, _ := filepath.Glob(filepath.Join(dir, "*.json")); len(files) != 2 {
+		t.Errorf("%d files in the cache, want 2", len(files))
+	}
+}
+
+func TestCacheTTL(t *testing.T) {
+	tests := []struct {
+		name     string
+		ttl      time.Duration
+		age      time.Duration
+		requests int32
+	}{
+		{"no TTL revalidates", 0, time.Second, 1},
+		{"fresh entry skips the network", time.Hour, time.Minute, 0},
+		{"stale entry revalidates", time.Minute, time.Hour, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var requests atomic.Int32
+			client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+				requests.Add(1)
+				if r.Header.Get("If-None-Match") != `"v1"` {
+					t.Errorf("If-None-Match = %q", r.Header.Get("If-None-Match"))
+				}
+				w.WriteHeader(http.StatusNotModified)
+			})
+			cache, err := NewDiskCache(t.TempDir(), 10)
+			if err != nil {
+				t.Fatal(err)
+			}
+			key := client.cacheKey(client.BaseURL() + "/repos/o/r")
+			cache.Set(key, CacheEntry{ETag: `"v1"`, Body: []byte(`{"name":"r"}`), FetchedAt: time.Now().Add(-tt.age)})
+			client.SetCache(cache)
+			client.SetCacheTTL(tt.ttl)
+
+			repo, err := client.GetRepo(context.Background(), "o", "r")
+			if err != nil || repo.Name != "r" {
+				t.Fatalf("GetRepo = %+v, %v", repo, err)
+			}
+			if got := requests.Load(); got != tt.requests {
+				t.Errorf("%d requests, want %d", got, tt.requests)
+			}
+			// A 304 confirms the body is current again
+			if e, _ := cache.Get(key); tt.requests > 0 && time.Since(e.FetchedAt) > time.Minute {
+				t.Errorf("FetchedAt = %v after revalidation", e.FetchedAt)
+			}
+		})
+	}
+}
+
+func TestServedStale(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		conn, _, _ := w.(http.Hijacker).Hijack()
+		conn.Close()
+	})
+	key := client.cacheKey(client.BaseURL() + "/repos/o/r")
+	cache := NewMemoryCache(10)
+	cache.Set(key, CacheEntry{ETag: `"v1"`, Body: []byte(`{"name":"r"}`)})
+
+	if _, err := client.GetRepo(context.Background(), "o", "r"); err == nil {
+		t.Fatal("GetRepo succeeded offline without a cache")
+	}
+	if client.ServedStale() {
+		t.Error("ServedStale() without a cached body")
+	}
+
+	client.SetCache(cache)
+	repo, err := client.GetRepo(context.Background(), "o", "r")
+	if err != nil || repo.Name != "r" {
+		t.Fatalf("GetRepo offline = %+v, %v, want the cached body", repo, err)
+	}
+	if !client.ServedStale() {
+		t.Error("ServedStale() = false after falling back to the cache")
+	}
+	client.ResetServedStale()
+	if client.ServedStale() {
+		t.Error("ServedStale() = true after ResetServedStale")
+	}
+}
@@ -0,0 +1,115 @@
+package github
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// ErrRateLimited is matched by errors.Is for any rate limit rejection
+var ErrRateLimited = errors.New("GitHub API rate limit exceeded")
+
+// Sentinels matched by errors.Is against a *StatusError with that status
+var (
+	ErrNotFound      = errors.New("not found")     // 404
+	ErrUnauthorized  = errors.New("unauthorized")  // 401, a bad or expired token
+	ErrForbidden     = errors.New("forbidden")     // 403 other than rate limiting
+	ErrUnprocessable = errors.New("unprocessable") // 422, e.g. an invalid ref or query
+)
+
+// ErrRefNotFound is matched by errors.Is when a branch, tag or SHA doesn't exist
+var ErrRefNotFound = errors.New("ref not found")
+
+// RefNotFoundError names the missing ref
+type RefNotFoundError struct {
+	Ref  string
+	Repo string // owner/name
+}
+
+func (e *RefNotFoundError) Error() string {
+	return fmt.Sprintf("ref not found: %q in %s", e.Ref, e.Repo)
+}
+
+func (e *RefNotFoundError) Is(target error) bool {
+	return target == ErrRefNotFound
+}
+
+// RateLimitError is returned when GitHub rejects a request because the
+// rate limit is exhausted
+type RateLimitError struct {
+	Reset time.Time
+}
+
+func (e *RateLimitError) Error() string {
+	wait := time.Until(e.Reset).Round(time.Minute)
+	if wait < time.Minute {
+		return "Rate limit exceeded, resets in less than a minute — add a token to continue"
+	}
+	return fmt.Sprintf("Rate limit exceeded, resets in %s — add a token to continue", formatWait(wait))
+}
+
+func (e *RateLimitError) Is(target error) bool {
+	return target == ErrRateLimited
+}
+
+// ErrSecondaryRateLimit is matched by errors.Is when GitHub throttles a burst
+// of requests, independently of the hourly rate limit
+var ErrSecondaryRateLimit = errors.New("GitHub secondary rate limit exceeded")
+
+// SecondaryRateLimitError is returned when GitHub's abuse detection
+// rejects a request even after waiting out Retry-After once
+type SecondaryRateLimitError struct {
+	RetryAfter time.Duration
+}
+
+func (e *SecondaryRateLimitError) Error() string {
+	return fmt.Sprintf("GitHub secondary rate limit hit (too many requests at once), try again in %s", e.RetryAfter.Round(time.Second))
+}
+
+func (e *SecondaryRateLimitError) Is(target error) bool {
+	return target == ErrSecondaryRateLimit
+}
+
+// StatusError is returned when GitHub answers with an unexpected status
+type StatusError struct {
+	StatusCode int
+	Status     string
+	Message    string // the "message" field of GitHub's error body, if any
+}
+
+func (e *StatusError) Error() string {
+	if e.Message != "" {
+		return fmt.Sprintf("GitHub API error: %s: %s", e.Status, e.Message)
+	}
+	return fmt.Sprintf("GitHub API error: %s (tip: set GITHUB_TOKEN env variable)", e.Status)
+}
+
+func (e *StatusError) Is(target error) bool {
+	switch e.StatusCode {
+	case http.StatusNotFound:
+		return target == ErrNotFound
+	case http.StatusUnauthorized:
+		return target == ErrUnauthorized
+	case http.StatusForbidden:
+		return target == ErrForbidden
+	case http.StatusUnprocessableEntity:
+		return target == ErrUnprocessable
+	}
+	return false
+}
+
+// IsServerError reports whether GitHub failed on its side (5xx)
+func (e *StatusError) IsServerError() bool {
+	return e.StatusCode >= 500
+}
+
+// formatWait renders a duration as "12m" or "1h5m"
+func formatWait(d time.Duration) string {
+	h := int(d.Hours())
+	m := int(d.Minutes()) % 60
+	if h > 0 {
+		return fmt.Sprintf("%dh%dm", h, m)
+	}
+	return fmt.Sprintf("%dm", m)
+}
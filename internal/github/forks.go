@@ -0,0 +1,64 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+)
+
+// DefaultMaxForks caps how many forks are listed when looking for active ones
+const DefaultMaxForks = 100
+
+// Comparison is how far head has diverged from base
+type Comparison struct {
+	Status   string `json:"status"` // ahead, behind, diverged or identical
+	AheadBy  int    `json:"ahead_by"`
+	BehindBy int    `json:"behind_by"`
+}
+
+// GetForks lists a repo's forks, newest first, stopping after max (0 means
+// no limit)
+func (c *Client) GetForks(ctx context.Context, owner, repo string, max int) ([]Repo, error) {
+	var allForks []Repo
+
+	page := 1
+	perPage := 100
+	if max > 0 && max < perPage {
+		perPage = max
+	}
+
+	for {
+		url := fmt.Sprintf(
+			"%s/repos/%s/%s/forks?sort=newest&per_page=%d&page=%d",
+			c.baseURL, owner, repo, perPage, page,
+		)
+
+		var forks []Repo
+		if err := c.get(ctx, url, &forks); err != nil {
+			return allForks, err
+		}
+
+		allForks = append(allForks, forks...)
+
+		if max > 0 && len(allForks) >= max {
+			return allForks[:max], nil
+		}
+		if len(forks) < perPage {
+			break
+		}
+
+		page++
+	}
+
+	return allForks, nil
+}
+
+// CompareCommits compares base with head, where either can be a branch,
+// tag or SHA, and head can name a fork's branch as "owner:branch"
+func (c *Client) CompareCommits(ctx context.Context, owner, repo, base, head string) (*Comparison, error) {
+	var comparison Comparison
+	endpoint := c.baseURL + "/repos/" + owner + "/" + repo + "/compare/" +
+		url.PathEscape(base) + "..." + url.PathEscape(head)
+	err := c.get(ctx, endpoint, &comparison)
+	return &comparison, err
+}
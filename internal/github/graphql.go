@@ -0,0 +1,415 @@
+package github
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// Overview is the core repository data every analysis needs
+type Overview struct {
+	Repo             *Repo
+	Languages        map[string]int
+	Commits          []Commit
+	CommitsTruncated bool
+	// Mentionable is how many users can be mentioned in the repo, and
+	// Collaborators how many have been given access; -1 when unknown.
+	// Only GraphQL counts them, and collaborators only with push access.
+	Mentionable   int
+	Collaborators int
+	// LanguagesErr and CommitsErr record why Languages or Commits are
+	// missing when the REST fallback couldn't fetch them
+	LanguagesErr error
+	CommitsErr   error
+}
+
+// GetOverview fetches repository metadata, languages and commit history.
+// With a token it uses a single GraphQL query (plus extra pages of history
+// if needed); otherwise, or if GraphQL fails, it falls back to REST. History
+// of a ref other than the default branch always comes from REST. Only the
+// repo itself is required: a failed languages or commits request is recorded
+// in LanguagesErr or CommitsErr.
+func (c *Client) GetOverview(ctx context.Context, owner, repo string, opts CommitOptions) (*Overview, error) {
+	if c.tokenSource != TokenSourceNone && opts.Ref == "" {
+		if o, err := c.getOverviewGraphQL(ctx, owner, repo, opts); err == nil {
+			return o, nil
+		} else if ctx.Err() != nil {
+			return nil, err
+		}
+	}
+	return c.getOverviewREST(ctx, owner, repo, opts)
+}
+
+func (c *Client) getOverviewREST(ctx context.Context, owner, repo string, opts CommitOptions) (*Overview, error) {
+	r, err := c.GetRepo(ctx, owner, repo)
+	if err != nil {
+		return nil, err
+	}
+	langs, langsErr := c.GetLanguages(ctx, owner, repo)
+	commits, truncated, commitsErr := c.GetCommits(ctx, owner, repo, opts)
+
+	return &Overview{
+		Repo:             r,
+		Languages:        langs,
+		Commits:          commits,
+		CommitsTruncated: truncated,
+		Mentionable:      -1,
+		Collaborators:    -1,
+		LanguagesErr:     langsErr,
+		CommitsErr:       commitsErr,
+	}, nil
+}
+
+// historyFields selects a page of commit history in both queries
+const historyFields = `totalCount
+            pageInfo { hasNextPage endCursor }
+            nodes { oid message parents(first: 2) { nodes { oid } } committer { email } signature { isValid state } author { name email date user { login } } }`
+
+const overviewQuery = `query($owner: String!, $name: String!, $since: GitTimestamp, $until: GitTimestamp) {
+  repository(owner: $owner, name: $name) {
+    name
+    nameWithOwner
+    description
+    url
+    stargazerCount
+    forkCount
+    createdAt
+    updatedAt
+    pushedAt
+    isFork
+    isArchived
+    isPrivate
+    hasIssuesEnabled
+    primaryLanguage { name }
+    licenseInfo { key name spdxId }
+    repositoryTopics(first: 20) { nodes { topic { name } } }
+    watchers { totalCount }
+    mentionableUsers { totalCount }
+    viewerPermission
+    issues(states: OPEN) { totalCount }
+    pullRequests(states: OPEN) { totalCount }
+    languages(first: 100, orderBy: {field: SIZE, direction: DESC}) {
+      edges { size node { name } }
+    }
+    defaultBranchRef {
+      name
+      target {
+        ... on Commit {
+          history(first: 100, since: $since, until: $until) {
+            ` + historyFields + `
+          }
+        }
+      }
+    }
+  }
+}`
+
+// collaboratorsQuery counts collaborators, which needs push access
+const collaboratorsQuery = `query($owner: String!, $name: String!) {
+  repository(owner: $owner, name: $name) {
+    collaborators { totalCount }
+  }
+}`
+
+// historyQuery fetches the pages of history after the overview's first
+const historyQuery = `query($owner: String!, $name: String!, $since: GitTimestamp, $until: GitTimestamp, $after: String!) {
+  repository(owner: $owner, name: $name) {
+    defaultBranchRef {
+      target {
+        ... on Commit {
+          history(first: 100, since: $since, until: $until, after: $after) {
+            ` + historyFields + `
+          }
+        }
+      }
+    }
+  }
+}`
+
+type overviewResponse struct {
+	Repository *struct {
+		Name            string    `json:"name"`
+		NameWithOwner   string    `json:"nameWithOwner"`
+		Description     string    `json:"description"`
+		URL             string    `json:"url"`
+		StargazerCount  int       `json:"stargazerCount"`
+		ForkCount       int       `json:"forkCount"`
+		CreatedAt       time.Time `json:"createdAt"`
+		UpdatedAt       time.Time `json:"updatedAt"`
+		PushedAt        time.Time `json:"pushedAt"`
+		IsFork          bool      `json:"isFork"`
+		IsArchived      bool      `json:"isArchived"`
+		IsPrivate       bool      `json:"isPrivate"`
+		HasIssues       bool      `json:"hasIssuesEnabled"`
+		PrimaryLanguage *struct {
+			Name string `json:"name"`
+		} `json:"primaryLanguage"`
+		LicenseInfo *struct {
+			Key    string `json:"key"`
+			Name   string `json:"name"`
+			SpdxID string `json:"spdxId"`
+		} `json:"licenseInfo"`
+		RepositoryTopics struct {
+			Nodes []struct {
+				Topic struct {
+					Name string `json:"name"`
+				} `json:"topic"`
+			} `json:"nodes"`
+		} `json:"repositoryTopics"`
+		Watchers         totalCount `json:"watchers"`
+		MentionableUsers totalCount `json:"mentionableUsers"`
+		ViewerPermission string     `json:"viewerPermission"`
+		Issues           totalCount `json:"issues"`
+		PullRequests     totalCount `json:"pullRequests"`
+		Languages        struct {
+			Edges []struct {
+				Size int `json:"size"`
+				Node struct {
+					Name string `json:"name"`
+				} `json:"node"`
+			} `json:"edges"`
+		} `json:"languages"`
+		DefaultBranchRef *struct {
+			Name   string `json:"name"`
+			Target struct {
+				History commitHistory `json:"history"`
+			} `json:"target"`
+		} `json:"defaultBranchRef"`
+	} `json:"repository"`
+}
+
+type historyResponse struct {
+	Repository *struct {
+		DefaultBranchRef *struct {
+			Target struct {
+				History commitHistory `json:"history"`
+			} `json:"target"`
+		} `json:"defaultBranchRef"`
+	} `json:"repository"`
+}
+
+type totalCount struct {
+	TotalCount int `json:"totalCount"`
+}
+
+type commitHistory struct {
+	TotalCount int `json:"totalCount"`
+	PageInfo   struct {
+		HasNextPage bool   `json:"hasNextPage"`
+		EndCursor   string `json:"endCursor"`
+	} `json:"pageInfo"`
+	Nodes []struct {
+		Oid     string `json:"oid"`
+		Message string `json:"message"`
+		Parents struct {
+			Nodes []struct {
+				Oid string `json:"oid"`
+			} `json:"nodes"`
+		} `json:"parents"`
+		Committer struct {
+			Email string `json:"email"`
+		} `json:"committer"`
+		Signature *struct {
+			IsValid bool   `json:"isValid"`
+			State   string `json:"state"`
+		} `json:"signature"`
+		Author struct {
+			Name  string    `json:"name"`
+			Email string    `json:"email"`
+			Date  time.Time `json:"date"`
+			User  *struct {
+				Login string `json:"login"`
+			} `json:"user"`
+		} `json:"author"`
+	} `json:"nodes"`
+}
+
+func (c *Client) getOverviewGraphQL(ctx context.Context, owner, repo string, opts CommitOptions) (*Overview, error) {
+	vars := map[string]interface{}{"owner": owner, "name": repo}
+	if !opts.Since.IsZero() {
+		vars["since"] = opts.Since.UTC().Format(time.RFC3339)
+	}
+	if !opts.Until.IsZero() {
+		vars["until"] = opts.Until.UTC().Format(time.RFC3339)
+	}
+
+	var resp overviewResponse
+	if err := c.graphql(ctx, overviewQuery, vars, &resp); err != nil {
+		return nil, err
+	}
+	r := resp.Repository
+	if r == nil {
+		return nil, fmt.Errorf("repository %s/%s not found", owner, repo)
+	}
+
+	o := &Overview{
+		Repo: &Repo{
+			Name:          r.Name,
+			FullName:      r.NameWithOwner,
+			Description:   r.Description,
+			Stars:         r.StargazerCount,
+			Forks:         r.ForkCount,
+			OpenIssues:    r.Issues.TotalCount + r.PullRequests.TotalCount, // REST counts PRs too
+			CreatedAt:     r.CreatedAt,
+			UpdatedAt:     r.UpdatedAt,
+			PushedAt:      r.PushedAt,
+			WatchersCount: r.StargazerCount, // REST's watchers_count is the star count
+			Subscribers:   r.Watchers.TotalCount,
+			Fork:          r.IsFork,
+			Archived:      r.IsArchived,
+			HasIssues:     r.HasIssues,
+			Private:       r.IsPrivate,
+			HTMLURL:       r.URL,
+			CloneURL:      r.URL + ".git",
+		},
+		Languages:     make(map[string]int),
+		Mentionable:   r.MentionableUsers.TotalCount,
+		Collaborators: -1,
+	}
+	if r.PrimaryLanguage != nil {
+		o.Repo.Language = r.PrimaryLanguage.Name
+	}
+	if l := r.LicenseInfo; l != nil {
+		o.Repo.License = &License{Key: l.Key, Name: l.Name, SPDXID: l.SpdxID}
+	}
+	for _, t := range r.RepositoryTopics.Nodes {
+		o.Repo.Topics = append(o.Repo.Topics, t.Topic.Name)
+	}
+	for _, e := range r.Languages.Edges {
+		o.Languages[e.Node.Name] = e.Size
+	}
+	switch r.ViewerPermission {
+	case "ADMIN", "MAINTAIN", "WRITE":
+		o.Collaborators = c.countCollaborators(ctx, owner, repo)
+	}
+	if r.DefaultBranchRef == nil {
+		// Empty repository
+		return o, nil
+	}
+	o.Repo.DefaultBranch = r.DefaultBranchRef.Name
+
+	history := r.DefaultBranchRef.Target.History
+	for {
+		o.Commits = append(o.Commits, history.commits()...)
+
+		if opts.Max > 0 && len(o.Commits) >= opts.Max {
+			o.CommitsTruncated = len(o.Commits) > opts.Max || history.PageInfo.HasNextPage
+			if len(o.Commits) > opts.Max {
+				o.Commits = o.Commits[:opts.Max]
+			}
+			break
+		}
+		if !history.PageInfo.HasNextPage {
+			break
+		}
+
+		vars["after"] = history.PageInfo.EndCursor
+		var page historyResponse
+		if err := c.graphql(ctx, historyQuery, vars, &page); err != nil {
+			return nil, err
+		}
+		if page.Repository == nil || page.Repository.DefaultBranchRef == nil {
+			break
+		}
+		history = page.Repository.DefaultBranchRef.Target.History
+	}
+
+	return o, nil
+}
+
+// countCollaborators returns the repo's collaborator count, or -1 if the
+// query fails
+func (c *Client) countCollaborators(ctx context.Context, owner, repo string) int {
+	var resp struct {
+		Repository *struct {
+			Collaborators *totalCount `json:"collaborators"`
+		} `json:"repository"`
+	}
+	vars := map[string]interface{}{"owner": owner, "name": repo}
+	if err := c.graphql(ctx, collaboratorsQuery, vars, &resp); err != nil || resp.Repository == nil || resp.Repository.Collaborators == nil {
+		return -1
+	}
+	return resp.Repository.Collaborators.TotalCount
+}
+
+func (h commitHistory) commits() []Commit {
+	commits := make([]Commit, len(h.Nodes))
+	for i, n := range h.Nodes {
+		commits[i].SHA = n.Oid
+		commits[i].Commit.Author.Name = n.Author.Name
+		commits[i].Commit.Author.Email = n.Author.Email
+		commits[i].Commit.Author.Date = n.Author.Date
+		commits[i].Commit.Message = n.Message
+		commits[i].Commit.Committer.Email = n.Committer.Email
+		// GraphQL has no signature for unsigned commits, and states match
+		// REST's verification reasons once lowercased
+		commits[i].Commit.Verification = &CommitVerification{Reason: "unsigned"}
+		if n.Signature != nil {
+			commits[i].Commit.Verification = &CommitVerification{Verified: n.Signature.IsValid, Reason: strings.ToLower(n.Signature.State)}
+		}
+		for _, p := range n.Parents.Nodes {
+			commits[i].Parents = append(commits[i].Parents, CommitParent{SHA: p.Oid})
+		}
+		if n.Author.User != nil {
+			commits[i].Author = &CommitAuthor{Login: n.Author.User.Login}
+		}
+	}
+	return commits
+}
+
+// graphqlURL returns the GraphQL endpoint matching the REST base URL
+func (c *Client) graphqlURL() string {
+	if c.baseURL == DefaultBaseURL {
+		return DefaultBaseURL + "/graphql"
+	}
+	// GitHub Enterprise Server serves GraphQL at /api/graphql
+	return strings.TrimSuffix(c.baseURL, "/v3") + "/graphql"
+}
+
+// graphql runs a GraphQL query and decodes its data field into target
+func (c *Client) graphql(ctx context.Context, query string, vars map[string]interface{}, target interface{}) error {
+	payload, err := json.Marshal(map[string]interface{}{"query": query, "variables": vars})
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", c.graphqlURL(), bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", c.userAgent)
+	token, err := c.authToken(ctx)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	resp, err := c.do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("GitHub GraphQL error: %s", resp.Status)
+	}
+
+	var result struct {
+		Data   json.RawMessage `json:"data"`
+		Errors []struct {
+			Message string `json:"message"`
+		} `json:"errors"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return err
+	}
+	if len(result.Errors) > 0 {
+		return fmt.Errorf("GitHub GraphQL error: %s", result.Errors[0].Message)
+	}
+	return json.Unmarshal(result.Data, target)
+}
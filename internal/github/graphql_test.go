@@ -0,0 +1,149 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// graphqlRequest is the body of a GraphQL POST
+type graphqlRequest struct {
+	Query     string                 `json:"query"`
+	Variables map[string]interface{} `json:"variables"`
+}
+
+func TestOverviewGraphQLRetries(t *testing.T) {
+	var attempts atomic.Int32
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/graphql" {
+			t.Errorf("request to %s, want the GraphQL endpoint", r.URL.Path)
+		}
+		var req graphqlRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.Variables["owner"] != "o" {
+			t.Errorf("attempt %d sent body %+v (%v), want the query again", attempts.Load()+1, req, err)
+		}
+		if attempts.Add(1) == 1 {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		w.Write([]byte(`{"data":{"repository":{"name":"r","nameWithOwner":"o/r"}}}`))
+	}, WithRetryPolicy(RetryPolicy{MaxAttempts: 3}))
+
+	overview, err := client.GetOverview(context.Background(), "o", "r", CommitOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if overview.Repo.FullName != "o/r" {
+		t.Errorf("repo = %q, want o/r from GraphQL", overview.Repo.FullName)
+	}
+	if got := attempts.Load(); got != 2 {
+		t.Errorf("%d attempts, want the 502 retried once", got)
+	}
+}
+
+func TestOverviewGraphQLWindow(t *testing.T) {
+	since := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	until := time.Date(2025, 4, 1, 0, 0, 0, 0, time.UTC)
+	var vars map[string]interface{}
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		var req graphqlRequest
+		json.NewDecoder(r.Body).Decode(&req)
+		vars = req.Variables
+		w.Write([]byte(`{"data":{"repository":{"name":"r","nameWithOwner":"o/r"}}}`))
+	})
+
+	if _, err := client.GetOverview(context.Background(), "o", "r", CommitOptions{Since: since, Until: until}); err != nil {
+		t.Fatal(err)
+	}
+	if vars["since"] != "2025-01-01T00:00:00Z" || vars["until"] != "2025-04-01T00:00:00Z" {
+		t.Errorf("variables = %v, want the since and until of the window", vars)
+	}
+}
+
+func TestOverviewGraphQLHistoryPages(t *testing.T) {
+	page := func(n int, next bool) string {
+		nodes := make([]map[string]interface{}, n)
+		for i := range nodes {
+			nodes[i] = map[string]interface{}{"oid": "sha", "author": map[string]string{"date": "2025-01-01T00:00:00Z"}}
+		}
+		history, _ := json.Marshal(map[string]interface{}{
+			"totalCount": 150,
+			"pageInfo":   map[string]interface{}{"hasNextPage": next, "endCursor": "cursor"},
+			"nodes":      nodes,
+		})
+		return string(history)
+	}
+	var queries []graphqlRequest
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		var req graphqlRequest
+		json.NewDecoder(r.Body).Decode(&req)
+		queries = append(queries, req)
+		if len(queries) == 1 {
+			w.Write([]byte(`{"data":{"repository":{"name":"r","nameWithOwner":"o/r","stargazerCount":42,"watchers":{"totalCount":7},
+				"defaultBranchRef":{"name":"main","target":{"history":` + page(100, true) + `}}}}}`))
+			return
+		}
+		w.Write([]byte(`{"data":{"repository":{"defaultBranchRef":{"target":{"history":` + page(50, false) + `}}}}}`))
+	})
+
+	overview, err := client.GetOverview(context.Background(), "o", "r", CommitOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(overview.Commits) != 150 || overview.CommitsTruncated {
+		t.Errorf("got %d commits (truncated %t), want all 150", len(overview.Commits), overview.CommitsTruncated)
+	}
+	if len(queries) != 2 {
+		t.Fatalf("%d queries, want the overview and one page of history", len(queries))
+	}
+	if queries[1].Query != historyQuery || queries[1].Variables["after"] != "cursor" {
+		t.Errorf("second query = %+v, want the history query after the cursor", queries[1])
+	}
+	// Both paths fill Repo alike: REST's watchers_count is the star count
+	if overview.Repo.WatchersCount != 42 || overview.Repo.Subscribers != 7 {
+		t.Errorf("WatchersCount = %d, Subscribers = %d, want 42 and 7", overview.Repo.WatchersCount, overview.Repo.Subscribers)
+	}
+}
+
+func TestOverviewGraphQLPeopleCounts(t *testing.T) {
+	tests := []struct {
+		permission    string
+		queries       int
+		collaborators int
+	}{
+		{"ADMIN", 2, 9},
+		{"WRITE", 2, 9},
+		{"READ", 1, -1},
+		{"", 1, -1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.permission, func(t *testing.T) {
+			queries := 0
+			client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+				var req graphqlRequest
+				json.NewDecoder(r.Body).Decode(&req)
+				queries++
+				if req.Query == collaboratorsQuery {
+					w.Write([]byte(`{"data":{"repository":{"collaborators":{"totalCount":9}}}}`))
+					return
+				}
+				w.Write([]byte(`{"data":{"repository":{"name":"r","nameWithOwner":"o/r",
+					"mentionableUsers":{"totalCount":31},"viewerPermission":"` + tt.permission + `"}}}`))
+			})
+
+			overview, err := client.GetOverview(context.Background(), "o", "r", CommitOptions{})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if overview.Mentionable != 31 || overview.Collaborators != tt.collaborators {
+				t.Errorf("Mentionable = %d, Collaborators = %d, want 31 and %d", overview.Mentionable, overview.Collaborators, tt.collaborators)
+			}
+			if queries != tt.queries {
+				t.Errorf("%d queries, want %d", queries, tt.queries)
+			}
+		})
+	}
+}
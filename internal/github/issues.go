@@ -1,12 +1,206 @@
 package github
 
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"sync"
+	"time"
+)
+
+// DefaultIssueSample is how many issues are sampled for issue statistics
+const DefaultIssueSample = 200
+
 type Issue struct {
-	State string `json:"state"`
+	Number      int        `json:"number"`
+	Title       string     `json:"title"`
+	State       string     `json:"state"`
+	CreatedAt   time.Time  `json:"created_at"`
+	ClosedAt    *time.Time `json:"closed_at"`
+	PullRequest *struct{}  `json:"pull_request"` // set when the "issue" is a PR
+	Labels      []Label    `json:"labels"`
+	User        struct {
+		Login string `json:"login"`
+	} `json:"user"`
+	// AuthorAssociation is the author's relation to the repo, e.g. OWNER,
+	// MEMBER, COLLABORATOR, CONTRIBUTOR or NONE
+	AuthorAssociation string `json:"author_association"`
+	Comments          int    `json:"comments"`
+}
+
+// IsPullRequest reports whether the issues API returned a pull request
+func (i Issue) IsPullRequest() bool {
+	return i.PullRequest != nil
+}
+
+// IssueOptions selects which issues to list
+type IssueOptions struct {
+	State     string // open, closed or all
+	Sort      string // created, updated or comments
+	Direction string // asc or desc
+	Max       int    // cap on issues returned, excluding pull requests
+}
+
+// IssuePageSlack is how many pages past those opts.Max issues would fill
+// GetIssues reads, to make up for the pull requests mixed in
+const IssuePageSlack = 2
+
+// GetIssues lists issues page by page, skipping the pull requests the issues
+// API mixes in, until opts.Max issues are collected. With a Max, at most
+// IssuePageSlack pages more than Max issues fill are read, so a repository
+// with many more pull requests than issues returns fewer.
+func (c *Client) GetIssues(ctx context.Context, owner, repo string, opts IssueOptions) ([]Issue, error) {
+	var allIssues []Issue
+
+	page := 1
+	perPage := 100
+	maxPages := 0
+	if opts.Max > 0 {
+		maxPages = (opts.Max+perPage-1)/perPage + IssuePageSlack
+	}
+
+	for {
+		endpoint := fmt.Sprintf(
+			"%s/repos/%s/%s/issues?state=%s&sort=%s&direction=%s&per_page=%d&page=%d",
+			c.baseURL, owner, repo, opts.State, opts.Sort, opts.Direction, perPage, page,
+		)
+
+		var issues []Issue
+		if err := c.get(ctx, endpoint, &issues); err != nil {
+			return allIssues, err
+		}
+
+		for _, issue := range issues {
+			if !issue.IsPullRequest() {
+				allIssues = append(allIssues, issue)
+			}
+		}
+
+		if opts.Max > 0 && len(allIssues) >= opts.Max {
+			return allIssues[:opts.Max], nil
+		}
+		if len(issues) < perPage || page == maxPages {
+			break
+		}
+
+		page++
+	}
+
+	return allIssues, nil
 }
 
-func (c *Client) GetIssues(owner, repo string, state string) ([]Issue, error) {
-	var issues []Issue
-	url := "https://api.github.com/repos/" + owner + "/" + repo + "/issues?state=" + state
-	err := c.get(url, &issues)
-	return issues, err
+// DefaultResponseSample is how many recent issues have their comments
+// fetched to measure the time to first response, at one request each for
+// issues with comments
+const DefaultResponseSample = 30
+
+// IssueComment is a comment on an issue
+type IssueComment struct {
+	User struct {
+		Login string `json:"login"`
+	} `json:"user"`
+	AuthorAssociation string    `json:"author_association"`
+	CreatedAt         time.Time `json:"created_at"`
+}
+
+// IssueThread is an issue with its comments
+type IssueThread struct {
+	Issue
+	Comments []IssueComment
+}
+
+// GetIssueComments lists the comments on an issue (the first 100)
+func (c *Client) GetIssueComments(ctx context.Context, owner, repo string, number int) ([]IssueComment, error) {
+	var comments []IssueComment
+	endpoint := fmt.Sprintf("%s/repos/%s/%s/issues/%d/comments?per_page=100", c.baseURL, owner, repo, number)
+	err := c.get(ctx, endpoint, &comments)
+	return comments, err
+}
+
+// GetIssueThreads fetches the comments of up to max of issues. Issues
+// without comments cost no request; those whose comments can't be fetched
+// are left out.
+func (c *Client) GetIssueThreads(ctx context.Context, owner, repo string, issues []Issue, max int) []IssueThread {
+	if len(issues) > max {
+		issues = issues[:max]
+	}
+
+	threads := make([]*IssueThread, len(issues))
+	var wg sync.WaitGroup
+	for i, issue := range issues {
+		if issue.Comments == 0 {
+			threads[i] = &IssueThread{Issue: issue}
+			continue
+		}
+		wg.Add(1)
+		go func(i int, issue Issue) {
+			defer wg.Done()
+			comments, err := c.GetIssueComments(ctx, owner, repo, issue.Number)
+			if err != nil {
+				return
+			}
+			threads[i] = &IssueThread{Issue: issue, Comments: comments}
+		}(i, issue)
+	}
+	wg.Wait()
+
+	var result []IssueThread
+	for _, t := range threads {
+		if t != nil {
+			result = append(result, *t)
+		}
+	}
+	return result
+}
+
+// CountIssues returns the total_count of an issue search, e.g.
+// "repo:owner/name type:issue state:open"
+func (c *Client) CountIssues(ctx context.Context, query string) (int, error) {
+	var result struct {
+		TotalCount int `json:"total_count"`
+	}
+	endpoint := c.baseURL + "/search/issues?per_page=1&q=" + url.QueryEscape(query)
+	err := c.get(ctx, endpoint, &result)
+	return result.TotalCount, err
+}
+
+// DefaultMaxLabels caps how many labels are listed for a repo
+const DefaultMaxLabels = 300
+
+// Label is an issue label
+type Label struct {
+	Name string `json:"name"`
+}
+
+// GetLabels lists the labels defined in a repo, up to max
+func (c *Client) GetLabels(ctx context.Context, owner, repo string, max int) ([]Label, error) {
+	var allLabels []Label
+
+	page := 1
+	perPage := 100
+
+	for {
+		endpoint := fmt.Sprintf(
+			"%s/repos/%s/%s/labels?per_page=%d&page=%d",
+			c.baseURL, owner, repo, perPage, page,
+		)
+
+		var labels []Label
+		if err := c.get(ctx, endpoint, &labels); err != nil {
+			return allLabels, err
+		}
+
+		allLabels = append(allLabels, labels...)
+
+		if max > 0 && len(allLabels) >= max {
+			return allLabels[:max], nil
+		}
+		if len(labels) < perPage {
+			break
+		}
+
+		page++
+	}
+
+	return allLabels, nil
 }
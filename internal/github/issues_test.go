@@ -0,0 +1,69 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"sync/atomic"
+	"testing"
+)
+
+func TestGetIssues(t *testing.T) {
+	everyOther := func(i int) bool { return i%2 == 1 }
+	allPulls := func(int) bool { return true }
+	pullsUntil := func(n int) func(int) bool {
+		return func(i int) bool { return i < n }
+	}
+	tests := []struct {
+		name         string
+		total        int
+		isPull       func(i int) bool
+		max          int
+		wantIssues   int
+		wantRequests int32
+	}{
+		{"pull requests excluded", 150, everyOther, 0, 75, 2},
+		{"stops at max", 300, func(int) bool { return false }, 150, 150, 2},
+		{"max counts issues only", 300, everyOther, 100, 100, 2},
+		{"page budget with mostly pull requests", 5000, allPulls, DefaultIssueSample, 0, 2 + IssuePageSlack},
+		{"oldest issue behind pull requests", 1000, pullsUntil(150), 1, 1, 2},
+		{"oldest issue past the page budget", 1000, pullsUntil(900), 1, 0, 1 + IssuePageSlack},
+		{"no issues", 0, allPulls, DefaultIssueSample, 0, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var requests atomic.Int32
+			client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+				requests.Add(1)
+				page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+				issues := []map[string]interface{}{}
+				for i := (page - 1) * 100; i < page*100 && i < tt.total; i++ {
+					issue := map[string]interface{}{"number": i + 1}
+					if tt.isPull(i) {
+						issue["pull_request"] = map[string]string{}
+					}
+					issues = append(issues, issue)
+				}
+				json.NewEncoder(w).Encode(issues)
+			})
+
+			issues, err := client.GetIssues(context.Background(), "o", "r",
+				IssueOptions{State: "open", Sort: "created", Direction: "asc", Max: tt.max})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(issues) != tt.wantIssues {
+				t.Errorf("got %d issues, want %d", len(issues), tt.wantIssues)
+			}
+			for _, issue := range issues {
+				if issue.IsPullRequest() {
+					t.Errorf("#%d is a pull request", issue.Number)
+				}
+			}
+			if got := requests.Load(); got != tt.wantRequests {
+				t.Errorf("%d requests, want %d", got, tt.wantRequests)
+			}
+		})
+	}
+}
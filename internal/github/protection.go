@@ -0,0 +1,46 @@
+package github
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+// ErrBranchNotProtected is returned by GetBranchProtection when GitHub
+// confirms the branch has no protection rules
+var ErrBranchNotProtected = errors.New("branch not protected")
+
+// BranchProtection holds the protection rules of a branch
+type BranchProtection struct {
+	RequiredStatusChecks *struct {
+		Strict   bool     `json:"strict"`
+		Contexts []string `json:"contexts"`
+	} `json:"required_status_checks"`
+	RequiredPullRequestReviews *struct {
+		RequiredApprovingReviewCount int `json:"required_approving_review_count"`
+	} `json:"required_pull_request_reviews"`
+	EnforceAdmins *struct {
+		Enabled bool `json:"enabled"`
+	} `json:"enforce_admins"`
+}
+
+// GetBranchProtection fetches the protection rules of a branch. Reading them
+// needs admin access to the repo: ErrBranchNotProtected means the branch is
+// confirmed unprotected, while any other error (typically a 403 or a bare
+// 404) means the settings couldn't be seen.
+func (c *Client) GetBranchProtection(ctx context.Context, owner, repo, branch string) (*BranchProtection, error) {
+	endpoint := fmt.Sprintf("%s/repos/%s/%s/branches/%s/protection", c.baseURL, owner, repo, url.PathEscape(branch))
+
+	var protection BranchProtection
+	if err := c.get(ctx, endpoint, &protection); err != nil {
+		var statusErr *StatusError
+		if errors.As(err, &statusErr) && statusErr.StatusCode == http.StatusNotFound &&
+			statusErr.Message == "Branch not protected" {
+			return nil, ErrBranchNotProtected
+		}
+		return nil, err
+	}
+	return &protection, nil
+}
@@ -0,0 +1,70 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// DefaultPullRequestSample is how many recently updated pull requests are
+// sampled for pull request statistics
+const DefaultPullRequestSample = 100
+
+type PullRequest struct {
+	Number    int        `json:"number"`
+	Title     string     `json:"title"`
+	State     string     `json:"state"`
+	Draft     bool       `json:"draft"`
+	CreatedAt time.Time  `json:"created_at"`
+	ClosedAt  *time.Time `json:"closed_at"`
+	MergedAt  *time.Time `json:"merged_at"`
+	User      struct {
+		Login string `json:"login"`
+	} `json:"user"`
+	// MergedBy is only filled in by GetPullRequest, not in lists
+	MergedBy *struct {
+		Login string `json:"login"`
+	} `json:"merged_by"`
+}
+
+// IsMerged reports whether the pull request was merged (rather than closed)
+func (p PullRequest) IsMerged() bool {
+	return p.MergedAt != nil
+}
+
+// GetPullRequests lists pull requests in any state, most recently updated
+// first, stopping after max (0 means no limit)
+func (c *Client) GetPullRequests(ctx context.Context, owner, repo string, max int) ([]PullRequest, error) {
+	var allPulls []PullRequest
+
+	page := 1
+	perPage := 100
+	if max > 0 && max < perPage {
+		perPage = max
+	}
+
+	for {
+		url := fmt.Sprintf(
+			"%s/repos/%s/%s/pulls?state=all&sort=updated&direction=desc&per_page=%d&page=%d",
+			c.baseURL, owner, repo, perPage, page,
+		)
+
+		var pulls []PullRequest
+		if err := c.get(ctx, url, &pulls); err != nil {
+			return allPulls, err
+		}
+
+		allPulls = append(allPulls, pulls...)
+
+		if max > 0 && len(allPulls) >= max {
+			return allPulls[:max], nil
+		}
+		if len(pulls) < perPage {
+			break
+		}
+
+		page++
+	}
+
+	return allPulls, nil
+}
@@ -1,8 +1,10 @@
 package github
 
 import (
+	"context"
 	"time"
 )
+
 type RateLimit struct {
 	Resources struct {
 		Core struct {
@@ -12,9 +14,10 @@ type RateLimit struct {
 		} `json:"core"`
 	} `json:"resources"`
 }
-func (c *Client) GetRateLimit() (*RateLimit, error) {
+
+func (c *Client) GetRateLimit(ctx context.Context) (*RateLimit, error) {
 	var rateLimit RateLimit
-	err := c.get("https://api.github.com/rate_limit", &rateLimit)
+	err := c.get(ctx, c.baseURL+"/rate_limit", &rateLimit)
 	if err != nil {
 		return nil, err
 	}
@@ -23,3 +26,18 @@ func (c *Client) GetRateLimit() (*RateLimit, error) {
 func (r *RateLimit) ResetTime() time.Time {
 	return time.Unix(int64(r.Resources.Core.Reset), 0)
 }
+
+// RateLimitStatus is the rate limit state reported by the most recent response
+type RateLimitStatus struct {
+	Limit     int
+	Remaining int
+	Reset     time.Time
+	Known     bool // false until a response carried rate limit headers
+}
+
+// RateLimitStatus returns the rate limit state seen on the last API response
+func (c *Client) RateLimitStatus() RateLimitStatus {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.rateLimit
+}
@@ -0,0 +1,119 @@
+package github
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// rateLimited answers like GitHub once the hourly limit is used up
+func rateLimited(w http.ResponseWriter, status int, reset time.Time) {
+	w.Header().Set("X-RateLimit-Limit", "60")
+	w.Header().Set("X-RateLimit-Remaining", "0")
+	w.Header().Set("X-RateLimit-Reset", strconv.FormatInt(reset.Unix(), 10))
+	w.WriteHeader(status)
+	w.Write([]byte(`{"message":"API rate limit exceeded"}`))
+}
+
+func TestRateLimitError(t *testing.T) {
+	reset := time.Now().Add(time.Hour).Truncate(time.Second)
+	for _, status := range []int{http.StatusForbidden, http.StatusTooManyRequests} {
+		client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+			rateLimited(w, status, reset)
+		})
+
+		_, err := client.GetRepo(context.Background(), "o", "r")
+		if !errors.Is(err, ErrRateLimited) {
+			t.Fatalf("status %d: err = %v, want ErrRateLimited", status, err)
+		}
+		var rlErr *RateLimitError
+		if !errors.As(err, &rlErr) || !rlErr.Reset.Equal(reset) {
+			t.Errorf("status %d: err = %#v, want reset %v", status, err, reset)
+		}
+		if errors.Is(err, ErrForbidden) {
+			t.Errorf("status %d: rate limit reported as ErrForbidden", status)
+		}
+
+		got := client.RateLimitStatus()
+		want := RateLimitStatus{Limit: 60, Remaining: 0, Reset: reset, Known: true}
+		if got.Limit != want.Limit || got.Remaining != want.Remaining || !got.Reset.Equal(want.Reset) || !got.Known {
+			t.Errorf("status %d: RateLimitStatus() = %+v, want %+v", status, got, want)
+		}
+	}
+}
+
+func TestRateLimitStatusFromHeaders(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-RateLimit-Limit", "5000")
+		w.Header().Set("X-RateLimit-Remaining", "4999")
+		w.Header().Set("X-RateLimit-Reset", "1700000000")
+		w.Write([]byte(`{}`))
+	})
+
+	if client.RateLimitStatus().Known {
+		t.Fatal("RateLimitStatus() known before any request")
+	}
+	if _, err := client.GetRepo(context.Background(), "o", "r"); err != nil {
+		t.Fatal(err)
+	}
+	got := client.RateLimitStatus()
+	if !got.Known || got.Limit != 5000 || got.Remaining != 4999 || got.Reset.Unix() != 1700000000 {
+		t.Errorf("RateLimitStatus() = %+v", got)
+	}
+}
+
+func TestWaitOnRateLimit(t *testing.T) {
+	tests := []struct {
+		name     string
+		wait     time.Duration
+		reset    time.Duration // from now
+		wantErr  bool
+		requests int32
+	}{
+		{"disabled", 0, 0, true, 1},
+		{"reset within the wait", time.Minute, 0, false, 2},
+		{"reset too far away", time.Minute, time.Hour, true, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var requests atomic.Int32
+			reset := time.Now().Add(tt.reset)
+			client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+				if requests.Add(1) == 1 {
+					rateLimited(w, http.StatusForbidden, reset)
+					return
+				}
+				w.Write([]byte(`{"name":"r"}`))
+			})
+			client.WaitOnRateLimit(tt.wait)
+
+			repo, err := client.GetRepo(context.Background(), "o", "r")
+			if tt.wantErr != (err != nil) {
+				t.Fatalf("err = %v, want error: %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && repo.Name != "r" {
+				t.Errorf("repo name = %q after waiting", repo.Name)
+			}
+			if got := requests.Load(); got != tt.requests {
+				t.Errorf("%d requests, want %d", got, tt.requests)
+			}
+		})
+	}
+}
+
+func TestWaitOnRateLimitHonorsContext(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		rateLimited(w, http.StatusForbidden, time.Now().Add(30*time.Second))
+	})
+	client.WaitOnRateLimit(time.Minute)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	if _, err := client.GetRepo(ctx, "o", "r"); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("err = %v, want context.DeadlineExceeded", err)
+	}
+}
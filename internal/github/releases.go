@@ -0,0 +1,57 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// DefaultMaxReleases caps how many releases are fetched for a repo
+const DefaultMaxReleases = 100
+
+// Release represents a GitHub release
+type Release struct {
+	TagName     string    `json:"tag_name"`
+	Name        string    `json:"name"`
+	Draft       bool      `json:"draft"`
+	Prerelease  bool      `json:"prerelease"`
+	CreatedAt   time.Time `json:"created_at"`
+	PublishedAt time.Time `json:"published_at"`
+	HTMLURL     string    `json:"html_url"`
+}
+
+// GetReleases fetches the most recent releases (newest first), up to max
+func (c *Client) GetReleases(ctx context.Context, owner, repo string, max int) ([]Release, error) {
+	var allReleases []Release
+
+	page := 1
+	perPage := 100
+	if max > 0 && max < perPage {
+		perPage = max
+	}
+
+	for {
+		url := fmt.Sprintf(
+			"%s/repos/%s/%s/releases?per_page=%d&page=%d",
+			c.baseURL, owner, repo, perPage, page,
+		)
+
+		var releases []Release
+		if err := c.get(ctx, url, &releases); err != nil {
+			return allReleases, err
+		}
+
+		allReleases = append(allReleases, releases...)
+
+		if max > 0 && len(allReleases) >= max {
+			return allReleases[:max], nil
+		}
+		if len(releases) < perPage {
+			break
+		}
+
+		page++
+	}
+
+	return allReleases, nil
+}
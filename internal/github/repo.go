@@ -1,32 +1,54 @@
 package github
 
-import "time"
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+)
 
 type Repo struct {
-	Name              string    `json:"name"`
-	FullName          string    `json:"full_name"`
-	Stars             int       `json:"stargazers_count"`
-	StargazersCount   int       `json:"stargazers_count"`
-	Forks             int       `json:"forks_count"`
-	ForksCount        int       `json:"forks_count"`
-	OpenIssues        int       `json:"open_issues_count"`
-	OpenIssuesCount   int       `json:"open_issues_count"`
-	Description       string    `json:"description"`
-	CreatedAt         time.Time `json:"created_at"`
-	UpdatedAt         time.Time `json:"updated_at"`
-	PushedAt          time.Time `json:"pushed_at"`
-	WatchersCount     int       `json:"watchers_count"`
-	Language          string    `json:"language"`
-	Fork              bool      `json:"fork"`
-	Archived          bool      `json:"archived"`
-	Private           bool      `json:"private"`
-	DefaultBranch     string    `json:"default_branch"`
-	HTMLURL           string    `json:"html_url"`
-	CloneURL          string    `json:"clone_url"`
+	Name          string    `json:"name"`
+	FullName      string    `json:"full_name"`
+	Stars         int       `json:"stargazers_count"`
+	Forks         int       `json:"forks_count"`
+	OpenIssues    int       `json:"open_issues_count"`
+	Description   string    `json:"description"`
+	CreatedAt     time.Time `json:"created_at"`
+	UpdatedAt     time.Time `json:"updated_at"`
+	PushedAt      time.Time `json:"pushed_at"`
+	WatchersCount int       `json:"watchers_count"`
+	// Subscribers counts the people watching the repo; REST's
+	// watchers_count is a legacy alias of the star count
+	Subscribers   int      `json:"subscribers_count"`
+	Language      string   `json:"language"`
+	Fork          bool     `json:"fork"`
+	Archived      bool     `json:"archived"`
+	HasIssues     bool     `json:"has_issues"`
+	Private       bool     `json:"private"`
+	DefaultBranch string   `json:"default_branch"`
+	HTMLURL       string   `json:"html_url"`
+	CloneURL      string   `json:"clone_url"`
+	Topics        []string `json:"topics,omitempty"`
+	License       *License `json:"license"`
 }
 
-func (c *Client) GetRepo(owner, repo string) (*Repo, error) {
+// License is the license GitHub detected for a repo. SPDXID is
+// "NOASSERTION" when a license file exists but wasn't recognized.
+type License struct {
+	Key    string `json:"key"`
+	Name   string `json:"name"`
+	SPDXID string `json:"spdx_id"`
+}
+
+func (c *Client) GetRepo(ctx context.Context, owner, repo string) (*Repo, error) {
 	var r Repo
-	err := c.get("https://api.github.com/repos/"+owner+"/"+repo, &r)
+	err := c.get(ctx, c.baseURL+"/repos/"+owner+"/"+repo, &r)
+	// Installations see only the repos they were granted, and GitHub
+	// reports the rest as missing
+	var statusErr *StatusError
+	if c.app != nil && errors.As(err, &statusErr) && statusErr.StatusCode == http.StatusNotFound {
+		return &r, &InstallationAccessError{Repo: owner + "/" + repo}
+	}
 	return &r, err
 }
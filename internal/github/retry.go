@@ -0,0 +1,181 @@
+package github
+
+import (
+	"bytes"
+	"io"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// RetryPolicy controls how GET requests and GraphQL queries are retried on
+// transient failures: 5xx responses, timeouts and connection errors. Delays
+// grow exponentially from BaseDelay up to MaxDelay, with jitter, unless the
+// server sends Retry-After, which is honored up to MaxDelay.
+//
+// A secondary rate limit is waited out once, for Retry-After (a minute if
+// absent) as long as that's within MaxSecondaryWait; 0 never waits.
+type RetryPolicy struct {
+	MaxAttempts      int // total attempts including the first; 1 disables retries
+	BaseDelay        time.Duration
+	MaxDelay         time.Duration
+	MaxSecondaryWait time.Duration
+}
+
+// DefaultRetryPolicy is used unless WithRetryPolicy is given
+var DefaultRetryPolicy = RetryPolicy{
+	MaxAttempts:      4,
+	BaseDelay:        500 * time.Millisecond,
+	MaxDelay:         10 * time.Second,
+	MaxSecondaryWait: 2 * time.Minute,
+}
+
+// DefaultMaxConcurrency bounds in-flight requests per client (and so per
+// host), as GitHub recommends against bursts of concurrent requests
+const DefaultMaxConcurrency = 4
+
+// WithMaxConcurrency bounds how many requests the client has in flight at
+// once; n <= 0 removes the bound. Methods that fetch details for many items
+// start a goroutine per item and rely on this bound to throttle them.
+func WithMaxConcurrency(n int) Option {
+	return func(c *Client) {
+		c.limiter = nil
+		if n > 0 {
+			c.limiter = make(chan struct{}, n)
+		}
+	}
+}
+
+// WithRetryPolicy replaces the retry policy, e.g. with zero delays in tests
+func WithRetryPolicy(p RetryPolicy) Option {
+	return func(c *Client) {
+		c.retry = p
+	}
+}
+
+// do sends an idempotent request, retrying transient failures according to
+// the client's retry policy and waiting out a secondary rate limit once.
+// Other statuses (401, 404, 422, ...) are returned immediately for fetch to
+// turn into errors.
+func (c *Client) do(req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+	waitedSecondary := false
+	for attempt := 1; ; attempt++ {
+		if attempt > 1 && req.GetBody != nil {
+			// The last attempt consumed the body, as of a GraphQL POST
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, err
+			}
+			req.Body = body
+		}
+		resp, err := c.send(req)
+
+		var delay time.Duration
+		switch {
+		case ctx.Err() != nil:
+			return resp, err
+		case err == nil && isSecondaryRateLimited(resp):
+			wait := secondaryWait(resp)
+			if waitedSecondary || wait > c.retry.MaxSecondaryWait {
+				return resp, err
+			}
+			waitedSecondary = true
+			delay = wait
+		case attempt >= c.retry.MaxAttempts || !isTransient(resp, err):
+			return resp, err
+		default:
+			delay = c.retry.delay(attempt, resp)
+		}
+
+		if resp != nil {
+			io.Copy(io.Discard, resp.Body)
+			resp.Body.Close()
+		}
+
+		select {
+		case <-time.After(delay):
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+}
+
+// send performs a single request, waiting for a free slot if the client
+// bounds concurrency
+func (c *Client) send(req *http.Request) (*http.Response, error) {
+	if c.limiter != nil {
+		select {
+		case c.limiter <- struct{}{}:
+		case <-req.Context().Done():
+			return nil, req.Context().Err()
+		}
+		defer func() { <-c.limiter }()
+	}
+	return c.http.Do(req)
+}
+
+// isSecondaryRateLimited reports whether a response is GitHub's abuse
+// detection throttling a burst, rather than an auth failure or the hourly
+// limit running out. The body is left readable.
+func isSecondaryRateLimited(resp *http.Response) bool {
+	if resp.StatusCode != http.StatusForbidden && resp.StatusCode != http.StatusTooManyRequests {
+		return false
+	}
+	if resp.Header.Get("X-RateLimit-Remaining") == "0" {
+		return false // the primary rate limit
+	}
+	if resp.Header.Get("Retry-After") != "" {
+		return true
+	}
+
+	body, _ := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+	message := strings.ToLower(string(body))
+	return strings.Contains(message, "secondary rate limit") || strings.Contains(message, "abuse detection")
+}
+
+// secondaryWait is how long a secondary rate limit asks to wait: Retry-After,
+// or a minute as GitHub advises when it's absent
+func secondaryWait(resp *http.Response) time.Duration {
+	if secs, err := strconv.Atoi(resp.Header.Get("Retry-After")); err == nil && secs >= 0 {
+		return time.Duration(secs) * time.Second
+	}
+	return time.Minute
+}
+
+// isTransient reports whether a failed attempt is worth retrying. Any
+// transport error (timeout, connection reset, DNS hiccup) counts.
+func isTransient(resp *http.Response, err error) bool {
+	if err != nil {
+		return true
+	}
+	return resp.StatusCode >= 500
+}
+
+// delay returns how long to wait before the next attempt, honoring
+// Retry-After (in seconds) when the server sends it, up to MaxDelay
+func (p RetryPolicy) delay(attempt int, resp *http.Response) time.Duration {
+	if resp != nil {
+		if secs, err := strconv.Atoi(resp.Header.Get("Retry-After")); err == nil && secs >= 0 {
+			d := time.Duration(secs) * time.Second
+			if p.MaxDelay > 0 && d > p.MaxDelay {
+				d = p.MaxDelay
+			}
+			return d
+		}
+	}
+
+	if p.BaseDelay <= 0 {
+		return 0
+	}
+	d := p.BaseDelay << (attempt - 1)
+	if d <= 0 || (p.MaxDelay > 0 && d > p.MaxDelay) {
+		d = p.MaxDelay // also guards against overflow
+	}
+	// Jitter between half and the full delay
+	return d/2 + time.Duration(rand.Int63n(int64(d/2)+1))
+}
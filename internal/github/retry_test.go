@@ -0,0 +1,211 @@
+package github
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestRetry(t *testing.T) {
+	const hangUp = -1 // close the connection without answering
+
+	tests := []struct {
+		name     string
+		statuses []int // per attempt; 200 once they run out
+		header   http.Header
+		wantErr  error
+		attempts int32
+	}{
+		{"success", nil, nil, nil, 1},
+		{"transient 5xx", []int{500, 502}, nil, nil, 3},
+		{"connection reset", []int{hangUp}, nil, nil, 2},
+		{"gives up", []int{503, 503, 503, 503, 503}, nil, &StatusError{StatusCode: 503}, 4},
+		{"unauthorized", []int{401}, nil, ErrUnauthorized, 1},
+		{"not found", []int{404}, nil, ErrNotFound, 1},
+		{"unprocessable", []int{422}, nil, ErrUnprocessable, 1},
+		{"secondary rate limit", []int{403}, http.Header{"Retry-After": {"0"}}, nil, 2},
+		{"secondary rate limit twice", []int{429, 429}, http.Header{"Retry-After": {"0"}}, ErrSecondaryRateLimit, 2},
+		{"secondary rate limit too long", []int{403}, http.Header{"Retry-After": {"3600"}}, ErrSecondaryRateLimit, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var attempts atomic.Int32
+			client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+				n := int(attempts.Add(1))
+				if n > len(tt.statuses) {
+					w.Write([]byte(`{"name":"r"}`))
+					return
+				}
+				if tt.statuses[n-1] == hangUp {
+					conn, _, _ := w.(http.Hijacker).Hijack()
+					conn.Close()
+					return
+				}
+				for k, v := range tt.header {
+					w.Header()[k] = v
+				}
+				w.WriteHeader(tt.statuses[n-1])
+				w.Write([]byte(`{"message":"failed"}`))
+			}, WithRetryPolicy(RetryPolicy{MaxAttempts: 4, MaxSecondaryWait: time.Second}))
+
+			repo, err := client.GetRepo(context.Background(), "o", "r")
+			if !sameError(err, tt.wantErr) {
+				t.Fatalf("err = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr == nil && repo.Name != "r" {
+				t.Errorf("repo name = %q, want r", repo.Name)
+			}
+			if got := attempts.Load(); got != tt.attempts {
+				t.Errorf("%d attempts, want %d", got, tt.attempts)
+			}
+		})
+	}
+}
+
+func TestRetryPolicyDelay(t *testing.T) {
+	p := RetryPolicy{BaseDelay: 100 * time.Millisecond, MaxDelay: time.Second}
+	tests := []struct {
+		attempt  int
+		min, max time.Duration
+	}{
+		{1, 50 * time.Millisecond, 100 * time.Millisecond},
+		{2, 100 * time.Millisecond, 200 * time.Millisecond},
+		{3, 200 * time.Millisecond, 400 * time.Millisecond},
+		{10, 500 * time.Millisecond, time.Second},
+		{70, 500 * time.Millisecond, time.Second}, // the shift overflows
+	}
+	for _, tt := range tests {
+		for i := 0; i < 20; i++ {
+			if d := p.delay(tt.attempt, nil); d < tt.min || d > tt.max {
+				t.Errorf("delay(%d) = %v, want between %v and %v", tt.attempt, d, tt.min, tt.max)
+			}
+		}
+	}
+
+	retryAfter := []struct {
+		header   string
+		maxDelay time.Duration
+		want     time.Duration
+	}{
+		{"3", time.Second, time.Second}, // clamped to MaxDelay
+		{"1", 5 * time.Second, time.Second},
+		{"0", time.Second, 0},
+		{"3", 0, 3 * time.Second}, // no MaxDelay to clamp to
+	}
+	for _, tt := range retryAfter {
+		resp := &http.Response{Header: http.Header{"Retry-After": {tt.header}}}
+		p := RetryPolicy{BaseDelay: 100 * time.Millisecond, MaxDelay: tt.maxDelay}
+		if d := p.delay(1, resp); d != tt.want {
+			t.Errorf("delay with Retry-After: %s and MaxDelay %v = %v, want %v", tt.header, tt.maxDelay, d, tt.want)
+		}
+	}
+	if d := (RetryPolicy{}).delay(1, nil); d != 0 {
+		t.Errorf("delay without a base delay = %v, want 0", d)
+	}
+}
+
+// sameError reports whether err matches want, comparing a *StatusError
+// want by status code
+func sameError(err, want error) bool {
+	var wantStatus, gotStatus *StatusError
+	if errors.As(want, &wantStatus) {
+		return errors.As(err, &gotStatus) && gotStatus.StatusCode == wantStatus.StatusCode
+	}
+	return errors.Is(err, want)
+}
+
+func TestSecondaryRateLimitWait(t *testing.T) {
+	tests := []struct {
+		name     string
+		header   http.Header
+		body     string
+		maxWait  time.Duration
+		wantErr  error
+		minWait  time.Duration
+		attempts int32
+	}{
+		{"waits Retry-After once", http.Header{"Retry-After": {"1"}}, `{}`, time.Minute, nil, time.Second, 2},
+		{"Retry-After beyond the cap", http.Header{"Retry-After": {"61"}}, `{}`, time.Minute, ErrSecondaryRateLimit, 0, 1},
+		{"no Retry-After means a minute", nil, `{"message":"You have exceeded a secondary rate limit"}`, 30 * time.Second, ErrSecondaryRateLimit, 0, 1},
+		{"zero Retry-After within a zero cap", http.Header{"Retry-After": {"0"}}, `{}`, 0, nil, 0, 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var attempts atomic.Int32
+			client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+				if attempts.Add(1) > 1 {
+					w.Write([]byte(`{"name":"r"}`))
+					return
+				}
+				for k, v := range tt.header {
+					w.Header()[k] = v
+				}
+				w.WriteHeader(http.StatusForbidden)
+				w.Write([]byte(tt.body))
+			}, WithRetryPolicy(RetryPolicy{MaxAttempts: 1, MaxSecondaryWait: tt.maxWait}))
+
+			start := time.Now()
+			_, err := client.GetRepo(context.Background(), "o", "r")
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("err = %v, want %v", err, tt.wantErr)
+			}
+			var secondary *SecondaryRateLimitError
+			if tt.wantErr != nil && (!errors.As(err, &secondary) || secondary.RetryAfter <= tt.maxWait) {
+				t.Errorf("err = %#v, want the wait beyond %v", err, tt.maxWait)
+			}
+			if waited := time.Since(start); waited < tt.minWait {
+				t.Errorf("waited %v, want at least %v", waited, tt.minWait)
+			}
+			if got := attempts.Load(); got != tt.attempts {
+				t.Errorf("%d attempts, want %d", got, tt.attempts)
+			}
+		})
+	}
+}
+
+func TestMaxConcurrency(t *testing.T) {
+	tests := []struct {
+		name string
+		opts []Option
+		want int32
+	}{
+		{"default", nil, DefaultMaxConcurrency},
+		{"custom", []Option{WithMaxConcurrency(2)}, 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var inFlight, peak atomic.Int32
+			client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+				n := inFlight.Add(1)
+				defer inFlight.Add(-1)
+				for {
+					p := peak.Load()
+					if n <= p || peak.CompareAndSwap(p, n) {
+						break
+					}
+				}
+				time.Sleep(20 * time.Millisecond)
+				w.Write([]byte(`{}`))
+			}, tt.opts...)
+
+			var wg sync.WaitGroup
+			for i := 0; i < 5*int(tt.want); i++ {
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					if _, err := client.GetRepo(context.Background(), "o", "r"); err != nil {
+						t.Error(err)
+					}
+				}()
+			}
+			wg.Wait()
+			if got := peak.Load(); got != tt.want {
+				t.Errorf("peak of %d requests in flight, want %d", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,84 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// DefaultReviewSample is how many recently merged pull requests have their
+// reviews fetched, at two requests each
+const DefaultReviewSample = 20
+
+// Review is a pull request review
+type Review struct {
+	User struct {
+		Login string `json:"login"`
+	} `json:"user"`
+	State       string    `json:"state"` // APPROVED, CHANGES_REQUESTED, COMMENTED, ...
+	SubmittedAt time.Time `json:"submitted_at"`
+}
+
+// ReviewedPullRequest is a merged pull request with who merged it and its reviews
+type ReviewedPullRequest struct {
+	PullRequest
+	Reviews []Review
+}
+
+// GetPullRequest fetches a single pull request, including who merged it
+func (c *Client) GetPullRequest(ctx context.Context, owner, repo string, number int) (*PullRequest, error) {
+	var pr PullRequest
+	endpoint := fmt.Sprintf("%s/repos/%s/%s/pulls/%d", c.baseURL, owner, repo, number)
+	if err := c.get(ctx, endpoint, &pr); err != nil {
+		return nil, err
+	}
+	return &pr, nil
+}
+
+// GetReviews lists the reviews of a pull request (the first 100)
+func (c *Client) GetReviews(ctx context.Context, owner, repo string, number int) ([]Review, error) {
+	var reviews []Review
+	endpoint := fmt.Sprintf("%s/repos/%s/%s/pulls/%d/reviews?per_page=100", c.baseURL, owner, repo, number)
+	err := c.get(ctx, endpoint, &reviews)
+	return reviews, err
+}
+
+// GetReviewedPullRequests fetches the merger and reviews of up to max of
+// the merged pull requests in pulls. Pull requests whose details can't be
+// fetched are left out.
+func (c *Client) GetReviewedPullRequests(ctx context.Context, owner, repo string, pulls []PullRequest, max int) []ReviewedPullRequest {
+	var merged []PullRequest
+	for _, pr := range pulls {
+		if pr.IsMerged() && len(merged) < max {
+			merged = append(merged, pr)
+		}
+	}
+
+	reviewed := make([]*ReviewedPullRequest, len(merged))
+	var wg sync.WaitGroup
+	for i, pr := range merged {
+		wg.Add(1)
+		go func(i int, number int) {
+			defer wg.Done()
+			detail, err := c.GetPullRequest(ctx, owner, repo, number)
+			if err != nil {
+				return
+			}
+			reviews, err := c.GetReviews(ctx, owner, repo, number)
+			if err != nil {
+				return
+			}
+			reviewed[i] = &ReviewedPullRequest{PullRequest: *detail, Reviews: reviews}
+		}(i, pr.Number)
+	}
+	wg.Wait()
+
+	var result []ReviewedPullRequest
+	for _, r := range reviewed {
+		if r != nil {
+			result = append(result, *r)
+		}
+	}
+	return result
+}
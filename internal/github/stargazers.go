@@ -0,0 +1,36 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// StargazersPerPage is the page size used when listing stargazers
+const StargazersPerPage = 100
+
+// MaxStargazerPages is the deepest page GitHub serves for stargazers; the
+// stars after the first 40,000 can't be listed
+const MaxStargazerPages = 400
+
+// MaxStarHistoryPages is how many pages of stargazers star history reads
+// at most, sampling larger repos
+const MaxStarHistoryPages = 10
+
+// Stargazer is one star and when it was given
+type Stargazer struct {
+	StarredAt time.Time `json:"starred_at"`
+}
+
+// GetStargazersPage fetches one page of stargazers, oldest first, with the
+// time each star was given
+func (c *Client) GetStargazersPage(ctx context.Context, owner, repo string, page int) ([]Stargazer, error) {
+	url := fmt.Sprintf(
+		"%s/repos/%s/%s/stargazers?per_page=%d&page=%d",
+		c.baseURL, owner, repo, StargazersPerPage, page,
+	)
+
+	var stargazers []Stargazer
+	err := c.fetch(ctx, url, "application/vnd.github.star+json", &stargazers, c.rateLimitWait > 0)
+	return stargazers, err
+}
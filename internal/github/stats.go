@@ -0,0 +1,112 @@
+package github
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// ErrStatsPending is returned when GitHub is still computing a statistic
+// after all retries. Asking again later usually succeeds.
+var ErrStatsPending = errors.New("GitHub is still computing repository statistics")
+
+// statsRetries and statsRetryDelay control how long a 202 from the stats
+// endpoints is waited out; the delay doubles on each attempt
+var (
+	statsRetries    = 4
+	statsRetryDelay = time.Second
+)
+
+// CommitActivityWeek is one week of the yearly commit histogram
+type CommitActivityWeek struct {
+	Week  int64  `json:"week"` // Unix timestamp of the week's Sunday
+	Total int    `json:"total"`
+	Days  [7]int `json:"days"`
+}
+
+// Start returns the start of the week
+func (w CommitActivityWeek) Start() time.Time {
+	return time.Unix(w.Week, 0).UTC()
+}
+
+// CodeFrequencyWeek is one week of lines added and deleted
+type CodeFrequencyWeek struct {
+	Start     time.Time
+	Additions int
+	Deletions int // as a positive count
+}
+
+// Participation holds weekly commit counts for the last 52 weeks, oldest
+// first, for everyone and for the repo owner
+type Participation struct {
+	All   []int `json:"all"`
+	Owner []int `json:"owner"`
+}
+
+// GetCommitActivity fetches the last year of commit activity grouped by week,
+// oldest first
+func (c *Client) GetCommitActivity(ctx context.Context, owner, repo string) ([]CommitActivityWeek, error) {
+	var weeks []CommitActivityWeek
+	url := fmt.Sprintf("%s/repos/%s/%s/stats/commit_activity", c.baseURL, owner, repo)
+	if err := c.getStats(ctx, url, &weeks); err != nil {
+		return nil, err
+	}
+	return weeks, nil
+}
+
+// GetCodeFrequency fetches the lines added and deleted each week over the
+// repo's history, oldest first. GitHub answers 422 for repos with 10,000
+// commits or more.
+func (c *Client) GetCodeFrequency(ctx context.Context, owner, repo string) ([]CodeFrequencyWeek, error) {
+	var rows [][3]int64 // week timestamp, additions, deletions (negative)
+	url := fmt.Sprintf("%s/repos/%s/%s/stats/code_frequency", c.baseURL, owner, repo)
+	if err := c.getStats(ctx, url, &rows); err != nil {
+		return nil, err
+	}
+	weeks := make([]CodeFrequencyWeek, len(rows))
+	for i, row := range rows {
+		weeks[i] = CodeFrequencyWeek{
+			Start:     time.Unix(row[0], 0).UTC(),
+			Additions: int(row[1]),
+			Deletions: int(-row[2]),
+		}
+	}
+	return weeks, nil
+}
+
+// GetParticipation fetches weekly commit counts for the last 52 weeks
+func (c *Client) GetParticipation(ctx context.Context, owner, repo string) (*Participation, error) {
+	var p Participation
+	url := fmt.Sprintf("%s/repos/%s/%s/stats/participation", c.baseURL, owner, repo)
+	if err := c.getStats(ctx, url, &p); err != nil {
+		return nil, err
+	}
+	return &p, nil
+}
+
+// getStats fetches a stats endpoint, which answers 202 Accepted while GitHub
+// computes the data in the background; those responses are retried with a
+// growing delay before giving up with ErrStatsPending
+func (c *Client) getStats(ctx context.Context, url string, target interface{}) error {
+	delay := statsRetryDelay
+	for attempt := 0; ; attempt++ {
+		err := c.get(ctx, url, target)
+
+		var statusErr *StatusError
+		if !errors.As(err, &statusErr) || statusErr.StatusCode != http.StatusAccepted {
+			return err
+		}
+		if attempt == statsRetries {
+			return ErrStatsPending
+		}
+
+		select {
+		case <-time.After(delay):
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+		delay *= 2
+	}
+}
@@ -0,0 +1,73 @@
+package github
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestGetStatsRetriesAccepted(t *testing.T) {
+	defer func(d time.Duration) { statsRetryDelay = d }(statsRetryDelay)
+	statsRetryDelay = time.Millisecond
+
+	tests := []struct {
+		name     string
+		accepted int32 // 202 responses before the data
+		status   int   // sent instead of the data when not 0
+		wantErr  error
+		requests int32
+	}{
+		{"ready", 0, 0, nil, 1},
+		{"computed after two polls", 2, 0, nil, 3},
+		{"still computing", 100, 0, ErrStatsPending, int32(statsRetries) + 1},
+		{"too many commits", 0, http.StatusUnprocessableEntity, ErrUnprocessable, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var requests atomic.Int32
+			client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+				switch {
+				case requests.Add(1) <= tt.accepted:
+					w.WriteHeader(http.StatusAccepted)
+					w.Write([]byte(`{}`))
+				case tt.status != 0:
+					w.WriteHeader(tt.status)
+				default:
+					w.Write([]byte(`[[1700000000, 10, -4]]`))
+				}
+			})
+
+			weeks, err := client.GetCodeFrequency(context.Background(), "o", "r")
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("err = %v, want %v", err, tt.wantErr)
+			}
+			if got := requests.Load(); got != tt.requests {
+				t.Errorf("%d requests, want %d", got, tt.requests)
+			}
+			if tt.wantErr != nil {
+				return
+			}
+			want := CodeFrequencyWeek{Start: time.Unix(1700000000, 0).UTC(), Additions: 10, Deletions: 4}
+			if len(weeks) != 1 || weeks[0] != want {
+				t.Errorf("weeks = %+v, want [%+v]", weeks, want)
+			}
+		})
+	}
+}
+
+func TestGetStatsHonorsContext(t *testing.T) {
+	defer func(d time.Duration) { statsRetryDelay = d }(statsRetryDelay)
+	statsRetryDelay = time.Hour
+
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusAccepted)
+	})
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	if _, err := client.GetParticipation(ctx, "o", "r"); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("err = %v, want context.DeadlineExceeded", err)
+	}
+}
@@ -0,0 +1,50 @@
+package github
+
+import (
+	"context"
+	"fmt"
+)
+
+// DefaultMaxTags caps how many tags are fetched for a repo
+const DefaultMaxTags = 300
+
+// Tag represents a git tag as listed by the tags API
+type Tag struct {
+	Name   string `json:"name"`
+	Commit struct {
+		SHA string `json:"sha"`
+	} `json:"commit"`
+}
+
+// GetTags fetches the repository's tags, up to max
+func (c *Client) GetTags(ctx context.Context, owner, repo string, max int) ([]Tag, error) {
+	var allTags []Tag
+
+	page := 1
+	perPage := 100
+
+	for {
+		url := fmt.Sprintf(
+			"%s/repos/%s/%s/tags?per_page=%d&page=%d",
+			c.baseURL, owner, repo, perPage, page,
+		)
+
+		var tags []Tag
+		if err := c.get(ctx, url, &tags); err != nil {
+			return allTags, err
+		}
+
+		allTags = append(allTags, tags...)
+
+		if max > 0 && len(allTags) >= max {
+			return allTags[:max], nil
+		}
+		if len(tags) < perPage {
+			break
+		}
+
+		page++
+	}
+
+	return allTags, nil
+}
@@ -0,0 +1,125 @@
+package github
+
+import (
+	"bufio"
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// TokenSource describes where the client's token came from
+type TokenSource string
+
+const (
+	TokenSourceNone  TokenSource = ""
+	TokenSourceFlag  TokenSource = "flag"
+	TokenSourceEnv   TokenSource = "GITHUB_TOKEN"
+	TokenSourceGHEnv TokenSource = "GH_TOKEN"
+	TokenSourceGHCLI TokenSource = "gh CLI"
+	// The per-host variables gh reads for GitHub Enterprise Server
+	TokenSourceEnterpriseEnv   TokenSource = "GH_ENTERPRISE_TOKEN"
+	TokenSourceGHEnterpriseEnv TokenSource = "GITHUB_ENTERPRISE_TOKEN"
+)
+
+// ResolveToken finds a token for host (e.g. "github.com"), checking in order:
+// the explicit value, GH_ENTERPRISE_TOKEN and GITHUB_ENTERPRISE_TOKEN for
+// hosts other than github.com, GITHUB_TOKEN, GH_TOKEN, then the gh CLI: its
+// hosts.yml, or `gh auth token` for logins kept in the system keyring.
+func ResolveToken(explicit, host string) (string, TokenSource) {
+	if explicit != "" {
+		return explicit, TokenSourceFlag
+	}
+	// As with gh, the enterprise variables come first for other hosts, so
+	// a github.com token isn't sent there when both are set
+	if host != "github.com" {
+		if t := os.Getenv("GH_ENTERPRISE_TOKEN"); t != "" {
+			return t, TokenSourceEnterpriseEnv
+		}
+		if t := os.Getenv("GITHUB_ENTERPRISE_TOKEN"); t != "" {
+			return t, TokenSourceGHEnterpriseEnv
+		}
+	}
+	if t := os.Getenv("GITHUB_TOKEN"); t != "" {
+		return t, TokenSourceEnv
+	}
+	if t := os.Getenv("GH_TOKEN"); t != "" {
+		return t, TokenSourceGHEnv
+	}
+	if t := ghCLIToken(ghHostsPath(), host); t != "" {
+		return t, TokenSourceGHCLI
+	}
+	if t := ghAuthToken(host); t != "" {
+		return t, TokenSourceGHCLI
+	}
+	return "", TokenSourceNone
+}
+
+// ghAuthTokenTimeout bounds how long `gh auth token` may take, e.g. while
+// the keyring is locked
+const ghAuthTokenTimeout = 5 * time.Second
+
+// ghAuthToken asks the gh CLI for host's token; a variable so tests can
+// stub it. No gh on the PATH, or no login, yields no token.
+var ghAuthToken = func(host string) string {
+	ctx, cancel := context.WithTimeout(context.Background(), ghAuthTokenTimeout)
+	defer cancel()
+	out, err := exec.CommandContext(ctx, "gh", "auth", "token", "--hostname", host).Output()
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(out))
+}
+
+// ghHostsPath returns the location of the gh CLI's hosts.yml
+func ghHostsPath() string {
+	if dir := os.Getenv("GH_CONFIG_DIR"); dir != "" {
+		return filepath.Join(dir, "hosts.yml")
+	}
+	if dir := os.Getenv("XDG_CONFIG_HOME"); dir != "" {
+		return filepath.Join(dir, "gh", "hosts.yml")
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(home, ".config", "gh", "hosts.yml")
+}
+
+// ghCLIToken reads the oauth_token for host from a gh hosts.yml file. Only the
+// small subset of YAML gh writes is understood; anything unexpected (or a
+// missing file) yields no token rather than an error.
+func ghCLIToken(path, host string) string {
+	if path == "" {
+		return ""
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		return ""
+	}
+	defer f.Close()
+
+	inHost := false
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := scanner.Text()
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" || strings.HasPrefix(trimmed, "#") {
+			continue
+		}
+
+		// Unindented lines start a new host block
+		if line[0] != ' ' && line[0] != '\t' {
+			inHost = strings.TrimSuffix(trimmed, ":") == host
+			continue
+		}
+
+		if inHost && strings.HasPrefix(trimmed, "oauth_token:") {
+			token := strings.TrimSpace(strings.TrimPrefix(trimmed, "oauth_token:"))
+			return strings.Trim(token, `"'`)
+		}
+	}
+	return ""
+}
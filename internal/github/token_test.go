@@ -0,0 +1,158 @@
+package github
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+)
+
+const hostsYML = `github.com:
+    user: octocat
+    oauth_token: gho_hosts
+    git_protocol: https
+ghe.example:
+    oauth_token: "gho_ghe_hosts"
+`
+
+func TestResolveToken(t *testing.T) {
+	tests := []struct {
+		name       string
+		explicit   string
+		host       string
+		env        map[string]string
+		hosts      string // hosts.yml contents; "" for none
+		ghCLI      string // what `gh auth token` prints
+		wantToken  string
+		wantSource TokenSource
+	}{
+		{
+			name: "flag wins", explicit: "flag", host: "github.com",
+			env:   map[string]string{"GITHUB_TOKEN": "env", "GH_TOKEN": "gh", "GH_ENTERPRISE_TOKEN": "ent"},
+			hosts: hostsYML, ghCLI: "cli",
+			wantToken: "flag", wantSource: TokenSourceFlag,
+		},
+		{
+			name: "GITHUB_TOKEN before GH_TOKEN", host: "github.com",
+			env:   map[string]string{"GITHUB_TOKEN": "env", "GH_TOKEN": "gh"},
+			hosts: hostsYML, ghCLI: "cli",
+			wantToken: "env", wantSource: TokenSourceEnv,
+		},
+		{
+			name: "GH_TOKEN", host: "github.com",
+			env:   map[string]string{"GH_TOKEN": "gh"},
+			hosts: hostsYML, ghCLI: "cli",
+			wantToken: "gh", wantSource: TokenSourceGHEnv,
+		},
+		{
+			name: "enterprise variables before the github.com ones", host: "ghe.example",
+			env:       map[string]string{"GITHUB_TOKEN": "env", "GH_TOKEN": "gh", "GH_ENTERPRISE_TOKEN": "ent", "GITHUB_ENTERPRISE_TOKEN": "ent2"},
+			wantToken: "ent", wantSource: TokenSourceEnterpriseEnv,
+		},
+		{
+			name: "GITHUB_ENTERPRISE_TOKEN before GITHUB_TOKEN", host: "ghe.example",
+			env:       map[string]string{"GITHUB_TOKEN": "env", "GITHUB_ENTERPRISE_TOKEN": "ent2"},
+			wantToken: "ent2", wantSource: TokenSourceGHEnterpriseEnv,
+		},
+		{
+			name: "GITHUB_TOKEN for an Enterprise host without enterprise variables", host: "ghe.example",
+			env:       map[string]string{"GITHUB_TOKEN": "env"},
+			hosts:     hostsYML,
+			wantToken: "env", wantSource: TokenSourceEnv,
+		},
+		{
+			name: "GH_ENTERPRISE_TOKEN for an Enterprise host", host: "ghe.example",
+			env:   map[string]string{"GH_ENTERPRISE_TOKEN": "ent", "GITHUB_ENTERPRISE_TOKEN": "ent2"},
+			hosts: hostsYML, ghCLI: "cli",
+			wantToken: "ent", wantSource: TokenSourceEnterpriseEnv,
+		},
+		{
+			name: "GITHUB_ENTERPRISE_TOKEN", host: "ghe.example",
+			env:       map[string]string{"GITHUB_ENTERPRISE_TOKEN": "ent2"},
+			hosts:     hostsYML,
+			wantToken: "ent2", wantSource: TokenSourceGHEnterpriseEnv,
+		},
+		{
+			name: "enterprise variables ignored for github.com", host: "github.com",
+			env:       map[string]string{"GH_ENTERPRISE_TOKEN": "ent"},
+			ghCLI:     "cli",
+			wantToken: "cli", wantSource: TokenSourceGHCLI,
+		},
+		{
+			name: "hosts.yml", host: "github.com",
+			hosts: hostsYML, ghCLI: "cli",
+			wantToken: "gho_hosts", wantSource: TokenSourceGHCLI,
+		},
+		{
+			name: "hosts.yml for the host asked about", host: "ghe.example",
+			hosts:     hostsYML,
+			wantToken: "gho_ghe_hosts", wantSource: TokenSourceGHCLI,
+		},
+		{
+			name: "gh auth token when hosts.yml has none", host: "github.com",
+			hosts:     "github.com:\n    user: octocat\n",
+			ghCLI:     "cli",
+			wantToken: "cli", wantSource: TokenSourceGHCLI,
+		},
+		{
+			name: "malformed hosts.yml is skipped", host: "github.com",
+			hosts:     ":\n\t- [oauth_token\n  oauth_token:",
+			ghCLI:     "cli",
+			wantToken: "cli", wantSource: TokenSourceGHCLI,
+		},
+		{
+			name: "nothing", host: "github.com",
+			wantToken: "", wantSource: TokenSourceNone,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, name := range []string{"GITHUB_TOKEN", "GH_TOKEN", "GH_ENTERPRISE_TOKEN", "GITHUB_ENTERPRISE_TOKEN"} {
+				t.Setenv(name, tt.env[name])
+			}
+			dir := t.TempDir()
+			t.Setenv("GH_CONFIG_DIR", dir)
+			if tt.hosts != "" {
+				if err := os.WriteFile(filepath.Join(dir, "hosts.yml"), []byte(tt.hosts), 0o600); err != nil {
+					t.Fatal(err)
+				}
+			}
+			defer func(f func(string) string) { ghAuthToken = f }(ghAuthToken)
+			ghAuthToken = func(host string) string {
+				if host != tt.host {
+					t.Errorf("gh auth token asked for %q, want %q", host, tt.host)
+				}
+				return tt.ghCLI
+			}
+
+			token, source := ResolveToken(tt.explicit, tt.host)
+			if token != tt.wantToken || source != tt.wantSource {
+				t.Errorf("ResolveToken = %q, %q, want %q, %q", token, source, tt.wantToken, tt.wantSource)
+			}
+		})
+	}
+}
+
+func TestGHAuthTokenRunsGH(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("stubs gh with a shell script")
+	}
+	dir := t.TempDir()
+	script := "#!/bin/sh\n[ \"$*\" = \"auth token --hostname ghe.example\" ] && echo gho_from_gh\n"
+	if err := os.WriteFile(filepath.Join(dir, "gh"), []byte(script), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("PATH", dir)
+
+	if got := ghAuthToken("ghe.example"); got != "gho_from_gh" {
+		t.Errorf("ghAuthToken = %q, want the token gh printed", got)
+	}
+	if got := ghAuthToken("github.com"); got != "" {
+		t.Errorf("ghAuthToken when gh fails = %q, want none", got)
+	}
+
+	t.Setenv("PATH", t.TempDir())
+	if got := ghAuthToken("github.com"); got != "" {
+		t.Errorf("ghAuthToken without gh = %q, want none", got)
+	}
+}
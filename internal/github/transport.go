@@ -0,0 +1,83 @@
+package github
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// DefaultTimeout bounds each request made with the default http.Client
+const DefaultTimeout = 30 * time.Second
+
+// DefaultUserAgent is sent with every request unless WithUserAgent is given
+const DefaultUserAgent = "Repo-lyzer"
+
+// newHTTPClient returns the default http.Client: a 30s timeout and a
+// transport honoring HTTPS_PROXY/HTTP_PROXY/NO_PROXY
+func newHTTPClient() *http.Client {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = http.ProxyFromEnvironment
+	return &http.Client{Timeout: DefaultTimeout, Transport: transport}
+}
+
+// transport returns the client's *http.Transport for options to adjust,
+// cloning it first so shared transports (like http.DefaultTransport) are
+// never modified. Custom RoundTrippers from WithHTTPClient are replaced.
+func (c *Client) transport() *http.Transport {
+	hc := *c.http
+	if t, ok := hc.Transport.(*http.Transport); ok {
+		hc.Transport = t.Clone()
+	} else {
+		hc.Transport = http.DefaultTransport.(*http.Transport).Clone()
+	}
+	c.http = &hc
+	return hc.Transport.(*http.Transport)
+}
+
+// WithProxy sends requests through the proxy at proxyURL (which may carry
+// user:password credentials) instead of the one from the environment
+func WithProxy(proxyURL *url.URL) Option {
+	return func(c *Client) {
+		c.transport().Proxy = http.ProxyURL(proxyURL)
+	}
+}
+
+// WithRootCAs trusts the certificates in pool, e.g. a corporate CA
+func WithRootCAs(pool *x509.CertPool) Option {
+	return func(c *Client) {
+		t := c.transport()
+		if t.TLSClientConfig == nil {
+			t.TLSClientConfig = &tls.Config{}
+		}
+		t.TLSClientConfig.RootCAs = pool
+	}
+}
+
+// WithTimeout replaces the per-request timeout; 0 means no timeout
+func WithTimeout(timeout time.Duration) Option {
+	return func(c *Client) {
+		hc := *c.http
+		hc.Timeout = timeout
+		c.http = &hc
+	}
+}
+
+// WithUserAgent replaces the User-Agent header sent with every request
+func WithUserAgent(userAgent string) Option {
+	return func(c *Client) {
+		c.userAgent = userAgent
+	}
+}
+
+// HTTPClient returns the http.Client requests are sent with, so clients for
+// other hosts can share its proxy, CA and timeout settings
+func (c *Client) HTTPClient() *http.Client {
+	return c.http
+}
+
+// UserAgent returns the User-Agent header sent with every request
+func (c *Client) UserAgent() string {
+	return c.userAgent
+}
@@ -1,5 +1,7 @@
 package github
 
+import "context"
+
 type TreeEntry struct {
 	Path string `json:"path"`
 	Mode string `json:"mode"`
@@ -15,9 +17,9 @@ type TreeResponse struct {
 	Truncated bool        `json:"truncated"`
 }
 
-func (c *Client) GetFileTree(owner, repo, branch string) ([]TreeEntry, error) {
+func (c *Client) GetFileTree(ctx context.Context, owner, repo, branch string) ([]TreeEntry, error) {
 	var t TreeResponse
 	// recursive=1 to get full tree
-	err := c.get("https://api.github.com/repos/"+owner+"/"+repo+"/git/trees/"+branch+"?recursive=1", &t)
+	err := c.get(ctx, c.baseURL+"/repos/"+owner+"/"+repo+"/git/trees/"+branch+"?recursive=1", &t)
 	return t.Tree, err
 }
@@ -0,0 +1,64 @@
+package github
+
+import (
+	"context"
+	"sync"
+)
+
+// DefaultEnrichContributors is how many top contributors get profile details
+const DefaultEnrichContributors = 10
+
+// enrichWorkers bounds the concurrent profile requests
+const enrichWorkers = 4
+
+// User is a GitHub account profile
+type User struct {
+	Login   string `json:"login"`
+	Name    string `json:"name"`
+	Company string `json:"company"`
+	Type    string `json:"type"`
+}
+
+func (c *Client) GetUser(ctx context.Context, login string) (*User, error) {
+	var u User
+	err := c.get(ctx, c.baseURL+"/users/"+login, &u)
+	return &u, err
+}
+
+// EnrichContributors fills in the profile details of the first top
+// contributors in place. Profiles that can't be fetched are left as they
+// are, since the details are optional.
+func (c *Client) EnrichContributors(ctx context.Context, contributors []Contributor, top int) {
+	if top > len(contributors) {
+		top = len(contributors)
+	}
+
+	indexes := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < enrichWorkers && w < top; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				user, err := c.GetUser(ctx, contributors[i].Login)
+				if err != nil {
+					continue
+				}
+				contributors[i].Name = user.Name
+				contributors[i].Company = user.Company
+				if user.Type != "" {
+					contributors[i].Type = user.Type
+				}
+			}
+		}()
+	}
+
+	for i := 0; i < top && ctx.Err() == nil; i++ {
+		if contributors[i].IsBot() {
+			continue // bots have no meaningful profile
+		}
+		indexes <- i
+	}
+	close(indexes)
+	wg.Wait()
+}
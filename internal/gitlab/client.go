@@ -0,0 +1,111 @@
+// Package gitlab is a minimal GitLab REST client that maps projects onto the
+// github package's types, so the analyzers work on either host.
+package gitlab
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+
+	"github.com/agnivo988/Repo-lyzer/internal/github"
+)
+
+// DefaultBaseURL is the API root for gitlab.com
+const DefaultBaseURL = "https://gitlab.com/api/v4"
+
+type Client struct {
+	http      *http.Client
+	token     string
+	baseURL   string
+	userAgent string
+}
+
+// Option configures a Client
+type Option func(*Client)
+
+// WithHTTPClient replaces the underlying http.Client, e.g. to share the
+// GitHub client's proxy, CA and timeout settings
+func WithHTTPClient(hc *http.Client) Option {
+	return func(c *Client) {
+		c.http = hc
+	}
+}
+
+// WithUserAgent replaces the User-Agent header sent with every request
+func WithUserAgent(userAgent string) Option {
+	return func(c *Client) {
+		c.userAgent = userAgent
+	}
+}
+
+// NewClient creates a client for the GitLab instance at host, e.g.
+// "gitlab.com" or "https://gitlab.example.com". The token is read from
+// GITLAB_TOKEN; public projects work without one.
+func NewClient(host string, opts ...Option) *Client {
+	base := DefaultBaseURL
+	if host != "" && host != "gitlab.com" {
+		if !strings.Contains(host, "://") {
+			host = "https://" + host
+		}
+		base = strings.TrimSuffix(host, "/") + "/api/v4"
+	}
+	c := &Client{
+		http:      &http.Client{Timeout: github.DefaultTimeout},
+		token:     os.Getenv("GITLAB_TOKEN"),
+		baseURL:   base,
+		userAgent: github.DefaultUserAgent,
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// errNotFound is returned for 404 responses, matching github.ErrNotFound so
+// callers can handle either host alike
+var errNotFound error = notFoundError{}
+
+type notFoundError struct{}
+
+func (notFoundError) Error() string {
+	return "GitLab API error: 404 Not Found (tip: set GITLAB_TOKEN env variable)"
+}
+
+func (notFoundError) Is(target error) bool {
+	return target == github.ErrNotFound
+}
+
+// projectURL returns the API URL of a project, addressed by its URL-encoded
+// full path (owner may include subgroups)
+func (c *Client) projectURL(owner, repo string) string {
+	return c.baseURL + "/projects/" + url.PathEscape(owner+"/"+repo)
+}
+
+func (c *Client) get(ctx context.Context, url string, target interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("User-Agent", c.userAgent)
+	if c.token != "" {
+		req.Header.Set("PRIVATE-TOKEN", c.token)
+	}
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return errNotFound
+	}
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("GitLab API error: %s (tip: set GITLAB_TOKEN env variable)", resp.Status)
+	}
+	return json.NewDecoder(resp.Body).Decode(target)
+}
@@ -0,0 +1,231 @@
+package gitlab
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/agnivo988/Repo-lyzer/internal/github"
+)
+
+// newTestClient returns a client for an httptest server running handler
+func newTestClient(t *testing.T, handler http.HandlerFunc, opts ...Option) *Client {
+	t.Helper()
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+	t.Setenv("GITLAB_TOKEN", "test")
+	return NewClient(server.URL, append([]Option{WithHTTPClient(server.Client())}, opts...)...)
+}
+
+// paged serves n items made by item, perPage at a time as GitLab pages them
+func paged(t *testing.T, w http.ResponseWriter, r *http.Request, n int, item func(i int) interface{}) {
+	t.Helper()
+	page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+	perPage, _ := strconv.Atoi(r.URL.Query().Get("per_page"))
+	if page < 1 || perPage < 1 {
+		t.Errorf("%s isn't paginated", r.URL)
+	}
+	items := []interface{}{}
+	for i := (page - 1) * perPage; i < page*perPage && i < n; i++ {
+		items = append(items, item(i))
+	}
+	json.NewEncoder(w).Encode(items)
+}
+
+func TestGetRepo(t *testing.T) {
+	var path, userAgent, token string
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		path = r.URL.EscapedPath()
+		userAgent = r.Header.Get("User-Agent")
+		token = r.Header.Get("PRIVATE-TOKEN")
+		w.Write([]byte(`{
+			"name": "project",
+			"path_with_namespace": "group/sub/project",
+			"description": "A project",
+			"star_count": 12,
+			"forks_count": 3,
+			"open_issues_count": 4,
+			"created_at": "2020-01-02T03:04:05Z",
+			"last_activity_at": "2024-05-06T07:08:09Z",
+			"default_branch": "main",
+			"web_url": "https://gitlab.com/group/sub/project",
+			"http_url_to_repo": "https://gitlab.com/group/sub/project.git",
+			"archived": true,
+			"visibility": "internal",
+			"issues_enabled": true,
+			"topics": ["go"],
+			"forked_from_project": {"id": 1},
+			"license": {"key": "mit", "name": "MIT License"}
+		}`))
+	}, WithUserAgent("acme-audit/1.0"))
+
+	repo, err := client.GetRepo(context.Background(), "group/sub", "project")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "/api/v4/projects/group%2Fsub%2Fproject"; path != want {
+		t.Errorf("path = %s, want %s", path, want)
+	}
+	if userAgent != "acme-audit/1.0" || token != "test" {
+		t.Errorf("User-Agent = %q, PRIVATE-TOKEN = %q", userAgent, token)
+	}
+
+	activity := time.Date(2024, 5, 6, 7, 8, 9, 0, time.UTC)
+	if repo.Name != "project" || repo.FullName != "group/sub/project" || repo.Description != "A project" {
+		t.Errorf("names = %q %q %q", repo.Name, repo.FullName, repo.Description)
+	}
+	if repo.Stars != 12 || repo.Forks != 3 || repo.OpenIssues != 4 {
+		t.Errorf("counts = %d stars, %d forks, %d issues", repo.Stars, repo.Forks, repo.OpenIssues)
+	}
+	if !repo.CreatedAt.Equal(time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)) || !repo.UpdatedAt.Equal(activity) || !repo.PushedAt.Equal(activity) {
+		t.Errorf("dates = %v %v %v", repo.CreatedAt, repo.UpdatedAt, repo.PushedAt)
+	}
+	if !repo.Fork || !repo.Archived || !repo.HasIssues || !repo.Private {
+		t.Errorf("flags = fork %t, archived %t, issues %t, private %t", repo.Fork, repo.Archived, repo.HasIssues, repo.Private)
+	}
+	if repo.DefaultBranch != "main" || repo.CloneURL != "https://gitlab.com/group/sub/project.git" || len(repo.Topics) != 1 {
+		t.Errorf("repo = %+v", repo)
+	}
+	if repo.License == nil || repo.License.Key != "mit" || repo.License.SPDXID != "" {
+		t.Errorf("License = %+v, want mit without an SPDX ID", repo.License)
+	}
+}
+
+func TestGetRepoNotFound(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	})
+	if _, err := client.GetRepo(context.Background(), "o", "missing"); !errors.Is(err, github.ErrNotFound) {
+		t.Errorf("err = %v, want github.ErrNotFound", err)
+	}
+}
+
+func TestGetCommits(t *testing.T) {
+	authored := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	commit := func(i int) interface{} {
+		return map[string]interface{}{
+			"id":            fmt.Sprintf("sha%d", i),
+			"author_name":   "Ann",
+			"author_email":  "ann@example.com",
+			"authored_date": authored,
+			"message":       "Fix things\n\nDetails",
+			"parent_ids":    []string{"p1", "p2"},
+		}
+	}
+	tests := []struct {
+		name      string
+		total     int
+		max       int
+		want      int
+		truncated bool
+	}{
+		{"all pages", 130, 0, 130, false},
+		{"within max", 130, 500, 130, false},
+		{"cut at max", 130, 120, 120, true},
+		{"max at a full page", 230, 100, 100, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var query url.Values
+			client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+				query = r.URL.Query()
+				paged(t, w, r, tt.total, commit)
+			})
+
+			since := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+			commits, truncated, err := client.GetCommits(context.Background(), "o", "r",
+				github.CommitOptions{Since: since, Ref: "feature/x", Max: tt.max})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(commits) != tt.want || truncated != tt.truncated {
+				t.Fatalf("got %d commits (truncated %t), want %d (%t)", len(commits), truncated, tt.want, tt.truncated)
+			}
+			if query.Get("since") != "2024-01-01T00:00:00Z" || query.Get("ref_name") != "feature/x" {
+				t.Errorf("query = %v, want the window and ref", query)
+			}
+
+			c := commits[len(commits)-1]
+			if c.SHA != fmt.Sprintf("sha%d", tt.want-1) || c.Commit.Author.Name != "Ann" ||
+				c.Commit.Author.Email != "ann@example.com" || !c.Commit.Author.Date.Equal(authored) ||
+				c.Commit.Message != "Fix things\n\nDetails" {
+				t.Errorf("commit = %+v", c)
+			}
+			if len(c.Parents) != 2 || c.Parents[1].SHA != "p2" {
+				t.Errorf("parents = %+v", c.Parents)
+			}
+			if c.Author == nil || c.Author.Login != "Ann" {
+				t.Errorf("Author = %+v, want the author's name as login", c.Author)
+			}
+		})
+	}
+}
+
+func TestGetContributors(t *testing.T) {
+	tests := []struct {
+		name      string
+		total     int
+		max       int
+		want      int
+		truncated bool
+	}{
+		{"all pages", 150, 0, 150, false},
+		{"cut at max", 150, 120, 120, true},
+		{"fewer than max", 50, 100, 50, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Query().Get("order_by") != "commits" {
+					t.Errorf("query = %s, want contributors ordered by commits", r.URL.RawQuery)
+				}
+				paged(t, w, r, tt.total, func(i int) interface{} {
+					return map[string]interface{}{"name": fmt.Sprintf("dev%d", i), "commits": 1000 - i}
+				})
+			})
+
+			contributors, truncated, err := client.GetContributors(context.Background(), "o", "r", tt.max)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(contributors) != tt.want || truncated != tt.truncated {
+				t.Fatalf("got %d contributors (truncated %t), want %d (%t)", len(contributors), truncated, tt.want, tt.truncated)
+			}
+			if c := contributors[0]; c.Login != "dev0" || c.Commits != 1000 {
+				t.Errorf("first contributor = %+v", c)
+			}
+		})
+	}
+}
+
+func TestGetFileTree(t *testing.T) {
+	var ref string
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		ref = r.URL.Query().Get("ref")
+		paged(t, w, r, 250, func(i int) interface{} {
+			return map[string]string{"id": fmt.Sprintf("blob%d", i), "type": "blob", "path": fmt.Sprintf("dir/f%d.go", i), "mode": "100644"}
+		})
+	})
+
+	entries, err := client.GetFileTree(context.Background(), "o", "r", "release/1.x")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ref != "release/1.x" {
+		t.Errorf("ref = %q, want the branch", ref)
+	}
+	if len(entries) != 250 {
+		t.Fatalf("got %d entries, want all 250 across pages", len(entries))
+	}
+	want := github.TreeEntry{Path: "dir/f249.go", Mode: "100644", Type: "blob", Sha: "blob249"}
+	if entries[249] != want {
+		t.Errorf("entry = %+v, want %+v", entries[249], want)
+	}
+}
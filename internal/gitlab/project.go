@@ -0,0 +1,124 @@
+package gitlab
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/agnivo988/Repo-lyzer/internal/github"
+)
+
+type project struct {
+	Name              string    `json:"name"`
+	PathWithNamespace string    `json:"path_with_namespace"`
+	Description       string    `json:"description"`
+	StarCount         int       `json:"star_count"`
+	ForksCount        int       `json:"forks_count"`
+	OpenIssuesCount   int       `json:"open_issues_count"`
+	CreatedAt         time.Time `json:"created_at"`
+	LastActivityAt    time.Time `json:"last_activity_at"`
+	DefaultBranch     string    `json:"default_branch"`
+	WebURL            string    `json:"web_url"`
+	HTTPURLToRepo     string    `json:"http_url_to_repo"`
+	Archived          bool      `json:"archived"`
+	Visibility        string    `json:"visibility"`
+	IssuesEnabled     bool      `json:"issues_enabled"`
+	Topics            []string  `json:"topics"`
+	ForkedFrom        *struct{} `json:"forked_from_project"`
+	License           *struct {
+		Key  string `json:"key"`
+		Name string `json:"name"`
+	} `json:"license"`
+}
+
+// GetRepo fetches a project, mapped onto github.Repo
+func (c *Client) GetRepo(ctx context.Context, owner, repo string) (*github.Repo, error) {
+	var p project
+	if err := c.get(ctx, c.projectURL(owner, repo)+"?license=true", &p); err != nil {
+		return nil, err
+	}
+
+	r := &github.Repo{
+		Name:          p.Name,
+		FullName:      p.PathWithNamespace,
+		Description:   p.Description,
+		Stars:         p.StarCount,
+		Forks:         p.ForksCount,
+		OpenIssues:    p.OpenIssuesCount,
+		CreatedAt:     p.CreatedAt,
+		UpdatedAt:     p.LastActivityAt,
+		PushedAt:      p.LastActivityAt,
+		Fork:          p.ForkedFrom != nil,
+		Archived:      p.Archived,
+		HasIssues:     p.IssuesEnabled,
+		Private:       p.Visibility != "public",
+		DefaultBranch: p.DefaultBranch,
+		HTMLURL:       p.WebURL,
+		CloneURL:      p.HTTPURLToRepo,
+		Topics:        p.Topics,
+	}
+	if p.License != nil {
+		// GitLab doesn't report SPDX identifiers
+		r.License = &github.License{Key: p.License.Key, Name: p.License.Name}
+	}
+	return r, nil
+}
+
+// GetLanguages returns the project's languages. GitLab only reports
+// percentages, so they're scaled to pseudo byte counts (100 per percent)
+// that keep the same proportions.
+func (c *Client) GetLanguages(ctx context.Context, owner, repo string) (map[string]int, error) {
+	var percentages map[string]float64
+	if err := c.get(ctx, c.projectURL(owner, repo)+"/languages", &percentages); err != nil {
+		return nil, err
+	}
+	langs := make(map[string]int, len(percentages))
+	for name, pct := range percentages {
+		langs[name] = int(pct * 100)
+	}
+	return langs, nil
+}
+
+// GetContributors fetches contributors ordered by commit count. GitLab
+// identifies them by name rather than username.
+func (c *Client) GetContributors(ctx context.Context, owner, repo string, max int) ([]github.Contributor, bool, error) {
+	var allContributors []github.Contributor
+
+	page := 1
+	perPage := 100
+
+	for {
+		url := fmt.Sprintf(
+			"%s/repository/contributors?order_by=commits&sort=desc&per_page=%d&page=%d",
+			c.projectURL(owner, repo), perPage, page,
+		)
+
+		var contributors []struct {
+			Name    string `json:"name"`
+			Commits int    `json:"commits"`
+		}
+		if err := c.get(ctx, url, &contributors); err != nil {
+			return nil, false, err
+		}
+
+		for _, contributor := range contributors {
+			allContributors = append(allContributors, github.Contributor{Login: contributor.Name, Commits: contributor.Commits})
+		}
+
+		if max > 0 && len(allContributors) >= max {
+			truncated := len(allContributors) > max || len(contributors) == perPage
+			if len(allContributors) > max {
+				allContributors = allContributors[:max]
+			}
+			return allContributors, truncated, nil
+		}
+
+		if len(contributors) < perPage {
+			break
+		}
+
+		page++
+	}
+
+	return allContributors, false, nil
+}
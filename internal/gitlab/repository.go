@@ -0,0 +1,168 @@
+package gitlab
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/agnivo988/Repo-lyzer/internal/github"
+)
+
+// MaxTreeEntries caps how much of a repository tree is listed
+const MaxTreeEntries = 10000
+
+// GetCommits fetches commits on opts.Ref (the default branch when empty)
+// within the window in opts
+func (c *Client) GetCommits(ctx context.Context, owner, repo string, opts github.CommitOptions) ([]github.Commit, bool, error) {
+	var allCommits []github.Commit
+
+	page := 1
+	perPage := 100
+
+	for {
+		endpoint := fmt.Sprintf(
+			"%s/repository/commits?per_page=%d&page=%d",
+			c.projectURL(owner, repo), perPage, page,
+		)
+		if !opts.Since.IsZero() {
+			endpoint += "&since=" + opts.Since.UTC().Format(time.RFC3339)
+		}
+		if !opts.Until.IsZero() {
+			endpoint += "&until=" + opts.Until.UTC().Format(time.RFC3339)
+		}
+		if opts.Ref != "" {
+			endpoint += "&ref_name=" + url.QueryEscape(opts.Ref)
+		}
+
+		var commits []struct {
+			ID           string    `json:"id"`
+			AuthorName   string    `json:"author_name"`
+			AuthorEmail  string    `json:"author_email"`
+			AuthoredDate time.Time `json:"authored_date"`
+			Message      string    `json:"message"`
+			ParentIDs    []string  `json:"parent_ids"`
+		}
+		if err := c.get(ctx, endpoint, &commits); err != nil {
+			return allCommits, false, err
+		}
+
+		for _, gc := range commits {
+			var commit github.Commit
+			commit.SHA = gc.ID
+			commit.Commit.Author.Name = gc.AuthorName
+			commit.Commit.Author.Email = gc.AuthorEmail
+			commit.Commit.Author.Date = gc.AuthoredDate
+			commit.Commit.Message = gc.Message
+			for _, id := range gc.ParentIDs {
+				commit.Parents = append(commit.Parents, github.CommitParent{SHA: id})
+			}
+			// Contributors are identified by name on GitLab, so match that
+			commit.Author = &github.CommitAuthor{Login: gc.AuthorName}
+			allCommits = append(allCommits, commit)
+		}
+
+		if opts.Max > 0 && len(allCommits) >= opts.Max {
+			truncated := len(allCommits) > opts.Max || len(commits) == perPage
+			if len(allCommits) > opts.Max {
+				allCommits = allCommits[:opts.Max]
+			}
+			return allCommits, truncated, nil
+		}
+
+		if len(commits) < perPage {
+			break
+		}
+
+		page++
+	}
+
+	return allCommits, false, nil
+}
+
+// GetFileTree lists the whole repository tree at branch, up to MaxTreeEntries
+func (c *Client) GetFileTree(ctx context.Context, owner, repo, branch string) ([]github.TreeEntry, error) {
+	var allEntries []github.TreeEntry
+
+	page := 1
+	perPage := 100
+
+	for len(allEntries) < MaxTreeEntries {
+		endpoint := fmt.Sprintf(
+			"%s/repository/tree?recursive=true&ref=%s&per_page=%d&page=%d",
+			c.projectURL(owner, repo), url.QueryEscape(branch), perPage, page,
+		)
+
+		var entries []struct {
+			ID   string `json:"id"`
+			Type string `json:"type"`
+			Path string `json:"path"`
+			Mode string `json:"mode"`
+		}
+		if err := c.get(ctx, endpoint, &entries); err != nil {
+			return allEntries, err
+		}
+
+		for _, e := range entries {
+			allEntries = append(allEntries, github.TreeEntry{Path: e.Path, Mode: e.Mode, Type: e.Type, Sha: e.ID})
+		}
+
+		if len(entries) < perPage {
+			break
+		}
+
+		page++
+	}
+
+	return allEntries, nil
+}
+
+// GetFileContent fetches a file at ref (the default branch when empty).
+// Content is returned base64 encoded, as with GitHub.
+func (c *Client) GetFileContent(ctx context.Context, owner, repo, path, ref string) (*github.FileContent, error) {
+	if ref == "" {
+		ref = "HEAD"
+	}
+
+	var f struct {
+		FileName string `json:"file_name"`
+		FilePath string `json:"file_path"`
+		Size     int    `json:"size"`
+		Encoding string `json:"encoding"`
+		Content  string `json:"content"`
+		BlobID   string `json:"blob_id"`
+	}
+	endpoint := fmt.Sprintf(
+		"%s/repository/files/%s?ref=%s",
+		c.projectURL(owner, repo), url.PathEscape(path), url.QueryEscape(ref),
+	)
+	if err := c.get(ctx, endpoint, &f); err != nil {
+		return nil, err
+	}
+
+	return &github.FileContent{
+		Name:     f.FileName,
+		Path:     f.FilePath,
+		Sha:      f.BlobID,
+		Size:     f.Size,
+		Type:     "file",
+		Encoding: f.Encoding,
+		Content:  f.Content,
+	}, nil
+}
+
+// ResolveRef returns the commit SHA a branch, tag or SHA points to, or a
+// *github.RefNotFoundError if the project has no such ref
+func (c *Client) ResolveRef(ctx context.Context, owner, repo, ref string) (string, error) {
+	var commit struct {
+		ID string `json:"id"`
+	}
+	endpoint := c.projectURL(owner, repo) + "/repository/commits/" + url.PathEscape(ref)
+	if err := c.get(ctx, endpoint, &commit); errors.Is(err, errNotFound) {
+		return "", &github.RefNotFoundError{Ref: ref, Repo: owner + "/" + repo}
+	} else if err != nil {
+		return "", err
+	}
+	return commit.ID, nil
+}
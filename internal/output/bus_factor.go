@@ -0,0 +1,16 @@
+package output
+
+import (
+	"fmt"
+
+	"github.com/agnivo988/Repo-lyzer/internal/analyzer"
+)
+
+func PrintBusFactor(info analyzer.BusFactorInfo, inequality analyzer.ContributionInequality) {
+	fmt.Println(SectionStyle.Render("\n🚌 Bus Factor"))
+	fmt.Printf("%s - %s\n", info.Label(), info.Risk)
+	if info.LowerBound {
+		fmt.Println("(contributor list truncated, so these are lower bounds)")
+	}
+	fmt.Println("Work spread: Gini", inequality.Summary())
+}
@@ -0,0 +1,19 @@
+package output
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/agnivo988/Repo-lyzer/internal/analyzer"
+)
+
+func PrintCI(ci analyzer.CIInfo) {
+	fmt.Println(SectionStyle.Render("\n⚙️ CI"))
+	fmt.Println(ci.Summary())
+	if workflows := ci.WorkflowFiles(); len(workflows) > 0 {
+		fmt.Println("  Workflow files:", strings.Join(workflows, ", "))
+	}
+	for _, w := range ci.Workflows {
+		fmt.Printf("  %s: %s\n", w.Name, w.Label())
+	}
+}
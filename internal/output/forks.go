@@ -0,0 +1,19 @@
+package output
+
+import (
+	"fmt"
+
+	"github.com/agnivo988/Repo-lyzer/internal/analyzer"
+	"github.com/agnivo988/Repo-lyzer/internal/github"
+)
+
+func PrintActiveForks(repo *github.Repo, forks []analyzer.ForkCandidate) {
+	fmt.Println(SectionStyle.Render("\n🍴 Possible Active Forks"))
+	fmt.Printf("No push since %s\n", repo.PushedAt.Format("2006-01-02"))
+	if len(forks) == 0 {
+		fmt.Println("No recently active forks found")
+	}
+	for _, fork := range forks {
+		fmt.Println("• " + fork.Summary())
+	}
+}
@@ -1,16 +1,17 @@
 package output
 
 import (
+	"context"
 	"fmt"
-	"os"
 
 	"github.com/charmbracelet/lipgloss"
+	"github.com/agnivo988/Repo-lyzer/internal/analyzer"
 	"github.com/agnivo988/Repo-lyzer/internal/github"
 )
 
 
 
-func PrintHealth(score int) {
+func PrintHealth(score int, weights analyzer.HealthWeights, components []analyzer.ScoreComponent) {
     color := "#FF5F5F"
 	label:= "🔴 Poor"
 
@@ -25,21 +26,26 @@ func PrintHealth(score int) {
 	 style := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color(color))
 
      fmt.Println(style.Render(
-		fmt.Sprintf("\n🏆 Repo Health Score : %d/100 (%s)\n",score,label),
+		fmt.Sprintf("\n🏆 Repo Health Score : %s %s", analyzer.ScoreWithGrade(score), label),
 	 ))
+	fmt.Printf("Weights: %s\n", weights)
+	for _, c := range components {
+		if !c.Evaluated {
+			fmt.Printf("  %-14s not evaluated\n", c.Name)
+			continue
+		}
+		fmt.Printf("  %-14s %5.1f / %-3d %s\n", c.Name, c.Contribution, c.Weight, c.Detail)
+	}
+	fmt.Println()
 }
-func PrintGitHubAPIStatus(client *github.Client) {
-	rateLimit, err := client.GetRateLimit()
+func PrintGitHubAPIStatus(ctx context.Context, client *github.Client) {
+	rateLimit, err := client.GetRateLimit(ctx)
 	if err != nil {
 		fmt.Println("⚠️ Unable to fetch GitHub API status")
 		return
 	}
 
-	mode := "Unauthenticated"
-if os.Getenv("GITHUB_TOKEN") != "" {
-	mode = "Authenticated"
-}
-
+	mode := client.AuthDescription()
 
 	style := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("#7AE7C7"))
 
@@ -0,0 +1,29 @@
+package output
+
+import (
+	"fmt"
+
+	"github.com/agnivo988/Repo-lyzer/internal/analyzer"
+)
+
+func PrintDirectoryOwners(owners []analyzer.DirectoryOwner) {
+	if len(owners) == 0 {
+		return
+	}
+	fmt.Println(SectionStyle.Render("\n🗂️ Directory Ownership"))
+	for _, d := range owners {
+		risk := ""
+		if d.Risky() {
+			risk = "  ⚠️ single owner"
+		}
+		fmt.Printf("  %-24s %-30s %4d changes%s\n", d.Directory, d.Summary(), d.Changes, risk)
+	}
+}
+
+func PrintHotspots(report analyzer.HotspotReport) {
+	fmt.Println(SectionStyle.Render("\n🔥 Hotspots"))
+	fmt.Println(report.Summary())
+	for _, h := range report.Hotspots {
+		fmt.Printf("  %4d  %3.0f%%  %s\n", h.Changes, h.Share*100, h.Path)
+	}
+}
@@ -0,0 +1,24 @@
+package output
+
+import (
+	"fmt"
+
+	"github.com/agnivo988/Repo-lyzer/internal/analyzer"
+)
+
+func PrintIssues(stats analyzer.IssueStats) {
+	fmt.Println(SectionStyle.Render("\n🐛 Issues"))
+	fmt.Println(stats.Summary())
+	if stats.Ages.Evaluated {
+		fmt.Println("Open issue ages:", stats.Ages.Summary())
+	}
+	fmt.Println("Responsiveness:", analyzer.RateIssueResponsiveness(stats).Summary())
+}
+
+func PrintPullRequests(stats analyzer.PullRequestStats) {
+	fmt.Println(SectionStyle.Render("\n🔀 Pull Requests"))
+	fmt.Println(stats.Summary())
+	if stats.Evaluated {
+		fmt.Printf("Merge ratio : %s\n", stats.MergeRatioLabel())
+	}
+}
@@ -4,22 +4,32 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/agnivo988/Repo-lyzer/internal/analyzer"
 	"github.com/charmbracelet/lipgloss"
 )
 
 func PrintLanguages(langs map[string]int) {
 
 	fmt.Println(SectionStyle.Render("\n⛳ Language Breakdown"))
+	printLanguageBars(langs)
+}
 
-	total := 0
-    for _,v := range langs {
-		total += v
+// PrintAdjustedLanguages prints the breakdown without generated and vendored code
+func PrintAdjustedLanguages(langs map[string]int) {
+	fmt.Println(SectionStyle.Render("\n⛳ Language Breakdown (excluding generated and vendored code)"))
+	printLanguageBars(langs)
+}
+
+func printLanguageBars(langs map[string]int) {
+	ranked := analyzer.RankLanguages(langs)
+	if len(ranked) == 0 {
+		fmt.Println("No language data available")
+		return
 	}
 
-	for lang,size := range langs {
-		percent := float64(size) / float64(total) * 100
-		bar := lipgloss.NewStyle().Foreground(lipgloss.Color("#7CFF00")).Render(strings.Repeat("🟩",int(percent/5)))
+	for _, lang := range analyzer.TopLanguages(ranked, analyzer.ListedLanguages) {
+		bar := lipgloss.NewStyle().Foreground(lipgloss.Color("#7CFF00")).Render(strings.Repeat("🟩", int(lang.Percent/5)))
 
-		fmt.Printf("%-10s %s %.1f%%\n",lang,bar,percent)
+		fmt.Printf("%-10s %s %.1f%%\n", lang.Name, bar, lang.Percent)
 	}
 }
\ No newline at end of file
@@ -0,0 +1,20 @@
+package output
+
+import (
+	"fmt"
+
+	"github.com/agnivo988/Repo-lyzer/internal/analyzer"
+)
+
+// PrintMaturity prints the maturity level and what each factor adds to it
+func PrintMaturity(maturity analyzer.Maturity) {
+	fmt.Println(SectionStyle.Render("\n🏗️ Maturity"))
+	fmt.Printf("%s, %s\n", maturity.Level, analyzer.ScoreWithGrade(maturity.Score))
+	for _, c := range maturity.Components {
+		if !c.Evaluated {
+			fmt.Printf("  %-10s %s\n", c.Name, c.Detail)
+			continue
+		}
+		fmt.Printf("  %-10s %5.1f / %-3d %s\n", c.Name, c.Contribution, c.Weight, c.Detail)
+	}
+}
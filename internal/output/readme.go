@@ -0,0 +1,23 @@
+package output
+
+import (
+	"fmt"
+
+	"github.com/agnivo988/Repo-lyzer/internal/analyzer"
+)
+
+// PrintReadme prints the README facts and its quality checklist
+func PrintReadme(readme analyzer.ReadmeInfo, quality analyzer.ReadmeQuality) {
+	fmt.Println(SectionStyle.Render("\n📖 README"))
+	fmt.Println(readme.Summary())
+	if !quality.Evaluated {
+		return
+	}
+	fmt.Println("Quality:", analyzer.ScoreWithGrade(quality.Score))
+	for _, c := range quality.Checks {
+		fmt.Printf("  %s %-20s %s\n", c.Mark(), c.Name, c.Detail)
+	}
+	for _, link := range quality.BrokenLinks {
+		fmt.Println("  broken link:", link)
+	}
+}
@@ -0,0 +1,12 @@
+package output
+
+import (
+	"fmt"
+
+	"github.com/agnivo988/Repo-lyzer/internal/analyzer"
+)
+
+func PrintReleases(stats analyzer.ReleaseStats) {
+	fmt.Println(SectionStyle.Render("\n🏷️ Releases"))
+	fmt.Println(stats.Summary())
+}
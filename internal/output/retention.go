@@ -0,0 +1,12 @@
+package output
+
+import (
+	"fmt"
+
+	"github.com/agnivo988/Repo-lyzer/internal/analyzer"
+)
+
+func PrintContributorTrend(trend analyzer.ContributorTrend) {
+	fmt.Println(SectionStyle.Render("\n📈 Contributor Trend"))
+	fmt.Println(trend.Summary())
+}
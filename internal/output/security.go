@@ -0,0 +1,42 @@
+package output
+
+import (
+	"fmt"
+
+	"github.com/agnivo988/Repo-lyzer/internal/analyzer"
+	"github.com/agnivo988/Repo-lyzer/internal/scorecard"
+)
+
+func PrintBranchProtection(info analyzer.BranchProtectionInfo) {
+	fmt.Println(SectionStyle.Render("\n🛡️ Branch Protection"))
+	fmt.Println(info.Summary())
+}
+
+func PrintSecurityScore(security analyzer.SecurityScore) {
+	fmt.Println(SectionStyle.Render("\n🔒 Security Posture"))
+	if !security.Evaluated() {
+		fmt.Println("Not evaluated")
+		return
+	}
+	fmt.Println("Score:", analyzer.ScoreWithGrade(security.Score))
+	for _, c := range security.Components {
+		if !c.Evaluated {
+			fmt.Printf("  %-18s %s\n", c.Name, c.Detail)
+			continue
+		}
+		fmt.Printf("  %-18s %5.1f  %s\n", c.Name, c.Contribution, c.Detail)
+	}
+}
+
+// PrintScorecard prints the OpenSSF Scorecard results, apart from our own
+// security posture
+func PrintScorecard(result *scorecard.Result) {
+	fmt.Println(SectionStyle.Render("\n🏅 OpenSSF Scorecard"))
+	fmt.Println(result.Summary())
+	if result == nil {
+		return
+	}
+	for _, c := range result.Checks {
+		fmt.Printf("  %-24s %-5s %s\n", c.Name, c.CheckScore(), c.Reason)
+	}
+}
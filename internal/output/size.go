@@ -0,0 +1,42 @@
+package output
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/agnivo988/Repo-lyzer/internal/analyzer"
+)
+
+// maxLargeFiles is how many of the largest files get listed
+const maxLargeFiles = 10
+
+func PrintMonorepo(mono analyzer.Monorepo) {
+	if !mono.Detected {
+		return
+	}
+	fmt.Println(SectionStyle.Render("\n🧩 Monorepo"))
+	fmt.Printf("%d packages (%s)\n", len(mono.Packages), strings.Join(mono.Signals, ", "))
+	for _, pkg := range mono.Packages {
+		deps := "?"
+		if pkg.Dependencies >= 0 {
+			deps = fmt.Sprint(pkg.Dependencies)
+		}
+		fmt.Printf("  %-30s %-10s %-12s %5d files  %s deps\n", pkg.Path, pkg.Ecosystem, pkg.Language, pkg.Files, deps)
+	}
+}
+
+func PrintRepoSize(size analyzer.RepoSize) {
+	fmt.Println(SectionStyle.Render("\n📦 Repo Size"))
+	fmt.Println(size.Summary())
+	for i, f := range size.LargeFiles {
+		if i == maxLargeFiles {
+			fmt.Printf("  … and %d more\n", len(size.LargeFiles)-i)
+			break
+		}
+		kind := ""
+		if f.Binary {
+			kind = " (binary)"
+		}
+		fmt.Printf("  %9s  %s%s\n", analyzer.FormatBytes(f.Bytes), f.Path, kind)
+	}
+}
@@ -0,0 +1,12 @@
+package output
+
+import (
+	"fmt"
+
+	"github.com/agnivo988/Repo-lyzer/internal/analyzer"
+)
+
+func PrintStarHistory(stars analyzer.StarHistory) {
+	fmt.Println(SectionStyle.Render("\n⭐ Star Growth"))
+	fmt.Println(stars.Summary())
+}
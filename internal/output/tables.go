@@ -3,7 +3,9 @@ package output
 import (
 	"fmt"
 	"os"
+	"strings"
 
+	"github.com/agnivo988/Repo-lyzer/internal/analyzer"
 	"github.com/agnivo988/Repo-lyzer/internal/github"
 
 	"github.com/olekukonko/tablewriter"
@@ -20,4 +22,9 @@ func PrintRepo(r *github.Repo) {
 	})
 
 	table.Render()
+
+	fmt.Printf("License: %s (%s)\n", analyzer.LicenseLabel(r), analyzer.ClassifyLicense(r).Class)
+	if len(r.Topics) > 0 {
+		fmt.Printf("Topics: %s\n", strings.Join(r.Topics, ", "))
+	}
 }
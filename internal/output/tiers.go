@@ -0,0 +1,12 @@
+package output
+
+import (
+	"fmt"
+
+	"github.com/agnivo988/Repo-lyzer/internal/analyzer"
+)
+
+func PrintContributorTiers(tiers analyzer.ContributorTiers) {
+	fmt.Println(SectionStyle.Render("\n👥 Contributor Tiers"))
+	fmt.Println(tiers.Summary())
+}
@@ -0,0 +1,280 @@
+// Package pdf writes simple text documents as PDF: wrapped paragraphs in
+// the standard Helvetica fonts and monospaced tables, on A4 pages that
+// break automatically. It needs no fonts or external tools, as the
+// standard fonts are built into every PDF reader; text outside their
+// Windows-1252 character set is drawn as "?".
+package pdf
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// Font is one of the standard fonts
+type Font int
+
+const (
+	Regular Font = iota
+	Bold
+	Mono
+	MonoBold
+)
+
+var fontNames = []string{"Helvetica", "Helvetica-Bold", "Courier", "Courier-Bold"}
+
+// Page geometry, in points
+const (
+	pageWidth    = 595.0 // A4
+	pageHeight   = 842.0
+	margin       = 50.0
+	footerHeight = 20.0
+	lineSpacing  = 1.3
+	// TableSize is the font size of tables
+	TableSize = 8.5
+)
+
+// ContentWidth is the width text is wrapped to
+const ContentWidth = pageWidth - 2*margin
+
+// monoAdvance is Courier's character width per point of font size
+const monoAdvance = 0.6
+
+// helveticaWidths are Helvetica's character widths from space to "~", in
+// thousandths of the font size
+var helveticaWidths = []int{
+	278, 278, 355, 556, 556, 889, 667, 191, 333, 333, 389, 584, 278, 333, 278, 278,
+	556, 556, 556, 556, 556, 556, 556, 556, 556, 556, 278, 278, 584, 584, 584, 556,
+	1015, 667, 667, 722, 722, 667, 611, 778, 722, 278, 500, 667, 556, 833, 722, 778,
+	667, 778, 722, 667, 611, 722, 667, 944, 667, 667, 611, 278, 278, 278, 469, 556,
+	333, 556, 556, 500, 556, 556, 278, 556, 556, 222, 222, 500, 222, 833, 556, 556,
+	556, 556, 333, 500, 278, 556, 500, 722, 500, 500, 500, 334, 260, 334, 584,
+}
+
+// Document is a PDF being laid out
+type Document struct {
+	title string
+	pages []*bytes.Buffer
+	y     float64 // baseline of the next line, from the bottom of the page
+}
+
+// New starts a document with one empty page
+func New(title string) *Document {
+	d := &Document{title: title}
+	d.NewPage()
+	return d
+}
+
+// NewPage starts a new page
+func (d *Document) NewPage() {
+	d.pages = append(d.pages, new(bytes.Buffer))
+	d.y = pageHeight - margin
+}
+
+// Gap leaves vertical space, starting a new page if it runs off this one
+func (d *Document) Gap(points float64) {
+	d.y -= points
+	if d.y < margin+footerHeight {
+		d.NewPage()
+	}
+}
+
+// Text writes a paragraph, wrapped to ContentWidth. Words too long for a
+// line, such as long URLs, are broken wherever they overflow.
+func (d *Document) Text(font Font, size float64, text string) {
+	for _, paragraph := range strings.Split(text, "\n") {
+		for _, line := range wrap(font, size, paragraph, ContentWidth) {
+			d.line(font, size, margin, line)
+		}
+	}
+}
+
+// Table writes rows in Courier, each cell padded or cut to its column's
+// width in characters; about 95 fit across the page. The header is
+// repeated at the top of each page the table continues on.
+func (d *Document) Table(header []string, widths []int, rows [][]string) {
+	d.line(MonoBold, TableSize, margin, tableRow(header, widths))
+	for _, row := range rows {
+		if d.y-TableSize*lineSpacing < margin+footerHeight {
+			d.NewPage()
+			d.line(MonoBold, TableSize, margin, tableRow(header, widths))
+		}
+		d.line(Mono, TableSize, margin, tableRow(row, widths))
+	}
+}
+
+func tableRow(cells []string, widths []int) string {
+	var sb strings.Builder
+	for i, width := range widths {
+		cell := ""
+		if i < len(cells) {
+			cell = cells[i]
+		}
+		runes := []rune(cell)
+		if len(runes) > width-1 {
+			runes = append(runes[:max(width-2, 0)], '~')
+		}
+		sb.WriteString(string(runes))
+		sb.WriteString(strings.Repeat(" ", width-len(runes)))
+	}
+	return strings.TrimRight(sb.String(), " ")
+}
+
+// line draws one line of text at the cursor, breaking the page first if
+// it doesn't fit
+func (d *Document) line(font Font, size, x float64, text string) {
+	height := size * lineSpacing
+	if d.y-height < margin+footerHeight {
+		d.NewPage()
+	}
+	d.y -= height
+	fmt.Fprintf(d.pages[len(d.pages)-1], "BT /F%d %.1f Tf %.2f %.2f Td (%s) Tj ET\n", font+1, size, x, d.y, escape(text))
+}
+
+// Write lays out the pages with their footers and writes the PDF
+func (d *Document) Write(w io.Writer) error {
+	buffered := bufio.NewWriter(w)
+	out := &countingWriter{w: buffered}
+	var offsets []int64
+	object := func(body string) {
+		offsets = append(offsets, out.n)
+		fmt.Fprintf(out, "%d 0 obj\n%s\nendobj\n", len(offsets), body)
+	}
+
+	fmt.Fprint(out, "%PDF-1.4\n%\xe2\xe3\xcf\xd3\n")
+	// Objects 1-3 are the catalog, page tree and info, then the fonts,
+	// then a page and its contents for each page
+	firstPage := 4 + len(fontNames)
+	kids := make([]string, len(d.pages))
+	for i := range d.pages {
+		kids[i] = fmt.Sprintf("%d 0 R", firstPage+2*i)
+	}
+	object("<< /Type /Catalog /Pages 2 0 R >>")
+	object(fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", strings.Join(kids, " "), len(d.pages)))
+	object(fmt.Sprintf("<< /Title (%s) /Producer (Repo-lyzer) >>", escape(d.title)))
+	for _, name := range fontNames {
+		object(fmt.Sprintf("<< /Type /Font /Subtype /Type1 /BaseFont /%s /Encoding /WinAnsiEncoding >>", name))
+	}
+	fonts := make([]string, len(fontNames))
+	for i := range fontNames {
+		fonts[i] = fmt.Sprintf("/F%d %d 0 R", i+1, 4+i)
+	}
+	for i, page := range d.pages {
+		footer := fmt.Sprintf("Page %d of %d", i+1, len(d.pages))
+		fmt.Fprintf(page, "BT /F1 8.0 Tf %.2f %.2f Td (%s) Tj ET\n",
+			pageWidth-margin-textWidth(Regular, 8, footer), margin-footerHeight/2, escape(footer))
+		object(fmt.Sprintf("<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %.0f %.0f] /Resources << /Font << %s >> >> /Contents %d 0 R >>",
+			pageWidth, pageHeight, strings.Join(fonts, " "), firstPage+2*i+1))
+		object(fmt.Sprintf("<< /Length %d >>\nstream\n%sendstream", page.Len(), page.String()))
+	}
+
+	xref := out.n
+	fmt.Fprintf(out, "xref\n0 %d\n0000000000 65535 f \n", len(offsets)+1)
+	for _, offset := range offsets {
+		fmt.Fprintf(out, "%010d 00000 n \n", offset)
+	}
+	fmt.Fprintf(out, "trailer\n<< /Size %d /Root 1 0 R /Info 3 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(offsets)+1, xref)
+	if out.err != nil {
+		return out.err
+	}
+	return buffered.Flush()
+}
+
+// wrap splits text into lines no wider than width
+func wrap(font Font, size float64, text string, width float64) []string {
+	var lines []string
+	line := ""
+	for _, word := range strings.Fields(text) {
+		candidate := word
+		if line != "" {
+			candidate = line + " " + word
+		}
+		if textWidth(font, size, candidate) <= width {
+			line = candidate
+			continue
+		}
+		if line != "" {
+			lines = append(lines, line)
+		}
+		// Break a word that's too long on its own
+		line = ""
+		for _, r := range word {
+			if line != "" && textWidth(font, size, line+string(r)) > width {
+				lines = append(lines, line)
+				line = ""
+			}
+			line += string(r)
+		}
+	}
+	if line != "" || len(lines) == 0 {
+		lines = append(lines, line)
+	}
+	return lines
+}
+
+// textWidth estimates the width of text in points. Bold is taken as 10%
+// wider than regular, which errs on the side of wrapping early.
+func textWidth(font Font, size float64, text string) float64 {
+	if font == Mono || font == MonoBold {
+		return float64(len([]rune(text))) * size * monoAdvance
+	}
+	total := 0
+	for _, r := range text {
+		if r >= ' ' && r <= '~' {
+			total += helveticaWidths[r-' ']
+		} else {
+			total += 556
+		}
+	}
+	width := float64(total) * size / 1000
+	if font == Bold {
+		width *= 1.1
+	}
+	return width
+}
+
+// winAnsi maps the characters outside Latin-1 that Windows-1252 has
+var winAnsi = map[rune]byte{
+	'€': 0x80, '‚': 0x82, '„': 0x84, '…': 0x85, '‘': 0x91, '’': 0x92,
+	'“': 0x93, '”': 0x94, '•': 0x95, '–': 0x96, '—': 0x97, '™': 0x99,
+}
+
+// escape encodes text as a PDF string literal's contents
+func escape(text string) string {
+	var sb strings.Builder
+	for _, r := range text {
+		switch {
+		case r == '\\' || r == '(' || r == ')':
+			sb.WriteByte('\\')
+			sb.WriteRune(r)
+		case r >= ' ' && r <= '~':
+			sb.WriteRune(r)
+		case winAnsi[r] != 0:
+			fmt.Fprintf(&sb, "\\%03o", winAnsi[r])
+		case r >= 0xa0 && r <= 0xff:
+			fmt.Fprintf(&sb, "\\%03o", r)
+		default:
+			sb.WriteByte('?')
+		}
+	}
+	return sb.String()
+}
+
+// countingWriter tracks the byte offsets the cross-reference table needs
+type countingWriter struct {
+	w   io.Writer
+	n   int64
+	err error
+}
+
+func (c *countingWriter) Write(p []byte) (int, error) {
+	if c.err != nil {
+		return 0, c.err
+	}
+	n, err := c.w.Write(p)
+	c.n += int64(n)
+	c.err = err
+	return n, err
+}
@@ -0,0 +1,43 @@
+// Package provider abstracts the code hosts Repo-lyzer can analyze. GitHub
+// gets extra analysis (releases, issues, community profile...) on top of
+// what every Provider offers.
+package provider
+
+import (
+	"context"
+
+	"github.com/agnivo988/Repo-lyzer/internal/github"
+)
+
+// Provider is what the core analysis needs from a code host. Both
+// *github.Client and *gitlab.Client implement it.
+type Provider interface {
+	GetRepo(ctx context.Context, owner, repo string) (*github.Repo, error)
+	GetCommits(ctx context.Context, owner, repo string, opts github.CommitOptions) ([]github.Commit, bool, error)
+	GetContributors(ctx context.Context, owner, repo string, max int) ([]github.Contributor, bool, error)
+	GetLanguages(ctx context.Context, owner, repo string) (map[string]int, error)
+	GetFileTree(ctx context.Context, owner, repo, branch string) ([]github.TreeEntry, error)
+	GetFileContent(ctx context.Context, owner, repo, path, ref string) (*github.FileContent, error)
+	ResolveRef(ctx context.Context, owner, repo, ref string) (string, error)
+}
+
+// GetRepoOverview fetches repository metadata. When the provider can return
+// languages and commit history along with it (GitHub, with a token) it does
+// so and reports true if both came back; otherwise, including when either
+// request failed, it reports false and the caller fetches them again, so a
+// failure is recorded rather than passed off as an empty section.
+func GetRepoOverview(ctx context.Context, p Provider, owner, repo string, opts github.CommitOptions) (*github.Overview, bool, error) {
+	if gh, ok := p.(*github.Client); ok && gh.TokenSource() != github.TokenSourceNone {
+		overview, err := gh.GetOverview(ctx, owner, repo, opts)
+		if err != nil {
+			return nil, false, err
+		}
+		return overview, overview.LanguagesErr == nil && overview.CommitsErr == nil, nil
+	}
+
+	r, err := p.GetRepo(ctx, owner, repo)
+	if err != nil {
+		return nil, false, err
+	}
+	return &github.Overview{Repo: r, Mentionable: -1, Collaborators: -1}, false, nil
+}
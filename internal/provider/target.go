@@ -0,0 +1,94 @@
+package provider
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Kind identifies a code host
+type Kind string
+
+const (
+	GitHub Kind = "GitHub"
+	GitLab Kind = "GitLab"
+)
+
+// Target is a repository to analyze, parsed from user input
+type Target struct {
+	Kind  Kind
+	Host  string // e.g. "github.com", "gitlab.example.com"
+	Owner string // may contain subgroups on GitLab, e.g. "group/subgroup"
+	Name  string
+	Ref   string // branch, tag or SHA; "" means the default branch
+}
+
+// ParseTarget understands "owner/repo" (on the configured GitHub host) as
+// well as URLs like github.com/owner/repo or
+// https://gitlab.com/group/subgroup/project. Hosts other than githubHost are
+// treated as GitLab when their name contains "gitlab". Any of these can be
+// followed by @ref, e.g. owner/repo@v1.2.0 or owner/repo@feature/x.
+func ParseTarget(input, githubHost string) (Target, error) {
+	s := strings.TrimSpace(input)
+	if i := strings.Index(s, "://"); i >= 0 {
+		s = s[i+3:]
+	}
+	s, ref, hasRef := strings.Cut(s, "@")
+	if hasRef && ref == "" {
+		return Target{}, fmt.Errorf("missing ref after @")
+	}
+	s = strings.TrimSuffix(strings.TrimSuffix(s, "/"), ".git")
+
+	target, err := parseRepo(s, githubHost)
+	if err != nil {
+		return Target{}, err
+	}
+	target.Ref = ref
+	return target, nil
+}
+
+// parseRepo parses the host/owner/name part of a target
+func parseRepo(s, githubHost string) (Target, error) {
+	parts := strings.Split(s, "/")
+	for _, part := range parts {
+		if part == "" {
+			return Target{}, fmt.Errorf("repository must be in owner/repo format")
+		}
+	}
+
+	if len(parts) == 2 {
+		return Target{Kind: GitHub, Host: githubHost, Owner: parts[0], Name: parts[1]}, nil
+	}
+	if len(parts) < 3 {
+		return Target{}, fmt.Errorf("repository must be in owner/repo format")
+	}
+
+	host, path := parts[0], parts[1:]
+	switch {
+	case host == githubHost || host == "github.com" || host == "www.github.com":
+		if len(path) != 2 {
+			return Target{}, fmt.Errorf("GitHub repositories must be in owner/repo format")
+		}
+		if host != githubHost {
+			return Target{}, fmt.Errorf("%s is not the configured GitHub host (%s)", host, githubHost)
+		}
+		return Target{Kind: GitHub, Host: host, Owner: path[0], Name: path[1]}, nil
+	case strings.Contains(host, "gitlab"):
+		return Target{
+			Kind:  GitLab,
+			Host:  host,
+			Owner: strings.Join(path[:len(path)-1], "/"),
+			Name:  path[len(path)-1],
+		}, nil
+	default:
+		return Target{}, fmt.Errorf("unsupported host %s", host)
+	}
+}
+
+// String renders the target the way ParseTarget accepts it back
+func (t Target) String() string {
+	s := t.Host + "/" + t.Owner + "/" + t.Name
+	if t.Ref != "" {
+		s += "@" + t.Ref
+	}
+	return s
+}
@@ -0,0 +1,123 @@
+// Package scorecard reads the OpenSSF Scorecard results published for
+// public repositories at api.securityscorecards.dev.
+package scorecard
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// DefaultBaseURL is the root of the public Scorecard API
+const DefaultBaseURL = "https://api.securityscorecards.dev"
+
+// ErrNoData is returned for repos the public dataset doesn't cover
+var ErrNoData = errors.New("no Scorecard data")
+
+// Check is one Scorecard check. Score is out of 10, or -1 when the check
+// didn't apply or was inconclusive.
+type Check struct {
+	Name   string `json:"name"`
+	Score  int    `json:"score"`
+	Reason string `json:"reason"`
+}
+
+// Result is a repo's latest Scorecard scan
+type Result struct {
+	// Date is when the scan ran
+	Date   time.Time `json:"date"`
+	Commit string    `json:"commit"` // the commit scanned
+	Score  float64   `json:"score"`  // out of 10
+	Checks []Check   `json:"checks"`
+}
+
+type Client struct {
+	http    *http.Client
+	baseURL string
+}
+
+// NewClient creates a client for the Scorecard API at baseURL, or
+// DefaultBaseURL when it's empty
+func NewClient(baseURL string) *Client {
+	if baseURL == "" {
+		baseURL = DefaultBaseURL
+	}
+	return &Client{
+		http:    &http.Client{Timeout: 15 * time.Second},
+		baseURL: strings.TrimSuffix(baseURL, "/"),
+	}
+}
+
+// Get fetches the latest results for the repo at host/owner/repo, e.g.
+// github.com/ossf/scorecard. Repos outside the dataset return ErrNoData.
+func (c *Client) Get(ctx context.Context, host, owner, repo string) (*Result, error) {
+	u := c.baseURL + "/projects/" + host + "/" + url.PathEscape(owner) + "/" + url.PathEscape(repo)
+	req, err := http.NewRequestWithContext(ctx, "GET", u, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, ErrNoData
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("Scorecard API error: %s", resp.Status)
+	}
+	var raw struct {
+		Date string `json:"date"`
+		Repo struct {
+			Commit string `json:"commit"`
+		} `json:"repo"`
+		Score  float64 `json:"score"`
+		Checks []Check `json:"checks"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&raw); err != nil {
+		return nil, err
+	}
+	result := &Result{Commit: raw.Repo.Commit, Score: raw.Score, Checks: raw.Checks}
+	result.Date = parseDate(raw.Date)
+	return result, nil
+}
+
+// parseDate reads the scan date, which the API has served both as a full
+// timestamp and as a bare day
+func parseDate(s string) time.Time {
+	for _, layout := range []string{time.RFC3339, "2006-01-02"} {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t
+		}
+	}
+	return time.Time{}
+}
+
+// Summary renders the overall score and scan date, e.g.
+// "7.2/10, scanned 2024-05-06"; nil results have no data
+func (r *Result) Summary() string {
+	if r == nil {
+		return ErrNoData.Error()
+	}
+	summary := fmt.Sprintf("%.1f/10", r.Score)
+	if !r.Date.IsZero() {
+		summary += ", scanned " + r.Date.Format("2006-01-02")
+	}
+	return summary
+}
+
+// CheckScore renders a check's score, e.g. "8/10", or "n/a" when the
+// check didn't apply
+func (c Check) CheckScore() string {
+	if c.Score < 0 {
+		return "n/a"
+	}
+	return fmt.Sprintf("%d/10", c.Score)
+}
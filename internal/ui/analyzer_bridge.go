@@ -25,7 +25,7 @@ func NewAnalyzerDataBridge(result AnalysisResult) *AnalyzerDataBridge {
 		repo:          result.Repo,
 		commits:       result.Commits,
 		contributors:  result.Contributors,
-		languages:     result.Languages,
+		languages:     result.MetricLanguages(),
 		healthScore:   result.HealthScore,
 		busFactor:     result.BusFactor,
 		busRisk:       result.BusRisk,
@@ -236,16 +236,10 @@ func (b *AnalyzerDataBridge) calculateActivityTrend() string {
 
 func (b *AnalyzerDataBridge) getPrimaryLanguage() string {
 	// Language field not available in Repo struct, use languages map instead
-	maxBytes := 0
-	primaryLang := "Unknown"
-	for lang, bytes := range b.languages {
-		if bytes > maxBytes {
-			maxBytes = bytes
-			primaryLang = lang
-		}
+	if primary := analyzer.PrimaryLanguage(b.languages); primary != "" {
+		return primary
 	}
-
-	return primaryLang
+	return "Unknown"
 }
 
 func (b *AnalyzerDataBridge) calculateLanguageDiversity() float64 {
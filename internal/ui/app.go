@@ -1,11 +1,19 @@
 package ui
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"slices"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/agnivo988/Repo-lyzer/internal/analyzer"
 	"github.com/agnivo988/Repo-lyzer/internal/github"
+	"github.com/agnivo988/Repo-lyzer/internal/gitlab"
+	"github.com/agnivo988/Repo-lyzer/internal/provider"
+	"github.com/agnivo988/Repo-lyzer/internal/scorecard"
 	"github.com/charmbracelet/bubbles/help"
 	"github.com/charmbracelet/bubbles/spinner"
 	"github.com/charmbracelet/bubbletea"
@@ -44,26 +52,36 @@ type MainModel struct {
 	windowWidth   int
 	windowHeight  int
 	analysisType  string // quick, detailed, custom
-	appSettings    tea.LogOptionsSetter
-	compareResult *CompareResult // Holds comparison data
+	appSettings   tea.LogOptionsSetter
+	compareResult *CompareResult     // Holds comparison data
+	compareExport bool               // Shows the comparison's export picker
+	compareStatus string             // Outcome of the last comparison export
+	client        *github.Client     // Shared so cached responses survive re-analysis
+	options       Options            // Tunes what each analysis fetches
+	cancel        context.CancelFunc // Cancels the in-flight analysis, if any
 }
 
-func NewMainModel() MainModel {
+func NewMainModel(client *github.Client, options Options) MainModel {
 	s := spinner.New()
 	s.Spinner = spinner.Dot
 	s.Style = lipgloss.NewStyle().Foreground(lipgloss.Color("205"))
 
+	dashboard := NewDashboardModel()
+	dashboard.client = client
+	dashboard.exportOptions = options.Export
+
 	return MainModel{
-		state:        stateMenu,
-		menu:         NewMenuModel(),
-		spinner:      s,
-		dashboard:    NewDashboardModel(),
-		tree:         NewTreeModel(nil),
-		appSettings:  nil, 
+		state:       stateMenu,
+		menu:        NewMenuModel(),
+		spinner:     s,
+		dashboard:   dashboard,
+		tree:        NewTreeModel(nil),
+		appSettings: nil,
+		client:      client,
+		options:     options,
 	}
 }
 
-
 func (m MainModel) Init() tea.Cmd {
 	return m.spinner.Tick
 }
@@ -85,10 +103,12 @@ func (m MainModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 	case tea.KeyMsg:
 		if msg.String() == "ctrl+c" {
+			m.cancelRequests()
 			return m, tea.Quit
 		}
 		// Global shortcuts
 		if msg.String() == "q" && m.state == stateMenu {
+			m.cancelRequests()
 			return m, tea.Quit
 		}
 
@@ -110,7 +130,8 @@ func (m MainModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			// Re-analyze the current repo
 			if m.dashboard.data.Repo != nil {
 				m.state = stateLoading
-				cmds = append(cmds, m.analyzeRepo(m.dashboard.data.Repo.FullName))
+				m.progress = NewProgressTracker()
+				cmds = append(cmds, m.analyzeRepo(m.newRequestContext(), refreshTarget(m.dashboard.data), m.progress))
 			}
 		}
 	}
@@ -141,7 +162,8 @@ func (m MainModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			case tea.KeyEnter:
 				if m.input != "" {
 					m.state = stateLoading
-					cmds = append(cmds, m.analyzeRepo(m.input))
+					m.progress = NewProgressTracker()
+					cmds = append(cmds, m.analyzeRepo(m.newRequestContext(), m.input, m.progress))
 				}
 			case tea.KeyBackspace:
 				if len(m.input) > 0 {
@@ -180,7 +202,7 @@ func (m MainModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				} else if m.compareStep == 1 && m.compareInput2 != "" {
 					// Both repos entered, start comparison
 					m.state = stateCompareLoading
-					cmds = append(cmds, m.compareRepos(m.compareInput1, m.compareInput2))
+					cmds = append(cmds, m.compareRepos(m.newRequestContext(), m.compareInput1, m.compareInput2))
 				}
 			case tea.KeyBackspace:
 				if m.compareStep == 0 && len(m.compareInput1) > 0 {
@@ -239,13 +261,18 @@ func (m MainModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		case CompareResult:
 			m.compareResult = &msg
 			m.state = stateCompareResult
+			m.compareExport = false
 			m.err = nil
 		case error:
+			if errors.Is(msg, context.Canceled) {
+				break
+			}
 			m.err = msg
 			m.state = stateCompareInput
 			m.compareStep = 0
 		case tea.KeyMsg:
 			if msg.String() == "esc" {
+				m.cancelRequests()
 				m.state = stateMenu
 				m.compareInput1 = ""
 				m.compareInput2 = ""
@@ -255,13 +282,47 @@ func (m MainModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 	case stateCompareResult:
 		switch msg := msg.(type) {
+		case exportMsg:
+			if msg.err != nil {
+				m.compareStatus = fmt.Sprintf("Export failed: %v", msg.err)
+			} else {
+				m.compareStatus = msg.msg
+			}
+			cmds = append(cmds, tea.Tick(3*time.Second, func(time.Time) tea.Msg {
+				return "clear_compare_status"
+			}))
+		case string:
+			if msg == "clear_compare_status" {
+				m.compareStatus = ""
+			}
 		case tea.KeyMsg:
 			switch msg.String() {
 			case "q", "esc":
+				if m.compareExport {
+					m.compareExport = false
+					break
+				}
 				m.state = stateMenu
 				m.compareResult = nil
 				m.compareInput1 = ""
 				m.compareInput2 = ""
+				m.compareStatus = ""
+			case "e":
+				m.compareExport = !m.compareExport
+			case "j":
+				if m.compareExport && m.compareResult != nil {
+					data, exportOptions := *m.compareResult, m.options.Export
+					cmds = append(cmds, func() tea.Msg {
+						return exportMsg{ExportCompareJSON(data, "comparison.json", exportOptions), "Exported to comparison.json"}
+					})
+				}
+			case "m":
+				if m.compareExport && m.compareResult != nil {
+					data := *m.compareResult
+					cmds = append(cmds, func() tea.Msg {
+						return exportMsg{ExportCompareMarkdown(data, "comparison.md"), "Exported to comparison.md"}
+					})
+				}
 			}
 		}
 
@@ -274,11 +335,16 @@ func (m MainModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			m.state = stateDashboard
 			m.progress = nil
 		}
-		if err, ok := msg.(error); ok {
+		if err, ok := msg.(error); ok && !errors.Is(err, context.Canceled) {
 			m.err = err
 			m.state = stateInput // Go back to input on error
 			m.progress = nil
 		}
+		if key, ok := msg.(tea.KeyMsg); ok && key.String() == "esc" {
+			m.cancelRequests()
+			m.state = stateInput
+			m.progress = nil
+		}
 
 	case stateDashboard:
 		newDash, newCmd := m.dashboard.Update(msg)
@@ -327,7 +393,9 @@ func (m MainModel) View() string {
 			statusView += "\n\n"
 			for _, stage := range stages {
 				prefix := "⏳ "
-				if stage.IsComplete {
+				if stage.Failed {
+					prefix = "⚠️  "
+				} else if stage.IsComplete {
 					prefix = "✅ "
 				} else if stage.IsActive {
 					prefix = "⚙️  "
@@ -371,10 +439,10 @@ func (m MainModel) inputView() string {
 	inputContent :=
 		TitleStyle.Render("📥 ENTER REPOSITORY") + "\n\n" +
 			InputStyle.Render("> "+m.input) + "\n\n" +
-			SubtleStyle.Render("Format: owner/repo  •  Press Enter to run")
+			SubtleStyle.Render("Format: owner/repo[@ref] or gitlab.com/group/project  •  Press Enter to run")
 
 	if m.err != nil {
-		inputContent += "\n\n" + ErrorStyle.Render(fmt.Sprintf("Error: %v", m.err))
+		inputContent += "\n\n" + renderError(m.err, m.client.AuthCapability())
 	}
 
 	box := BoxStyle.Render(inputContent)
@@ -392,58 +460,861 @@ func (m MainModel) inputView() string {
 	)
 }
 
-func (m MainModel) analyzeRepo(repoName string) tea.Cmd {
+// newRequestContext cancels any in-flight analysis and returns a context for the next one
+func (m *MainModel) newRequestContext() context.Context {
+	m.cancelRequests()
+	ctx, cancel := context.WithCancel(context.Background())
+	m.cancel = cancel
+	return ctx
+}
+
+// cancelRequests aborts outstanding GitHub API calls
+func (m *MainModel) cancelRequests() {
+	if m.cancel != nil {
+		m.cancel()
+		m.cancel = nil
+	}
+}
+
+// refreshTarget returns input that re-analyzes the same repo and ref on the
+// same host
+func refreshTarget(data AnalysisResult) string {
+	target := data.Repo.FullName
+	if data.Repo.HTMLURL != "" {
+		target = data.Repo.HTMLURL
+	}
+	if data.RefSHA != "" {
+		target += "@" + data.Ref
+	}
+	return target
+}
+
+func (m MainModel) analyzeRepo(ctx context.Context, repoName string, tracker *ProgressTracker) tea.Cmd {
 	return func() tea.Msg {
-		parts := strings.Split(repoName, "/")
-		if len(parts) != 2 {
-			return fmt.Errorf("repository must be in owner/repo format")
+		target, err := provider.ParseTarget(repoName, m.client.Host())
+		if err != nil {
+			return err
 		}
 
-		tracker := NewProgressTracker()
+		result, err := runAnalysis(ctx, m.providerFor(target), target, m.options, tracker)
+		if err != nil {
+			return err
+		}
+		return result
+	}
+}
 
-		// Stage 1: Fetch repository
-		client := github.NewClient()
-		repo, err := client.GetRepo(parts[0], parts[1])
+// providerFor returns the client to analyze target with
+func (m MainModel) providerFor(target provider.Target) provider.Provider {
+	return providerFor(m.client, target)
+}
+
+func providerFor(client *github.Client, target provider.Target) provider.Provider {
+	if target.Kind == provider.GitLab {
+		return gitlab.NewClient(target.Host,
+			gitlab.WithHTTPClient(client.HTTPClient()),
+			gitlab.WithUserAgent(client.UserAgent()))
+	}
+	return client
+}
+
+// Analyze runs the same analysis as the interactive mode, for headless
+// exports. repoName is anything the input box accepts, e.g. owner/repo@ref
+// or a URL.
+func Analyze(ctx context.Context, client *github.Client, repoName string, options Options) (AnalysisResult, error) {
+	target, err := provider.ParseTarget(repoName, client.Host())
+	if err != nil {
+		return AnalysisResult{}, err
+	}
+	return runAnalysis(ctx, providerFor(client, target), target, options, NewProgressTracker())
+}
+
+// runAnalysis fetches and scores a single repository. Repository metadata
+// comes first (the default branch is needed for the tree); everything else
+// is fetched concurrently. A failing section is recorded in Unavailable
+// rather than failing the run. GitHub repos get the full analysis; other
+// providers get what can be derived from the core data.
+//
+// History and files come from target.Ref when set; repo-wide facts like
+// stars and issues don't depend on it.
+func runAnalysis(ctx context.Context, p provider.Provider, target provider.Target, options Options, tracker *ProgressTracker) (AnalysisResult, error) {
+	owner, name := target.Owner, target.Name
+	gh, isGitHub := p.(*github.Client)
+	if isGitHub {
+		gh.ResetServedStale()
+	}
+
+	// Stage 1: Repository metadata (with languages and commits in the same
+	// GraphQL query when authenticated on GitHub)
+	history := github.LastDays(365)
+	history.Ref = target.Ref
+	overview, complete, err := provider.GetRepoOverview(ctx, p, owner, name, history)
+	if err != nil {
+		return AnalysisResult{}, err
+	}
+	repo := overview.Repo
+
+	// Resolve the ref up front so a typo fails clearly rather than
+	// producing an empty analysis
+	ref, refSHA := repo.DefaultBranch, ""
+	if target.Ref != "" {
+		if refSHA, err = p.ResolveRef(ctx, owner, name, target.Ref); err != nil {
+			return AnalysisResult{}, err
+		}
+		ref = target.Ref
+	}
+	treeRef := repo.DefaultBranch
+	if refSHA != "" {
+		treeRef = refSHA
+	}
+	tracker.Finish(stageRepo, nil)
+
+	result := AnalysisResult{
+		Repo:             repo,
+		Ref:              ref,
+		RefSHA:           refSHA,
+		Commits:          overview.Commits,
+		CommitsTruncated: overview.CommitsTruncated,
+		Languages:        overview.Languages,
+		MentionableUsers: overview.Mentionable,
+		Collaborators:    overview.Collaborators,
+		Unavailable:      make(map[string]string),
+	}
+
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	section := func(stage int, name string, fetch func() error) {
+		tracker.Start(stage)
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			err := fetch()
+			if err != nil {
+				mu.Lock()
+				result.Unavailable[name] = err.Error()
+				mu.Unlock()
+			}
+			tracker.Finish(stage, err)
+		}()
+	}
+
+	// Stage 2: Everything else, concurrently. Each section writes only its
+	// own fields of result.
+	var communityProfile *github.CommunityProfile
+	var statsWeeks []github.CommitActivityWeek
+	var codeFrequency []github.CodeFrequencyWeek
+	var codeOwners []analyzer.CodeOwnersRule
+	var commitDetails []github.Commit
+	var gitattributes string
+	var workflowRuns []github.WorkflowRun
+	var reviewedPulls []github.ReviewedPullRequest
+
+	section(stageCommits, SectionCommits, func() error {
+		if !complete {
+			var err error
+			result.Commits, result.CommitsTruncated, err = p.GetCommits(ctx, owner, name, history)
+			if err != nil {
+				return err
+			}
+		}
+		result.ContributorTrend = FetchContributorTrend(ctx, p, owner, name, history, result.Commits, result.CommitsTruncated)
+		if isGitHub && options.Hotspots {
+			commitDetails = FetchCommitDetails(ctx, gh, owner, name, result.Commits)
+		}
+		// The stats endpoint only covers the default branch
+		if isGitHub && target.Ref == "" {
+			statsWeeks, _ = gh.GetCommitActivity(ctx, owner, name)
+			codeFrequency, _ = gh.GetCodeFrequency(ctx, owner, name)
+		}
+		return nil
+	})
+	section(stageContributors, SectionContributors, func() error {
+		var err error
+		result.Contributors, result.ContributorsTruncated, err = p.GetContributors(ctx, owner, name, github.DefaultMaxContributors)
+		if err == nil && isGitHub && options.EnrichContributors > 0 {
+			gh.EnrichContributors(ctx, result.Contributors, options.EnrichContributors)
+		}
+		return err
+	})
+	section(stageLanguages, SectionLanguages, func() error {
+		if complete {
+			return nil
+		}
+		var err error
+		result.Languages, err = p.GetLanguages(ctx, owner, name)
+		return err
+	})
+	section(stageFileTree, SectionFileTree, func() error {
+		var err error
+		result.FileTree, err = p.GetFileTree(ctx, owner, name, treeRef)
 		if err != nil {
 			return err
 		}
-		tracker.NextStage()
+		if !isGitHub {
+			result.Readme = readmeFromTree(ctx, p, owner, name, treeRef, result.FileTree)
+		}
+		codeOwners = fetchCodeOwners(ctx, p, owner, name, treeRef, result.FileTree)
+		gitattributes = FetchGitAttributes(ctx, p, owner, name, treeRef, result.FileTree)
+		result.Automation = FetchAutomation(ctx, p, owner, name, treeRef, result.FileTree)
+		result.Monorepo = FetchMonorepo(ctx, p, owner, name, treeRef, result.FileTree)
+		result.Changelog = FetchChangelog(ctx, p, owner, name, treeRef, result.FileTree)
+		result.ReleaseAutomation = FetchReleaseAutomation(ctx, p, owner, name, treeRef, result.FileTree)
+		return nil
+	})
+
+	if isGitHub {
+		var insights sync.WaitGroup
+		insight := func(fetch func()) {
+			insights.Add(1)
+			go func() {
+				defer insights.Done()
+				fetch()
+			}()
+		}
+		insight(func() { result.Releases, result.ReleaseStats = FetchReleaseStats(ctx, gh, owner, name) })
+		insight(func() { result.Issues = FetchIssueStats(ctx, gh, repo) })
+		insight(func() { result.FirstResponse = FetchFirstResponse(ctx, gh, repo) })
+		insight(func() { result.PullRequests = FetchPullRequestStats(ctx, gh, repo) })
+		insight(func() { reviewedPulls = FetchReviewedPullRequests(ctx, gh, repo) })
+		insight(func() { result.BranchProtection = FetchBranchProtection(ctx, gh, repo) })
+		insight(func() { result.Branches = FetchBranchStats(ctx, gh, repo) })
+		insight(func() { result.Readme = FetchReadme(ctx, gh, owner, name, refSHA) })
+		insight(func() { communityProfile, _ = gh.GetCommunityProfile(ctx, owner, name) })
+		insight(func() { workflowRuns = fetchWorkflowRuns(ctx, gh, repo, ref) })
+		if options.StarHistory {
+			insight(func() { result.Stars = FetchStarHistory(ctx, gh, repo) })
+		}
+		if options.Scorecard {
+			result.ScorecardChecked = true
+			insight(func() { result.Scorecard = FetchScorecard(ctx, gh.Host(), repo) })
+		}
+		if analyzer.IsAbandoned(repo, options.AbandonedAfterDays, time.Now()) {
+			result.ForksChecked = true
+			insight(func() { result.ActiveForks = FetchActiveForks(ctx, gh, repo) })
+		}
 
-		// Stage 2: Analyze commits
-		commits, _ := client.GetCommits(parts[0], parts[1], 365)
-		tracker.NextStage()
+		// Insights degrade on their own, so the stage never fails
+		section(stageInsights, "", func() error {
+			insights.Wait()
+			return nil
+		})
+	} else {
+		result.ReleaseStats = analyzer.ReleaseCadence(nil)
+		result.Issues = analyzer.IssueStats{Enabled: repo.HasIssues}
+		result.BranchProtection = analyzer.BranchProtectionInfo{Branch: repo.DefaultBranch, Status: analyzer.ProtectionUnknown}
+		tracker.Finish(stageInsights, nil)
+	}
 
-		// Stage 3: Analyze contributors
-		contributors, _ := client.GetContributors(parts[0], parts[1])
-		tracker.NextStage()
+	wg.Wait()
+	if err := ctx.Err(); err != nil {
+		return AnalysisResult{}, err
+	}
+
+	// Fall back to what the core data can tell where the dedicated
+	// endpoints weren't available
+	if communityProfile != nil {
+		result.Community = analyzer.CommunityHealthFromProfile(communityProfile)
+	} else {
+		result.Community = analyzer.CommunityHealthFromTree(result.FileTree)
+	}
+	if len(statsWeeks) > 0 {
+		result.WeeklyCommits = analyzer.WeeklyActivityFromStats(statsWeeks)
+	} else {
+		result.WeeklyCommits = analyzer.WeeklyActivityFromCommits(result.Commits, time.Now())
+	}
+	result.ActivityTrend = analyzer.AnalyzeActivityTrend(result.WeeklyCommits, repo.CreatedAt, time.Now())
+	result.Churn = analyzer.AnalyzeChurn(codeFrequency, result.WeeklyCommits, commitDetails, time.Now())
+	result.Heatmap = analyzer.BuildCommitHeatmap(result.Commits, time.Now())
+	result.CommitHygiene = analyzer.AnalyzeCommitHygiene(result.Commits)
+	result.Workflow = analyzer.AnalyzeWorkflow(result.Commits)
+	result.SignedCommitRatio = analyzer.AnalyzeSignedCommits(result.Commits)
+	result.CI = analyzer.DetectCI(result.FileTree)
+	if result.CI.HasCI() {
+		result.CI.Workflows = analyzer.LatestWorkflowRuns(workflowRuns)
+	}
+	if len(result.Languages) == 0 && result.SectionError(SectionLanguages) == "" && len(result.FileTree) > 0 {
+		result.Languages = analyzer.EstimateLanguages(result.FileTree)
+		result.LanguagesEstimated = true
+	}
+	result.Tests = analyzer.DetectTests(result.FileTree)
+	result.TreeStats = analyzer.ComputeTreeStats(result.FileTree, true)
+	result.RepoSize = analyzer.AnalyzeRepoSize(result.FileTree, analyzer.ParseLFSPatterns(gitattributes), options.LargeFileMB)
+	result.AdjustedLanguages = analyzer.AdjustedLanguages(result.FileTree, options.LanguageExclusions(gitattributes))
+	result.LanguageProfile = analyzer.AnalyzeLanguageProfile(result.MetricLanguages())
+	result.License = analyzer.ClassifyLicense(repo)
+	result.Security = analyzer.ScoreSecurity(analyzer.SecurityInput{
+		Tree:                result.FileTree,
+		CI:                  result.CI,
+		BranchProtection:    result.BranchProtection,
+		FileTreeUnavailable: result.SectionError(SectionFileTree) != "",
+	})
+	result.Ownership = analyzer.AnalyzeOwnership(
+		analyzer.FindCodeOwners(result.FileTree), codeOwners, result.FileTree, result.Commits,
+	)
+	result.ReadmeQuality = analyzer.ScoreReadme(result.Readme, result.FileTree, result.SectionError(SectionFileTree) != "")
+	result.Hotspots = analyzer.AnalyzeHotspots(commitDetails)
+	result.DirectoryOwners = analyzer.AnalyzeDirectoryOwnership(commitDetails, codeOwners)
+
+	// Stage 3: Compute metrics
+	result.Responsiveness = analyzer.RateIssueResponsiveness(result.Issues)
+	// The community profile misses templates kept in .github/ISSUE_TEMPLATE
+	templates := result.Community.IssueTemplate || analyzer.CommunityHealthFromTree(result.FileTree).IssueTemplate
+	result.Friendliness = analyzer.RateFriendliness(result.Issues, templates)
+	result.Inequality = analyzer.ContributionGini(result.Contributors)
+	result.Engagement = analyzer.AnalyzeEngagement(repo, result.Issues, len(result.Contributors))
+	result.ContributorTiers = analyzer.ClassifyContributors(result.Contributors, result.Commits)
+	result.Reviews = analyzer.AnalyzeReviewCoverage(reviewedPulls, result.ContributorTiers.Core)
+	result.Timezones = analyzer.AnalyzeTimezones(result.Commits, result.ContributorTiers.ByLogin)
+	result.Abandonment = analyzer.AssessAbandonment(analyzer.AbandonmentInput{
+		Repo:         repo,
+		Trend:        result.ActivityTrend,
+		Issues:       result.Issues,
+		PullRequests: result.PullRequests,
+		Now:          time.Now(),
+	})
+	// Bus factor and health count everyone unless asked to count core only
+	counted := result.Contributors
+	if options.CoreContributorsOnly {
+		counted = result.ContributorTiers.CoreContributors(result.Contributors)
+	}
+	scorer := options.healthScorer()
+	result.HealthWeights = scorer.Weights
+	result.HealthComponents = scorer.Components(analyzer.HealthInput{
+		Repo:                    repo,
+		Commits:                 len(result.Commits),
+		ActivityTrend:           &result.ActivityTrend,
+		Contributors:            len(counted),
+		Inequality:              &result.Inequality,
+		Issues:                  result.Issues,
+		Responsiveness:          &result.Responsiveness,
+		PullRequests:            result.PullRequests,
+		Reviews:                 &result.Reviews,
+		Readme:                  result.Readme,
+		ReadmeQuality:           &result.ReadmeQuality,
+		CI:                      result.CI,
+		Tests:                   result.Tests,
+		Now:                     time.Now(),
+		CommitsUnavailable:      result.SectionError(SectionCommits) != "",
+		ContributorsUnavailable: result.SectionError(SectionContributors) != "",
+		FileTreeUnavailable:     result.SectionError(SectionFileTree) != "",
+	})
+	result.HealthScore = analyzer.ComponentsScore(result.HealthComponents)
+	result.HealthGrade = analyzer.GradeFor(result.HealthScore)
+	result.BusFactorInfo = analyzer.AnalyzeBusFactor(counted, options.BusFactorThreshold, result.ContributorsTruncated)
+	result.BusFactor, result.BusRisk = result.BusFactorInfo.Factor, result.BusFactorInfo.Risk
+	result.Changelog.CompareRelease(result.ReleaseStats.LatestTag)
+	maturity := analyzer.ScoreMaturity(analyzer.MaturityInput{
+		Repo:                repo,
+		Commits:             result.WeeklyCommits.Total(),
+		Releases:            result.ReleaseStats,
+		Readme:              result.Readme,
+		Tree:                result.FileTree,
+		CI:                  result.CI,
+		License:             result.License,
+		ReleaseAutomation:   result.ReleaseAutomation,
+		Changelog:           &result.Changelog,
+		Now:                 time.Now(),
+		FileTreeUnavailable: result.SectionError(SectionFileTree) != "",
+	})
+	result.MaturityScore, result.MaturityGrade, result.MaturityLevel, result.MaturityComponents = maturity.Score, maturity.Grade, maturity.Level, maturity.Components
+	result.ReleaseCadence = analyzer.RateReleaseCadence(result.ReleaseStats, result.WeeklyCommits.Total())
+	tracker.Finish(stageMetrics, nil)
+
+	// Mark complete
+	tracker.Finish(stageDone, nil)
+
+	result.FromStaleCache = isGitHub && gh.ServedStale()
+	return result, nil
+}
 
-		// Stage 4: Analyze languages
-		languages, _ := client.GetLanguages(parts[0], parts[1])
-		fileTree, _ := client.GetFileTree(parts[0], parts[1], repo.DefaultBranch)
-		tracker.NextStage()
+// readmeFromTree finds a README in the root of the tree and analyzes it, for
+// providers without a dedicated README endpoint
+func readmeFromTree(ctx context.Context, p provider.Provider, owner, name, ref string, tree []github.TreeEntry) analyzer.ReadmeInfo {
+	for _, entry := range tree {
+		if entry.Type != "blob" || strings.Contains(entry.Path, "/") ||
+			!strings.HasPrefix(strings.ToLower(entry.Path), "readme") {
+			continue
+		}
+		file, err := p.GetFileContent(ctx, owner, name, entry.Path, ref)
+		if err != nil {
+			return analyzer.ReadmeInfo{}
+		}
+		text, err := file.Decode()
+		if err != nil {
+			return analyzer.ReadmeInfo{}
+		}
+		return analyzer.AnalyzeReadme(entry.Path, string(text))
+	}
+	return analyzer.ReadmeInfo{}
+}
 
-		// Stage 5: Compute metrics
-		score := analyzer.CalculateHealth(repo, commits)
-		busFactor, busRisk := analyzer.BusFactor(contributors)
-		maturityScore, maturityLevel := analyzer.RepoMaturityScore(repo, len(commits), len(contributors), false)
-		tracker.NextStage()
+// fetchCodeOwners reads and parses the repo's CODEOWNERS file, if the tree has one
+func fetchCodeOwners(ctx context.Context, p provider.Provider, owner, name, ref string, tree []github.TreeEntry) []analyzer.CodeOwnersRule {
+	path := analyzer.FindCodeOwners(tree)
+	if path == "" {
+		return nil
+	}
+	file, err := p.GetFileContent(ctx, owner, name, path, ref)
+	if err != nil {
+		return nil
+	}
+	text, err := file.Decode()
+	if err != nil {
+		return nil
+	}
+	return analyzer.ParseCodeOwners(string(text))
+}
 
-		// Mark complete
-		tracker.NextStage()
+// FetchCommitDetails reads the changed files of the most recent commits,
+// at up to github.DefaultHotspotSample requests, for hotspots and
+// directory ownership
+func FetchCommitDetails(ctx context.Context, client *github.Client, owner, name string, commits []github.Commit) []github.Commit {
+	return client.GetCommitDetails(ctx, owner, name, commits, github.DefaultHotspotSample)
+}
 
-		return AnalysisResult{
-			Repo:          repo,
-			Commits:       commits,
-			Contributors:  contributors,
-			FileTree:      fileTree,
-			Languages:     languages,
-			HealthScore:   score,
-			BusFactor:     busFactor,
-			BusRisk:       busRisk,
-			MaturityScore: maturityScore,
-			MaturityLevel: maturityLevel,
+// FetchGitAttributes reads the root .gitattributes, "" when there is none
+func FetchGitAttributes(ctx context.Context, p provider.Provider, owner, name, ref string, tree []github.TreeEntry) string {
+	if !slices.ContainsFunc(tree, func(e github.TreeEntry) bool { return e.Path == ".gitattributes" }) {
+		return ""
+	}
+	file, err := p.GetFileContent(ctx, owner, name, ".gitattributes", ref)
+	if err != nil {
+		return ""
+	}
+	text, err := file.Decode()
+	if err != nil {
+		return ""
+	}
+	return string(text)
+}
+
+// FetchAutomation detects the templates and bots from the tree, reading
+// the issue template config and dependabot.yml when there are any
+func FetchAutomation(ctx context.Context, p provider.Provider, owner, name, ref string, tree []github.TreeEntry) analyzer.ProjectAutomation {
+	automation := analyzer.DetectAutomation(tree)
+	read := func(path string) string {
+		if path == "" {
+			return ""
+		}
+		file, err := p.GetFileContent(ctx, owner, name, path, ref)
+		if err != nil {
+			return ""
+		}
+		text, err := file.Decode()
+		if err != nil {
+			return ""
 		}
+		return string(text)
 	}
+	automation.BlankIssuesDisabled = analyzer.ParseIssueTemplateConfig(read(automation.IssueTemplateConfig))
+	automation.DependabotEcosystems = analyzer.ParseDependabotEcosystems(read(automation.DependabotConfig))
+	return automation
+}
+
+// FetchChangelog reads the changelog in the tree, if any, and parses its
+// latest entry
+func FetchChangelog(ctx context.Context, p provider.Provider, owner, name, ref string, tree []github.TreeEntry) analyzer.ChangelogInfo {
+	path := analyzer.FindChangelog(tree)
+	if path == "" {
+		return analyzer.ChangelogInfo{}
+	}
+	file, err := p.GetFileContent(ctx, owner, name, path, ref)
+	if err != nil {
+		return analyzer.ChangelogInfo{Path: path}
+	}
+	text, err := file.Decode()
+	if err != nil {
+		return analyzer.ChangelogInfo{Path: path}
+	}
+	return analyzer.ParseChangelog(path, string(text))
+}
+
+// FetchReleaseAutomation detects release tools from their config files
+// and the uses: and run: lines of up to analyzer.MaxReleaseWorkflows
+// workflows
+func FetchReleaseAutomation(ctx context.Context, p provider.Provider, owner, name, ref string, tree []github.TreeEntry) analyzer.ReleaseAutomation {
+	automation := analyzer.DetectReleaseAutomation(tree)
+	for _, workflow := range automation.Workflows {
+		file, err := p.GetFileContent(ctx, owner, name, workflow, ref)
+		if err != nil {
+			continue
+		}
+		if text, err := file.Decode(); err == nil {
+			automation.AddWorkflow(string(text))
+		}
+	}
+	return automation
+}
+
+// FetchMonorepo detects a monorepo and reads the manifests of up to
+// analyzer.MaxMonorepoPackages packages to count their dependencies
+func FetchMonorepo(ctx context.Context, p provider.Provider, owner, name, ref string, tree []github.TreeEntry) analyzer.Monorepo {
+	mono := analyzer.DetectMonorepo(tree)
+	var wg sync.WaitGroup
+	for i := range mono.Packages[:min(len(mono.Packages), analyzer.MaxMonorepoPackages)] {
+		wg.Add(1)
+		go func(pkg *analyzer.MonorepoPackage) {
+			defer wg.Done()
+			file, err := p.GetFileContent(ctx, owner, name, pkg.Manifest, ref)
+			if err != nil {
+				return
+			}
+			if text, err := file.Decode(); err == nil {
+				pkg.Dependencies = analyzer.CountDependencies(pkg.Manifest, string(text))
+			}
+		}(&mono.Packages[i])
+	}
+	wg.Wait()
+	return mono
+}
+
+// FetchReleaseStats fetches releases and computes their cadence, falling back
+// to version tags when a repo tags versions without publishing (many) GitHub Releases
+func FetchReleaseStats(ctx context.Context, client *github.Client, owner, name string) ([]github.Release, analyzer.ReleaseStats) {
+	releases, _ := client.GetReleases(ctx, owner, name, github.DefaultMaxReleases)
+	stats := analyzer.ReleaseCadence(releases)
+	if !analyzer.NeedsTagFallback(stats) {
+		return releases, stats
+	}
+
+	tags, _ := client.GetTags(ctx, owner, name, github.DefaultMaxTags)
+	tagStats := analyzer.AnalyzeTags(tags)
+	if tagStats.VersionCount <= stats.Count {
+		return releases, stats
+	}
+
+	var latestDate time.Time
+	if commit, err := client.GetCommit(ctx, owner, name, tagStats.LatestSHA); err == nil {
+		latestDate = commit.Commit.Author.Date
+	}
+	return releases, analyzer.TagReleaseStats(tagStats, latestDate)
+}
+
+// FetchIssueStats counts open and recently closed issues (excluding pull
+// requests) and measures time-to-close over a sample of closed issues
+func FetchIssueStats(ctx context.Context, client *github.Client, repo *github.Repo) analyzer.IssueStats {
+	stats := analyzer.IssueStats{Enabled: repo.HasIssues}
+	if !repo.HasIssues {
+		return stats
+	}
+
+	query := "repo:" + repo.FullName + " type:issue "
+	open, err := client.CountIssues(ctx, query+"state:open")
+	if err != nil {
+		return stats
+	}
+	since := time.Now().AddDate(0, 0, -90).Format("2006-01-02")
+	closed, err := client.CountIssues(ctx, query+"state:closed closed:>="+since)
+	if err != nil {
+		return stats
+	}
+	opened, err := client.CountIssues(ctx, query+"created:>="+since)
+	if err != nil {
+		return stats
+	}
+	staleBefore := time.Now().AddDate(0, 0, -analyzer.StaleIssueDays).Format("2006-01-02")
+	stale, err := client.CountIssues(ctx, query+"state:open created:<"+staleBefore)
+	if err != nil {
+		return stats
+	}
+
+	owner, name, _ := strings.Cut(repo.FullName, "/")
+	sample, _ := client.GetIssues(ctx, owner, name, github.IssueOptions{
+		State:     "closed",
+		Sort:      "created",
+		Direction: "desc",
+		Max:       github.DefaultIssueSample,
+	})
+
+	stats.Evaluated = true
+	stats.OpenIssues = open
+	stats.ClosedLast90Days = closed
+	stats.OpenedLast90Days = opened
+	stats.StaleOpen = stale
+	stats.MedianDaysToClose, stats.SampleSize = analyzer.MedianDaysToClose(sample)
+	newest, err := client.GetIssues(ctx, owner, name, github.IssueOptions{
+		State:     "open",
+		Sort:      "created",
+		Direction: "desc",
+		Max:       github.DefaultIssueSample,
+	})
+	if err != nil {
+		stats.Ages = analyzer.IssueAges{OldestDays: -1}
+		return stats
+	}
+	stats.Ages = fetchIssueAges(ctx, client, owner, name, newest, open)
+	if labels, err := client.GetLabels(ctx, owner, name, github.DefaultMaxLabels); err == nil {
+		stats.Labeling = analyzer.AnalyzeIssueLabeling(labels, newest)
+	}
+	return stats
+}
+
+// fetchIssueAges buckets the newest open issues, fetching the oldest one
+// when they don't reach back to it
+func fetchIssueAges(ctx context.Context, client *github.Client, owner, name string, newest []github.Issue, open int) analyzer.IssueAges {
+	var oldest *github.Issue
+	if open > len(newest) {
+		first, err := client.GetIssues(ctx, owner, name, github.IssueOptions{
+			State:     "open",
+			Sort:      "created",
+			Direction: "asc",
+			Max:       1,
+		})
+		if err == nil && len(first) > 0 {
+			oldest = &first[0]
+		}
+	}
+	return analyzer.AnalyzeIssueAges(newest, oldest, open, time.Now())
+}
+
+// FetchPullRequestStats counts open and recently merged pull requests and
+// measures merge time and outcomes over the most recently updated PRs
+func FetchPullRequestStats(ctx context.Context, client *github.Client, repo *github.Repo) analyzer.PullRequestStats {
+	query := "repo:" + repo.FullName + " type:pr "
+	open, err := client.CountIssues(ctx, query+"state:open")
+	if err != nil {
+		return analyzer.PullRequestStats{}
+	}
+	since := time.Now().AddDate(0, 0, -90).Format("2006-01-02")
+	merged, err := client.CountIssues(ctx, query+"is:merged merged:>="+since)
+	if err != nil {
+		return analyzer.PullRequestStats{}
+	}
+
+	owner, name, _ := strings.Cut(repo.FullName, "/")
+	sample, err := client.GetPullRequests(ctx, owner, name, github.DefaultPullRequestSample)
+	if err != nil {
+		return analyzer.PullRequestStats{}
+	}
+
+	stats := analyzer.AnalyzePullRequests(sample)
+	stats.Evaluated = true
+	stats.OpenPRs = open
+	stats.MergedLast90Days = merged
+	stats.UnreviewedOpen = -1
+	if unreviewed, err := client.CountIssues(ctx, query+"state:open draft:false review:none"); err == nil {
+		stats.UnreviewedOpen = unreviewed
+	}
+	return stats
+}
+
+// FetchBranchProtection checks the protection rules of the default branch
+func FetchBranchProtection(ctx context.Context, client *github.Client, repo *github.Repo) analyzer.BranchProtectionInfo {
+	if repo.DefaultBranch == "" {
+		return analyzer.BranchProtectionInfo{Status: analyzer.ProtectionUnknown}
+	}
+	owner, name, _ := strings.Cut(repo.FullName, "/")
+	protection, err := client.GetBranchProtection(ctx, owner, name, repo.DefaultBranch)
+	return analyzer.AnalyzeBranchProtection(repo.DefaultBranch, protection, err)
+}
+
+// FetchBranchStats lists the branches and counts the stale ones. Without
+// dates from GraphQL, a sample of branches gets their head commit looked up.
+func FetchBranchStats(ctx context.Context, client *github.Client, repo *github.Repo) analyzer.BranchStats {
+	owner, name, _ := strings.Cut(repo.FullName, "/")
+	list, err := client.GetBranches(ctx, owner, name, github.DefaultMaxBranches)
+	if err != nil {
+		return analyzer.BranchStats{}
+	}
+	client.DateBranches(ctx, owner, name, list.Branches, github.DefaultBranchDateSample)
+	return analyzer.AnalyzeBranches(list, time.Now())
+}
+
+// FetchCommunityHealth reads the community profile, falling back to scanning
+// the file tree (if any) where the endpoint isn't available
+func FetchCommunityHealth(ctx context.Context, client *github.Client, owner, name string, tree []github.TreeEntry) analyzer.CommunityHealth {
+	if profile, err := client.GetCommunityProfile(ctx, owner, name); err == nil {
+		return analyzer.CommunityHealthFromProfile(profile)
+	}
+	return analyzer.CommunityHealthFromTree(tree)
+}
+
+// FetchCI detects CI configuration in the file tree (fetched if nil) and the
+// latest GitHub Actions run per workflow on the default branch
+func FetchCI(ctx context.Context, client *github.Client, repo *github.Repo, tree []github.TreeEntry) analyzer.CIInfo {
+	if tree == nil && repo.DefaultBranch != "" {
+		owner, name, _ := strings.Cut(repo.FullName, "/")
+		tree, _ = client.GetFileTree(ctx, owner, name, repo.DefaultBranch)
+	}
+	ci := analyzer.DetectCI(tree)
+	if ci.HasCI() {
+		ci.Workflows = analyzer.LatestWorkflowRuns(fetchWorkflowRuns(ctx, client, repo, repo.DefaultBranch))
+	}
+	return ci
+}
+
+// fetchWorkflowRuns lists recent Actions runs on branch (none for a tag or
+// SHA). The endpoint is only queried with a token, as it is costly to page
+// through anonymously.
+func fetchWorkflowRuns(ctx context.Context, client *github.Client, repo *github.Repo, branch string) []github.WorkflowRun {
+	if client.TokenSource() == github.TokenSourceNone || branch == "" {
+		return nil
+	}
+	owner, name, _ := strings.Cut(repo.FullName, "/")
+	runs, _ := client.GetWorkflowRuns(ctx, owner, name, branch)
+	return runs
+}
+
+// FetchScorecard reads the repo's latest OpenSSF Scorecard results, nil
+// when the public dataset doesn't cover it or can't be reached
+func FetchScorecard(ctx context.Context, host string, repo *github.Repo) *scorecard.Result {
+	owner, name, _ := strings.Cut(repo.FullName, "/")
+	result, err := scorecard.NewClient("").Get(ctx, host, owner, name)
+	if err != nil {
+		return nil
+	}
+	return result
+}
+
+// FetchStarHistory reads stargazer timestamps to measure star growth. Repos
+// with more than github.MaxStarHistoryPages pages of stars are sampled: the
+// first page, a middle one and the most recent ones GitHub will serve.
+func FetchStarHistory(ctx context.Context, client *github.Client, repo *github.Repo) analyzer.StarHistory {
+	owner, name, _ := strings.Cut(repo.FullName, "/")
+	pages := (repo.Stars + github.StargazersPerPage - 1) / github.StargazersPerPage
+	reachable := pages
+	if reachable > github.MaxStargazerPages {
+		reachable = github.MaxStargazerPages
+	}
+
+	var toFetch []int
+	if reachable <= github.MaxStarHistoryPages {
+		for page := 1; page <= reachable; page++ {
+			toFetch = append(toFetch, page)
+		}
+	} else {
+		recent := reachable - github.MaxStarHistoryPages + 3
+		toFetch = append(toFetch, 1)
+		if middle := reachable / 2; middle > 1 && middle < recent {
+			toFetch = append(toFetch, middle)
+		}
+		for page := recent; page <= reachable; page++ {
+			toFetch = append(toFetch, page)
+		}
+	}
+
+	var samples []analyzer.StarSample
+	for _, page := range toFetch {
+		stargazers, err := client.GetStargazersPage(ctx, owner, name, page)
+		if err != nil {
+			return analyzer.StarHistory{}
+		}
+		sample := analyzer.StarSample{Offset: (page - 1) * github.StargazersPerPage}
+		for _, s := range stargazers {
+			sample.Times = append(sample.Times, s.StarredAt)
+		}
+		samples = append(samples, sample)
+	}
+
+	complete := len(toFetch) == pages
+	return analyzer.AnalyzeStarHistory(samples, repo.Stars, complete, time.Now())
+}
+
+// maxForkComparisons caps the compare requests made per analysis when
+// looking for active forks
+const maxForkComparisons = 5
+
+// FetchActiveForks looks through the newest forks for ones pushed to after
+// the upstream stalled, keeping those that are ahead of it (or couldn't be
+// compared), most recently pushed first
+func FetchActiveForks(ctx context.Context, client *github.Client, repo *github.Repo) []analyzer.ForkCandidate {
+	owner, name, _ := strings.Cut(repo.FullName, "/")
+	forks, err := client.GetForks(ctx, owner, name, github.DefaultMaxForks)
+	if err != nil && len(forks) == 0 {
+		return nil
+	}
+
+	candidates := analyzer.FindActiveForks(repo, forks, time.Now())
+	if len(candidates) > maxForkComparisons {
+		candidates = candidates[:maxForkComparisons]
+	}
+
+	branches := make(map[string]string, len(forks))
+	for _, fork := range forks {
+		branches[fork.FullName] = fork.DefaultBranch
+	}
+
+	var active []analyzer.ForkCandidate
+	for _, candidate := range candidates {
+		forkOwner, _, _ := strings.Cut(candidate.FullName, "/")
+		head := forkOwner + ":" + branches[candidate.FullName]
+		if comparison, err := client.CompareCommits(ctx, owner, name, repo.DefaultBranch, head); err == nil {
+			if comparison.AheadBy == 0 {
+				continue
+			}
+			candidate.AheadBy = comparison.AheadBy
+		}
+		active = append(active, candidate)
+	}
+	return active
+}
+
+// FetchReadme fetches and analyzes the README at ref (the default branch when
+// empty). A missing or unreadable README yields a ReadmeInfo with Exists
+// false rather than an error.
+func FetchReadme(ctx context.Context, client *github.Client, owner, name, ref string) analyzer.ReadmeInfo {
+	file, err := client.GetReadme(ctx, owner, name, ref)
+	if err != nil {
+		return analyzer.ReadmeInfo{}
+	}
+	text, err := file.Decode()
+	if err != nil {
+		return analyzer.ReadmeInfo{}
+	}
+	return analyzer.AnalyzeReadme(file.Path, string(text))
+}
+
+// FetchWeeklyActivity takes the yearly commit histogram from the stats
+// endpoint, bucketing the fetched commits by week if it isn't available
+func FetchWeeklyActivity(ctx context.Context, client *github.Client, owner, name string, commits []github.Commit) analyzer.WeeklyActivity {
+	if weeks, err := client.GetCommitActivity(ctx, owner, name); err == nil && len(weeks) > 0 {
+		return analyzer.WeeklyActivityFromStats(weeks)
+	}
+	return analyzer.WeeklyActivityFromCommits(commits, time.Now())
+}
+
+// FetchFirstResponse measures the time to first maintainer response over
+// the most recent issues, at one request per issue with comments
+func FetchFirstResponse(ctx context.Context, client *github.Client, repo *github.Repo) analyzer.FirstResponse {
+	if !repo.HasIssues {
+		return analyzer.FirstResponse{}
+	}
+	owner, name, _ := strings.Cut(repo.FullName, "/")
+	issues, err := client.GetIssues(ctx, owner, name, github.IssueOptions{
+		State:     "all",
+		Sort:      "created",
+		Direction: "desc",
+		Max:       github.DefaultResponseSample,
+	})
+	if err != nil {
+		return analyzer.FirstResponse{}
+	}
+	threads := client.GetIssueThreads(ctx, owner, name, issues, github.DefaultResponseSample)
+	return analyzer.AnalyzeFirstResponse(threads, time.Now())
+}
+
+// FetchReviewedPullRequests fetches the reviews of recently merged pull
+// requests, from the same sample FetchPullRequestStats uses
+func FetchReviewedPullRequests(ctx context.Context, client *github.Client, repo *github.Repo) []github.ReviewedPullRequest {
+	owner, name, _ := strings.Cut(repo.FullName, "/")
+	sample, err := client.GetPullRequests(ctx, owner, name, github.DefaultPullRequestSample)
+	if err != nil {
+		return nil
+	}
+	return client.GetReviewedPullRequests(ctx, owner, name, sample, github.DefaultReviewSample)
+}
+
+// FetchContributorTrend fetches the year of history before history.Since
+// and compares its contributors with those of recent, the commits within
+// history. If the earlier year can't be fetched the trend is unevaluated.
+func FetchContributorTrend(ctx context.Context, p provider.Provider, owner, name string, history github.CommitOptions, recent []github.Commit, truncated bool) analyzer.ContributorTrend {
+	prior := history
+	prior.Since, prior.Until = history.Since.AddDate(-1, 0, 0), history.Since
+	priorCommits, priorTruncated, err := p.GetCommits(ctx, owner, name, prior)
+	if err != nil {
+		return analyzer.ContributorTrend{}
+	}
+	return analyzer.AnalyzeContributorTrend(recent, priorCommits, time.Now(), truncated || priorTruncated)
 }
 
 func (m MainModel) compareInputView() string {
@@ -465,10 +1336,10 @@ func (m MainModel) compareInputView() string {
 	}
 
 	inputContent += InputStyle.Render("> "+currentInput) + "\n\n"
-	inputContent += SubtleStyle.Render("Format: owner/repo  •  Press Enter to continue  •  ESC to go back")
+	inputContent += SubtleStyle.Render("Format: owner/repo or gitlab.com/group/project  •  Press Enter to continue  •  ESC to go back")
 
 	if m.err != nil {
-		inputContent += "\n\n" + ErrorStyle.Render(fmt.Sprintf("Error: %v", m.err))
+		inputContent += "\n\n" + renderError(m.err, m.client.AuthCapability())
 	}
 
 	box := BoxStyle.Render(inputContent)
@@ -486,6 +1357,25 @@ func (m MainModel) compareInputView() string {
 	)
 }
 
+// primaryLanguageLabel renders the primary language and its share, e.g.
+// "Go (82%)"
+func primaryLanguageLabel(profile analyzer.LanguageProfile) string {
+	if !profile.Evaluated {
+		return "unknown"
+	}
+	return fmt.Sprintf("%s (%.0f%%)", profile.Primary, profile.PrimaryShare*100)
+}
+
+// weeklyOverlay draws both repos' weekly commits on one scale, so the
+// sparklines' heights compare
+func weeklyOverlay(r1, r2 AnalysisResult) string {
+	peak := max(r1.WeeklyCommits.Peak(), r2.WeeklyCommits.Peak())
+	width := max(len(r1.Repo.FullName), len(r2.Repo.FullName))
+	return fmt.Sprintf("📈 Weekly Commits (52 weeks, peak %d)\n%-*s  %s\n%-*s  %s", peak,
+		width, r1.Repo.FullName, RenderScaledSparkline(r1.WeeklyCommits.Counts(), peak),
+		width, r2.Repo.FullName, RenderScaledSparkline(r2.WeeklyCommits.Counts(), peak))
+}
+
 func (m MainModel) compareResultView() string {
 	if m.compareResult == nil || m.compareResult.Repo1.Repo == nil || m.compareResult.Repo2.Repo == nil {
 		return "No comparison data"
@@ -493,6 +1383,7 @@ func (m MainModel) compareResultView() string {
 
 	r1 := m.compareResult.Repo1
 	r2 := m.compareResult.Repo2
+	diff := m.compareResult.Diff()
 
 	header := TitleStyle.Render(fmt.Sprintf("📊 Comparison: %s vs %s", r1.Repo.FullName, r2.Repo.FullName))
 
@@ -502,14 +1393,21 @@ func (m MainModel) compareResultView() string {
 		strings.Repeat("─", 75),
 		fmt.Sprintf("%-20s │ %-25d │ %-25d", "⭐ Stars", r1.Repo.Stars, r2.Repo.Stars),
 		fmt.Sprintf("%-20s │ %-25d │ %-25d", "🍴 Forks", r1.Repo.Forks, r2.Repo.Forks),
-		fmt.Sprintf("%-20s │ %-25d │ %-25d", "📦 Commits (1y)", len(r1.Commits), len(r2.Commits)),
+		fmt.Sprintf("%-20s │ %-25s │ %-25s", "📜 License", analyzer.LicenseLabel(r1.Repo), analyzer.LicenseLabel(r2.Repo)),
+		fmt.Sprintf("%-20s │ %-25s │ %-25s", "📦 Commits (1y)", commitCountLabel(r1), commitCountLabel(r2)),
 		fmt.Sprintf("%-20s │ %-25d │ %-25d", "👥 Contributors", len(r1.Contributors), len(r2.Contributors)),
-		fmt.Sprintf("%-20s │ %-25s │ %-25s", "💚 Health Score", fmt.Sprintf("%d", r1.HealthScore), fmt.Sprintf("%d", r2.HealthScore)),
+		fmt.Sprintf("%-20s │ %-25s │ %-25s", "💚 Health Score", fmt.Sprintf("%d (%s)", r1.HealthScore, r1.HealthGrade), fmt.Sprintf("%d (%s)", r2.HealthScore, r2.HealthGrade)),
 		fmt.Sprintf("%-20s │ %-25s │ %-25s", "⚠️ Bus Factor", fmt.Sprintf("%d (%s)", r1.BusFactor, r1.BusRisk), fmt.Sprintf("%d (%s)", r2.BusFactor, r2.BusRisk)),
-		fmt.Sprintf("%-20s │ %-25s │ %-25s", "🏗️ Maturity", fmt.Sprintf("%s (%d)", r1.MaturityLevel, r1.MaturityScore), fmt.Sprintf("%s (%d)", r2.MaturityLevel, r2.MaturityScore)),
+		fmt.Sprintf("%-20s │ %-25s │ %-25s", "🏗️ Maturity", fmt.Sprintf("%s (%d, %s)", r1.MaturityLevel, r1.MaturityScore, r1.MaturityGrade), fmt.Sprintf("%s (%d, %s)", r2.MaturityLevel, r2.MaturityScore, r2.MaturityGrade)),
 	}
 
+	if r1.LanguageProfile.Evaluated || r2.LanguageProfile.Evaluated {
+		rows = append(rows, fmt.Sprintf("%-20s │ %-25s │ %-25s", "💻 Primary Language", primaryLanguageLabel(r1.LanguageProfile), primaryLanguageLabel(r2.LanguageProfile)))
+	}
 	tableContent := strings.Join(rows, "\n")
+	if diff.LanguageSimilarity >= 0 {
+		tableContent += "\n" + SubtleStyle.Render(fmt.Sprintf("Language profile similarity: %.0f%%", diff.LanguageSimilarity*100))
+	}
 	tableBox := BoxStyle.Render(tableContent)
 
 	// Verdict
@@ -523,15 +1421,27 @@ func (m MainModel) compareResultView() string {
 	}
 	verdictBox := BoxStyle.Render("📌 Verdict\n" + verdict)
 
-	footer := SubtleStyle.Render("q/ESC: back to menu")
+	sections := []string{header, tableBox}
+	if len(r1.WeeklyCommits.Weeks) > 0 && len(r2.WeeklyCommits.Weeks) > 0 {
+		sections = append(sections, BoxStyle.Render(weeklyOverlay(r1, r2)))
+	}
+	if len(diff.SharedTopics) > 0 {
+		sections = append(sections, BoxStyle.Render("🏷️ Shared Topics\n"+renderTopics(diff.SharedTopics)))
+	}
+	if len(diff.SharedContributors) > 0 {
+		sections = append(sections, BoxStyle.Render("👥 Shared Contributors\n"+strings.Join(diff.SharedContributors, ", ")))
+	}
+	sections = append(sections, verdictBox)
+	if m.compareExport {
+		sections = append(sections, BoxStyle.Render("📥 Export:\n[J] JSON\n[M] Markdown"))
+	}
+	if m.compareStatus != "" {
+		sections = append(sections, SubtleStyle.Render(m.compareStatus))
+	}
 
-	content := lipgloss.JoinVertical(
-		lipgloss.Left,
-		header,
-		tableBox,
-		verdictBox,
-		footer,
-	)
+	footer := SubtleStyle.Render("e: export • q/ESC: back to menu")
+
+	content := lipgloss.JoinVertical(lipgloss.Left, append(sections, footer)...)
 
 	if m.windowWidth == 0 {
 		return content
@@ -546,71 +1456,28 @@ func (m MainModel) compareResultView() string {
 	)
 }
 
-func (m MainModel) compareRepos(repo1Name, repo2Name string) tea.Cmd {
+func (m MainModel) compareRepos(ctx context.Context, repo1Name, repo2Name string) tea.Cmd {
 	return func() tea.Msg {
-		parts1 := strings.Split(repo1Name, "/")
-		parts2 := strings.Split(repo2Name, "/")
-
-		if len(parts1) != 2 {
-			return fmt.Errorf("first repository must be in owner/repo format")
+		target1, err := provider.ParseTarget(repo1Name, m.client.Host())
+		if err != nil {
+			return fmt.Errorf("first repository: %w", err)
 		}
-		if len(parts2) != 2 {
-			return fmt.Errorf("second repository must be in owner/repo format")
+		target2, err := provider.ParseTarget(repo2Name, m.client.Host())
+		if err != nil {
+			return fmt.Errorf("second repository: %w", err)
 		}
 
-		client := github.NewClient()
-
 		// Analyze first repo
-		repo1, err := client.GetRepo(parts1[0], parts1[1])
+		result1, err := runAnalysis(ctx, m.providerFor(target1), target1, m.options, NewProgressTracker())
 		if err != nil {
 			return fmt.Errorf("failed to fetch %s: %w", repo1Name, err)
 		}
-		commits1, _ := client.GetCommits(parts1[0], parts1[1], 365)
-		contributors1, _ := client.GetContributors(parts1[0], parts1[1])
-		languages1, _ := client.GetLanguages(parts1[0], parts1[1])
-		fileTree1, _ := client.GetFileTree(parts1[0], parts1[1], repo1.DefaultBranch)
-		score1 := analyzer.CalculateHealth(repo1, commits1)
-		busFactor1, busRisk1 := analyzer.BusFactor(contributors1)
-		maturityScore1, maturityLevel1 := analyzer.RepoMaturityScore(repo1, len(commits1), len(contributors1), false)
-
-		result1 := AnalysisResult{
-			Repo:          repo1,
-			Commits:       commits1,
-			Contributors:  contributors1,
-			FileTree:      fileTree1,
-			Languages:     languages1,
-			HealthScore:   score1,
-			BusFactor:     busFactor1,
-			BusRisk:       busRisk1,
-			MaturityScore: maturityScore1,
-			MaturityLevel: maturityLevel1,
-		}
 
 		// Analyze second repo
-		repo2, err := client.GetRepo(parts2[0], parts2[1])
+		result2, err := runAnalysis(ctx, m.providerFor(target2), target2, m.options, NewProgressTracker())
 		if err != nil {
 			return fmt.Errorf("failed to fetch %s: %w", repo2Name, err)
 		}
-		commits2, _ := client.GetCommits(parts2[0], parts2[1], 365)
-		contributors2, _ := client.GetContributors(parts2[0], parts2[1])
-		languages2, _ := client.GetLanguages(parts2[0], parts2[1])
-		fileTree2, _ := client.GetFileTree(parts2[0], parts2[1], repo2.DefaultBranch)
-		score2 := analyzer.CalculateHealth(repo2, commits2)
-		busFactor2, busRisk2 := analyzer.BusFactor(contributors2)
-		maturityScore2, maturityLevel2 := analyzer.RepoMaturityScore(repo2, len(commits2), len(contributors2), false)
-
-		result2 := AnalysisResult{
-			Repo:          repo2,
-			Commits:       commits2,
-			Contributors:  contributors2,
-			FileTree:      fileTree2,
-			Languages:     languages2,
-			HealthScore:   score2,
-			BusFactor:     busFactor2,
-			BusRisk:       busRisk2,
-			MaturityScore: maturityScore2,
-			MaturityLevel: maturityLevel2,
-		}
 
 		return CompareResult{
 			Repo1: result1,
@@ -619,8 +1486,8 @@ func (m MainModel) compareRepos(repo1Name, repo2Name string) tea.Cmd {
 	}
 }
 
-func Run() error {
-	p := tea.NewProgram(NewMainModel(), tea.WithAltScreen())
+func Run(client *github.Client, options Options) error {
+	p := tea.NewProgram(NewMainModel(client, options), tea.WithAltScreen())
 	_, err := p.Run()
 	return err
 }
@@ -0,0 +1,57 @@
+package ui
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/agnivo988/Repo-lyzer/internal/github"
+)
+
+func TestFetchWeeklyActivity(t *testing.T) {
+	sunday := time.Now().UTC().Truncate(24*time.Hour).AddDate(0, 0, -int(time.Now().UTC().Weekday()))
+	commits := make([]github.Commit, 3)
+	for i := range commits {
+		commits[i].Commit.Author.Date = sunday.Add(time.Hour)
+	}
+
+	tests := []struct {
+		name      string
+		status    int
+		body      string
+		fromStats bool
+		weeks     int
+		total     int
+	}{
+		{"stats available", http.StatusOK, `[{"week":1700352000,"total":7},{"week":1700956800,"total":0}]`, true, 2, 7},
+		{"stats missing", http.StatusNotFound, `{"message":"Not Found"}`, false, 52, 3},
+		{"stats empty", http.StatusOK, `[]`, false, 52, 3},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path != "/api/v3/repos/o/r/stats/commit_activity" {
+					t.Errorf("unexpected request to %s", r.URL.Path)
+				}
+				w.WriteHeader(tt.status)
+				w.Write([]byte(tt.body))
+			}))
+			defer server.Close()
+			client := github.NewClient(
+				github.WithBaseURL(server.URL),
+				github.WithRetryPolicy(github.RetryPolicy{MaxAttempts: 1}),
+			)
+			client.SetCache(nil)
+
+			got := FetchWeeklyActivity(context.Background(), client, "o", "r", commits)
+			if got.FromStats != tt.fromStats {
+				t.Errorf("FromStats = %v, want %v", got.FromStats, tt.fromStats)
+			}
+			if len(got.Weeks) != tt.weeks || got.Total() != tt.total {
+				t.Errorf("got %d weeks totalling %d, want %d totalling %d", len(got.Weeks), got.Total(), tt.weeks, tt.total)
+			}
+		})
+	}
+}
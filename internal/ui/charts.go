@@ -4,7 +4,9 @@ import (
 	"fmt"
 	"sort"
 	"strings"
+	"time"
 
+	"github.com/agnivo988/Repo-lyzer/internal/analyzer"
 	"github.com/charmbracelet/lipgloss"
 )
 
@@ -68,3 +70,103 @@ func RenderCommitActivity(data map[string]int, maxDays int) string {
 	}
 	return sb.String()
 }
+
+// RenderSparkline draws values as a single line of block characters
+func RenderSparkline(values []int) string {
+	max := 0
+	for _, v := range values {
+		if v > max {
+			max = v
+		}
+	}
+	return RenderScaledSparkline(values, max)
+}
+
+// RenderScaledSparkline draws values against a given maximum, so several
+// series drawn with the same one can be compared
+func RenderScaledSparkline(values []int, max int) string {
+	levels := []rune("▁▂▃▄▅▆▇█")
+
+	var sb strings.Builder
+	for _, v := range values {
+		level := 0
+		if max > 0 {
+			level = min(v, max) * (len(levels) - 1) / max
+		}
+		sb.WriteRune(levels[level])
+	}
+	return countStyle.Render(sb.String())
+}
+
+// RenderIssueAges draws the open-issue age buckets as horizontal bars
+func RenderIssueAges(ages analyzer.IssueAges) string {
+	max := 0
+	for _, n := range ages.Buckets {
+		if n > max {
+			max = n
+		}
+	}
+	var sb strings.Builder
+	for i, label := range analyzer.IssueAgeLabels {
+		n := ages.Buckets[i]
+		barLen := 0
+		if max > 0 {
+			barLen = n * 20 / max
+		}
+		sb.WriteString(fmt.Sprintf(
+			"%s | %s %s\n",
+			dateStyle.Render(fmt.Sprintf("%-8s", label)),
+			barColor(n, max).Render(strings.Repeat("█", barLen)),
+			countStyle.Render(fmt.Sprintf("%d", n)),
+		))
+	}
+	if ages.Unbucketed > 0 {
+		sb.WriteString(fmt.Sprintf("+ %d older issues not sampled\n", ages.Unbucketed))
+	}
+	if ages.OldestDays >= 0 {
+		sb.WriteString(fmt.Sprintf("Oldest: #%d, open %.0f days", ages.OldestNumber, ages.OldestDays))
+	}
+	return strings.TrimSuffix(sb.String(), "\n")
+}
+
+// timezoneColors tell the bands of RenderTimezones apart
+var timezoneColors = []string{"#FF5F87", "#FFAF00", "#00D7AF", "#5FAFFF", "#AF87FF"}
+
+// RenderTimezones draws the timezone bands as one 40-cell stacked bar,
+// with a legend of the bands that have commits
+func RenderTimezones(spread analyzer.TimezoneSpread) string {
+	var bar strings.Builder
+	var legend []string
+	for i, band := range spread.Bands {
+		if band.Commits == 0 {
+			continue
+		}
+		style := lipgloss.NewStyle().Foreground(lipgloss.Color(timezoneColors[i%len(timezoneColors)]))
+		bar.WriteString(style.Render(strings.Repeat("█", int(band.Share*40+0.5))))
+		legend = append(legend, style.Render("■")+fmt.Sprintf(" %s %.0f%%", band.Label, band.Share*100))
+	}
+	return bar.String() + "\n" + strings.Join(legend, "  ")
+}
+
+// RenderHeatmap draws the commit punch card, one row per weekday from
+// Monday and one column per hour, shaded by commit count
+func RenderHeatmap(h analyzer.CommitHeatmap) string {
+	shades := []rune(" ░▒▓█")
+	days := []time.Weekday{time.Monday, time.Tuesday, time.Wednesday, time.Thursday, time.Friday, time.Saturday, time.Sunday}
+	max := h.Max()
+
+	var sb strings.Builder
+	sb.WriteString("    " + dateStyle.Render("0     6     12    18    ") + "\n")
+	for _, day := range days {
+		var row strings.Builder
+		for _, n := range h.Matrix[day] {
+			shade := 0
+			if n > 0 && max > 0 {
+				shade = 1 + n*(len(shades)-2)/max
+			}
+			row.WriteRune(shades[shade])
+		}
+		sb.WriteString(fmt.Sprintf("%s %s\n", dateStyle.Render(day.String()[:3]), countStyle.Render(row.String())))
+	}
+	return sb.String()
+}
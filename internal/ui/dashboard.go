@@ -2,11 +2,14 @@ package ui
 
 import (
 	"fmt"
+	"math"
 	"sort"
 	"strings"
 	"time"
 
 	"github.com/agnivo988/Repo-lyzer/internal/analyzer"
+	"github.com/agnivo988/Repo-lyzer/internal/github"
+	"github.com/agnivo988/Repo-lyzer/internal/scorecard"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 )
@@ -22,6 +25,8 @@ const (
 	viewContributors
 	viewRecruiter
 	viewAPIStatus
+	viewIssues
+	viewFiles
 )
 
 type DashboardModel struct {
@@ -33,6 +38,14 @@ type DashboardModel struct {
 	statusMsg   string
 	currentView dashboardView
 	showHelp    bool
+	// adjustedLanguages shows the language breakdown without generated
+	// and vendored code
+	adjustedLanguages bool
+	// showMaturity expands the overview's maturity row into its factors
+	showMaturity bool
+	// exportOptions tune what the export keys write
+	exportOptions ExportOptions
+	client        *github.Client // for live auth and rate limit status
 }
 
 func NewDashboardModel() DashboardModel {
@@ -95,14 +108,65 @@ func (m DashboardModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 		case "j":
 			if m.showExport {
+				data, exportOptions := m.data, m.exportOptions
 				return m, func() tea.Msg {
-					return exportMsg{nil, "Exported to analysis.json"}
+					return exportMsg{ExportJSON(data, "analysis.json", exportOptions), "Exported to analysis.json"}
+				}
+			}
+
+		case "y":
+			if m.showExport {
+				data, exportOptions := m.data, m.exportOptions
+				return m, func() tea.Msg {
+					return exportMsg{ExportYAML(data, "analysis.yaml", exportOptions), "Exported to analysis.yaml"}
+				}
+			}
+
+		case "w":
+			if m.showExport {
+				data, exportOptions := m.data, m.exportOptions
+				return m, func() tea.Msg {
+					return exportMsg{ExportHTML(data, "analysis.html", exportOptions), "Exported to analysis.html"}
+				}
+			}
+
+		case "p":
+			if m.showExport {
+				data, exportOptions := m.data, m.exportOptions
+				return m, func() tea.Msg {
+					return exportMsg{ExportPDF(data, "analysis.pdf", exportOptions), "Exported to analysis.pdf"}
+				}
+			}
+
+		case "b":
+			if m.showExport {
+				data := m.data
+				return m, func() tea.Msg {
+					return exportMsg{ExportBadges(data, "badges"), "Exported badges to badges/"}
+				}
+			}
+
+		case "c":
+			if m.showExport {
+				data := m.data
+				return m, func() tea.Msg {
+					return exportMsg{ExportCSV(data, "analysis.csv"), "Exported to analysis.csv, analysis_languages.csv and analysis_contributors.csv"}
 				}
 			}
 
 		case "f":
 			return m, func() tea.Msg { return "switch_to_tree" }
 
+		case "a":
+			if m.currentView == viewLanguages {
+				m.adjustedLanguages = !m.adjustedLanguages
+			}
+
+		case "m":
+			if m.currentView == viewOverview {
+				m.showMaturity = !m.showMaturity
+			}
+
 		case "r":
 			// Refresh - re-analyze current repo
 			if m.data.Repo != nil {
@@ -138,11 +202,19 @@ func (m DashboardModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			m.currentView = viewAPIStatus
 			m.showHelp = false
 			m.showExport = false
+		case "8":
+			m.currentView = viewIssues
+			m.showHelp = false
+			m.showExport = false
+		case "9":
+			m.currentView = viewFiles
+			m.showHelp = false
+			m.showExport = false
 
 		// Arrow key navigation between views
 		case "right", "l":
 			if !m.showHelp && !m.showExport {
-				if m.currentView < viewAPIStatus {
+				if m.currentView < viewFiles {
 					m.currentView++
 				}
 			}
@@ -185,6 +257,10 @@ func (m DashboardModel) View() string {
 		content = m.recruiterView()
 	case viewAPIStatus:
 		content = m.apiStatusView()
+	case viewIssues:
+		content = m.issuesView()
+	case viewFiles:
+		content = m.filesView()
 	}
 
 	// Add export panel if shown
@@ -192,17 +268,26 @@ func (m DashboardModel) View() string {
 		content = lipgloss.JoinVertical(
 			lipgloss.Left,
 			content,
-			BoxStyle.Render("📥 Export:\n[J] JSON"),
+			BoxStyle.Render("📥 Export:\n[J] JSON\n[Y] YAML\n[C] CSV\n[W] HTML report\n[P] PDF report\n[B] Badges"),
 		)
 	}
 
+	if len(m.data.Unavailable) > 0 {
+		content += "\n" + ErrorStyle.Render("⚠️ Some sections failed to load: "+strings.Join(sortedKeys(m.data.Unavailable), ", "))
+	}
+	if m.data.FromStaleCache {
+		content += "\n" + ErrorStyle.Render("⚠️ GitHub unreachable: showing data from cache, possibly stale")
+	}
 	if m.statusMsg != "" {
 		content += "\n" + SubtleStyle.Render(m.statusMsg)
 	}
 
 	// Navigation tabs
 	tabs := m.renderTabs()
-	footer := SubtleStyle.Render("←→/hl: switch view • 1-6: jump to view • e: export • f: file tree • ?: help • q: back")
+	footer := SubtleStyle.Render("←→/hl: switch view • 1-9: jump to view • e: export • f: file tree • ?: help • q: back")
+	if m.client != nil {
+		footer += "\n" + SubtleStyle.Render("🔐 "+m.client.AuthCapability().Label())
+	}
 
 	fullContent := lipgloss.JoinVertical(
 		lipgloss.Left,
@@ -225,7 +310,7 @@ func (m DashboardModel) View() string {
 }
 
 func (m DashboardModel) renderTabs() string {
-	views := []string{"Overview", "Repo", "Languages", "Activity", "Contributors", "Recruiter", "API"}
+	views := []string{"Overview", "Repo", "Languages", "Activity", "Contributors", "Recruiter", "API", "Issues", "Files"}
 	var tabs []string
 
 	for i, name := range views {
@@ -240,38 +325,207 @@ func (m DashboardModel) renderTabs() string {
 	return BoxStyle.Render(strings.Join(tabs, "│"))
 }
 
+// weeklySparkline is the year of weekly commits as a sparkline for the
+// overview's activity line, empty without the series
+func weeklySparkline(weekly analyzer.WeeklyActivity) string {
+	if len(weekly.Weeks) == 0 {
+		return ""
+	}
+	return "  " + RenderSparkline(weekly.Counts())
+}
+
 func (m DashboardModel) overviewView() string {
 	header := TitleStyle.Render(
 		fmt.Sprintf("📊 Analysis for %s", m.data.Repo.FullName),
-	)
+	) + SubtleStyle.Render("  @ "+m.data.RefLabel())
 
 	metrics := fmt.Sprintf(
-		"Health Score: %d\nBus Factor: %d (%s)\nMaturity: %s (%d)",
+		"Health Score: %d %s\nActivity: %s%s\nBus Factor: %s (%s)\nWork Spread: Gini %s\nMaturity: %s (%d) %s",
 		m.data.HealthScore,
-		m.data.BusFactor,
-		m.data.BusRisk,
+		renderGrade(m.data.HealthGrade),
+		m.data.ActivityTrend.Summary(),
+		weeklySparkline(m.data.WeeklyCommits),
+		m.data.BusFactorInfo.Label(),
+		toneStyles[busFactorTone(m.data.BusFactorInfo)].Render(m.data.BusRisk),
+		m.data.Inequality.Summary(),
 		m.data.MaturityLevel,
 		m.data.MaturityScore,
+		renderGrade(m.data.MaturityGrade),
 	)
+	if len(m.data.MaturityComponents) > 0 {
+		hint := "  (m: show factors)"
+		if m.showMaturity {
+			hint = "  (m: hide factors)"
+		}
+		metrics += SubtleStyle.Render(hint)
+	}
+	metrics += SubtleStyle.Render(fmt.Sprintf("\n  Releases: %s (+%d/%d)",
+		m.data.ReleaseCadence.Summary(), m.data.ReleaseCadence.Points, analyzer.ReleaseCadenceWeight))
+	if changelog := m.data.Changelog; changelog.Path != "" {
+		line := "\n  Changelog: " + changelog.Summary()
+		if changelog.Behind {
+			metrics += WarningStyle.Render(line)
+		} else {
+			metrics += SubtleStyle.Render(line)
+		}
+	}
+	metrics += "\nLanguages: " + m.data.LanguageProfile.Summary()
+	metrics += "\nEngagement: " + m.data.Engagement.Summary()
+	for _, note := range m.data.Engagement.Interpretations {
+		metrics += SubtleStyle.Render("\n  " + note)
+	}
+	if m.data.SectionError(SectionFileTree) == "" {
+		ci := "CI: " + m.data.CI.Summary()
+		if !m.data.CI.Live() {
+			ci = ErrorStyle.Render(ci)
+		}
+		metrics += "\n" + ci
+		tests := "Tests: " + m.data.Tests.Summary()
+		if m.data.Tests.Evaluated && m.data.Tests.TestFiles == 0 {
+			tests = ErrorStyle.Render(tests)
+		}
+		metrics += "\n" + tests
+	}
+	metrics += "\n" + licenseLine(m.data.Repo, m.data.License)
+	if m.data.HealthWeights != analyzer.DefaultHealthWeights {
+		metrics += SubtleStyle.Render("\n(health weights: " + m.data.HealthWeights.String() + ")")
+	}
+	if m.data.ContributorsTruncated {
+		metrics += SubtleStyle.Render(fmt.Sprintf("\n(bus factor from the top %d contributors only)", len(m.data.Contributors)))
+	}
 	metricsBox := BoxStyle.Render(metrics)
 
 	activity := analyzer.CommitsPerDay(m.data.Commits)
 	chart := RenderCommitActivity(activity, 10)
 	chartBox := BoxStyle.Render(chart)
 
-	return lipgloss.JoinVertical(
-		lipgloss.Left,
+	sections := []string{
 		header,
+		statusLine(m.data.Abandonment),
 		lipgloss.JoinHorizontal(lipgloss.Top, metricsBox, chartBox),
-	)
+	}
+	if m.showMaturity {
+		sections = append(sections, breakdownBox("🏗️ Maturity Factors", m.data.MaturityComponents))
+	}
+	sections = append(sections, breakdownBox("💚 Health Breakdown", m.data.HealthComponents))
+	return lipgloss.JoinVertical(lipgloss.Left, sections...)
+}
+
+// breakdownBox shows what each component adds to a score, bars filled by
+// the component's sub-score
+func breakdownBox(title string, components []analyzer.ScoreComponent) string {
+	if len(components) == 0 {
+		return ""
+	}
+	lines := []string{title}
+	for _, c := range components {
+		if !c.Evaluated {
+			lines = append(lines, SubtleStyle.Render(fmt.Sprintf("%-14s %s  not evaluated (weight %d)", c.Name, strings.Repeat("·", 10), c.Weight)))
+			continue
+		}
+		filled := int(math.Round(c.Score * 10))
+		bar := strings.Repeat("█", filled) + strings.Repeat("░", 10-filled)
+		lines = append(lines, fmt.Sprintf("%-14s %s %5.1f  %s", c.Name, bar, c.Contribution, SubtleStyle.Render(c.Detail)))
+	}
+	return BoxStyle.Render(strings.Join(lines, "\n"))
+}
+
+// renderGrade colors a grade from green for an A to red for an F
+func renderGrade(grade analyzer.Grade) string {
+	return toneStyles[gradeTone(grade)].Render(string(grade))
+}
+
+// tone is the color band a result is shown in. The dashboard's styles and
+// the badges' colors both key off it, so they always agree.
+type tone int
+
+const (
+	toneGood tone = iota
+	toneFair
+	tonePoor
+	toneBad
+	toneUnknown
+)
+
+var toneStyles = map[tone]lipgloss.Style{
+	toneGood:    SelectedStyle,
+	toneFair:    InputStyle,
+	tonePoor:    WarningStyle,
+	toneBad:     ErrorStyle,
+	toneUnknown: SubtleStyle,
+}
+
+// gradeTone is green for an A, yellow for a B or C, orange for a D and red
+// for an F
+func gradeTone(grade analyzer.Grade) tone {
+	switch grade {
+	case analyzer.GradeAPlus, analyzer.GradeA:
+		return toneGood
+	case analyzer.GradeB, analyzer.GradeC:
+		return toneFair
+	case analyzer.GradeD:
+		return tonePoor
+	}
+	return toneBad
+}
+
+// busFactorTone follows the bus factor's risk: a single point of failure
+// is red, two people orange
+func busFactorTone(info analyzer.BusFactorInfo) tone {
+	switch info.Factor {
+	case 0:
+		return toneUnknown
+	case 1:
+		return toneBad
+	case 2:
+		return tonePoor
+	}
+	return toneGood
+}
+
+// statusLine shows the project's status up front, colored by how far
+// gone it is, with the reasons after it
+func statusLine(risk analyzer.AbandonmentRisk) string {
+	line := "Status: " + strings.ToUpper(risk.Status)
+	switch risk.Status {
+	case analyzer.StatusActive:
+		line = SelectedStyle.Render(line)
+	case analyzer.StatusSlowing:
+		line = WarningStyle.Render(line)
+	default:
+		line = ErrorStyle.Render(line)
+	}
+	if len(risk.Reasons) > 0 {
+		line += SubtleStyle.Render("  " + strings.Join(risk.Reasons, ", "))
+	}
+	return line
+}
+
+// licenseLine shows the license and its class, in warning colors when
+// there is none or it isn't open source
+func licenseLine(repo *github.Repo, license analyzer.LicenseInfo) string {
+	switch license.Class {
+	case analyzer.LicenseNone:
+		return ErrorStyle.Render("No license")
+	case analyzer.LicenseSourceAvailable:
+		return WarningStyle.Render("License: " + analyzer.LicenseLabel(repo) + " (source-available, not open source)")
+	}
+	line := "License: " + analyzer.LicenseLabel(repo)
+	if license.Class != analyzer.LicenseCustom {
+		line += SubtleStyle.Render(" (" + string(license.Class) + ")")
+	}
+	return line
 }
 
 func (m DashboardModel) repoView() string {
 	header := TitleStyle.Render("📦 Repository Details")
 
+	license := "📜 " + licenseLine(m.data.Repo, m.data.License)
+
 	info := fmt.Sprintf(
 		"Name: %s\n"+
 			"Description: %s\n"+
+			"%s\n"+
 			"⭐ Stars: %d\n"+
 			"🍴 Forks: %d\n"+
 			"🐛 Open Issues: %d\n"+
@@ -281,6 +535,7 @@ func (m DashboardModel) repoView() string {
 			"🔗 URL: %s",
 		m.data.Repo.FullName,
 		m.data.Repo.Description,
+		license,
 		m.data.Repo.Stars,
 		m.data.Repo.Forks,
 		m.data.Repo.OpenIssues,
@@ -289,65 +544,475 @@ func (m DashboardModel) repoView() string {
 		m.data.Repo.DefaultBranch,
 		m.data.Repo.HTMLURL,
 	)
+	if m.data.MentionableUsers >= 0 {
+		info += fmt.Sprintf("\n👥 Mentionable Users: %d", m.data.MentionableUsers)
+	}
+	if m.data.Collaborators >= 0 {
+		info += fmt.Sprintf("\n🔑 Collaborators: %d", m.data.Collaborators)
+	}
+	if len(m.data.Repo.Topics) > 0 {
+		info += "\n\n" + renderTopics(m.data.Repo.Topics)
+	}
+
+	releases := "🏷️ Releases: " + m.data.ReleaseStats.Summary()
+	if m.data.ReleaseStats.Count > 0 && !m.data.ReleaseStats.Semver {
+		releases += SubtleStyle.Render("\n(tags don't follow semantic versioning)")
+	}
+	if automation := m.data.ReleaseAutomation; automation.Evaluated {
+		releases += "\nRelease automation: " + automation.Summary()
+	}
 
-	return lipgloss.JoinVertical(lipgloss.Left, header, BoxStyle.Render(info))
+	readme := "📖 README: " + m.data.Readme.Summary()
+	if !m.data.Readme.Exists {
+		readme = ErrorStyle.Render(readme)
+	}
+	if quality := m.data.ReadmeQuality; quality.Evaluated {
+		readme += fmt.Sprintf("\nQuality: %d/100 %s", quality.Score, renderGrade(analyzer.GradeFor(quality.Score)))
+		for _, c := range quality.Checks {
+			line := c.Mark() + " " + c.Name
+			if c.Detail != "" {
+				line += " (" + c.Detail + ")"
+			}
+			if !c.Passed || c.Skipped {
+				line = SubtleStyle.Render(line)
+			}
+			readme += "\n" + line
+		}
+		if n := len(quality.BrokenLinks); n > 0 {
+			readme += "\n" + WarningStyle.Render("Broken links: "+strings.Join(quality.BrokenLinks[:min(n, 5)], ", "))
+		}
+	}
+
+	hygiene := "🛡️ Branch Protection: " + m.data.BranchProtection.Summary()
+	if m.data.BranchProtection.Status == analyzer.ProtectionUnknown {
+		hygiene = SubtleStyle.Render(hygiene)
+	}
+	if m.data.Branches.Evaluated {
+		hygiene += "\n🌿 Branches: " + m.data.Branches.Summary()
+	}
+
+	return lipgloss.JoinVertical(
+		lipgloss.Left,
+		header,
+		lipgloss.JoinHorizontal(lipgloss.Top, BoxStyle.Render(info), BoxStyle.Render(m.communityChecklist())),
+		BoxStyle.Render(releases+"\n"+readme),
+		lipgloss.JoinHorizontal(lipgloss.Top, BoxStyle.Render(hygiene+"\n"+m.ciStatus()), BoxStyle.Render(m.automationChecklist())),
+		m.securityBox(),
+		m.activeForksBox(),
+	)
 }
 
-func (m DashboardModel) languagesView() string {
-	header := TitleStyle.Render("💻 Languages")
+// securityBox breaks down the security posture score and, when asked
+// for, the OpenSSF Scorecard results, leaving out the box when there's
+// neither
+func (m DashboardModel) securityBox() string {
+	security := m.data.Security
+	var lines []string
+	if security.Evaluated() {
+		lines = append(lines, fmt.Sprintf("🔒 Security Posture: %d/100 %s", security.Score, renderGrade(security.Grade)))
+		for _, c := range security.Components {
+			line := fmt.Sprintf("%-18s %s", c.Name, c.Detail)
+			switch {
+			case !c.Evaluated:
+				line = SubtleStyle.Render(line)
+			case c.Score == 0:
+				line = ErrorStyle.Render(line)
+			}
+			lines = append(lines, line)
+		}
+	}
+	if signing := m.data.SignedCommitRatio; security.Evaluated() || signing.Evaluated {
+		line := "Signed commits: " + signing.Label()
+		if signing.WebUI > 0 {
+			if ratio := signing.ContributorRatio(); ratio >= 0 {
+				line += SubtleStyle.Render(fmt.Sprintf("  (%.0f%% excluding %d web UI commits)", ratio*100, signing.WebUI))
+			}
+		}
+		if len(lines) > 0 {
+			lines = append(lines, "")
+		}
+		lines = append(lines, line)
+	}
+	if m.data.ScorecardChecked {
+		if len(lines) > 0 {
+			lines = append(lines, "")
+		}
+		lines = append(lines, scorecardLines(m.data.Scorecard)...)
+	}
+	if len(lines) == 0 {
+		return ""
+	}
+	return BoxStyle.Render(strings.Join(lines, "\n"))
+}
 
-	if len(m.data.Languages) == 0 {
-		return lipgloss.JoinVertical(lipgloss.Left, header, BoxStyle.Render("No language data available"))
+// scorecardLines lists the OpenSSF Scorecard checks, attributed to
+// Scorecard so they aren't mistaken for our own signals
+func scorecardLines(result *scorecard.Result) []string {
+	lines := []string{"🏅 OpenSSF Scorecard: " + result.Summary()}
+	if result == nil {
+		return lines
+	}
+	for _, c := range result.Checks {
+		line := fmt.Sprintf("%-24s %s", c.Name, c.CheckScore())
+		switch {
+		case c.Score < 0:
+			line = SubtleStyle.Render(line)
+		case c.Score == 0:
+			line = ErrorStyle.Render(line)
+		}
+		lines = append(lines, line)
+	}
+	return lines
+}
+
+// activeForksBox lists forks that may have taken over maintenance, shown
+// only when the repo looks abandoned
+func (m DashboardModel) activeForksBox() string {
+	if !m.data.ForksChecked {
+		return ""
+	}
+	lines := []string{
+		"🍴 Possible Active Forks",
+		SubtleStyle.Render("No push since " + m.data.Repo.PushedAt.Format("2006-01-02")),
+	}
+	if len(m.data.ActiveForks) == 0 {
+		lines = append(lines, "No recently active forks found")
+	}
+	for _, fork := range m.data.ActiveForks {
+		lines = append(lines, "• "+fork.Summary())
+	}
+	return BoxStyle.Render(strings.Join(lines, "\n"))
+}
+
+// ciStatus lists CI providers and the latest run of each workflow, colored
+// by outcome
+func (m DashboardModel) ciStatus() string {
+	ci := m.data.CI
+	if !ci.HasCI() {
+		return ErrorStyle.Render("⚙️ CI: " + ci.Summary())
+	}
+
+	lines := []string{"⚙️ CI: " + ci.Summary()}
+	for _, w := range ci.Workflows {
+		line := fmt.Sprintf("  %s: %s", w.Name, w.Label())
+		switch {
+		case w.Passing():
+			line = SelectedStyle.Render(line)
+		case w.Failing():
+			line = ErrorStyle.Render(line)
+		default:
+			line = SubtleStyle.Render(line)
+		}
+		lines = append(lines, line)
+	}
+	return strings.Join(lines, "\n")
+}
+
+// renderTopics draws topics as chips, a handful per line
+func renderTopics(topics []string) string {
+	const perLine = 5
+
+	var lines []string
+	for i := 0; i < len(topics); i += perLine {
+		end := i + perLine
+		if end > len(topics) {
+			end = len(topics)
+		}
+		var chips []string
+		for _, topic := range topics[i:end] {
+			chips = append(chips, TopicStyle.Render(topic))
+		}
+		lines = append(lines, strings.Join(chips, " "))
+	}
+	return strings.Join(lines, "\n")
+}
+
+func (m DashboardModel) communityChecklist() string {
+	community := m.data.Community
+	content := "👥 Community"
+	if community.HealthPercentage >= 0 {
+		content += fmt.Sprintf(" (%d%%)", community.HealthPercentage)
 	}
+	if community.Source == "" {
+		return content + "\n" + SubtleStyle.Render("No data available")
+	}
+
+	for _, item := range community.Checklist() {
+		if item.Present {
+			content += "\n✅ " + item.Name
+		} else {
+			content += "\n" + SubtleStyle.Render("❌ "+item.Name)
+		}
+	}
+	if community.Source == analyzer.CommunityFromTree {
+		content += "\n" + SubtleStyle.Render("(from the file tree)")
+	}
+	return content
+}
+
+// automationChecklist shows the .github templates and bots configured
+func (m DashboardModel) automationChecklist() string {
+	automation := m.data.Automation
+	content := "🤖 Automation"
+	if !automation.Evaluated {
+		return content + "\n" + SubtleStyle.Render("No data available")
+	}
+	for _, item := range automation.Checklist() {
+		if item.Present {
+			content += "\n✅ " + item.Name
+		} else {
+			content += "\n" + SubtleStyle.Render("❌ "+item.Name)
+		}
+	}
+	if automation.IssueTemplates > 0 {
+		content += "\n" + SubtleStyle.Render(fmt.Sprintf("(%d issue templates)", automation.IssueTemplates))
+	}
+	if len(automation.DependabotEcosystems) > 0 {
+		content += "\n" + SubtleStyle.Render("Dependabot: "+strings.Join(automation.DependabotEcosystems, ", "))
+	}
+	return content
+}
 
-	// Calculate total bytes
-	total := 0
-	for _, bytes := range m.data.Languages {
-		total += bytes
+func (m DashboardModel) languagesView() string {
+	header := TitleStyle.Render("💻 Languages")
+	languages := m.data.Languages
+	switch {
+	case m.adjustedLanguages:
+		languages = m.data.AdjustedLanguages
+		header += SubtleStyle.Render("  (excluding generated and vendored code; a: show all)")
+	case m.data.LanguagesEstimated:
+		header += SubtleStyle.Render("  (estimated from file extensions; a: exclude generated code)")
+	default:
+		header += SubtleStyle.Render("  (a: exclude generated and vendored code)")
 	}
 
-	// Sort languages by bytes
-	type langStat struct {
-		name  string
-		bytes int
+	if msg := m.data.SectionError(SectionLanguages); msg != "" && !m.adjustedLanguages {
+		return lipgloss.JoinVertical(lipgloss.Left, header, unavailableBox(msg))
 	}
-	var langs []langStat
-	for name, bytes := range m.data.Languages {
-		langs = append(langs, langStat{name, bytes})
+
+	ranked := analyzer.RankLanguages(languages)
+	if len(ranked) == 0 {
+		return lipgloss.JoinVertical(lipgloss.Left, header, BoxStyle.Render("No language data available"))
 	}
-	sort.Slice(langs, func(i, j int) bool {
-		return langs[i].bytes > langs[j].bytes
-	})
 
 	var lines []string
-	for _, lang := range langs {
-		pct := float64(lang.bytes) / float64(total) * 100
-		barLen := int(pct / 5) // 20 chars max
-		if barLen < 1 && lang.bytes > 0 {
+	for _, lang := range analyzer.TopLanguages(ranked, analyzer.ListedLanguages) {
+		barLen := int(lang.Percent / 5) // 20 chars max
+		if barLen < 1 && lang.Bytes > 0 {
 			barLen = 1
 		}
 		bar := strings.Repeat("█", barLen)
-		lines = append(lines, fmt.Sprintf("%-15s %s %.1f%%", lang.name, bar, pct))
+		lines = append(lines, fmt.Sprintf("%-15s %s %.1f%%", lang.Name, bar, lang.Percent))
 	}
 
 	return lipgloss.JoinVertical(lipgloss.Left, header, BoxStyle.Render(strings.Join(lines, "\n")))
 }
 
+// filesView breaks the file tree down by extension, with the largest and
+// most deeply nested files
+func (m DashboardModel) filesView() string {
+	header := TitleStyle.Render("🗂️ Files")
+
+	if msg := m.data.SectionError(SectionFileTree); msg != "" {
+		return lipgloss.JoinVertical(lipgloss.Left, header, unavailableBox(msg))
+	}
+	stats := m.data.TreeStats
+	if stats.Files == 0 {
+		return lipgloss.JoinVertical(lipgloss.Left, header, BoxStyle.Render("No files found"))
+	}
+
+	summary := stats.Summary()
+	summary += fmt.Sprintf("\nDeepest: %s (%d levels)", stats.DeepestPath, stats.MaxDepth)
+	if stats.Excluded > 0 {
+		summary += SubtleStyle.Render(fmt.Sprintf("\n(%d vendored or generated files excluded)", stats.Excluded))
+	}
+
+	lines := []string{"By extension"}
+	for i, ext := range stats.Extensions {
+		if i == 10 {
+			lines = append(lines, SubtleStyle.Render(fmt.Sprintf("… and %d more", len(stats.Extensions)-i)))
+			break
+		}
+		pct := 0.0
+		if stats.Bytes > 0 {
+			pct = float64(ext.Bytes) / float64(stats.Bytes) * 100
+		}
+		bar := strings.Repeat("█", max(1, int(pct/5)))
+		lines = append(lines, fmt.Sprintf("%-10s %-20s %5.1f%%  %d files, %s",
+			ext.Extension, bar, pct, ext.Files, analyzer.FormatBytes(ext.Bytes)))
+	}
+
+	largest := []string{"Largest files"}
+	for _, f := range stats.Largest {
+		largest = append(largest, fmt.Sprintf("%9s  %s", analyzer.FormatBytes(f.Bytes), f.Path))
+	}
+
+	return lipgloss.JoinVertical(
+		lipgloss.Left,
+		header,
+		BoxStyle.Render(summary),
+		lipgloss.JoinHorizontal(lipgloss.Top,
+			BoxStyle.Render(strings.Join(lines, "\n")),
+			BoxStyle.Render(strings.Join(largest, "\n"))),
+		m.repoSizeBox(),
+		m.monorepoBox(),
+	)
+}
+
+// monorepoBox tabulates a monorepo's packages; single-package repos get
+// nothing
+func (m DashboardModel) monorepoBox() string {
+	mono := m.data.Monorepo
+	if !mono.Detected {
+		return ""
+	}
+	lines := []string{
+		fmt.Sprintf("🧩 Monorepo: %d packages", len(mono.Packages)) + SubtleStyle.Render(" ("+strings.Join(mono.Signals, ", ")+")"),
+		SubtleStyle.Render(fmt.Sprintf("%-30s %-10s %-12s %6s %5s", "Package", "Ecosystem", "Language", "Files", "Deps")),
+	}
+	for _, pkg := range mono.Packages {
+		lines = append(lines, fmt.Sprintf("%-30s %-10s %-12s %6d %5s",
+			pkg.Path, pkg.Ecosystem, pkg.Language, pkg.Files, dependencyCount(pkg.Dependencies)))
+	}
+	return BoxStyle.Render(strings.Join(lines, "\n"))
+}
+
+// dependencyCount renders a dependency count, "?" when unknown
+func dependencyCount(n int) string {
+	if n < 0 {
+		return "?"
+	}
+	return fmt.Sprint(n)
+}
+
+// repoSizeBox flags the checked-in files over the large file threshold
+func (m DashboardModel) repoSizeBox() string {
+	size := m.data.RepoSize
+	summary := "📦 Repo Size: " + size.Summary()
+	if !size.Bloated() {
+		return BoxStyle.Render(summary)
+	}
+	lines := []string{ErrorStyle.Render(summary)}
+	for i, f := range size.LargeFiles {
+		if i == 5 {
+			lines = append(lines, SubtleStyle.Render(fmt.Sprintf("… and %d more", len(size.LargeFiles)-i)))
+			break
+		}
+		line := fmt.Sprintf("%9s  %s", analyzer.FormatBytes(f.Bytes), f.Path)
+		if f.Binary {
+			line += SubtleStyle.Render(" (binary)")
+		}
+		lines = append(lines, line)
+	}
+	return BoxStyle.Render(strings.Join(lines, "\n"))
+}
+
 func (m DashboardModel) activityView() string {
 	header := TitleStyle.Render("📈 Commit Activity (Last 30 Days)")
 
+	if msg := m.data.SectionError(SectionCommits); msg != "" {
+		return lipgloss.JoinVertical(lipgloss.Left, header, unavailableBox(msg))
+	}
+
 	activity := analyzer.CommitsPerDay(m.data.Commits)
 	chart := RenderCommitActivity(activity, 30)
 
-	totalCommits := len(m.data.Commits)
-	stats := fmt.Sprintf("\nTotal Commits (1 year): %d", totalCommits)
+	stats := fmt.Sprintf("\nTotal Commits (1 year): %s", commitCountLabel(m.data))
+	if m.data.Workflow.Evaluated {
+		stats += "\nWorkflow: " + m.data.Workflow.Summary()
+	}
+	if weeks := m.data.WeeklyCommits; len(weeks.Weeks) > 0 {
+		stats += fmt.Sprintf(
+			"\n\nWeekly (52 weeks): %s\nActive weeks: %d/%d",
+			RenderSparkline(weeks.Counts()),
+			weeks.ActiveWeeks(),
+			len(weeks.Weeks),
+		)
+	}
+
+	return lipgloss.JoinVertical(lipgloss.Left, header, BoxStyle.Render(chart+stats), m.churnBox(), m.hygieneBox(), m.heatmapBox(), m.hotspotsBox(), m.starGrowthBox())
+}
+
+// hotspotsBox lists the files changed most, shown only when the hotspot
+// analysis ran
+func (m DashboardModel) hotspotsBox() string {
+	report := m.data.Hotspots
+	if report.Sampled == 0 {
+		return ""
+	}
+	lines := []string{fmt.Sprintf("🔥 Hotspots (%d sampled commits)", report.Sampled)}
+	for _, h := range report.Hotspots {
+		lines = append(lines, fmt.Sprintf("%4d  %3.0f%%  %s", h.Changes, h.Share*100, h.Path))
+	}
+	return BoxStyle.Render(strings.Join(lines, "\n"))
+}
+
+// churnBox shows the lines added and deleted, with weekly sparklines when
+// the code frequency stats were available
+func (m DashboardModel) churnBox() string {
+	churn := m.data.Churn
+	if !churn.Evaluated {
+		return ""
+	}
+	content := "🧮 Code Churn (1 year)\n" + churn.Summary()
+	if len(churn.WeeklyAdditions) > 0 {
+		content += fmt.Sprintf(
+			"\n\nAdded:   %s\nDeleted: %s",
+			RenderSparkline(churn.WeeklyAdditions),
+			RenderSparkline(churn.WeeklyDeletions),
+		)
+	}
+	return BoxStyle.Render(content)
+}
 
-	return lipgloss.JoinVertical(lipgloss.Left, header, BoxStyle.Render(chart+stats))
+func (m DashboardModel) hygieneBox() string {
+	hygiene := m.data.CommitHygiene
+	if !hygiene.Evaluated {
+		return ""
+	}
+	content := fmt.Sprintf(
+		"✍️  Commit Messages: %d/100 (%d sampled)\n"+
+			"Conventional commits: %.0f%%\n"+
+			"Average subject:      %.0f chars\n"+
+			"Over %d chars:        %.0f%%\n"+
+			"Bare (\"fix\", \"wip\"):  %.0f%%\n"+
+			"Merge commits:        %.0f%%",
+		hygiene.Score, hygiene.Sampled,
+		hygiene.ConventionalShare*100,
+		hygiene.AverageSubjectLength,
+		analyzer.MaxSubjectLength, hygiene.LongSubjectShare*100,
+		hygiene.BareShare*100,
+		hygiene.MergeShare*100,
+	)
+	return BoxStyle.Render(content)
+}
+
+func (m DashboardModel) heatmapBox() string {
+	heatmap := m.data.Heatmap
+	if heatmap.Total == 0 {
+		return ""
+	}
+	content := "🕒 Commit Times (" + heatmap.ZoneLabel() + ")\n" + RenderHeatmap(heatmap) +
+		SubtleStyle.Render(heatmap.Summary())
+	return BoxStyle.Render(content)
+}
+
+func (m DashboardModel) starGrowthBox() string {
+	stars := m.data.Stars
+	content := "⭐ Star Growth\n" + stars.Summary()
+	if !stars.Evaluated {
+		return BoxStyle.Render(SubtleStyle.Render(content))
+	}
+	content += fmt.Sprintf("\n\nMonthly (12 months): %s", RenderSparkline(stars.Monthly))
+	return BoxStyle.Render(content)
 }
 
 func (m DashboardModel) contributorsView() string {
 	header := TitleStyle.Render("👥 Top Contributors")
 
+	if msg := m.data.SectionError(SectionContributors); msg != "" {
+		return lipgloss.JoinVertical(lipgloss.Left, header, unavailableBox(msg))
+	}
 	if len(m.data.Contributors) == 0 {
 		return lipgloss.JoinVertical(lipgloss.Left, header, BoxStyle.Render("No contributor data available"))
 	}
@@ -368,25 +1033,121 @@ func (m DashboardModel) contributorsView() string {
 			barLen = 1
 		}
 		bar := strings.Repeat("█", barLen)
-		lines = append(lines, fmt.Sprintf("%2d. %-20s %s %d", i+1, c.Login, bar, c.Commits))
+		line := fmt.Sprintf("%2d. %-20s %-20s %d", i+1, c.Login, bar, c.Commits)
+		switch tier := m.data.ContributorTiers.ByLogin[c.Login]; tier {
+		case analyzer.TierCore:
+			line += " " + SelectedStyle.Render(string(tier))
+		case analyzer.TierDriveBy:
+			line += " " + SubtleStyle.Render(string(tier))
+		}
+		if details := contributorDetails(c); details != "" {
+			line += SubtleStyle.Render("  " + details)
+		}
+		lines = append(lines, line)
 	}
 
-	summary := fmt.Sprintf("\nTotal Contributors: %d", len(m.data.Contributors))
+	total := fmt.Sprintf("%d", len(m.data.Contributors))
+	if m.data.ContributorsTruncated {
+		total += "+"
+	}
+	summary := fmt.Sprintf("\nTotal Contributors: %s (%s)", total, m.data.ContributorTiers.Summary())
 	lines = append(lines, summary)
 
-	return lipgloss.JoinVertical(lipgloss.Left, header, BoxStyle.Render(strings.Join(lines, "\n")))
+	return lipgloss.JoinVertical(lipgloss.Left,
+		header,
+		BoxStyle.Render(strings.Join(lines, "\n")),
+		m.contributorTrendBox(),
+		m.timezonesBox(),
+		m.ownershipBox(),
+	)
+}
+
+// timezonesBox shows the share of commits from each band of UTC offsets
+// as one stacked bar
+func (m DashboardModel) timezonesBox() string {
+	spread := m.data.Timezones
+	if !spread.Evaluated {
+		if spread.OnlyUTC {
+			return BoxStyle.Render(SubtleStyle.Render("🌍 Timezones: " + spread.Summary()))
+		}
+		return ""
+	}
+	return BoxStyle.Render("🌍 Timezones (" + fmt.Sprint(spread.Commits) + " commits)\n" + RenderTimezones(spread) +
+		SubtleStyle.Render(fmt.Sprintf("\n%d distinct offsets, %d among core contributors", spread.Offsets, spread.CoreOffsets)))
+}
+
+// contributorTrendBox shows contributors joining and leaving, with a
+// sparkline of active contributors per quarter over two years
+func (m DashboardModel) contributorTrendBox() string {
+	trend := m.data.ContributorTrend
+	if !trend.Evaluated {
+		return ""
+	}
+	lines := []string{
+		"📈 Contributor Trend",
+		fmt.Sprintf("Active: %d this year, %d last year", trend.ThisYear, trend.LastYear),
+		fmt.Sprintf("Retained: %d   Churned: %d", trend.Retained, trend.Churned),
+		fmt.Sprintf("New: %d in 365 days, %d in 90 days", trend.New365, trend.New90),
+		"Active per quarter: " + RenderSparkline(trend.ActiveByQuarter()),
+	}
+	if trend.Truncated {
+		lines = append(lines, SubtleStyle.Render("(from capped commit history, counts may be low)"))
+	}
+	return BoxStyle.Render(strings.Join(lines, "\n"))
+}
+
+// contributorDetails describes a contributor's profile, as far as it's known
+func contributorDetails(c github.Contributor) string {
+	if c.IsBot() {
+		return "🤖 bot"
+	}
+	var details []string
+	if c.Name != "" {
+		details = append(details, c.Name)
+	}
+	if c.Company != "" {
+		details = append(details, c.Company)
+	}
+	return strings.Join(details, ", ")
+}
+
+func (m DashboardModel) ownershipBox() string {
+	o := m.data.Ownership
+	lines := []string{"🔑 Ownership", o.Summary()}
+	if o.Path != "" {
+		lines = append(lines, "File: "+o.Path)
+	}
+	if len(o.InactiveOwners) > 0 {
+		lines = append(lines, "No recent commits: "+strings.Join(o.InactiveOwners, ", "))
+	}
+	if len(m.data.DirectoryOwners) > 0 {
+		lines = append(lines, "", SubtleStyle.Render(fmt.Sprintf("%-20s %-28s %7s  %s", "Directory", "Top author", "Changes", "CODEOWNERS")))
+		for _, d := range m.data.DirectoryOwners {
+			line := fmt.Sprintf("%-20s %-28s %7d  %s", d.Directory, d.Summary(), d.Changes, strings.Join(d.CodeOwners, " "))
+			if d.Risky() {
+				line = ErrorStyle.Render(line + "  ⚠️ single owner")
+			}
+			lines = append(lines, line)
+		}
+	}
+	return BoxStyle.Render(strings.Join(lines, "\n"))
 }
 
 func (m DashboardModel) recruiterView() string {
 	header := TitleStyle.Render("👔 Recruiter Summary")
 
 	// Determine activity level
+	commitCount := len(m.data.Commits)
+	if m.data.WeeklyCommits.FromStats {
+		commitCount = m.data.WeeklyCommits.Total()
+	}
+
 	activityLevel := "Low"
-	if len(m.data.Commits) > 500 {
+	if commitCount > 500 {
 		activityLevel = "Very High"
-	} else if len(m.data.Commits) > 200 {
+	} else if commitCount > 200 {
 		activityLevel = "High"
-	} else if len(m.data.Commits) > 50 {
+	} else if commitCount > 50 {
 		activityLevel = "Medium"
 	}
 
@@ -394,33 +1155,126 @@ func (m DashboardModel) recruiterView() string {
 		"Repository: %s\n"+
 			"⭐ Stars: %d\n"+
 			"🍴 Forks: %d\n"+
-			"📦 Commits (1y): %d\n"+
+			"📦 Commits (1y): %s\n"+
 			"👥 Contributors: %d\n"+
-			"🏗️ Maturity: %s (%d)\n"+
-			"⚠️ Bus Factor: %d - %s\n"+
+			"🏗️ Maturity: %s (%d) %s\n"+
+			"⚠️ Bus Factor: %s - %s\n"+
+			"⚖️ Work Spread: Gini %s\n"+
 			"🔥 Activity: %s\n"+
-			"💚 Health Score: %d/100",
+			"💚 Health Score: %d/100 %s",
 		m.data.Repo.FullName,
 		m.data.Repo.Stars,
 		m.data.Repo.Forks,
-		len(m.data.Commits),
+		commitCountLabel(m.data),
 		len(m.data.Contributors),
-		m.data.MaturityLevel, m.data.MaturityScore,
-		m.data.BusFactor, m.data.BusRisk,
+		m.data.MaturityLevel, m.data.MaturityScore, renderGrade(m.data.MaturityGrade),
+		m.data.BusFactorInfo.Label(), m.data.BusRisk,
+		m.data.Inequality.Summary(),
 		activityLevel,
-		m.data.HealthScore,
+		m.data.HealthScore, renderGrade(m.data.HealthGrade),
 	)
 
 	return lipgloss.JoinVertical(lipgloss.Left, header, BoxStyle.Render(summary))
 }
 
+func (m DashboardModel) issuesView() string {
+	header := TitleStyle.Render("🐛 Issues & Pull Requests")
+
+	return lipgloss.JoinVertical(lipgloss.Left, header, m.issuesPanel(), m.pullRequestsPanel())
+}
+
+func (m DashboardModel) issuesPanel() string {
+	issues := m.data.Issues
+	if !issues.Enabled {
+		return BoxStyle.Render("Issues disabled for this repository")
+	}
+	if !issues.Evaluated {
+		return BoxStyle.Render("Issue data unavailable")
+	}
+
+	closeTime := "n/a"
+	if issues.SampleSize > 0 {
+		closeTime = fmt.Sprintf("%.1f days (over %d closed issues)", issues.MedianDaysToClose, issues.SampleSize)
+	}
+
+	responsiveness := m.data.Responsiveness
+	label := responsiveness.Label
+	switch label {
+	case analyzer.Responsive:
+		label = SelectedStyle.Render(label)
+	case analyzer.Unresponsive:
+		label = ErrorStyle.Render(label)
+	}
+	panel := fmt.Sprintf(
+		"🐛 Open Issues: %d (%d older than %d days)\n"+
+			"✅ Closed (90d): %d, opened: %d\n"+
+			"⏱️ Median Time to Close: %s\n"+
+			"📣 Responsiveness: %s",
+		issues.OpenIssues, issues.StaleOpen, analyzer.StaleIssueDays,
+		issues.ClosedLast90Days, issues.OpenedLast90Days,
+		closeTime,
+		label,
+	)
+	if responsiveness.Evaluated {
+		panel += fmt.Sprintf(" (score %.2f)", responsiveness.Score)
+	}
+	if first := m.data.FirstResponse; first.Evaluated {
+		panel += fmt.Sprintf(
+			"\n💬 First Maintainer Response: %s median, %.0f%% unanswered (of %d recent issues)",
+			first.MedianLabel(), first.NoResponseShare*100, first.Sampled,
+		)
+	}
+	if f := m.data.Friendliness; f.Evaluated {
+		templates := "no"
+		if f.IssueTemplates {
+			templates = "yes"
+		}
+		panel += fmt.Sprintf(
+			"\n🤝 Contributor Friendliness: %s (%d)\n"+
+				"   %d labels, %.0f%% of open issues labeled, %d good first issues, %d help wanted, issue templates: %s",
+			f.Level, f.Score,
+			f.Labeling.Labels, f.Labeling.LabeledShare()*100, f.Labeling.GoodFirstIssues, f.Labeling.HelpWanted,
+			templates,
+		)
+	}
+	if issues.Ages.Evaluated {
+		panel += "\n\n⏳ Open Issue Ages\n" + RenderIssueAges(issues.Ages)
+	}
+	return BoxStyle.Render(panel)
+}
+
+func (m DashboardModel) pullRequestsPanel() string {
+	prs := m.data.PullRequests
+	if !prs.Evaluated {
+		return BoxStyle.Render("Pull request data unavailable")
+	}
+
+	mergeTime := "n/a"
+	if prs.MergeSampleSize > 0 {
+		mergeTime = fmt.Sprintf("%.1f days (over %d merged PRs, drafts excluded)", prs.MedianDaysToMerge, prs.MergeSampleSize)
+	}
+
+	return BoxStyle.Render(fmt.Sprintf(
+		"🔀 Open PRs: %d\n"+
+			"✅ Merged (90d): %d\n"+
+			"⏱️ Median Time to Merge: %s\n"+
+			"⚖️ Merged vs Closed Unmerged: %s\n"+
+			"👀 Reviews: %s",
+		prs.OpenPRs,
+		prs.MergedLast90Days,
+		mergeTime,
+		prs.MergeRatioLabel(),
+		m.data.Reviews.Summary(),
+	))
+}
+
 func (m DashboardModel) helpView() string {
 	header := TitleStyle.Render("❓ Keyboard Shortcuts")
 
 	help := `
 Dashboard Navigation:
   ←/→ or h/l    Switch between views
-  1-7           Jump to specific view
+  1-9           Jump to specific view
   
 Views:
   1  Overview     - Health, Bus Factor, Maturity
@@ -430,11 +1284,20 @@ Views:
   5  Contributors - Top contributors
   6  Recruiter    - Summary for recruiters
   7  API Status   - GitHub API rate limits
+  8  Issues       - Issue and pull request throughput
+  9  Files        - File counts and sizes by extension
 
 Actions:
   e             Toggle export menu
   j             Export to JSON (when export menu open)
+  y             Export to YAML (when export menu open)
+  c             Export to CSV (when export menu open)
+  w             Export an HTML report (when export menu open)
+  p             Export a PDF report (when export menu open)
+  b             Export shields.io badges to badges/ (when export menu open)
   f             Open file tree
+  a             Toggle generated code in Languages
+  m             Toggle maturity factors in Overview
   r             Refresh data
   ?/h           Toggle this help
   q/ESC         Go back / Close overlay
@@ -453,33 +1316,71 @@ Actions:
 func (m DashboardModel) apiStatusView() string {
 	header := TitleStyle.Render("🔐 GitHub API Status")
 
-	// Check if authenticated
-	mode := "Unauthenticated (60 req/hour)"
-	if m.data.Repo != nil && m.data.Repo.Private {
-		mode = "Authenticated (5000 req/hour)"
-	} else {
-		// Simple check - if we got detailed data, likely authenticated
-		if len(m.data.Contributors) > 30 {
-			mode = "Authenticated (5000 req/hour)"
+	mode := "Unknown"
+	requests := ""
+	tip := "Tip: Set GITHUB_TOKEN, GH_TOKEN or run\n" +
+		"`gh auth login` for higher rate limits (5000/hour)"
+	if m.client != nil {
+		mode = m.client.AuthDescription() + "\nCredential: " + m.client.AuthCapability().Label()
+		if m.client.TokenSource() != github.TokenSourceNone {
+			tip = ""
+		}
+		if rl := m.client.RateLimitStatus(); rl.Known {
+			requests = fmt.Sprintf("Requests: %d / %d (resets %s)\n", rl.Remaining, rl.Limit, rl.Reset.Format("15:04"))
 		}
 	}
 
 	info := fmt.Sprintf(
-		"Mode: %s\n\n"+
+		"Mode: %s\n"+
+			"%s\n"+
 			"Data Fetched:\n"+
 			"  • Repository info: ✓\n"+
-			"  • Commits (1 year): %d\n"+
+			"  • Commits (1 year): %s\n"+
 			"  • Contributors: %d\n"+
 			"  • Languages: %d\n"+
 			"  • File tree: %d entries\n\n"+
-			"Tip: Set GITHUB_TOKEN env variable\n"+
-			"for higher rate limits (5000/hour)",
+			"%s",
 		mode,
-		len(m.data.Commits),
+		requests,
+		commitCountLabel(m.data),
 		len(m.data.Contributors),
 		len(m.data.Languages),
 		len(m.data.FileTree),
+		tip,
 	)
 
-	return lipgloss.JoinVertical(lipgloss.Left, header, BoxStyle.Render(info))
+	return lipgloss.JoinVertical(lipgloss.Left, header, BoxStyle.Render(strings.TrimRight(info, "\n")))
+}
+
+// sortedKeys returns the keys of m in order
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// unavailableBox explains why a section has no data
+func unavailableBox(msg string) string {
+	return BoxStyle.Render(ErrorStyle.Render("⚠️ Unavailable") + "\n" + SubtleStyle.Render(msg))
+}
+
+// commitCountLabel formats the yearly commit count, marking it when the fetch
+// cap was hit and no exact figure from the stats endpoint is available
+func commitCountLabel(data AnalysisResult) string {
+	if !data.WeeklyCommits.FromStats && data.CommitsTruncated {
+		return fmt.Sprintf("%d+", commitCount(data))
+	}
+	return fmt.Sprintf("%d", commitCount(data))
+}
+
+// commitCount is the yearly commit count, a lower bound when the fetch was
+// truncated
+func commitCount(data AnalysisResult) int {
+	if data.WeeklyCommits.FromStats {
+		return data.WeeklyCommits.Total()
+	}
+	return len(data.Commits)
 }
@@ -0,0 +1,89 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/agnivo988/Repo-lyzer/internal/github"
+)
+
+// hostOnlyTransport fails requests to any host but the allowed one,
+// recording them
+type hostOnlyTransport struct {
+	host string
+
+	mu      sync.Mutex
+	visited []string
+	paths   []string
+}
+
+func (t *hostOnlyTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if req.URL.Host != t.host {
+		t.visited = append(t.visited, req.URL.String())
+		return nil, fmt.Errorf("request to %s outside the Enterprise host", req.URL.Host)
+	}
+	t.paths = append(t.paths, req.Method+" "+req.URL.Path)
+	return http.DefaultTransport.RoundTrip(req)
+}
+
+func TestAnalyzeEnterpriseStaysOnHost(t *testing.T) {
+	pushed := time.Now().AddDate(-3, 0, 0).UTC().Format(time.RFC3339)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v3/repos/corp/tool":
+			fmt.Fprintf(w, `{"name":"tool","full_name":"corp/tool","default_branch":"main","stargazers_count":3,"pushed_at":%q,"has_issues":true}`, pushed)
+		case "/api/v3/repos/corp/tool/contributors":
+			w.Write([]byte(`[{"login":"dev","contributions":10,"type":"User"}]`))
+		case "/api/v3/repos/corp/tool/commits":
+			w.Write([]byte(`[{"sha":"abc","commit":{"author":{"name":"Dev","date":"2025-01-01T00:00:00Z"},"message":"Initial"},"author":{"login":"dev"}}]`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"message":"Not Found"}`))
+		}
+	}))
+	defer server.Close()
+
+	serverURL, _ := url.Parse(server.URL)
+	transport := &hostOnlyTransport{host: serverURL.Host}
+	client := github.NewClient(
+		github.WithBaseURL(server.URL),
+		github.WithToken("test"),
+		github.WithHTTPClient(&http.Client{Transport: transport}),
+		github.WithRetryPolicy(github.RetryPolicy{MaxAttempts: 1}),
+	)
+	client.SetCache(nil)
+
+	options := Options{
+		EnrichContributors: 5,
+		StarHistory:        true,
+		Hotspots:           true,
+		AbandonedAfterDays: 30,
+		Export:             DefaultExportOptions,
+	}
+	result, err := Analyze(context.Background(), client, "corp/tool", options)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Repo.FullName != "corp/tool" || len(result.Contributors) != 1 {
+		t.Errorf("analysis didn't use the Enterprise server's data: %+v", result.Repo)
+	}
+	if !result.ForksChecked {
+		t.Error("forks weren't searched, so that request went untested")
+	}
+	if len(transport.visited) > 0 {
+		t.Errorf("requests left the Enterprise host: %s", strings.Join(transport.visited, ", "))
+	}
+	if !slices.Contains(transport.paths, "POST /api/graphql") {
+		t.Errorf("GraphQL wasn't sent to the Enterprise endpoint: %s", strings.Join(transport.paths, ", "))
+	}
+}
@@ -1,48 +1,485 @@
 package ui
 
 import (
+	"bufio"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"os"
+	"strings"
+
+	"github.com/agnivo988/Repo-lyzer/internal/analyzer"
+	"github.com/agnivo988/Repo-lyzer/internal/github"
 )
 
-func ExportJSON(data AnalysisResult, filename string) error {
+// Stdout is the export filename that writes to standard output instead of
+// a file, for piping
+const Stdout = "-"
+
+// noData stands in for a section with nothing to show
+const noData = "No data available\n"
+
+// errNoAnalysis is returned when exporting a result without a repository,
+// which only an analysis that never ran has
+var errNoAnalysis = errors.New("no analysis to export")
+
+// ExportOptions tune what the exports include
+type ExportOptions struct {
+	// ContributorLimit is how many of the top contributors the exports
+	// list, except the CSV, which has everyone; 0 lists them all. The JSON
+	// and YAML contributor_count is still the total.
+	ContributorLimit int
+	// IncludeCommits lists every commit in the window in the JSON and YAML
+	// exports, which otherwise only count them
+	IncludeCommits bool
+	// MermaidCharts adds Mermaid charts of the languages, weekly commits
+	// and contributors to the Markdown export, for renderers like GitHub's
+	// that draw them
+	MermaidCharts bool
+}
+
+// DefaultExportOptions list the top 10 contributors in summaries
+var DefaultExportOptions = ExportOptions{ContributorLimit: 10}
+
+// topContributors trims contributors, sorted most commits first, to the
+// limit
+func (o ExportOptions) topContributors(contributors []github.Contributor) []github.Contributor {
+	if o.ContributorLimit > 0 && len(contributors) > o.ContributorLimit {
+		return contributors[:o.ContributorLimit]
+	}
+	return contributors
+}
+
+// ExportFormats are the formats Export accepts
+var ExportFormats = []string{"json", "yaml", "markdown", "csv", "html", "pdf", "badges"}
+
+// Export writes data in format to filename, or to standard output when
+// filename is Stdout. For badges, filename is the directory to write them
+// to.
+func Export(data AnalysisResult, format, filename string, options ExportOptions) error {
+	switch strings.ToLower(format) {
+	case "json":
+		return ExportJSON(data, filename, options)
+	case "yaml":
+		return ExportYAML(data, filename, options)
+	case "markdown":
+		return ExportMarkdown(data, filename, options)
+	case "csv":
+		return ExportCSV(data, filename)
+	case "html":
+		return ExportHTML(data, filename, options)
+	case "pdf":
+		return ExportPDF(data, filename, options)
+	case "badges":
+		return ExportBadges(data, filename)
+	}
+	return fmt.Errorf("unknown export format %q (formats: %s)", format, strings.Join(ExportFormats, ", "))
+}
+
+// exportTo creates filename and hands it to write, or hands over standard
+// output when filename is Stdout
+func exportTo(filename string, write func(io.Writer) error) error {
+	if filename == Stdout {
+		return write(os.Stdout)
+	}
 	file, err := os.Create(filename)
 	if err != nil {
 		return err
 	}
 	defer file.Close()
 
-	encoder := json.NewEncoder(file)
-	encoder.SetIndent("", "  ")
-	return encoder.Encode(data)
+	if err := write(file); err != nil {
+		return err
+	}
+	return file.Close()
 }
 
-func ExportMarkdown(data AnalysisResult, filename string) error {
-	file, err := os.Create(filename)
+func ExportJSON(data AnalysisResult, filename string, options ExportOptions) error {
+	return exportTo(filename, func(w io.Writer) error { return WriteJSON(w, data, options) })
+}
+
+// WriteJSON writes data as indented JSON, versioned by SchemaVersion. The
+// commit list, which can run to a thousand entries, is encoded one commit
+// at a time after the rest of the document.
+func WriteJSON(w io.Writer, data AnalysisResult, options ExportOptions) error {
+	// The commits are streamed below rather than put in the document
+	doc := newJSONDocument(data, ExportOptions{ContributorLimit: options.ContributorLimit})
+	if !options.IncludeCommits {
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(doc)
+	}
+
+	head, err := json.MarshalIndent(doc, "", "  ")
 	if err != nil {
 		return err
 	}
-	defer file.Close()
+	bw := bufio.NewWriter(w)
+	// Reopen the object by dropping its closing "\n}"
+	bw.Write(head[:len(head)-2])
+	bw.WriteString(",\n  \"commits\": [")
+	for i, c := range data.Commits {
+		commit, err := json.MarshalIndent(newExportCommit(c), "    ", "  ")
+		if err != nil {
+			return err
+		}
+		if i > 0 {
+			bw.WriteString(",")
+		}
+		bw.WriteString("\n    ")
+		bw.Write(commit)
+	}
+	if len(data.Commits) > 0 {
+		bw.WriteString("\n  ")
+	}
+	bw.WriteString("]\n}\n")
+	return bw.Flush()
+}
 
+func ExportMarkdown(data AnalysisResult, filename string, options ExportOptions) error {
+	return exportTo(filename, func(w io.Writer) error { return WriteMarkdown(w, data, options) })
+}
+
+// WriteMarkdown writes data as a Markdown report. Sections without data
+// say so rather than being left out.
+func WriteMarkdown(w io.Writer, data AnalysisResult, options ExportOptions) error {
+	if data.Repo == nil {
+		return errNoAnalysis
+	}
 	md := fmt.Sprintf("# Analysis for %s\n\n", data.Repo.FullName)
-	md += fmt.Sprintf("## Health Score: %d\n", data.HealthScore)
-	md += fmt.Sprintf("## Bus Factor: %d (%s)\n", data.BusFactor, data.BusRisk)
-	md += fmt.Sprintf("## Maturity: %s (%d)\n", data.MaturityLevel, data.MaturityScore)
-	
+	md += fmt.Sprintf("Ref: %s\n\n", data.RefLabel())
+	for _, section := range sortedKeys(data.Unavailable) {
+		md += fmt.Sprintf("> ⚠️ %s unavailable: %s\n\n", section, data.Unavailable[section])
+	}
+	if data.FromStaleCache {
+		md += "> ⚠️ From cache, possibly stale: GitHub was unreachable during the analysis.\n\n"
+	}
+	md += fmt.Sprintf("License: %s (%s)\n\n", analyzer.LicenseLabel(data.Repo), data.License.Class)
+	if len(data.Repo.Topics) > 0 {
+		md += fmt.Sprintf("Topics: %s\n\n", strings.Join(data.Repo.Topics, ", "))
+	}
+	md += fmt.Sprintf("## Status: %s\n", data.Abandonment.Status)
+	for _, reason := range data.Abandonment.Reasons {
+		md += fmt.Sprintf("- %s\n", reason)
+	}
+	md += "\n"
+	md += fmt.Sprintf("## Health Score: %d (%s)\n", data.HealthScore, data.HealthGrade)
+	md += fmt.Sprintf("Weights: %s\n", data.HealthWeights)
+	if len(data.HealthComponents) > 0 {
+		md += "\n| Component | Value | Sub-score | Weight | Contribution |\n|---|---|---|---|---|\n"
+		for _, c := range data.HealthComponents {
+			if !c.Evaluated {
+				md += fmt.Sprintf("| %s | not evaluated | | %d | |\n", c.Name, c.Weight)
+				continue
+			}
+			md += fmt.Sprintf("| %s | %s | %.2f | %d | %.1f |\n", c.Name, c.Detail, c.Score, c.Weight, c.Contribution)
+		}
+		md += "\n"
+	}
+	md += languagesMarkdown("Languages", data.Languages, data.LanguagesEstimated)
+	if options.MermaidCharts {
+		md += mermaidLanguagePie(data.Languages)
+	}
+	if len(data.AdjustedLanguages) > 0 {
+		md += languagesMarkdown("Languages (excluding generated and vendored code)", data.AdjustedLanguages, false)
+	}
+	md += fmt.Sprintf("## Language Profile: %s\n", data.LanguageProfile.Summary())
+	if data.LanguageProfile.Evaluated {
+		md += fmt.Sprintf("Polyglot score: %.2f (%d languages)\n", data.LanguageProfile.Polyglot, data.LanguageProfile.Languages)
+	}
+	md += fmt.Sprintf("## Engagement: %s\n", data.Engagement.Summary())
+	for _, note := range data.Engagement.Interpretations {
+		md += "- " + note + "\n"
+	}
+	md += fmt.Sprintf("## Activity: %s\n", data.ActivityTrend.Summary())
+	if weekly := data.WeeklyCommits; len(weekly.Weeks) > 0 {
+		md += fmt.Sprintf("\nCommits per week from %s, up to %d:\n\n```\n%s\n```\n",
+			weekly.Weeks[0].Start.Format("2006-01-02"), weekly.Peak(), asciiSparkline(weekly.Counts()))
+		if options.MermaidCharts {
+			md += mermaidWeeklyCommits(weekly)
+		}
+		md += "\n"
+	}
+	md += fmt.Sprintf("## Bus Factor: %s (%s)\n", data.BusFactorInfo.Label(), data.BusRisk)
+	md += fmt.Sprintf("## Work Spread: Gini %s\n", data.Inequality.Summary())
+	md += fmt.Sprintf("## Maturity: %s (%d, %s)\n", data.MaturityLevel, data.MaturityScore, data.MaturityGrade)
+	md += fmt.Sprintf("Release cadence: %s (+%d/%d)\n", data.ReleaseCadence.Summary(), data.ReleaseCadence.Points, analyzer.ReleaseCadenceWeight)
+	md += fmt.Sprintf("Changelog: %s\n", data.Changelog.Summary())
+	if data.Changelog.LatestHeading != "" {
+		md += fmt.Sprintf("Latest entry: %s\n", data.Changelog.LatestHeading)
+	}
+	if len(data.MaturityComponents) > 0 {
+		md += "\n| Factor | Value | Weight | Contribution |\n|---|---|---|---|\n"
+		for _, c := range data.MaturityComponents {
+			if !c.Evaluated {
+				md += fmt.Sprintf("| %s | %s | %d | |\n", c.Name, c.Detail, c.Weight)
+				continue
+			}
+			md += fmt.Sprintf("| %s | %s | %d | %.1f |\n", c.Name, c.Detail, c.Weight, c.Contribution)
+		}
+		md += "\n"
+	}
+	md += fmt.Sprintf("## Releases: %s\n", data.ReleaseStats.Summary())
+	md += fmt.Sprintf("Release automation: %s\n", data.ReleaseAutomation.Summary())
+	md += fmt.Sprintf("## Issues: %s\n", data.Issues.Summary())
+	if data.Issues.Ages.Evaluated {
+		md += fmt.Sprintf("Open issue ages: %s\n", data.Issues.Ages.Summary())
+	}
+	md += fmt.Sprintf("Responsiveness: %s\n", data.Responsiveness.Summary())
+	md += fmt.Sprintf("First maintainer response: %s\n", data.FirstResponse.Summary())
+	md += fmt.Sprintf("Contributor friendliness: %s\n", data.Friendliness.Summary())
+	md += fmt.Sprintf("## Pull Requests: %s\n", data.PullRequests.Summary())
+	if data.PullRequests.Evaluated {
+		md += fmt.Sprintf("Merge ratio (recent PRs): %s\n", data.PullRequests.MergeRatioLabel())
+	}
+	md += fmt.Sprintf("Reviews: %s\n", data.Reviews.Summary())
+	md += fmt.Sprintf("## Branch Protection: %s\n", data.BranchProtection.Summary())
+	md += fmt.Sprintf("## Branches: %s\n", data.Branches.Summary())
+	if data.Security.Evaluated() {
+		md += fmt.Sprintf("## Security Posture: %d (%s)\n", data.Security.Score, data.Security.Grade)
+		md += "\n| Component | Value | Weight | Contribution |\n|---|---|---|---|\n"
+		for _, c := range data.Security.Components {
+			if !c.Evaluated {
+				md += fmt.Sprintf("| %s | %s | %d | |\n", c.Name, c.Detail, c.Weight)
+				continue
+			}
+			md += fmt.Sprintf("| %s | %s | %d | %.1f |\n", c.Name, c.Detail, c.Weight, c.Contribution)
+		}
+		md += "\n"
+	}
+	md += fmt.Sprintf("Signed commits: %s\n", data.SignedCommitRatio.Summary())
+	if data.ScorecardChecked {
+		md += fmt.Sprintf("## OpenSSF Scorecard: %s\n", data.Scorecard.Summary())
+		if data.Scorecard != nil {
+			md += "\n| Check | Score | Reason |\n|---|---|---|\n"
+			for _, c := range data.Scorecard.Checks {
+				md += fmt.Sprintf("| %s | %s | %s |\n", c.Name, c.CheckScore(), c.Reason)
+			}
+			md += "\n"
+		}
+	}
+	md += fmt.Sprintf("## README: %s\n", data.Readme.Summary())
+	if data.ReadmeQuality.Evaluated {
+		md += fmt.Sprintf("Quality: %d (%s)\n\n", data.ReadmeQuality.Score, analyzer.GradeFor(data.ReadmeQuality.Score))
+		for _, c := range data.ReadmeQuality.Checks {
+			md += fmt.Sprintf("- %s %s", c.Mark(), c.Name)
+			if c.Detail != "" {
+				md += " (" + c.Detail + ")"
+			}
+			md += "\n"
+		}
+		for _, link := range data.ReadmeQuality.BrokenLinks {
+			md += fmt.Sprintf("- broken link: `%s`\n", link)
+		}
+		md += "\n"
+	}
+	md += fmt.Sprintf("## Stars: %s\n", data.Stars.Summary())
+	md += fmt.Sprintf("## Commit Times: %s\n", data.Heatmap.Summary())
+	md += fmt.Sprintf("## Timezones: %s\n", data.Timezones.Summary())
+	if data.Timezones.Evaluated {
+		md += "\n| UTC offsets | Commits | Share |\n|---|---|---|\n"
+		for _, b := range data.Timezones.Bands {
+			md += fmt.Sprintf("| %s | %d | %.0f%% |\n", b.Label, b.Commits, b.Share*100)
+		}
+		md += "\n"
+	}
+	md += fmt.Sprintf("## Code Churn: %s\n", data.Churn.Summary())
+	md += fmt.Sprintf("## Workflow: %s\n", data.Workflow.Summary())
+	if w := data.Workflow; w.Evaluated {
+		md += fmt.Sprintf("Of %d default-branch commits: %d PR merges, %d squashed PRs, %d other merges, %d direct\n", w.Commits, w.PRMerges, w.Squashed, w.OtherMerge, w.Direct)
+	}
+	md += fmt.Sprintf("## Commit Messages: %s\n", data.CommitHygiene.Summary())
+	if h := data.CommitHygiene; h.Evaluated {
+		md += fmt.Sprintf("Average subject: %.0f chars, merge commits: %.0f%% (%d messages sampled)\n", h.AverageSubjectLength, h.MergeShare*100, h.Sampled)
+	}
+
+	md += "\n## Community\n"
+	if data.Community.HealthPercentage >= 0 {
+		md += fmt.Sprintf("Health percentage: %d%%\n\n", data.Community.HealthPercentage)
+	}
+	for _, item := range data.Community.Checklist() {
+		check := " "
+		if item.Present {
+			check = "x"
+		}
+		md += fmt.Sprintf("- [%s] %s\n", check, item.Name)
+	}
+
+	md += "\n## Automation\n"
+	md += data.Automation.Summary() + "\n\n"
+	if data.Automation.Evaluated {
+		for _, item := range data.Automation.Checklist() {
+			check := " "
+			if item.Present {
+				check = "x"
+			}
+			md += fmt.Sprintf("- [%s] %s\n", check, item.Name)
+		}
+	}
+
+	md += "\n## Contributor Trend\n"
+	md += data.ContributorTrend.Summary() + "\n"
+	if data.ContributorTrend.Evaluated {
+		md += "\n| Quarter from | Active | First seen | Last seen |\n|---|---|---|---|\n"
+		for _, q := range data.ContributorTrend.Quarters {
+			md += fmt.Sprintf("| %s | %d | %d | %d |\n", q.Start.Format("2006-01-02"), q.Active, q.FirstSeen, q.LastSeen)
+		}
+	}
+
+	md += "\n## Top Contributors\n"
+	md += fmt.Sprintf("Tiers: %s\n\n", data.ContributorTiers.Summary())
+	if len(data.Contributors) == 0 {
+		md += noData
+	}
+	for i, c := range options.topContributors(data.Contributors) {
+		md += fmt.Sprintf("%d. %s (%d commits)", i+1, c.Login, c.Commits)
+		if details := contributorDetails(c); details != "" {
+			md += " — " + details
+		}
+		md += "\n"
+	}
+	if options.MermaidCharts {
+		md += mermaidContributorPie(options.topContributors(data.Contributors))
+	}
+
+	if data.Hotspots.Sampled > 0 {
+		md += fmt.Sprintf("\n## Hotspots\nFrom the changed files of the last %d commits.\n", data.Hotspots.Sampled)
+		md += "\n| File | Commits | Share |\n|---|---|---|\n"
+		for _, h := range data.Hotspots.Hotspots {
+			md += fmt.Sprintf("| %s | %d | %.0f%% |\n", h.Path, h.Changes, h.Share*100)
+		}
+	}
+
+	md += "\n## CI\n"
+	md += fmt.Sprintf("%s\n", data.CI.Summary())
+	if len(data.CI.Files) > 0 {
+		md += fmt.Sprintf("\nConfig: %s\n", strings.Join(data.CI.Files, ", "))
+	}
+	if len(data.CI.Workflows) > 0 {
+		md += "\n| Workflow | Latest run |\n|---|---|\n"
+		for _, w := range data.CI.Workflows {
+			md += fmt.Sprintf("| %s | %s |\n", w.Name, w.Label())
+		}
+	}
+
+	md += "\n## Files\n"
+	md += fmt.Sprintf("%s\n", data.TreeStats.Summary())
+	if len(data.TreeStats.Extensions) > 0 {
+		md += "\n| Extension | Files | Size |\n|---|---|---|\n"
+		for _, ext := range data.TreeStats.Extensions[:min(len(data.TreeStats.Extensions), 10)] {
+			md += fmt.Sprintf("| %s | %d | %s |\n", ext.Extension, ext.Files, analyzer.FormatBytes(ext.Bytes))
+		}
+	}
+
+	md += fmt.Sprintf("\nRepo size: %s\n", data.RepoSize.Summary())
+	if data.RepoSize.Bloated() {
+		md += "\n| Large file | Size | Binary |\n|---|---|---|\n"
+		for _, f := range data.RepoSize.LargeFiles {
+			md += fmt.Sprintf("| %s | %s | %t |\n", f.Path, analyzer.FormatBytes(f.Bytes), f.Binary)
+		}
+	}
+
+	if data.Monorepo.Detected {
+		md += fmt.Sprintf("\n## Monorepo\n%d packages (%s)\n", len(data.Monorepo.Packages), strings.Join(data.Monorepo.Signals, ", "))
+		md += "\n| Package | Ecosystem | Language | Files | Dependencies |\n|---|---|---|---|---|\n"
+		for _, pkg := range data.Monorepo.Packages {
+			md += fmt.Sprintf("| %s | %s | %s | %d | %s |\n", pkg.Path, pkg.Ecosystem, pkg.Language, pkg.Files, dependencyCount(pkg.Dependencies))
+		}
+	}
+
+	md += "\n## Tests\n"
+	md += fmt.Sprintf("%s\n", data.Tests.Summary())
+	if len(data.Tests.Languages) > 0 {
+		md += "\n| Language | Test files | Source files | Ratio |\n|---|---|---|---|\n"
+		for _, l := range data.Tests.Languages {
+			md += fmt.Sprintf("| %s | %d | %d | %.0f%% |\n", l.Language, l.TestFiles, l.SourceFiles, l.Ratio()*100)
+		}
+	}
+
+	if data.ForksChecked {
+		md += "\n## Possible Active Forks\n"
+		md += fmt.Sprintf("The repository was last pushed to on %s.\n\n", data.Repo.PushedAt.Format("2006-01-02"))
+		if len(data.ActiveForks) == 0 {
+			md += "No recently active forks found.\n"
+		}
+		for _, fork := range data.ActiveForks {
+			md += fmt.Sprintf("- %s\n", fork.Summary())
+		}
+	}
+
+	md += "\n## Ownership\n"
+	md += fmt.Sprintf("%s\n", data.Ownership.Summary())
+	if len(data.DirectoryOwners) > 0 {
+		md += "\n| Directory | Top author | Share | Changes | CODEOWNERS | Risk |\n|---|---|---|---|---|---|\n"
+		for _, d := range data.DirectoryOwners {
+			risk := ""
+			if d.Risky() {
+				risk = "single owner"
+			}
+			md += fmt.Sprintf("| %s | %s | %.0f%% | %d | %s | %s |\n",
+				d.Directory, d.Owner, d.Share*100, d.Changes, strings.Join(d.CodeOwners, " "), risk)
+		}
+	}
+	if len(data.Ownership.InactiveOwners) > 0 {
+		md += fmt.Sprintf("\nOwners without recent commits: %s\n", strings.Join(data.Ownership.InactiveOwners, ", "))
+	}
+
 	md += "\n## File Tree (Top 20)\n"
-	limit := 20
-	if len(data.FileTree) < limit {
-		limit = len(data.FileTree)
+	if len(data.FileTree) == 0 {
+		md += noData
 	}
-	for i := 0; i < limit; i++ {
+	for _, entry := range data.FileTree[:min(len(data.FileTree), 20)] {
 		icon := "📄"
-		if data.FileTree[i].Type == "tree" {
+		if entry.Type == "tree" {
 			icon = "📁"
 		}
-		md += fmt.Sprintf("- %s %s\n", icon, data.FileTree[i].Path)
+		md += fmt.Sprintf("- %s %s\n", icon, entry.Path)
 	}
 
-	_, err = file.WriteString(md)
+	_, err := io.WriteString(w, md)
 	return err
 }
+
+// languagesMarkdown lists the top languages by share of bytes, the rest
+// rolled into Other
+func languagesMarkdown(title string, languages map[string]int, estimated bool) string {
+	md := "## " + title
+	if estimated {
+		md += " (estimated from file extensions)"
+	}
+	md += "\n"
+	ranked := analyzer.RankLanguages(languages)
+	if len(ranked) == 0 {
+		return md + noData
+	}
+	for _, l := range analyzer.TopLanguages(ranked, analyzer.ListedLanguages) {
+		md += fmt.Sprintf("- %s: %.1f%%\n", l.Name, l.Percent)
+	}
+	return md
+}
+
+// asciiSparkline draws values as a line of ASCII characters, from a space
+// for none to # for the maximum
+func asciiSparkline(values []int) string {
+	levels := " .:-=+*#"
+	max := 0
+	for _, v := range values {
+		if v > max {
+			max = v
+		}
+	}
+	var sb strings.Builder
+	for _, v := range values {
+		level := 0
+		if max > 0 {
+			level = v * (len(levels) - 1) / max
+		}
+		if v > 0 && level == 0 {
+			// Tell quiet weeks from empty ones
+			level = 1
+		}
+		sb.WriteByte(levels[level])
+	}
+	return sb.String()
+}
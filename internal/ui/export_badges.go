@@ -0,0 +1,80 @@
+package ui
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+)
+
+// BadgeSchemaVersion is the shields.io endpoint schema version
+const BadgeSchemaVersion = 1
+
+// toneBadgeColors are the shields.io colors for each tone
+var toneBadgeColors = map[tone]string{
+	toneGood:    "green",
+	toneFair:    "yellow",
+	tonePoor:    "orange",
+	toneBad:     "red",
+	toneUnknown: "lightgrey",
+}
+
+// Badge is a shields.io endpoint badge, see
+// https://shields.io/badges/endpoint-badge. Message must be a string even
+// for numbers.
+type Badge struct {
+	SchemaVersion int    `json:"schemaVersion"`
+	Label         string `json:"label"`
+	Message       string `json:"message"`
+	Color         string `json:"color"`
+}
+
+// Badges are the health, maturity and bus factor badges, keyed by the name
+// ExportBadges gives their files, colored as the dashboard colors them
+func Badges(data AnalysisResult) map[string]Badge {
+	badge := func(label, message string, t tone) Badge {
+		return Badge{BadgeSchemaVersion, label, message, toneBadgeColors[t]}
+	}
+	busFactor := "unknown"
+	if data.BusFactorInfo.Factor > 0 {
+		busFactor = strconv.Itoa(data.BusFactorInfo.Factor)
+	}
+	return map[string]Badge{
+		"health":     badge("Repo-lyzer health", strconv.Itoa(data.HealthScore), gradeTone(data.HealthGrade)),
+		"maturity":   badge("Repo-lyzer maturity", data.MaturityLevel, gradeTone(data.MaturityGrade)),
+		"bus_factor": badge("bus factor", busFactor, busFactorTone(data.BusFactorInfo)),
+	}
+}
+
+// ExportBadges writes each badge to its own file in dir, e.g.
+// badges/health.json, creating dir as needed. Point shields.io at a file's
+// raw URL: https://img.shields.io/endpoint?url=...
+func ExportBadges(data AnalysisResult, dir string) error {
+	if dir == Stdout {
+		return fmt.Errorf("badges are written to a directory; combine them to write to stdout")
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return err
+	}
+	for name, badge := range Badges(data) {
+		err := exportTo(filepath.Join(dir, name+".json"), func(w io.Writer) error { return writeBadgeJSON(w, badge) })
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ExportBadgesCombined writes all the badges to one JSON object keyed by
+// name, for tools that pick a badge out with a query
+func ExportBadgesCombined(data AnalysisResult, filename string) error {
+	return exportTo(filename, func(w io.Writer) error { return writeBadgeJSON(w, Badges(data)) })
+}
+
+func writeBadgeJSON(w io.Writer, v any) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(v)
+}
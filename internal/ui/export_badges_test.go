@@ -0,0 +1,87 @@
+package ui
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+
+	"github.com/agnivo988/Repo-lyzer/internal/analyzer"
+)
+
+func TestBadgeColorAtGradeCutoffs(t *testing.T) {
+	tests := []struct {
+		score int
+		color string
+	}{
+		{100, "green"},
+		{95, "green"}, // A+
+		{94, "green"},
+		{85, "green"}, // A
+		{84, "yellow"},
+		{70, "yellow"}, // B
+		{69, "yellow"},
+		{55, "yellow"}, // C
+		{54, "orange"},
+		{40, "orange"}, // D
+		{39, "red"},
+		{0, "red"},
+	}
+	for _, tt := range tests {
+		data := fixture(1)
+		data.HealthScore, data.HealthGrade = tt.score, analyzer.GradeFor(tt.score)
+		data.MaturityScore, data.MaturityGrade = tt.score, analyzer.GradeFor(tt.score)
+		badges := Badges(data)
+		if got := badges["health"]; got.Color != tt.color || got.Message != strconv.Itoa(tt.score) {
+			t.Errorf("health %d: got %s %q, want %s", tt.score, got.Color, got.Message, tt.color)
+		}
+		if got := badges["maturity"].Color; got != tt.color {
+			t.Errorf("maturity %d: got %s, want %s", tt.score, got, tt.color)
+		}
+	}
+}
+
+func TestBusFactorBadge(t *testing.T) {
+	tests := []struct {
+		factor  int
+		message string
+		color   string
+	}{
+		{0, "unknown", "lightgrey"},
+		{1, "1", "red"},
+		{2, "2", "orange"},
+		{3, "3", "green"},
+	}
+	for _, tt := range tests {
+		data := fixture(1)
+		data.BusFactorInfo.Factor = tt.factor
+		if got := Badges(data)["bus_factor"]; got.Message != tt.message || got.Color != tt.color {
+			t.Errorf("bus factor %d: got %q %s, want %q %s", tt.factor, got.Message, got.Color, tt.message, tt.color)
+		}
+	}
+}
+
+func TestExportBadges(t *testing.T) {
+	data := fixture(1)
+	data.HealthScore, data.HealthGrade = 72, analyzer.GradeFor(72)
+	dir := filepath.Join(t.TempDir(), "badges")
+	if err := ExportBadges(data, dir); err != nil {
+		t.Fatal(err)
+	}
+	raw, err := os.ReadFile(filepath.Join(dir, "health.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var badge Badge
+	if err := json.Unmarshal(raw, &badge); err != nil {
+		t.Fatal(err)
+	}
+	want := Badge{BadgeSchemaVersion, "Repo-lyzer health", "72", "yellow"}
+	if badge != want {
+		t.Errorf("health.json = %+v, want %+v", badge, want)
+	}
+	if err := ExportBadges(data, Stdout); err == nil {
+		t.Error("ExportBadges to stdout: want an error")
+	}
+}
@@ -0,0 +1,98 @@
+package ui
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/agnivo988/Repo-lyzer/internal/analyzer"
+)
+
+// compareMetricLabels names RepoComparison's metrics for the Markdown
+var compareMetricLabels = map[string]string{
+	"stars":          "Stars",
+	"forks":          "Forks",
+	"commits":        "Commits (1y)",
+	"contributors":   "Contributors",
+	"health_score":   "Health score",
+	"maturity_score": "Maturity score",
+	"security_score": "Security score",
+	"bus_factor":     "Bus factor",
+}
+
+// compareDocument is what ExportCompareJSON writes: each repo's full
+// analysis, as ExportJSON writes it, and the difference between them
+type compareDocument struct {
+	SchemaVersion string                  `json:"schema_version"`
+	Repo1         jsonDocument            `json:"repo1"`
+	Repo2         jsonDocument            `json:"repo2"`
+	Diff          analyzer.RepoComparison `json:"diff"`
+}
+
+// ExportCompareJSON writes both analyses and their diff as JSON
+func ExportCompareJSON(data CompareResult, filename string, options ExportOptions) error {
+	return exportTo(filename, func(w io.Writer) error { return WriteCompareJSON(w, data, options) })
+}
+
+// WriteCompareJSON writes both analyses and their diff as indented JSON
+func WriteCompareJSON(w io.Writer, data CompareResult, options ExportOptions) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(compareDocument{SchemaVersion, newJSONDocument(data.Repo1, options), newJSONDocument(data.Repo2, options), data.Diff()})
+}
+
+// ExportCompareMarkdown writes the comparison as a Markdown table, one row
+// per metric with the delta and an arrow towards the repo ahead
+func ExportCompareMarkdown(data CompareResult, filename string) error {
+	return exportTo(filename, func(w io.Writer) error { return WriteCompareMarkdown(w, data) })
+}
+
+// WriteCompareMarkdown writes the comparison as Markdown
+func WriteCompareMarkdown(w io.Writer, data CompareResult) error {
+	r1, r2 := data.Repo1, data.Repo2
+	if r1.Repo == nil || r2.Repo == nil {
+		return fmt.Errorf("no comparison data")
+	}
+	name1, name2 := r1.Repo.FullName, r2.Repo.FullName
+	diff := data.Diff()
+
+	md := fmt.Sprintf("# Comparison: %s vs %s\n\n", name1, name2)
+	md += fmt.Sprintf("Refs: %s, %s\n\n", r1.RefLabel(), r2.RefLabel())
+	md += fmt.Sprintf("| Metric | %s | %s | Delta | Leads |\n|---|---|---|---|---|\n", name1, name2)
+	for _, metric := range diff.Metrics {
+		first, second := strconv.Itoa(metric.First), strconv.Itoa(metric.Second)
+		if metric.Metric == "commits" {
+			first, second = commitCountLabel(r1), commitCountLabel(r2)
+		}
+		leads := "="
+		switch metric.Leader {
+		case 1:
+			leads = "← " + name1
+		case 2:
+			leads = "→ " + name2
+		}
+		md += fmt.Sprintf("| %s | %s | %s | %+d | %s |\n", compareMetricLabels[metric.Metric], first, second, metric.Delta, leads)
+	}
+	md += "\n"
+
+	if diff.LanguageSimilarity >= 0 {
+		md += fmt.Sprintf("Language profile similarity: %.0f%%\n\n", diff.LanguageSimilarity*100)
+	}
+	for _, shared := range []struct {
+		title string
+		items []string
+	}{
+		{"Shared languages", diff.SharedLanguages},
+		{"Shared contributors", diff.SharedContributors},
+		{"Shared topics", diff.SharedTopics},
+	} {
+		if len(shared.items) > 0 {
+			md += fmt.Sprintf("%s: %s\n\n", shared.title, strings.Join(shared.items, ", "))
+		}
+	}
+
+	_, err := io.WriteString(w, md)
+	return err
+}
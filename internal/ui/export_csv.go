@@ -0,0 +1,128 @@
+package ui
+
+import (
+	"encoding/csv"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/agnivo988/Repo-lyzer/internal/analyzer"
+	"github.com/agnivo988/Repo-lyzer/internal/github"
+)
+
+// ExportCSV writes the metrics to filename, one name,value row each, and
+// the languages and contributors to companion files named after it, e.g.
+// analysis_languages.csv next to analysis.csv. Rows come in a fixed order
+// so repeat exports diff cleanly. Exporting to Stdout writes just the
+// metrics, as the three tables don't share columns.
+func ExportCSV(data AnalysisResult, filename string) error {
+	if data.Repo == nil {
+		return errNoAnalysis
+	}
+	if err := writeCSV(filename, csvMetrics(data)); err != nil || filename == Stdout {
+		return err
+	}
+	base := strings.TrimSuffix(filename, ".csv")
+	if err := writeCSV(base+"_languages.csv", csvLanguages(data.MetricLanguages())); err != nil {
+		return err
+	}
+	return writeCSV(base+"_contributors.csv", csvContributors(data))
+}
+
+func writeCSV(filename string, rows [][]string) error {
+	return exportTo(filename, func(w io.Writer) error { return csv.NewWriter(w).WriteAll(rows) })
+}
+
+// WriteCSV writes the metrics table ExportCSV puts in its main file
+func WriteCSV(w io.Writer, data AnalysisResult) error {
+	if data.Repo == nil {
+		return errNoAnalysis
+	}
+	return csv.NewWriter(w).WriteAll(csvMetrics(data))
+}
+
+// csvMetrics lists the headline figures; ones that weren't evaluated are
+// left empty
+func csvMetrics(data AnalysisResult) [][]string {
+	repo := data.Repo
+	rows := [][]string{{"metric", "value"}}
+	add := func(name, value string) {
+		rows = append(rows, []string{name, value})
+	}
+	ratio := func(name string, value float64, known bool) {
+		if known {
+			add(name, strconv.FormatFloat(value, 'f', 4, 64))
+		} else {
+			add(name, "")
+		}
+	}
+
+	add("repository", repo.FullName)
+	add("description", repo.Description)
+	add("ref", data.RefLabel())
+	add("license", analyzer.LicenseLabel(repo))
+	add("created_at", repo.CreatedAt.Format("2006-01-02"))
+	add("pushed_at", repo.PushedAt.Format("2006-01-02"))
+	add("archived", strconv.FormatBool(repo.Archived))
+	add("stars", strconv.Itoa(repo.Stars))
+	add("forks", strconv.Itoa(repo.Forks))
+	add("watchers", strconv.Itoa(data.Engagement.Watchers))
+	add("open_issues", strconv.Itoa(repo.OpenIssues))
+	add("status", data.Abandonment.Status)
+	add("health_score", strconv.Itoa(data.HealthScore))
+	add("health_grade", string(data.HealthGrade))
+	for _, c := range data.HealthComponents {
+		ratio("health."+c.Name, c.Contribution, c.Evaluated)
+	}
+	add("maturity_level", data.MaturityLevel)
+	add("maturity_score", strconv.Itoa(data.MaturityScore))
+	add("maturity_grade", string(data.MaturityGrade))
+	add("security_score", strconv.Itoa(data.Security.Score))
+	add("security_grade", string(data.Security.Grade))
+	add("bus_factor", strconv.Itoa(data.BusFactor))
+	add("bus_risk", data.BusRisk)
+	add("commits_1y", strconv.Itoa(data.WeeklyCommits.Total()))
+	add("active_weeks", strconv.Itoa(data.WeeklyCommits.ActiveWeeks()))
+	add("activity_trend", data.ActivityTrend.Summary())
+	add("contributors", strconv.Itoa(len(data.Contributors)))
+	add("primary_language", data.LanguageProfile.Primary)
+	ratio("polyglot_score", data.LanguageProfile.Polyglot, data.LanguageProfile.Evaluated)
+	ratio("fork_ratio", data.Engagement.ForkRatio, data.Engagement.ForkRatio >= 0)
+	ratio("issues_per_1k_stars", data.Engagement.IssuesPerKStars, data.Engagement.IssuesPerKStars >= 0)
+	ratio("contributors_per_1k_stars", data.Engagement.ContributorsPerKStars, data.Engagement.ContributorsPerKStars >= 0)
+	ratio("first_response_median_hours", data.FirstResponse.MedianHours, data.FirstResponse.Responded > 0)
+	ratio("no_response_share", data.FirstResponse.NoResponseShare, data.FirstResponse.Evaluated)
+	ratio("signed_commit_ratio", data.SignedCommitRatio.Ratio, data.SignedCommitRatio.Evaluated)
+	add("releases", strconv.Itoa(data.ReleaseStats.Count))
+	add("latest_release", data.ReleaseStats.LatestTag)
+	add("release_automation", strings.Join(data.ReleaseAutomation.Tools, "+"))
+	add("changelog_latest_version", data.Changelog.LatestVersion)
+	add("readme_quality", strconv.Itoa(data.ReadmeQuality.Score))
+	return rows
+}
+
+// csvLanguages lists the languages by bytes, largest first
+func csvLanguages(languages map[string]int) [][]string {
+	rows := [][]string{{"language", "bytes", "percent"}}
+	for _, l := range analyzer.RankLanguages(languages) {
+		rows = append(rows, []string{l.Name, strconv.Itoa(l.Bytes), strconv.FormatFloat(l.Percent, 'f', 2, 64)})
+	}
+	return rows
+}
+
+// csvContributors lists the contributors by commits, most first
+func csvContributors(data AnalysisResult) [][]string {
+	contributors := append([]github.Contributor(nil), data.Contributors...)
+	sort.SliceStable(contributors, func(i, j int) bool {
+		if contributors[i].Commits != contributors[j].Commits {
+			return contributors[i].Commits > contributors[j].Commits
+		}
+		return contributors[i].Login < contributors[j].Login
+	})
+	rows := [][]string{{"login", "commits"}}
+	for _, c := range contributors {
+		rows = append(rows, []string{c.Login, strconv.Itoa(c.Commits)})
+	}
+	return rows
+}
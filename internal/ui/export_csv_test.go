@@ -0,0 +1,124 @@
+package ui
+
+import (
+	"encoding/csv"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/agnivo988/Repo-lyzer/internal/github"
+)
+
+func TestCSVWithoutRepo(t *testing.T) {
+	var sb strings.Builder
+	if err := WriteCSV(&sb, AnalysisResult{}); !errors.Is(err, errNoAnalysis) {
+		t.Errorf("WriteCSV without a repo: got %v, want errNoAnalysis", err)
+	}
+	filename := filepath.Join(t.TempDir(), "analysis.csv")
+	if err := ExportCSV(AnalysisResult{}, filename); !errors.Is(err, errNoAnalysis) {
+		t.Errorf("ExportCSV without a repo: got %v, want errNoAnalysis", err)
+	}
+}
+
+func TestWriteCSVMetrics(t *testing.T) {
+	var sb strings.Builder
+	data := AnalysisResult{Repo: &github.Repo{FullName: "owner/repo", Stars: 42}}
+	if err := WriteCSV(&sb, data); err != nil {
+		t.Fatal(err)
+	}
+	rows, err := csv.NewReader(strings.NewReader(sb.String())).ReadAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := strings.Join(rows[0], ","); got != "metric,value" {
+		t.Errorf("header = %q, want metric,value", got)
+	}
+	if got := strings.Join(rows[1], ","); got != "repository,owner/repo" {
+		t.Errorf("first row = %q, want the repo", got)
+	}
+}
+
+// readCSV parses filename with csv.Reader, which also checks every row has
+// as many fields as the header
+func readCSV(t *testing.T, filename string) [][]string {
+	t.Helper()
+	f, err := os.Open(filename)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	rows, err := csv.NewReader(f).ReadAll()
+	if err != nil {
+		t.Fatalf("%s: %v", filepath.Base(filename), err)
+	}
+	return rows
+}
+
+func TestExportCSVFiles(t *testing.T) {
+	description := "Fast, \"small\" tools,\nfor the shell"
+	data := AnalysisResult{
+		Repo:      &github.Repo{FullName: "owner/repo", Description: description, Stars: 42},
+		Ref:       "main",
+		Languages: map[string]int{"Go": 7000, "Shell": 2000, "Makefile": 1000},
+		Contributors: []github.Contributor{
+			{Login: "bob", Commits: 5},
+			{Login: "ann", Commits: 20},
+			{Login: "cy", Commits: 5},
+		},
+	}
+	filename := filepath.Join(t.TempDir(), "analysis.csv")
+	if err := ExportCSV(data, filename); err != nil {
+		t.Fatal(err)
+	}
+
+	metrics := readCSV(t, filename)
+	if got := strings.Join(metrics[0], ","); got != "metric,value" {
+		t.Errorf("metrics header = %q, want metric,value", got)
+	}
+	// One row per metric and no health components in this analysis
+	if len(metrics) != 39 {
+		t.Errorf("got %d metric rows, want 39 with the header", len(metrics))
+	}
+	var names []string
+	for _, row := range metrics[1:4] {
+		names = append(names, row[0])
+	}
+	if got := strings.Join(names, ","); got != "repository,description,ref" {
+		t.Errorf("first metrics = %s, want repository,description,ref", got)
+	}
+	if got := metrics[len(metrics)-1][0]; got != "readme_quality" {
+		t.Errorf("last metric = %s, want readme_quality", got)
+	}
+	if metrics[2][1] != description {
+		t.Errorf("description read back as %q, want %q", metrics[2][1], description)
+	}
+
+	want := map[string][][]string{
+		"analysis_languages.csv": {
+			{"language", "bytes", "percent"},
+			{"Go", "7000", "70.00"},
+			{"Shell", "2000", "20.00"},
+			{"Makefile", "1000", "10.00"},
+		},
+		"analysis_contributors.csv": {
+			{"login", "commits"},
+			{"ann", "20"},
+			{"bob", "5"},
+			{"cy", "5"},
+		},
+	}
+	for name, rows := range want {
+		got := readCSV(t, filepath.Join(filepath.Dir(filename), name))
+		if len(got) != len(rows) {
+			t.Errorf("%s has %d rows, want %d", name, len(got), len(rows))
+			continue
+		}
+		for i := range rows {
+			if strings.Join(got[i], ",") != strings.Join(rows[i], ",") {
+				t.Errorf("%s row %d = %q, want %q", name, i, got[i], rows[i])
+			}
+		}
+	}
+}
@@ -0,0 +1,206 @@
+package ui
+
+import (
+	_ "embed"
+	"fmt"
+	"html/template"
+	"io"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/agnivo988/Repo-lyzer/internal/analyzer"
+)
+
+//go:embed templates/report.html.tmpl
+var reportTemplateText string
+
+var reportTemplate = template.Must(template.New("report").Parse(reportTemplateText))
+
+// Sizes of the report's SVG charts
+const (
+	gaugeRadius       = 40.0
+	sparklineWidth    = 520.0
+	sparklineHeight   = 60.0
+	languageBarMax    = 360.0
+	languageBarHeight = 22
+)
+
+// htmlReport is what the report template renders
+type htmlReport struct {
+	Repo        string
+	Description string
+	URL         string
+	Ref         string
+	License     string
+	Status      string
+	Generated   string
+	Cards       []reportCard
+	Gauges      []reportGauge
+	Languages   []reportLanguage
+	// LanguagesHeight is the language chart's height, languageBarHeight
+	// per language
+	LanguagesHeight int
+	Sparkline       string // SVG polyline points
+	Commits         int
+	ActiveWeeks     int
+	Weeks           int
+	Contributors    []reportContributor
+	Packages        []analyzer.MonorepoPackage
+	Summaries       []reportCard
+}
+
+type reportCard struct {
+	Label string
+	Value string
+}
+
+// toneReportColors are the gauge colors for each tone, so a gauge is colored
+// by its grade as the dashboard and badges color it
+var toneReportColors = map[tone]string{
+	toneGood:    "#00c853",
+	toneFair:    "#ffab00",
+	tonePoor:    "#ff6d00",
+	toneBad:     "#d50000",
+	toneUnknown: "#8c959f",
+}
+
+// reportGauge is a score drawn as a ring; Dash is the stroke-dasharray
+// that fills Score percent of it
+type reportGauge struct {
+	Label string
+	Score int
+	Grade analyzer.Grade
+	Dash  string
+	Color string
+}
+
+type reportLanguage struct {
+	Name    string
+	Percent float64
+	Width   float64
+	Y       float64
+}
+
+type reportContributor struct {
+	Login   string
+	Commits int
+}
+
+// ExportHTML writes a self-contained HTML report, with inline SVG charts
+// and no external assets, so it works offline
+func ExportHTML(data AnalysisResult, filename string, options ExportOptions) error {
+	return exportTo(filename, func(w io.Writer) error { return WriteHTML(w, data, options) })
+}
+
+// WriteHTML writes the HTML report, generated now
+func WriteHTML(w io.Writer, data AnalysisResult, options ExportOptions) error {
+	return renderHTMLReport(w, data, options, time.Now())
+}
+
+// renderHTMLReport renders the report as of generated
+func renderHTMLReport(w io.Writer, data AnalysisResult, options ExportOptions, generated time.Time) error {
+	if data.Repo == nil {
+		return errNoAnalysis
+	}
+	return reportTemplate.Execute(w, buildHTMLReport(data, options, generated))
+}
+
+func buildHTMLReport(data AnalysisResult, options ExportOptions, generated time.Time) htmlReport {
+	repo := data.Repo
+	report := htmlReport{
+		Repo:        repo.FullName,
+		Description: repo.Description,
+		URL:         repo.HTMLURL,
+		Ref:         data.RefLabel(),
+		License:     analyzer.LicenseLabel(repo),
+		Status:      data.Abandonment.Status,
+		Generated:   generated.UTC().Format("2006-01-02 15:04 UTC"),
+		Cards: []reportCard{
+			{"Stars", fmt.Sprint(repo.Stars)},
+			{"Forks", fmt.Sprint(repo.Forks)},
+			{"Open issues", fmt.Sprint(repo.OpenIssues)},
+			{"Contributors", fmt.Sprint(len(data.Contributors))},
+			{"Bus factor", fmt.Sprintf("%d (%s)", data.BusFactor, data.BusRisk)},
+		},
+		Gauges: []reportGauge{
+			newReportGauge("Health", data.HealthScore, data.HealthGrade),
+			newReportGauge("Maturity", data.MaturityScore, data.MaturityGrade),
+			newReportGauge("Security", data.Security.Score, data.Security.Grade),
+		},
+		Commits:     data.WeeklyCommits.Total(),
+		ActiveWeeks: data.WeeklyCommits.ActiveWeeks(),
+		Weeks:       len(data.WeeklyCommits.Weeks),
+		Sparkline:   sparklinePoints(data.WeeklyCommits.Counts()),
+		Packages:    data.Monorepo.Packages,
+		Summaries: []reportCard{
+			{"Activity", data.ActivityTrend.Summary()},
+			{"Maturity", data.MaturityLevel},
+			{"Languages", data.LanguageProfile.Summary()},
+			{"Engagement", data.Engagement.Summary()},
+			{"Releases", data.ReleaseStats.Summary()},
+			{"Issues", data.Issues.Summary()},
+			{"First maintainer response", data.FirstResponse.Summary()},
+			{"README", data.ReadmeQuality.Summary()},
+		},
+	}
+	report.Languages = reportLanguages(data.MetricLanguages())
+	report.LanguagesHeight = len(report.Languages) * languageBarHeight
+
+	for _, c := range options.topContributors(data.Contributors) {
+		report.Contributors = append(report.Contributors, reportContributor{c.Login, c.Commits})
+	}
+	return report
+}
+
+func newReportGauge(label string, score int, grade analyzer.Grade) reportGauge {
+	circumference := 2 * math.Pi * gaugeRadius
+	filled := circumference * float64(analyzer.NormalizeScore(float64(score))) / 100
+	return reportGauge{
+		Label: label,
+		Score: score,
+		Grade: grade,
+		Dash:  fmt.Sprintf("%.1f %.1f", filled, circumference),
+		Color: toneReportColors[gradeTone(grade)],
+	}
+}
+
+// reportLanguages lists the languages by share, largest first, with their
+// bar widths and positions
+func reportLanguages(languages map[string]int) []reportLanguage {
+	ranked := analyzer.RankLanguages(languages)
+	if len(ranked) == 0 {
+		return nil
+	}
+	bars := make([]reportLanguage, 0, len(ranked))
+	for i, l := range ranked {
+		bars = append(bars, reportLanguage{
+			Name:    l.Name,
+			Percent: l.Percent,
+			Width:   math.Max(l.Percent/100*languageBarMax, 1),
+			Y:       float64(i * languageBarHeight),
+		})
+	}
+	return bars
+}
+
+// sparklinePoints lays values out as the points of an SVG polyline
+func sparklinePoints(values []int) string {
+	if len(values) < 2 {
+		return ""
+	}
+	peak := 0
+	for _, v := range values {
+		peak = max(peak, v)
+	}
+	points := make([]string, len(values))
+	for i, v := range values {
+		x := float64(i) * sparklineWidth / float64(len(values)-1)
+		y := sparklineHeight
+		if peak > 0 {
+			y -= float64(v) * sparklineHeight / float64(peak)
+		}
+		points[i] = fmt.Sprintf("%.1f,%.1f", x, y)
+	}
+	return strings.Join(points, " ")
+}
@@ -0,0 +1,96 @@
+package ui
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/agnivo988/Repo-lyzer/internal/analyzer"
+)
+
+var update = flag.Bool("update", false, "rewrite the golden files in testdata")
+
+// reportFixture is fixture(3) with enough data to draw every chart
+func reportFixture() AnalysisResult {
+	data := fixture(3)
+	data.Repo.Description = "A <tool> & its \"report\""
+	data.Repo.HTMLURL = "https://github.com/owner/repo"
+	data.Repo.Stars = 42
+	data.Repo.Forks = 7
+	data.Ref = "main"
+	data.Languages = map[string]int{"Go": 7500, "Shell": 2000, "Makefile": 500}
+	data.HealthScore, data.HealthGrade = 82, analyzer.GradeFor(82)
+	data.MaturityScore, data.MaturityGrade = 55, analyzer.GradeFor(55)
+	data.BusFactor, data.BusRisk = 2, "Medium"
+	start := time.Date(2025, 1, 5, 0, 0, 0, 0, time.UTC)
+	for i, n := range []int{3, 0, 5, 1} {
+		data.WeeklyCommits.Weeks = append(data.WeeklyCommits.Weeks, analyzer.WeekCount{Start: start.AddDate(0, 0, 7*i), Commits: n})
+	}
+	return data
+}
+
+// golden compares got with testdata/name, rewriting it with -update
+func golden(t *testing.T, name, got string) {
+	t.Helper()
+	path := filepath.Join("testdata", name)
+	if *update {
+		if err := os.WriteFile(path, []byte(got), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	want, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != string(want) {
+		t.Errorf("%s differs from the golden file; rerun with -update and review the diff", name)
+	}
+}
+
+func TestHTMLReportGolden(t *testing.T) {
+	generated := time.Date(2025, 2, 1, 9, 30, 0, 0, time.UTC)
+	var out strings.Builder
+	if err := renderHTMLReport(&out, reportFixture(), DefaultExportOptions, generated); err != nil {
+		t.Fatal(err)
+	}
+	golden(t, "report.html", out.String())
+}
+
+func TestHTMLReportIsSelfContained(t *testing.T) {
+	var out strings.Builder
+	if err := WriteHTML(&out, reportFixture(), DefaultExportOptions); err != nil {
+		t.Fatal(err)
+	}
+	html := out.String()
+	for _, external := range []string{`src="http`, `href="http://`, "<link ", "@import"} {
+		if strings.Contains(html, external) {
+			t.Errorf("report references an external asset: %s", external)
+		}
+	}
+	if strings.Contains(html, "<tool>") {
+		t.Error("description not escaped")
+	}
+}
+
+func TestHTMLReportWithoutRepo(t *testing.T) {
+	if err := WriteHTML(&strings.Builder{}, AnalysisResult{}, DefaultExportOptions); err != errNoAnalysis {
+		t.Errorf("err = %v, want errNoAnalysis", err)
+	}
+}
+
+func TestReportGaugeColorFollowsGrade(t *testing.T) {
+	for _, tt := range []struct {
+		score int
+		color string
+	}{
+		{100, "#00c853"}, {85, "#00c853"}, {84, "#ffab00"}, {55, "#ffab00"}, {54, "#ff6d00"}, {0, "#d50000"},
+	} {
+		grade := analyzer.GradeFor(tt.score)
+		if got := newReportGauge("Health", tt.score, grade).Color; got != tt.color {
+			t.Errorf("score %d (%s) colored %s, want %s", tt.score, grade, got, tt.color)
+		}
+	}
+}
@@ -0,0 +1,79 @@
+package ui
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/agnivo988/Repo-lyzer/internal/analyzer"
+	"github.com/agnivo988/Repo-lyzer/internal/github"
+)
+
+// mermaidLabel makes s safe inside a quoted Mermaid label: double quotes
+// end the label and line breaks end the statement, so both are replaced
+func mermaidLabel(s string) string {
+	return strings.Map(func(r rune) rune {
+		switch {
+		case r == '"':
+			return '\''
+		case r < ' ' || r == 0x7f:
+			return ' '
+		}
+		return r
+	}, s)
+}
+
+// mermaidBlock fences lines as a mermaid code block, after a blank line
+func mermaidBlock(lines []string) string {
+	return "\n```mermaid\n" + strings.Join(lines, "\n") + "\n```\n"
+}
+
+// mermaidLanguagePie charts the languages listed above it, percentages to
+// one decimal. Slices that round to nothing are left out, as pie charts
+// reject zero values.
+func mermaidLanguagePie(languages map[string]int) string {
+	lines := []string{"pie title Languages"}
+	for _, l := range analyzer.TopLanguages(analyzer.RankLanguages(languages), analyzer.ListedLanguages) {
+		if percent := strconv.FormatFloat(l.Percent, 'f', 1, 64); percent != "0.0" {
+			lines = append(lines, fmt.Sprintf(`    "%s" : %s`, mermaidLabel(l.Name), percent))
+		}
+	}
+	if len(lines) == 1 {
+		return ""
+	}
+	return mermaidBlock(lines)
+}
+
+// mermaidWeeklyCommits charts commits per week as bars, numbering the
+// weeks as 52 date labels don't fit
+func mermaidWeeklyCommits(weekly analyzer.WeeklyActivity) string {
+	if len(weekly.Weeks) == 0 {
+		return ""
+	}
+	counts := make([]string, len(weekly.Weeks))
+	for i, week := range weekly.Weeks {
+		counts[i] = strconv.Itoa(week.Commits)
+	}
+	return mermaidBlock([]string{
+		"xychart-beta",
+		`    title "Commits per week"`,
+		fmt.Sprintf(`    x-axis "Weeks from %s" 1 --> %d`, weekly.Weeks[0].Start.Format("2006-01-02"), len(weekly.Weeks)),
+		fmt.Sprintf(`    y-axis "Commits" 0 --> %d`, max(weekly.Peak(), 1)),
+		"    bar [" + strings.Join(counts, ", ") + "]",
+	})
+}
+
+// mermaidContributorPie charts how the listed contributors' commits split
+// between them
+func mermaidContributorPie(contributors []github.Contributor) string {
+	lines := []string{"pie title Commits by contributor"}
+	for _, c := range contributors {
+		if c.Commits > 0 {
+			lines = append(lines, fmt.Sprintf(`    "%s" : %d`, mermaidLabel(c.Login), c.Commits))
+		}
+	}
+	if len(lines) == 1 {
+		return ""
+	}
+	return mermaidBlock(lines)
+}
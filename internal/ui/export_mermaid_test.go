@@ -0,0 +1,22 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestMermaidMarkdownGolden(t *testing.T) {
+	options := DefaultExportOptions
+	options.MermaidCharts = true
+	var out strings.Builder
+	if err := WriteMarkdown(&out, reportFixture(), options); err != nil {
+		t.Fatal(err)
+	}
+	golden(t, "report_mermaid.md", out.String())
+}
+
+func TestMermaidLabel(t *testing.T) {
+	if got := mermaidLabel("say \"hi\"\nnow"); got != "say 'hi' now" {
+		t.Errorf("mermaidLabel = %q", got)
+	}
+}
@@ -0,0 +1,125 @@
+package ui
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+
+	"github.com/agnivo988/Repo-lyzer/internal/analyzer"
+	"github.com/agnivo988/Repo-lyzer/internal/pdf"
+)
+
+// ExportPDF writes the report as a paginated PDF: a title page with the
+// overall grade, then the score breakdowns, summaries, and the language,
+// contributor and package tables
+func ExportPDF(data AnalysisResult, filename string, options ExportOptions) error {
+	return exportTo(filename, func(w io.Writer) error { return WritePDF(w, data, options) })
+}
+
+// WritePDF writes the PDF report, generated now
+func WritePDF(w io.Writer, data AnalysisResult, options ExportOptions) error {
+	return renderPDFReport(w, data, options, time.Now())
+}
+
+// renderPDFReport renders the report as of generated
+func renderPDFReport(w io.Writer, data AnalysisResult, options ExportOptions, generated time.Time) error {
+	if data.Repo == nil {
+		return errNoAnalysis
+	}
+	repo := data.Repo
+	doc := pdf.New("Repo-lyzer report: " + repo.FullName)
+
+	doc.Gap(120)
+	doc.Text(pdf.Bold, 26, repo.FullName)
+	doc.Gap(8)
+	if repo.Description != "" {
+		doc.Text(pdf.Regular, 12, repo.Description)
+		doc.Gap(8)
+	}
+	doc.Text(pdf.Bold, 16, fmt.Sprintf("Overall grade: %s (health %d/100)", data.HealthGrade, data.HealthScore))
+	doc.Gap(16)
+	doc.Text(pdf.Regular, 11, fmt.Sprintf("Ref: %s\nLicense: %s\nStatus: %s\nURL: %s\nGenerated %s by Repo-lyzer",
+		data.RefLabel(), analyzer.LicenseLabel(repo), data.Abandonment.Status, repo.HTMLURL,
+		generated.UTC().Format("2006-01-02 15:04 UTC")))
+
+	doc.NewPage()
+	pdfHeading(doc, "Overview")
+	doc.Text(pdf.Regular, 10, fmt.Sprintf("Stars: %d   Forks: %d   Open issues: %d   Contributors: %d   Bus factor: %d (%s)",
+		repo.Stars, repo.Forks, repo.OpenIssues, len(data.Contributors), data.BusFactor, data.BusRisk))
+	for _, line := range []reportCard{
+		{"Activity", data.ActivityTrend.Summary()},
+		{"Languages", data.LanguageProfile.Summary()},
+		{"Engagement", data.Engagement.Summary()},
+		{"Releases", data.ReleaseStats.Summary()},
+		{"Release automation", data.ReleaseAutomation.Summary()},
+		{"Changelog", data.Changelog.Summary()},
+		{"Issues", data.Issues.Summary()},
+		{"First maintainer response", data.FirstResponse.Summary()},
+		{"Pull requests", data.PullRequests.Summary()},
+		{"README", data.ReadmeQuality.Summary()},
+		{"Signed commits", data.SignedCommitRatio.Summary()},
+	} {
+		doc.Text(pdf.Regular, 10, line.Label+": "+line.Value)
+	}
+
+	pdfHeading(doc, fmt.Sprintf("Health score: %d (%s)", data.HealthScore, data.HealthGrade))
+	pdfComponents(doc, data.HealthComponents)
+	pdfHeading(doc, fmt.Sprintf("Maturity: %s (%d, %s)", data.MaturityLevel, data.MaturityScore, data.MaturityGrade))
+	pdfComponents(doc, data.MaturityComponents)
+	pdfHeading(doc, fmt.Sprintf("Security: %d (%s)", data.Security.Score, data.Security.Grade))
+	pdfComponents(doc, data.Security.Components)
+
+	if languages := reportLanguages(data.MetricLanguages()); len(languages) > 0 {
+		pdfHeading(doc, "Languages")
+		metric := data.MetricLanguages()
+		rows := make([][]string, len(languages))
+		for i, l := range languages {
+			rows[i] = []string{l.Name, strconv.Itoa(metric[l.Name]), fmt.Sprintf("%.1f%%", l.Percent)}
+		}
+		doc.Table([]string{"Language", "Bytes", "Share"}, []int{40, 16, 10}, rows)
+	}
+
+	if contributors := options.topContributors(data.Contributors); len(contributors) > 0 {
+		pdfHeading(doc, "Contributors")
+		rows := make([][]string, len(contributors))
+		for i, c := range contributors {
+			rows[i] = []string{c.Login, strconv.Itoa(c.Commits)}
+		}
+		doc.Table([]string{"Contributor", "Commits"}, []int{40, 10}, rows)
+	}
+
+	if len(data.Monorepo.Packages) > 0 {
+		pdfHeading(doc, "Packages")
+		rows := make([][]string, len(data.Monorepo.Packages))
+		for i, p := range data.Monorepo.Packages {
+			dependencies := "not read"
+			if p.Dependencies >= 0 {
+				dependencies = strconv.Itoa(p.Dependencies)
+			}
+			rows[i] = []string{p.Path, p.Ecosystem, p.Manifest, dependencies}
+		}
+		doc.Table([]string{"Path", "Ecosystem", "Manifest", "Dependencies"}, []int{32, 12, 38, 12}, rows)
+	}
+
+	return doc.Write(w)
+}
+
+func pdfHeading(doc *pdf.Document, title string) {
+	doc.Gap(14)
+	doc.Text(pdf.Bold, 14, title)
+	doc.Gap(4)
+}
+
+// pdfComponents tabulates a score's components
+func pdfComponents(doc *pdf.Document, components []analyzer.ScoreComponent) {
+	rows := make([][]string, len(components))
+	for i, c := range components {
+		if !c.Evaluated {
+			rows[i] = []string{c.Name, c.Detail, "", strconv.Itoa(c.Weight), ""}
+			continue
+		}
+		rows[i] = []string{c.Name, c.Detail, fmt.Sprintf("%.2f", c.Score), strconv.Itoa(c.Weight), fmt.Sprintf("%.1f", c.Contribution)}
+	}
+	doc.Table([]string{"Component", "Detail", "Score", "Weight", "Points"}, []int{20, 50, 8, 8, 8}, rows)
+}
@@ -0,0 +1,28 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestWritePDF(t *testing.T) {
+	for name, data := range map[string]AnalysisResult{"empty": fixture(0), "report": reportFixture()} {
+		t.Run(name, func(t *testing.T) {
+			var sb strings.Builder
+			if err := renderPDFReport(&sb, data, DefaultExportOptions, time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)); err != nil {
+				t.Fatal(err)
+			}
+			out := sb.String()
+			if !strings.HasPrefix(out, "%PDF-") {
+				t.Errorf("output starts %q, want a %%PDF header", out[:min(len(out), 16)])
+			}
+			if !strings.HasSuffix(strings.TrimRight(out, "\r\n"), "%%EOF") {
+				t.Error("output doesn't end with an EOF marker")
+			}
+			if !strings.Contains(out, "/Type /Page") {
+				t.Error("output has no pages")
+			}
+		})
+	}
+}
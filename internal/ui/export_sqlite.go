@@ -0,0 +1,200 @@
+package ui
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	_ "modernc.org/sqlite" // pure Go, so builds stay cgo-free
+)
+
+// sqliteMigrations create the store's schema, one entry per version: the
+// migration at index i brings a database to version i+1. Append new ones;
+// never edit those already released.
+var sqliteMigrations = []string{
+	`CREATE TABLE repos (
+		id             INTEGER PRIMARY KEY,
+		full_name      TEXT NOT NULL UNIQUE,
+		html_url       TEXT NOT NULL,
+		description    TEXT NOT NULL,
+		default_branch TEXT NOT NULL,
+		created_at     TEXT NOT NULL
+	);
+	CREATE TABLE analyses (
+		id                INTEGER PRIMARY KEY,
+		repo_id           INTEGER NOT NULL REFERENCES repos(id) ON DELETE CASCADE,
+		analyzed_at       TEXT NOT NULL,
+		ref               TEXT NOT NULL,
+		ref_sha           TEXT NOT NULL,
+		stars             INTEGER NOT NULL,
+		forks             INTEGER NOT NULL,
+		open_issues       INTEGER NOT NULL,
+		watchers          INTEGER NOT NULL,
+		commit_count      INTEGER NOT NULL,
+		contributor_count INTEGER NOT NULL,
+		health_score      INTEGER NOT NULL,
+		health_grade      TEXT NOT NULL,
+		maturity_score    INTEGER NOT NULL,
+		maturity_grade    TEXT NOT NULL,
+		maturity_level    TEXT NOT NULL,
+		security_score    INTEGER NOT NULL,
+		security_grade    TEXT NOT NULL,
+		bus_factor        INTEGER NOT NULL,
+		bus_risk          TEXT NOT NULL,
+		status            TEXT NOT NULL
+	);
+	CREATE INDEX analyses_repo_time ON analyses (repo_id, analyzed_at);
+	CREATE TABLE languages (
+		analysis_id INTEGER NOT NULL REFERENCES analyses(id) ON DELETE CASCADE,
+		name        TEXT NOT NULL,
+		bytes       INTEGER NOT NULL,
+		PRIMARY KEY (analysis_id, name)
+	);
+	CREATE TABLE contributors (
+		analysis_id INTEGER NOT NULL REFERENCES analyses(id) ON DELETE CASCADE,
+		login       TEXT NOT NULL,
+		commits     INTEGER NOT NULL,
+		type        TEXT NOT NULL,
+		PRIMARY KEY (analysis_id, login)
+	);`,
+}
+
+// ExportSQLite records data as a new analysis in the SQLite database at
+// filename, creating the database and its schema if needed. Each run adds
+// a row, so the database builds up a history to query, e.g. a repo's
+// health score over time.
+func ExportSQLite(data AnalysisResult, filename string) error {
+	if data.Repo == nil {
+		return errNoAnalysis
+	}
+	if filename == Stdout {
+		return fmt.Errorf("the SQLite store needs a database file, not stdout")
+	}
+	db, err := openSQLiteStore(filename)
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	if err := storeAnalysis(context.Background(), db, data, time.Now()); err != nil {
+		return err
+	}
+	return db.Close()
+}
+
+// openSQLiteStore opens the database at filename with its schema up to date
+func openSQLiteStore(filename string) (*sql.DB, error) {
+	db, err := sql.Open("sqlite", filename)
+	if err != nil {
+		return nil, err
+	}
+	// The foreign_keys pragma is per connection, so keep to one
+	db.SetMaxOpenConns(1)
+	if _, err := db.Exec("PRAGMA foreign_keys = ON"); err != nil {
+		db.Close()
+		return nil, err
+	}
+	if err := migrateSQLiteStore(db); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("preparing %s: %w", filename, err)
+	}
+	return db, nil
+}
+
+// migrateSQLiteStore applies the migrations the database hasn't had yet,
+// recording each in schema_version. It's a no-op on an up to date database.
+func migrateSQLiteStore(db *sql.DB) error {
+	if _, err := db.Exec("CREATE TABLE IF NOT EXISTS schema_version (version INTEGER NOT NULL)"); err != nil {
+		return err
+	}
+	var version int
+	if err := db.QueryRow("SELECT COALESCE(MAX(version), 0) FROM schema_version").Scan(&version); err != nil {
+		return err
+	}
+	if version > len(sqliteMigrations) {
+		return fmt.Errorf("schema version %d is newer than this Repo-lyzer supports (%d)", version, len(sqliteMigrations))
+	}
+
+	for ; version < len(sqliteMigrations); version++ {
+		tx, err := db.Begin()
+		if err != nil {
+			return err
+		}
+		if _, err := tx.Exec(sqliteMigrations[version]); err != nil {
+			tx.Rollback()
+			return fmt.Errorf("migrating to schema version %d: %w", version+1, err)
+		}
+		if _, err := tx.Exec("INSERT INTO schema_version (version) VALUES (?)", version+1); err != nil {
+			tx.Rollback()
+			return err
+		}
+		if err := tx.Commit(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// storeAnalysis upserts the repo and adds the analysis made at analyzedAt,
+// with its languages and contributors, in one transaction
+func storeAnalysis(ctx context.Context, db *sql.DB, data AnalysisResult, analyzedAt time.Time) error {
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	repo := data.Repo
+	var repoID int64
+	err = tx.QueryRowContext(ctx, `INSERT INTO repos (full_name, html_url, description, default_branch, created_at)
+		VALUES (?, ?, ?, ?, ?)
+		ON CONFLICT (full_name) DO UPDATE SET
+			html_url = excluded.html_url,
+			description = excluded.description,
+			default_branch = excluded.default_branch,
+			created_at = excluded.created_at
+		RETURNING id`,
+		repo.FullName, repo.HTMLURL, repo.Description, repo.DefaultBranch, sqliteTime(repo.CreatedAt),
+	).Scan(&repoID)
+	if err != nil {
+		return err
+	}
+
+	result, err := tx.ExecContext(ctx, `INSERT INTO analyses (
+			repo_id, analyzed_at, ref, ref_sha, stars, forks, open_issues, watchers,
+			commit_count, contributor_count, health_score, health_grade,
+			maturity_score, maturity_grade, maturity_level, security_score, security_grade,
+			bus_factor, bus_risk, status
+		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		repoID, sqliteTime(analyzedAt), data.Ref, data.RefSHA, repo.Stars, repo.Forks, repo.OpenIssues, repo.Subscribers,
+		len(data.Commits), len(data.Contributors), data.HealthScore, string(data.HealthGrade),
+		data.MaturityScore, string(data.MaturityGrade), data.MaturityLevel, data.Security.Score, string(data.Security.Grade),
+		data.BusFactor, data.BusRisk, data.Abandonment.Status,
+	)
+	if err != nil {
+		return err
+	}
+	analysisID, err := result.LastInsertId()
+	if err != nil {
+		return err
+	}
+
+	for name, bytes := range data.MetricLanguages() {
+		if _, err := tx.ExecContext(ctx, "INSERT INTO languages (analysis_id, name, bytes) VALUES (?, ?, ?)", analysisID, name, bytes); err != nil {
+			return err
+		}
+	}
+	for _, c := range data.Contributors {
+		if _, err := tx.ExecContext(ctx, "INSERT INTO contributors (analysis_id, login, commits, type) VALUES (?, ?, ?, ?)", analysisID, c.Login, c.Commits, c.Type); err != nil {
+			return err
+		}
+	}
+	return tx.Commit()
+}
+
+// sqliteTime formats t as SQLite's date functions expect, in UTC so rows
+// sort chronologically as text
+func sqliteTime(t time.Time) string {
+	return t.UTC().Format("2006-01-02 15:04:05")
+}
@@ -0,0 +1,144 @@
+package ui
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/agnivo988/Repo-lyzer/internal/analyzer"
+	"github.com/agnivo988/Repo-lyzer/internal/github"
+)
+
+func TestSQLiteStoreAccumulatesAnalyses(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "history.db")
+	first := time.Date(2025, 3, 2, 8, 0, 0, 0, time.UTC)
+	runs := []struct {
+		at     time.Time
+		stars  int
+		health int
+	}{
+		{first, 42, 71},
+		{first.AddDate(0, 0, 7), 45, 78},
+	}
+	for _, run := range runs {
+		data := reportFixture()
+		data.Repo.Stars = run.stars
+		data.HealthScore, data.HealthGrade = run.health, analyzer.GradeFor(run.health)
+		data.Contributors[0].Type = "User"
+
+		// Reopening each time checks creating the schema is idempotent
+		db, err := openSQLiteStore(filename)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := storeAnalysis(context.Background(), db, data, run.at); err != nil {
+			t.Fatal(err)
+		}
+		db.Close()
+	}
+
+	db, err := openSQLiteStore(filename)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	var versions, repos int
+	db.QueryRow("SELECT COUNT(*) FROM schema_version").Scan(&versions)
+	db.QueryRow("SELECT COUNT(*) FROM repos").Scan(&repos)
+	if versions != len(sqliteMigrations) || repos != 1 {
+		t.Errorf("%d schema_version rows and %d repos, want %d and 1", versions, repos, len(sqliteMigrations))
+	}
+
+	type analysisRow struct {
+		id                                     int64
+		name, at, grade                        string
+		stars, health, contributors, busFactor int
+	}
+	rows, err := db.Query(`SELECT a.id, r.full_name, a.analyzed_at, a.stars, a.health_score, a.health_grade, a.contributor_count, a.bus_factor
+		FROM analyses a JOIN repos r ON r.id = a.repo_id ORDER BY a.analyzed_at`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var analyses []analysisRow
+	for rows.Next() {
+		var a analysisRow
+		if err := rows.Scan(&a.id, &a.name, &a.at, &a.stars, &a.health, &a.grade, &a.contributors, &a.busFactor); err != nil {
+			t.Fatal(err)
+		}
+		analyses = append(analyses, a)
+	}
+	rows.Close()
+	if len(analyses) != len(runs) {
+		t.Fatalf("%d analyses stored, want %d", len(analyses), len(runs))
+	}
+
+	for i, a := range analyses {
+		want := runs[i]
+		if a.name != "owner/repo" || a.at != sqliteTime(want.at) || a.stars != want.stars || a.health != want.health ||
+			a.grade != string(analyzer.GradeFor(want.health)) || a.contributors != 3 || a.busFactor != 2 {
+			t.Errorf("analysis %d = %+v, want %+v", i, a, want)
+		}
+
+		var languages, goBytes int
+		db.QueryRow("SELECT COUNT(*) FROM languages WHERE analysis_id = ?", a.id).Scan(&languages)
+		db.QueryRow("SELECT bytes FROM languages WHERE analysis_id = ? AND name = 'Go'", a.id).Scan(&goBytes)
+		if languages != 3 || goBytes != 7500 {
+			t.Errorf("analysis %d: %d languages, Go %d bytes, want 3 and 7500", i, languages, goBytes)
+		}
+		var login, kind string
+		var commits int
+		db.QueryRow("SELECT login, commits, type FROM contributors WHERE analysis_id = ? ORDER BY commits DESC", a.id).Scan(&login, &commits, &kind)
+		if login != "user0" || commits != 100 || kind != "User" {
+			t.Errorf("analysis %d: top contributor %s with %d commits (%s)", i, login, commits, kind)
+		}
+	}
+}
+
+func TestSQLiteStoreForeignKeys(t *testing.T) {
+	db, err := openSQLiteStore(filepath.Join(t.TempDir(), "history.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	if _, err := db.Exec("INSERT INTO languages (analysis_id, name, bytes) VALUES (99, 'Go', 1)"); err == nil {
+		t.Error("a language row without its analysis was accepted")
+	}
+
+	if err := storeAnalysis(context.Background(), db, fixture(2), time.Now()); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := db.Exec("DELETE FROM repos"); err != nil {
+		t.Fatal(err)
+	}
+	var left int
+	db.QueryRow("SELECT (SELECT COUNT(*) FROM analyses) + (SELECT COUNT(*) FROM contributors)").Scan(&left)
+	if left != 0 {
+		t.Errorf("%d rows left after deleting the repo, want them cascaded", left)
+	}
+}
+
+func TestSQLiteStoreRejectsNewerSchema(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "history.db")
+	db, err := openSQLiteStore(filename)
+	if err != nil {
+		t.Fatal(err)
+	}
+	db.Exec("INSERT INTO schema_version (version) VALUES (?)", len(sqliteMigrations)+1)
+	db.Close()
+
+	if err := ExportSQLite(fixture(1), filename); err == nil {
+		t.Error("stored into a database from a newer version")
+	}
+}
+
+func TestExportSQLiteNeedsAFile(t *testing.T) {
+	if err := ExportSQLite(AnalysisResult{}, filepath.Join(t.TempDir(), "x.db")); err != errNoAnalysis {
+		t.Errorf("without a repo: err = %v, want errNoAnalysis", err)
+	}
+	if err := ExportSQLite(AnalysisResult{Repo: &github.Repo{FullName: "o/r"}}, Stdout); err == nil {
+		t.Error("exported a database to stdout")
+	}
+}
@@ -0,0 +1,272 @@
+package ui
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/agnivo988/Repo-lyzer/internal/github"
+)
+
+// fixture is a small analysis with n contributors, most commits first
+func fixture(n int) AnalysisResult {
+	data := AnalysisResult{Repo: &github.Repo{FullName: "owner/repo", DefaultBranch: "main"}}
+	for i := 0; i < n; i++ {
+		data.Contributors = append(data.Contributors, github.Contributor{Login: fmt.Sprintf("user%d", i), Commits: 100 - i})
+	}
+	return data
+}
+
+// rankedContributors counts the numbered contributor lines in Markdown
+func rankedContributors(t *testing.T, md string) int {
+	t.Helper()
+	_, section, ok := strings.Cut(md, "## Top Contributors\n")
+	if !ok {
+		t.Fatal("no Top Contributors section")
+	}
+	ranked := 0
+	for _, line := range strings.Split(section, "\n") {
+		if strings.HasPrefix(line, fmt.Sprintf("%d. user%d ", ranked+1, ranked)) {
+			ranked++
+		}
+	}
+	return ranked
+}
+
+func TestContributorLimit(t *testing.T) {
+	tests := []struct {
+		name      string
+		available int
+		limit     int
+		want      int
+	}{
+		{"limit below available", 15, 3, 3},
+		{"limit above available", 5, 50, 5},
+		{"limit 0 lists all", 15, 0, 15},
+		{"default", 15, DefaultExportOptions.ContributorLimit, 10},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data := fixture(tt.available)
+			options := ExportOptions{ContributorLimit: tt.limit}
+
+			var md strings.Builder
+			if err := WriteMarkdown(&md, data, options); err != nil {
+				t.Fatal(err)
+			}
+			if got := rankedContributors(t, md.String()); got != tt.want {
+				t.Errorf("Markdown ranks %d contributors, want %d", got, tt.want)
+			}
+
+			// The JSON lists as many, and counts everyone
+			var out strings.Builder
+			if err := WriteJSON(&out, data, options); err != nil {
+				t.Fatal(err)
+			}
+			var doc struct {
+				Contributors     []github.Contributor `json:"contributors"`
+				ContributorCount int                  `json:"contributor_count"`
+			}
+			if err := json.Unmarshal([]byte(out.String()), &doc); err != nil {
+				t.Fatal(err)
+			}
+			if len(doc.Contributors) != tt.want {
+				t.Errorf("JSON lists %d contributors, want %d", len(doc.Contributors), tt.want)
+			}
+			if doc.ContributorCount != tt.available {
+				t.Errorf("JSON contributor_count = %d, want the total %d", doc.ContributorCount, tt.available)
+			}
+		})
+	}
+}
+
+// withCommits adds n commits to data, alternating linked and unlinked
+// authors
+func withCommits(data AnalysisResult, n int) AnalysisResult {
+	for i := 0; i < n; i++ {
+		var c github.Commit
+		c.SHA = fmt.Sprintf("%040d", i)
+		c.Commit.Author.Name = "Unlinked <Name>"
+		c.Commit.Author.Date = time.Date(2025, 1, 1+i, 12, 0, 0, 0, time.UTC)
+		c.Commit.Message = fmt.Sprintf("Change %d\n\nWith a body", i)
+		if i%2 == 0 {
+			c.Author = &github.CommitAuthor{Login: fmt.Sprintf("user%d", i)}
+		}
+		data.Commits = append(data.Commits, c)
+	}
+	return data
+}
+
+func TestJSONCommits(t *testing.T) {
+	tests := []struct {
+		name    string
+		commits int
+		include bool
+	}{
+		{"counted only", 3, false},
+		{"listed", 3, true},
+		{"listed, none", 0, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data := withCommits(fixture(2), tt.commits)
+			var out strings.Builder
+			if err := WriteJSON(&out, data, ExportOptions{IncludeCommits: tt.include}); err != nil {
+				t.Fatal(err)
+			}
+			var doc map[string]any
+			if err := json.Unmarshal([]byte(out.String()), &doc); err != nil {
+				t.Fatalf("invalid JSON: %v\n%s", err, out.String())
+			}
+			if doc["commit_count"] != float64(tt.commits) {
+				t.Errorf("commit_count = %v, want %d", doc["commit_count"], tt.commits)
+			}
+			if doc["schema_version"] != SchemaVersion || doc["contributors"] == nil {
+				t.Errorf("the rest of the document is missing: %v", doc)
+			}
+			commits, listed := doc["commits"].([]any)
+			if listed != tt.include {
+				t.Fatalf("commits listed = %t, want %t", listed, tt.include)
+			}
+			if !tt.include {
+				return
+			}
+			if len(commits) != tt.commits {
+				t.Fatalf("%d commits listed, want %d", len(commits), tt.commits)
+			}
+			for i, c := range commits {
+				want := map[string]any{
+					"sha":     fmt.Sprintf("%040d", i),
+					"author":  "Unlinked <Name>",
+					"date":    time.Date(2025, 1, 1+i, 12, 0, 0, 0, time.UTC).Format(time.RFC3339),
+					"subject": fmt.Sprintf("Change %d", i),
+				}
+				if i%2 == 0 {
+					want["author"] = fmt.Sprintf("user%d", i)
+				}
+				if fmt.Sprint(c) != fmt.Sprint(want) {
+					t.Errorf("commit %d = %v, want %v", i, c, want)
+				}
+			}
+		})
+	}
+}
+
+func TestStreamedJSONMatchesDocument(t *testing.T) {
+	// The streamed commits must encode as the document's Commits would
+	data := withCommits(fixture(3), 4)
+	var streamed strings.Builder
+	if err := WriteJSON(&streamed, data, ExportOptions{IncludeCommits: true}); err != nil {
+		t.Fatal(err)
+	}
+	var got, want map[string]any
+	if err := json.Unmarshal([]byte(streamed.String()), &got); err != nil {
+		t.Fatal(err)
+	}
+	encoded, err := json.Marshal(newJSONDocument(data, ExportOptions{IncludeCommits: true}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := json.Unmarshal(encoded, &want); err != nil {
+		t.Fatal(err)
+	}
+	delete(got, "exported_at")
+	delete(want, "exported_at")
+	if fmt.Sprint(got) != fmt.Sprint(want) {
+		t.Errorf("streamed JSON differs from the document:\ngot  %v\nwant %v", got, want)
+	}
+}
+
+func TestEmptyDataExports(t *testing.T) {
+	writers := []struct {
+		format string
+		write  func(w io.Writer, data AnalysisResult) error
+	}{
+		{"markdown", func(w io.Writer, data AnalysisResult) error { return WriteMarkdown(w, data, DefaultExportOptions) }},
+		{"json", func(w io.Writer, data AnalysisResult) error { return WriteJSON(w, data, DefaultExportOptions) }},
+		{"yaml", func(w io.Writer, data AnalysisResult) error { return WriteYAML(w, data, DefaultExportOptions) }},
+		{"html", func(w io.Writer, data AnalysisResult) error { return WriteHTML(w, data, DefaultExportOptions) }},
+		{"pdf", func(w io.Writer, data AnalysisResult) error { return WritePDF(w, data, DefaultExportOptions) }},
+		{"csv", WriteCSV},
+	}
+	for _, tt := range writers {
+		t.Run(tt.format, func(t *testing.T) {
+			var out strings.Builder
+			if err := tt.write(&out, fixture(0)); err != nil {
+				t.Fatalf("empty analysis: %v", err)
+			}
+			for _, bad := range []string{"NaN", "+Inf", "-Inf"} {
+				if strings.Contains(out.String(), bad) {
+					t.Errorf("output contains %s", bad)
+				}
+			}
+			if tt.format == "json" || tt.format == "yaml" {
+				return // the document is written whatever it holds
+			}
+			if err := tt.write(&strings.Builder{}, AnalysisResult{}); err != errNoAnalysis {
+				t.Errorf("without a repo: err = %v, want errNoAnalysis", err)
+			}
+		})
+	}
+}
+
+func TestEmptyMarkdownSections(t *testing.T) {
+	var out strings.Builder
+	if err := WriteMarkdown(&out, fixture(0), DefaultExportOptions); err != nil {
+		t.Fatal(err)
+	}
+	md := out.String()
+	for _, section := range []string{"## Languages", "## Top Contributors"} {
+		_, rest, ok := strings.Cut(md, section)
+		if !ok {
+			t.Errorf("%s section missing", section)
+			continue
+		}
+		if !strings.Contains(strings.SplitN(rest, "\n## ", 2)[0], strings.TrimSpace(noData)) {
+			t.Errorf("%s section doesn't say there's no data", section)
+		}
+	}
+}
+
+func TestEmptyJSONCollections(t *testing.T) {
+	var out strings.Builder
+	if err := WriteJSON(&out, fixture(0), DefaultExportOptions); err != nil {
+		t.Fatal(err)
+	}
+	var doc map[string]json.RawMessage
+	if err := json.Unmarshal([]byte(out.String()), &doc); err != nil {
+		t.Fatal(err)
+	}
+	tests := map[string]string{
+		"contributors":      "[]",
+		"languages":         "{}",
+		"contributor_count": "0",
+		"commit_count":      "0",
+	}
+	for key, want := range tests {
+		if got := string(doc[key]); got != want {
+			t.Errorf("%s = %s, want %s", key, got, want)
+		}
+	}
+}
+
+// TestLanguageOrderGolden pins the order of a breakdown with more languages
+// than are listed, ties between them and bytes already in Other
+func TestLanguageOrderGolden(t *testing.T) {
+	languages := map[string]int{
+		"Go": 9000, "TypeScript": 4000, "JavaScript": 4000, "Shell": 1500, "Python": 1500,
+		"Makefile": 800, "Dockerfile": 600, "HTML": 500, "CSS": 500, "Rust": 300,
+		"C": 200, "Lua": 100, "Other": 250,
+	}
+	var out strings.Builder
+	out.WriteString(languagesMarkdown("Languages", languages, false))
+	out.WriteString("\n")
+	if err := csv.NewWriter(&out).WriteAll(csvLanguages(languages)); err != nil {
+		t.Fatal(err)
+	}
+	golden(t, "languages.txt", out.String())
+}
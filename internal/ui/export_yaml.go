@@ -0,0 +1,166 @@
+package ui
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+)
+
+// plainYAMLScalar matches the strings safe to write unquoted
+var plainYAMLScalar = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_./+-]*(?: [A-Za-z0-9_./+()-]+)*$`)
+
+// yamlReserved are the plain scalars YAML would read as something other
+// than a string
+var yamlReserved = map[string]bool{
+	"true": true, "false": true, "null": true, "yes": true, "no": true,
+	"on": true, "off": true, "y": true, "n": true, "~": true,
+}
+
+// yamlNode is a JSON value with its object keys in document order
+type yamlNode struct {
+	scalar string // set for scalars, already formatted
+	keys   []string
+	values []*yamlNode
+	items  []*yamlNode
+	object bool
+	array  bool
+}
+
+// ExportYAML writes the same document as ExportJSON, field names and
+// order included, as YAML with 2-space indentation. It's converted from
+// the JSON so the two formats can't drift apart.
+func ExportYAML(data AnalysisResult, filename string, options ExportOptions) error {
+	return exportTo(filename, func(w io.Writer) error { return WriteYAML(w, data, options) })
+}
+
+// WriteYAML writes data as YAML
+func WriteYAML(w io.Writer, data AnalysisResult, options ExportOptions) error {
+	var buf bytes.Buffer
+	if err := WriteJSON(&buf, data, options); err != nil {
+		return err
+	}
+	decoder := json.NewDecoder(&buf)
+	decoder.UseNumber()
+	root, err := decodeYAMLNode(decoder)
+	if err != nil {
+		return err
+	}
+
+	var out strings.Builder
+	out.WriteString("---\n")
+	writeYAMLNode(&out, root, 0)
+	_, err = io.WriteString(w, out.String())
+	return err
+}
+
+// decodeYAMLNode reads the next JSON value, keeping object keys in order
+func decodeYAMLNode(decoder *json.Decoder) (*yamlNode, error) {
+	token, err := decoder.Token()
+	if err != nil {
+		return nil, err
+	}
+	switch t := token.(type) {
+	case json.Delim:
+		node := &yamlNode{object: t == '{', array: t == '['}
+		for decoder.More() {
+			if node.object {
+				key, err := decoder.Token()
+				if err != nil {
+					return nil, err
+				}
+				node.keys = append(node.keys, key.(string))
+			}
+			value, err := decodeYAMLNode(decoder)
+			if err != nil {
+				return nil, err
+			}
+			if node.object {
+				node.values = append(node.values, value)
+			} else {
+				node.items = append(node.items, value)
+			}
+		}
+		// The closing delimiter
+		if _, err := decoder.Token(); err != nil {
+			return nil, err
+		}
+		return node, nil
+	case string:
+		return &yamlNode{scalar: yamlString(t)}, nil
+	case json.Number:
+		return &yamlNode{scalar: t.String()}, nil
+	case bool:
+		return &yamlNode{scalar: fmt.Sprint(t)}, nil
+	case nil:
+		return &yamlNode{scalar: "null"}, nil
+	}
+	return nil, fmt.Errorf("unexpected JSON token %v", token)
+}
+
+// yamlString writes s plain when that's unambiguous, and double-quoted
+// otherwise; JSON's escapes are all valid in YAML's double quotes
+func yamlString(s string) string {
+	if plainYAMLScalar.MatchString(s) && !yamlReserved[strings.ToLower(s)] {
+		return s
+	}
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	encoder.SetEscapeHTML(false)
+	encoder.Encode(s)
+	return strings.TrimSuffix(buf.String(), "\n")
+}
+
+// isBlock reports whether a node is written on lines of its own
+func (n *yamlNode) isBlock() bool {
+	return n.object && len(n.keys) > 0 || n.array && len(n.items) > 0
+}
+
+// inline renders a scalar or empty collection
+func (n *yamlNode) inline() string {
+	switch {
+	case n.object:
+		return "{}"
+	case n.array:
+		return "[]"
+	}
+	return n.scalar
+}
+
+func writeYAMLNode(out *strings.Builder, n *yamlNode, indent int) {
+	pad := strings.Repeat(" ", indent)
+	switch {
+	case n.object:
+		for i, key := range n.keys {
+			value := n.values[i]
+			out.WriteString(pad + yamlString(key) + ":")
+			if value.isBlock() {
+				out.WriteString("\n")
+				if value.array {
+					// Sequences sit at their key's indentation
+					writeYAMLNode(out, value, indent)
+				} else {
+					writeYAMLNode(out, value, indent+2)
+				}
+			} else {
+				out.WriteString(" " + value.inline() + "\n")
+			}
+		}
+	case n.array:
+		for _, item := range n.items {
+			if !item.isBlock() {
+				out.WriteString(pad + "- " + item.inline() + "\n")
+				continue
+			}
+			// Render the item one level in, then hang its first line off
+			// the dash
+			var nested strings.Builder
+			writeYAMLNode(&nested, item, indent+2)
+			out.WriteString(pad + "- " + strings.TrimPrefix(nested.String(), pad+"  "))
+		}
+	default:
+		out.WriteString(pad + n.scalar + "\n")
+	}
+}
@@ -0,0 +1,203 @@
+package ui
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// yamlLine is a line of block YAML with its indentation
+type yamlLine struct {
+	indent int
+	text   string
+}
+
+// parseYAML reads back the block YAML subset WriteYAML emits into the
+// values encoding/json decodes to, so the two can be compared
+func parseYAML(t *testing.T, doc string) any {
+	t.Helper()
+	doc, ok := strings.CutPrefix(doc, "---\n")
+	if !ok {
+		t.Fatal("no document start marker")
+	}
+	var lines []yamlLine
+	for _, l := range strings.Split(strings.TrimSuffix(doc, "\n"), "\n") {
+		text := strings.TrimLeft(l, " ")
+		lines = append(lines, yamlLine{len(l) - len(text), text})
+	}
+	p := &yamlParser{t: t, lines: lines}
+	v := p.block(lines[0].indent)
+	if p.i != len(lines) {
+		t.Fatalf("line %d not consumed: %q", p.i+1, lines[p.i].text)
+	}
+	return v
+}
+
+type yamlParser struct {
+	t     *testing.T
+	lines []yamlLine
+	i     int
+}
+
+func (p *yamlParser) block(indent int) any {
+	if strings.HasPrefix(p.lines[p.i].text, "- ") {
+		return p.sequence(indent)
+	}
+	return p.mapping(indent)
+}
+
+func (p *yamlParser) sequence(indent int) []any {
+	items := []any{}
+	for p.i < len(p.lines) && p.lines[p.i].indent == indent && strings.HasPrefix(p.lines[p.i].text, "- ") {
+		rest := p.lines[p.i].text[2:]
+		if _, _, isKey := p.splitKey(rest); isKey || strings.HasPrefix(rest, "- ") {
+			// A collection hanging off the dash continues two columns in
+			p.lines[p.i] = yamlLine{indent + 2, rest}
+			items = append(items, p.block(indent+2))
+			continue
+		}
+		items = append(items, p.scalar(rest))
+		p.i++
+	}
+	return items
+}
+
+func (p *yamlParser) mapping(indent int) map[string]any {
+	m := map[string]any{}
+	for p.i < len(p.lines) && p.lines[p.i].indent == indent && !strings.HasPrefix(p.lines[p.i].text, "- ") {
+		key, value, ok := p.splitKey(p.lines[p.i].text)
+		if !ok {
+			p.t.Fatalf("line %d: expected a key: %q", p.i+1, p.lines[p.i].text)
+		}
+		p.i++
+		switch {
+		case value != "":
+			m[key] = p.scalar(value)
+		case p.i < len(p.lines) && p.lines[p.i].indent == indent && strings.HasPrefix(p.lines[p.i].text, "- "):
+			m[key] = p.sequence(indent)
+		case p.i < len(p.lines) && p.lines[p.i].indent > indent:
+			m[key] = p.block(p.lines[p.i].indent)
+		default:
+			p.t.Fatalf("line %d: key %q has no value", p.i, key)
+		}
+	}
+	return m
+}
+
+// splitKey splits "key: value" or "key:", where key may be double-quoted
+func (p *yamlParser) splitKey(text string) (key, value string, ok bool) {
+	if strings.HasPrefix(text, `"`) {
+		decoder := json.NewDecoder(strings.NewReader(text))
+		if decoder.Decode(&key) != nil {
+			return "", "", false
+		}
+		text = text[decoder.InputOffset():]
+	} else {
+		i := strings.IndexByte(text, ':')
+		if i < 0 {
+			return "", "", false
+		}
+		key, text = text[:i], text[i:]
+	}
+	if !strings.HasPrefix(text, ":") {
+		return "", "", false
+	}
+	return key, strings.TrimPrefix(text[1:], " "), true
+}
+
+func (p *yamlParser) scalar(text string) any {
+	switch {
+	case text == "{}":
+		return map[string]any{}
+	case text == "[]":
+		return []any{}
+	case text == "null":
+		return nil
+	case text == "true" || text == "false":
+		return text == "true"
+	case strings.HasPrefix(text, `"`), strings.ContainsAny(text[:1], "-0123456789"):
+		var v any
+		if err := json.Unmarshal([]byte(text), &v); err != nil {
+			p.t.Fatalf("line %d: bad scalar %q: %v", p.i+1, text, err)
+		}
+		return v
+	}
+	if plainYAMLScalar.MatchString(text) {
+		return text
+	}
+	p.t.Fatalf("line %d: unexpected plain scalar %q", p.i+1, text)
+	return nil
+}
+
+func TestYAMLRoundTrip(t *testing.T) {
+	tricky := reportFixture()
+	tricky = withCommits(tricky, 3)
+	tricky.Repo.Description = "yes: a \"quoted\" #hash\nand a second line – ünïcode"
+	tricky.Repo.Topics = []string{"cli", "true", "1.0", "null", "- dash", ""}
+	tricky.Repo.Language = "Go"
+
+	tests := []struct {
+		name    string
+		data    AnalysisResult
+		options ExportOptions
+	}{
+		{"minimal", fixture(0), DefaultExportOptions},
+		{"contributors", fixture(12), DefaultExportOptions},
+		{"tricky strings and commits", tricky, ExportOptions{IncludeCommits: true}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var jsonOut, yamlOut strings.Builder
+			if err := WriteJSON(&jsonOut, tt.data, tt.options); err != nil {
+				t.Fatal(err)
+			}
+			if err := WriteYAML(&yamlOut, tt.data, tt.options); err != nil {
+				t.Fatal(err)
+			}
+
+			var want map[string]any
+			if err := json.Unmarshal([]byte(jsonOut.String()), &want); err != nil {
+				t.Fatal(err)
+			}
+			got, ok := parseYAML(t, yamlOut.String()).(map[string]any)
+			if !ok {
+				t.Fatal("YAML document is not a mapping")
+			}
+			// Both were generated now, a moment apart
+			delete(got, "exported_at")
+			delete(want, "exported_at")
+			if !reflect.DeepEqual(got, want) {
+				for key := range want {
+					if !reflect.DeepEqual(got[key], want[key]) {
+						t.Errorf("%s: YAML %s, JSON %s", key, fmt.Sprint(got[key]), fmt.Sprint(want[key]))
+					}
+				}
+				t.Fatalf("YAML doesn't round-trip to the JSON:\n%s", yamlOut.String())
+			}
+		})
+	}
+}
+
+func TestYAMLString(t *testing.T) {
+	tests := []struct {
+		in, want string
+	}{
+		{"plain", "plain"},
+		{"two words", "two words"},
+		{"", `""`},
+		{"yes", `"yes"`},
+		{"Null", `"Null"`},
+		{"1.0", `"1.0"`},
+		{"a: b", `"a: b"`},
+		{"#tag", `"#tag"`},
+		{"<b>&", `"<b>&"`},
+		{"line\nbreak", `"line\nbreak"`},
+	}
+	for _, tt := range tests {
+		if got := yamlString(tt.in); got != tt.want {
+			t.Errorf("yamlString(%q) = %s, want %s", tt.in, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,127 @@
+package ui
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"time"
+
+	"github.com/agnivo988/Repo-lyzer/internal/github"
+)
+
+// UIMessage is an error explained for the user
+type UIMessage struct {
+	Title       string
+	Description string
+	Retryable   bool // trying again later may succeed
+}
+
+// MessageForError turns an analysis error into a UIMessage, recognising
+// the typed errors the GitHub client returns. What the credential can see
+// explains a "not found" that may really be a private repo.
+func MessageForError(err error, auth github.AuthCapability) UIMessage {
+	var rateLimit *github.RateLimitError
+	var secondary *github.SecondaryRateLimitError
+	var statusErr *github.StatusError
+	var refErr *github.RefNotFoundError
+	var netErr net.Error
+
+	switch {
+	case errors.Is(err, context.Canceled):
+		return UIMessage{
+			Title:       "Analysis cancelled",
+			Description: "The analysis was stopped before it finished.",
+			Retryable:   true,
+		}
+	case errors.As(err, &refErr):
+		return UIMessage{
+			Title:       "Ref not found",
+			Description: fmt.Sprintf("%s has no branch, tag or commit named %q.", refErr.Repo, refErr.Ref),
+		}
+	case errors.Is(err, github.ErrNoInstallationAccess):
+		return UIMessage{
+			Title:       "Repository not accessible to the GitHub App",
+			Description: err.Error(),
+		}
+	case errors.As(err, &rateLimit):
+		return UIMessage{
+			Title:       "Rate limit exceeded",
+			Description: "GitHub's hourly API limit resets at " + rateLimit.Reset.Local().Format(time.Kitchen) + ". Add a token for a higher limit.",
+			Retryable:   true,
+		}
+	case errors.As(err, &secondary):
+		return UIMessage{
+			Title:       "Too many requests at once",
+			Description: "GitHub throttled a burst of requests. Wait about " + secondary.RetryAfter.Round(time.Second).String() + " and try again.",
+			Retryable:   true,
+		}
+	case errors.Is(err, github.ErrNotFound):
+		return UIMessage{
+			Title:       "Repository not found — check the owner/name",
+			Description: notFoundHint(auth),
+		}
+	case errors.Is(err, github.ErrUnauthorized):
+		return UIMessage{
+			Title:       "Authentication failed",
+			Description: "GitHub rejected the token. Check that it's valid and hasn't expired.",
+		}
+	case errors.Is(err, github.ErrForbidden):
+		return UIMessage{
+			Title:       "Access denied",
+			Description: apiMessage(err, "Your token doesn't have permission to read this repository."),
+		}
+	case errors.Is(err, github.ErrUnprocessable):
+		return UIMessage{
+			Title:       "GitHub couldn't process the request",
+			Description: apiMessage(err, "The request was rejected as invalid."),
+		}
+	case errors.As(err, &statusErr) && statusErr.IsServerError():
+		return UIMessage{
+			Title:       "GitHub is having trouble",
+			Description: "GitHub answered " + statusErr.Status + ". This is usually temporary.",
+			Retryable:   true,
+		}
+	case errors.Is(err, context.DeadlineExceeded) || errors.As(err, &netErr):
+		return UIMessage{
+			Title:       "Network problem",
+			Description: "Couldn't reach the server: " + err.Error(),
+			Retryable:   true,
+		}
+	default:
+		return UIMessage{Title: "Analysis failed", Description: err.Error()}
+	}
+}
+
+// notFoundHint explains why a repo may appear missing given the credential
+func notFoundHint(auth github.AuthCapability) string {
+	switch {
+	case auth.Kind == github.AuthNone:
+		return "It doesn't exist, or it's private: set GITHUB_TOKEN to analyze private repositories."
+	case auth.Kind == github.AuthClassic && !auth.CanReadPrivateRepos():
+		return "Your token has no 'repo' scope — private repositories will appear as not found."
+	case auth.Kind == github.AuthFineGrained:
+		return "It doesn't exist, or your fine-grained token wasn't granted access to it (it needs read access to Contents and Metadata)."
+	default:
+		return "It doesn't exist, or it's private and your token can't see it."
+	}
+}
+
+// apiMessage returns the message from GitHub's error body, or fallback
+func apiMessage(err error, fallback string) string {
+	var statusErr *github.StatusError
+	if errors.As(err, &statusErr) && statusErr.Message != "" {
+		return statusErr.Message
+	}
+	return fallback
+}
+
+// renderError draws an error as its UIMessage
+func renderError(err error, auth github.AuthCapability) string {
+	msg := MessageForError(err, auth)
+	s := ErrorStyle.Render("✗ "+msg.Title) + "\n" + msg.Description
+	if msg.Retryable {
+		s += "\n" + SubtleStyle.Render("Press Enter to try again")
+	}
+	return s
+}
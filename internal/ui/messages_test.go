@@ -0,0 +1,75 @@
+package ui
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/agnivo988/Repo-lyzer/internal/github"
+)
+
+func TestMessageForError(t *testing.T) {
+	anonymous := github.AuthCapability{Kind: github.AuthNone}
+	publicOnly := github.AuthCapability{Kind: github.AuthClassic, Scopes: []string{"read:org"}}
+	fullAccess := github.AuthCapability{Kind: github.AuthClassic, Scopes: []string{"repo"}}
+	fineGrained := github.AuthCapability{Kind: github.AuthFineGrained}
+
+	tests := []struct {
+		name      string
+		err       error
+		auth      github.AuthCapability
+		title     string
+		mentions  string // in the description
+		retryable bool
+	}{
+		{"401", &github.StatusError{StatusCode: 401, Status: "401 Unauthorized"}, fullAccess,
+			"Authentication failed", "expired", false},
+		{"403 rate limited", &github.RateLimitError{Reset: time.Date(2025, 1, 1, 15, 4, 0, 0, time.UTC)}, anonymous,
+			"Rate limit exceeded", time.Date(2025, 1, 1, 15, 4, 0, 0, time.UTC).Local().Format(time.Kitchen), true},
+		{"403 secondary rate limit", &github.SecondaryRateLimitError{RetryAfter: 90 * time.Second}, fullAccess,
+			"Too many requests at once", "1m30s", true},
+		{"403 forbidden", &github.StatusError{StatusCode: 403, Status: "403 Forbidden", Message: "Resource not accessible by integration"}, fineGrained,
+			"Access denied", "Resource not accessible by integration", false},
+		{"404 unauthenticated", &github.StatusError{StatusCode: 404, Status: "404 Not Found"}, anonymous,
+			"Repository not found — check the owner/name", "set GITHUB_TOKEN", false},
+		{"404 without the repo scope", &github.StatusError{StatusCode: 404, Status: "404 Not Found"}, publicOnly,
+			"Repository not found — check the owner/name", "no 'repo' scope", false},
+		{"404 fine-grained", &github.StatusError{StatusCode: 404, Status: "404 Not Found"}, fineGrained,
+			"Repository not found — check the owner/name", "wasn't granted access", false},
+		{"404 with full access", &github.StatusError{StatusCode: 404, Status: "404 Not Found"}, fullAccess,
+			"Repository not found — check the owner/name", "your token can't see it", false},
+		{"422", &github.StatusError{StatusCode: 422, Status: "422 Unprocessable Entity"}, fullAccess,
+			"GitHub couldn't process the request", "rejected as invalid", false},
+		{"502", &github.StatusError{StatusCode: 502, Status: "502 Bad Gateway"}, fullAccess,
+			"GitHub is having trouble", "502 Bad Gateway", true},
+		{"network error", &net.OpError{Op: "dial", Net: "tcp", Err: errors.New("connection refused")}, fullAccess,
+			"Network problem", "connection refused", true},
+		{"timeout", fmt.Errorf("fetching: %w", context.DeadlineExceeded), fullAccess,
+			"Network problem", "deadline exceeded", true},
+		{"cancelled", fmt.Errorf("fetching: %w", context.Canceled), fullAccess,
+			"Analysis cancelled", "stopped", true},
+		{"missing ref", &github.RefNotFoundError{Ref: "v9", Repo: "o/r"}, fullAccess,
+			"Ref not found", `"v9"`, false},
+		{"anything else", errors.New("boom"), fullAccess,
+			"Analysis failed", "boom", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Errors arrive wrapped by the analysis
+			msg := MessageForError(fmt.Errorf("analyzing o/r: %w", tt.err), tt.auth)
+			if msg.Title != tt.title {
+				t.Errorf("title = %q, want %q", msg.Title, tt.title)
+			}
+			if !strings.Contains(msg.Description, tt.mentions) {
+				t.Errorf("description %q doesn't mention %q", msg.Description, tt.mentions)
+			}
+			if msg.Retryable != tt.retryable {
+				t.Errorf("retryable = %v, want %v", msg.Retryable, tt.retryable)
+			}
+		})
+	}
+}
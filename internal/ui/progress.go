@@ -1,8 +1,10 @@
 package ui
 
 import (
-	tea "github.com/charmbracelet/bubbletea"
+	"sync"
 	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
 )
 
 // ProgressStage represents a step in the analysis process
@@ -10,10 +12,25 @@ type ProgressStage struct {
 	Name       string
 	IsComplete bool
 	IsActive   bool
+	Failed     bool // completed without data; the section is marked unavailable
 }
 
-// ProgressTracker manages multi-step analysis progress
+// Indexes of the default stages, which run concurrently after stageRepo
+const (
+	stageRepo = iota
+	stageCommits
+	stageContributors
+	stageLanguages
+	stageFileTree
+	stageInsights
+	stageMetrics
+	stageDone
+)
+
+// ProgressTracker manages multi-step analysis progress. It's updated from the
+// fetching goroutines while the UI reads it, so access is synchronized.
 type ProgressTracker struct {
+	mu        sync.Mutex
 	stages    []ProgressStage
 	current   int
 	startTime time.Time
@@ -33,6 +50,8 @@ func NewProgressTracker() *ProgressTracker {
 			{Name: "📝 Analyzing commits", IsComplete: false, IsActive: false},
 			{Name: "👥 Analyzing contributors", IsComplete: false, IsActive: false},
 			{Name: "🗣️  Analyzing languages", IsComplete: false, IsActive: false},
+			{Name: "🌳 Reading file tree", IsComplete: false, IsActive: false},
+			{Name: "🔍 Fetching releases, issues and community data", IsComplete: false, IsActive: false},
 			{Name: "📊 Computing metrics", IsComplete: false, IsActive: false},
 			{Name: "✅ Analysis complete", IsComplete: false, IsActive: false},
 		},
@@ -43,6 +62,9 @@ func NewProgressTracker() *ProgressTracker {
 
 // NextStage moves to the next analysis stage
 func (pt *ProgressTracker) NextStage() {
+	pt.mu.Lock()
+	defer pt.mu.Unlock()
+
 	if pt.current < len(pt.stages) {
 		pt.stages[pt.current].IsComplete = true
 		pt.stages[pt.current].IsActive = false
@@ -53,8 +75,30 @@ func (pt *ProgressTracker) NextStage() {
 	}
 }
 
+// Start marks a stage as in progress, for stages running concurrently
+func (pt *ProgressTracker) Start(stage int) {
+	pt.mu.Lock()
+	defer pt.mu.Unlock()
+	pt.stages[stage].IsActive = true
+}
+
+// Finish marks a stage as done; a non-nil err flags it as failed
+func (pt *ProgressTracker) Finish(stage int, err error) {
+	pt.mu.Lock()
+	defer pt.mu.Unlock()
+	pt.stages[stage].IsActive = false
+	pt.stages[stage].IsComplete = true
+	pt.stages[stage].Failed = err != nil
+	if stage >= pt.current {
+		pt.current = stage + 1
+	}
+}
+
 // GetCurrentStage returns the current stage information
 func (pt *ProgressTracker) GetCurrentStage() ProgressStage {
+	pt.mu.Lock()
+	defer pt.mu.Unlock()
+
 	if pt.current < len(pt.stages) {
 		return pt.stages[pt.current]
 	}
@@ -63,11 +107,16 @@ func (pt *ProgressTracker) GetCurrentStage() ProgressStage {
 
 // GetAllStages returns all stages with their status
 func (pt *ProgressTracker) GetAllStages() []ProgressStage {
-	return pt.stages
+	pt.mu.Lock()
+	defer pt.mu.Unlock()
+	return append([]ProgressStage(nil), pt.stages...)
 }
 
 // GetProgress returns completed stages / total stages
 func (pt *ProgressTracker) GetProgress() (completed int, total int) {
+	pt.mu.Lock()
+	defer pt.mu.Unlock()
+
 	total = len(pt.stages)
 	for _, stage := range pt.stages {
 		if stage.IsComplete {
@@ -0,0 +1,189 @@
+package ui
+
+import (
+	"encoding/json"
+	"path"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/agnivo988/Repo-lyzer/internal/github"
+)
+
+// SchemaVersion is the version of the JSON and YAML exports' structure.
+// Adding a field keeps it; renaming, removing or retyping one bumps it, so
+// scripts can refuse a version they don't understand.
+const SchemaVersion = "4"
+
+// jsonDocument is what ExportJSON writes: the analysis, with the schema
+// version and export time alongside its fields
+type jsonDocument struct {
+	SchemaVersion string    `json:"schema_version"`
+	ExportedAt    time.Time `json:"exported_at"`
+	// CommitCount is how many commits the window has
+	CommitCount int `json:"commit_count"`
+	// ContributorCount is how many contributors were fetched; Contributors
+	// lists the top ExportOptions.ContributorLimit of them (10 by default,
+	// 0 for all)
+	ContributorCount int `json:"contributor_count"`
+	// Commits stands in for AnalysisResult's, with just what identifies
+	// each commit, and only with ExportOptions.IncludeCommits
+	Commits []exportCommit `json:"commits,omitempty"`
+	AnalysisResult
+}
+
+// exportCommit is a commit as the exports list it
+type exportCommit struct {
+	SHA     string    `json:"sha"`
+	Author  string    `json:"author"` // the GitHub login, or the git author name without one
+	Date    time.Time `json:"date"`
+	Subject string    `json:"subject"`
+}
+
+func newExportCommit(c github.Commit) exportCommit {
+	author := c.AuthorLogin()
+	if author == "" {
+		author = c.Commit.Author.Name
+	}
+	return exportCommit{c.SHA, author, c.Commit.Author.Date, c.Subject()}
+}
+
+func newJSONDocument(data AnalysisResult, options ExportOptions) jsonDocument {
+	doc := jsonDocument{
+		SchemaVersion:    SchemaVersion,
+		ExportedAt:       time.Now().UTC().Truncate(time.Second),
+		CommitCount:      len(data.Commits),
+		ContributorCount: len(data.Contributors),
+		AnalysisResult:   withEmptyCollections(data),
+	}
+	doc.Contributors = options.topContributors(doc.Contributors)
+	if options.IncludeCommits {
+		doc.Commits = make([]exportCommit, len(data.Commits))
+		for i, c := range data.Commits {
+			doc.Commits[i] = newExportCommit(c)
+		}
+	}
+	return doc
+}
+
+// withEmptyCollections replaces data's nil lists and maps with empty ones,
+// so the export has [] and {} where scripts iterate rather than null
+func withEmptyCollections(data AnalysisResult) AnalysisResult {
+	v := reflect.ValueOf(&data).Elem()
+	for i := 0; i < v.NumField(); i++ {
+		field := v.Field(i)
+		switch {
+		case field.Kind() == reflect.Slice && field.IsNil():
+			field.Set(reflect.MakeSlice(field.Type(), 0, 0))
+		case field.Kind() == reflect.Map && field.IsNil():
+			field.Set(reflect.MakeMap(field.Type()))
+		}
+	}
+	return data
+}
+
+var timeType = reflect.TypeOf(time.Time{})
+
+// JSONSchema describes the JSON export as a JSON Schema (draft 2020-12).
+// It's generated from the exported types themselves, so it can't drift
+// from what ExportJSON writes.
+func JSONSchema() ([]byte, error) {
+	g := schemaGenerator{defs: make(map[string]any)}
+	root := g.structSchema(reflect.TypeOf(jsonDocument{}))
+	root["$schema"] = "https://json-schema.org/draft/2020-12/schema"
+	root["title"] = "Repo-lyzer analysis, schema version " + SchemaVersion
+	root["$defs"] = g.defs
+	return json.MarshalIndent(root, "", "  ")
+}
+
+// schemaGenerator collects named structs under $defs, which also keeps
+// recursive types finite
+type schemaGenerator struct {
+	defs map[string]any
+}
+
+// schema describes how encoding/json encodes a value of type t
+func (g *schemaGenerator) schema(t reflect.Type) map[string]any {
+	if t == timeType {
+		return map[string]any{"type": "string", "format": "date-time"}
+	}
+	switch t.Kind() {
+	case reflect.Pointer:
+		return map[string]any{"anyOf": []any{g.schema(t.Elem()), map[string]any{"type": "null"}}}
+	case reflect.Struct:
+		if t.Name() == "" {
+			return g.structSchema(t)
+		}
+		name := path.Base(t.PkgPath()) + "." + t.Name()
+		if _, ok := g.defs[name]; !ok {
+			g.defs[name] = nil // reserved while its fields are described
+			g.defs[name] = g.structSchema(t)
+		}
+		return map[string]any{"$ref": "#/$defs/" + name}
+	case reflect.Slice:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return map[string]any{"type": []string{"string", "null"}, "contentEncoding": "base64"}
+		}
+		// Nil slices and maps encode as null
+		return map[string]any{"type": []string{"array", "null"}, "items": g.schema(t.Elem())}
+	case reflect.Array:
+		return map[string]any{"type": "array", "items": g.schema(t.Elem()), "minItems": t.Len(), "maxItems": t.Len()}
+	case reflect.Map:
+		return map[string]any{"type": []string{"object", "null"}, "additionalProperties": g.schema(t.Elem())}
+	case reflect.String:
+		return map[string]any{"type": "string"}
+	case reflect.Bool:
+		return map[string]any{"type": "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return map[string]any{"type": "integer"}
+	case reflect.Float32, reflect.Float64:
+		return map[string]any{"type": "number"}
+	}
+	// Interfaces hold anything
+	return map[string]any{}
+}
+
+// structSchema lists a struct's fields as encoding/json names them, with
+// embedded structs' fields promoted unless a shallower field has their name.
+// Fields without omitempty are always written, so they're required.
+func (g *schemaGenerator) structSchema(t reflect.Type) map[string]any {
+	properties := make(map[string]any)
+	required := []string{}
+	var addFields func(t reflect.Type)
+	addFields = func(t reflect.Type) {
+		var embedded []reflect.Type
+		for i := 0; i < t.NumField(); i++ {
+			field := t.Field(i)
+			tag := field.Tag.Get("json")
+			if tag == "-" || !field.IsExported() && !field.Anonymous {
+				continue
+			}
+			name, options, _ := strings.Cut(tag, ",")
+			if field.Anonymous && name == "" && field.Type.Kind() == reflect.Struct {
+				embedded = append(embedded, field.Type)
+				continue
+			}
+			if name == "" {
+				name = field.Name
+			}
+			if _, shadowed := properties[name]; shadowed {
+				continue
+			}
+			properties[name] = g.schema(field.Type)
+			if !strings.Contains(","+options+",", ",omitempty,") {
+				required = append(required, name)
+			}
+		}
+		for _, e := range embedded {
+			addFields(e)
+		}
+	}
+	addFields(t)
+	return map[string]any{
+		"type":                 "object",
+		"properties":           properties,
+		"required":             required,
+		"additionalProperties": false,
+	}
+}
@@ -0,0 +1,224 @@
+package ui
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+)
+
+var snakeCase = regexp.MustCompile(`^[a-z][a-z0-9]*(_[a-z0-9]+)*$`)
+
+// propertyNames collects the property names of every object in a schema
+func propertyNames(node any, names map[string]bool) {
+	switch n := node.(type) {
+	case map[string]any:
+		if properties, ok := n["properties"].(map[string]any); ok {
+			for name := range properties {
+				names[name] = true
+			}
+		}
+		for _, child := range n {
+			propertyNames(child, names)
+		}
+	case []any:
+		for _, child := range n {
+			propertyNames(child, names)
+		}
+	}
+}
+
+func TestJSONSchemaKeysAreSnakeCase(t *testing.T) {
+	schema := loadSchema(t)
+	names := make(map[string]bool)
+	propertyNames(schema, names)
+	if len(names) == 0 {
+		t.Fatal("schema has no properties")
+	}
+	for name := range names {
+		if !snakeCase.MatchString(name) {
+			t.Errorf("export key %q isn't snake_case; add a json tag", name)
+		}
+	}
+}
+
+// validate checks value against the subset of JSON Schema that JSONSchema
+// generates, returning one message per violation
+func validate(root, schema map[string]any, value any, at string) []string {
+	if ref, ok := schema["$ref"].(string); ok {
+		def, _ := root["$defs"].(map[string]any)[strings.TrimPrefix(ref, "#/$defs/")].(map[string]any)
+		if def == nil {
+			return []string{at + ": unresolved " + ref}
+		}
+		return validate(root, def, value, at)
+	}
+	if anyOf, ok := schema["anyOf"].([]any); ok {
+		for _, s := range anyOf {
+			if len(validate(root, s.(map[string]any), value, at)) == 0 {
+				return nil
+			}
+		}
+		return []string{fmt.Sprintf("%s: %v matches no alternative", at, value)}
+	}
+
+	if types, ok := schema["type"]; ok && !hasType(types, value) {
+		return []string{fmt.Sprintf("%s: %T doesn't match type %v", at, value, types)}
+	}
+
+	var errs []string
+	switch v := value.(type) {
+	case string:
+		if schema["format"] == "date-time" {
+			if _, err := time.Parse(time.RFC3339, v); err != nil {
+				errs = append(errs, fmt.Sprintf("%s: %q isn't a date-time", at, v))
+			}
+		}
+	case []any:
+		if n, ok := schema["minItems"].(float64); ok && len(v) < int(n) {
+			errs = append(errs, fmt.Sprintf("%s: %d items, want at least %v", at, len(v), n))
+		}
+		if n, ok := schema["maxItems"].(float64); ok && len(v) > int(n) {
+			errs = append(errs, fmt.Sprintf("%s: %d items, want at most %v", at, len(v), n))
+		}
+		if items, ok := schema["items"].(map[string]any); ok {
+			for i, item := range v {
+				errs = append(errs, validate(root, items, item, fmt.Sprintf("%s[%d]", at, i))...)
+			}
+		}
+	case map[string]any:
+		properties, _ := schema["properties"].(map[string]any)
+		required, _ := schema["required"].([]any)
+		for _, name := range required {
+			if _, ok := v[name.(string)]; !ok {
+				errs = append(errs, fmt.Sprintf("%s: missing %q", at, name))
+			}
+		}
+		for key, child := range v {
+			if s, ok := properties[key].(map[string]any); ok {
+				errs = append(errs, validate(root, s, child, at+"."+key)...)
+				continue
+			}
+			switch extra := schema["additionalProperties"].(type) {
+			case bool:
+				if !extra {
+					errs = append(errs, fmt.Sprintf("%s: unexpected %q", at, key))
+				}
+			case map[string]any:
+				errs = append(errs, validate(root, extra, child, at+"."+key)...)
+			}
+		}
+	}
+	return errs
+}
+
+// hasType reports whether a decoded JSON value is of one of the schema types
+func hasType(types any, value any) bool {
+	list, ok := types.([]any)
+	if !ok {
+		list = []any{types}
+	}
+	for _, t := range list {
+		switch v := value.(type) {
+		case nil:
+			if t == "null" {
+				return true
+			}
+		case bool:
+			if t == "boolean" {
+				return true
+			}
+		case float64:
+			if t == "number" || t == "integer" && v == math.Trunc(v) {
+				return true
+			}
+		case string:
+			if t == "string" {
+				return true
+			}
+		case []any:
+			if t == "array" {
+				return true
+			}
+		case map[string]any:
+			if t == "object" {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func loadSchema(t *testing.T) map[string]any {
+	t.Helper()
+	raw, err := JSONSchema()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var schema map[string]any
+	if err := json.Unmarshal(raw, &schema); err != nil {
+		t.Fatal(err)
+	}
+	return schema
+}
+
+func TestJSONExportMatchesSchema(t *testing.T) {
+	schema := loadSchema(t)
+	tests := []struct {
+		name    string
+		data    AnalysisResult
+		options ExportOptions
+	}{
+		{"empty", fixture(0), DefaultExportOptions},
+		{"report", reportFixture(), DefaultExportOptions},
+		{"with commits", withCommits(reportFixture(), 5), ExportOptions{IncludeCommits: true}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var out strings.Builder
+			if err := WriteJSON(&out, tt.data, tt.options); err != nil {
+				t.Fatal(err)
+			}
+			var doc any
+			if err := json.Unmarshal([]byte(out.String()), &doc); err != nil {
+				t.Fatal(err)
+			}
+			for _, e := range validate(schema, schema, doc, "$") {
+				t.Error(e)
+			}
+		})
+	}
+}
+
+func TestSchemaValidatorRejects(t *testing.T) {
+	// Guards against a validator that accepts everything
+	schema := loadSchema(t)
+	var out strings.Builder
+	if err := WriteJSON(&out, fixture(1), DefaultExportOptions); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name   string
+		change func(doc map[string]any)
+	}{
+		{"missing required key", func(doc map[string]any) { delete(doc, "schema_version") }},
+		{"unknown key", func(doc map[string]any) { doc["surprise"] = 1 }},
+		{"wrong type", func(doc map[string]any) { doc["commit_count"] = "many" }},
+		{"fractional integer", func(doc map[string]any) { doc["bus_factor"] = 1.5 }},
+		{"bad date", func(doc map[string]any) { doc["exported_at"] = "yesterday" }},
+		{"nested", func(doc map[string]any) { doc["contributors"].([]any)[0].(map[string]any)["contributions"] = "x" }},
+	}
+	for _, tt := range tests {
+		var doc map[string]any
+		if err := json.Unmarshal([]byte(out.String()), &doc); err != nil {
+			t.Fatal(err)
+		}
+		tt.change(doc)
+		if len(validate(schema, schema, doc, "$")) == 0 {
+			t.Errorf("%s: accepted", tt.name)
+		}
+	}
+}
@@ -29,4 +29,13 @@ var (
 	ErrorStyle = lipgloss.NewStyle().
 		Foreground(lipgloss.Color("#FF0000")).
 		Bold(true)
+
+	WarningStyle = lipgloss.NewStyle().
+		Foreground(lipgloss.Color("#FFB000")).
+		Bold(true)
+
+	TopicStyle = lipgloss.NewStyle().
+		Foreground(lipgloss.Color("#FFFFFF")).
+		Background(lipgloss.Color("#7D56F4")).
+		Padding(0, 1)
 )
@@ -3,6 +3,7 @@ package ui
 import (
 	"fmt"
 
+	"github.com/agnivo988/Repo-lyzer/internal/analyzer"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 )
@@ -211,7 +212,8 @@ func BuildFileTree(result AnalysisResult) *FileNode {
 	}
 
 	// Add files based on actual languages detected
-	for lang := range result.Languages {
+	for _, language := range analyzer.RankLanguages(result.Languages) {
+		lang := language.Name
 		ext := ".txt"
 		switch lang {
 		case "Go": ext = ".go"
@@ -1,18 +1,231 @@
 package ui
 
-import "github.com/agnivo988/Repo-lyzer/internal/github"
+import (
+	"slices"
+
+	"github.com/agnivo988/Repo-lyzer/internal/analyzer"
+	"github.com/agnivo988/Repo-lyzer/internal/github"
+	"github.com/agnivo988/Repo-lyzer/internal/scorecard"
+)
+
+// Sections of an analysis that can fail without failing the whole run
+const (
+	SectionCommits      = "commits"
+	SectionContributors = "contributors"
+	SectionLanguages    = "languages"
+	SectionFileTree     = "file tree"
+)
+
+// Options tune what an analysis fetches
+type Options struct {
+	// EnrichContributors is how many top contributors get their profile
+	// details fetched (one request each); 0 skips enrichment
+	EnrichContributors int
+	// StarHistory fetches stargazer timestamps for star growth, which
+	// costs up to github.MaxStarHistoryPages requests
+	StarHistory bool
+	// AbandonedAfterDays is how long without a push before looking for
+	// active forks; 0 never looks
+	AbandonedAfterDays int
+	// HealthWeights overrides the health score formula; the zero value
+	// means analyzer.DefaultHealthWeights
+	HealthWeights analyzer.HealthWeights
+	// BusFactorThreshold is the percent of commits the bus factor covers;
+	// 0 keeps the classic classification (analyzer.ClassicBusFactor)
+	BusFactorThreshold int
+	// CoreContributorsOnly bases bus factor and health on core
+	// contributors rather than everyone who ever committed
+	CoreContributorsOnly bool
+	// LargeFileMB is the size above which files count as bloat; 0 means
+	// analyzer.DefaultLargeFileMB
+	LargeFileMB int
+	// Hotspots fetches the changed files of recent commits to find the
+	// most changed ones, at up to github.DefaultHotspotSample requests
+	Hotspots bool
+	// Scorecard fetches the repo's OpenSSF Scorecard results from the
+	// public dataset
+	Scorecard bool
+	// ExcludePaths are .gitattributes-style patterns left out of the
+	// adjusted language breakdown, on top of the defaults
+	ExcludePaths []string
+	// Export tunes what the dashboard's and compare view's exports include
+	Export ExportOptions
+}
+
+// LanguageExclusions gathers the patterns left out of the adjusted language
+// breakdown: the defaults, the repo's linguist hints and ExcludePaths
+func (o Options) LanguageExclusions(gitattributes string) []string {
+	exclusions := slices.Clone(analyzer.DefaultLanguageExclusions)
+	exclusions = append(exclusions, analyzer.ParseLinguistExclusions(gitattributes)...)
+	return append(exclusions, o.ExcludePaths...)
+}
+
+// healthScorer returns the scorer for the configured weights
+func (o Options) healthScorer() analyzer.HealthScorer {
+	if scorer, err := analyzer.NewHealthScorer(o.HealthWeights); err == nil {
+		return scorer
+	}
+	return analyzer.DefaultHealthScorer()
+}
 
 type AnalysisResult struct {
-	Repo          *github.Repo
-	Commits       []github.Commit
-	Contributors  []github.Contributor
-	FileTree      []github.TreeEntry
-	Languages     map[string]int
-	HealthScore   int
-	BusFactor     int
-	BusRisk       string
-	MaturityScore int
-	MaturityLevel string
+	Repo *github.Repo `json:"repo"`
+	// Ref is the branch, tag or SHA analyzed, the default branch unless
+	// one was asked for
+	Ref string `json:"ref"`
+	// RefSHA is the commit an explicitly requested Ref resolved to
+	RefSHA  string          `json:"ref_sha"`
+	Commits []github.Commit `json:"commits"`
+	// CommitsTruncated is set when the commit window hit the fetch cap
+	CommitsTruncated bool `json:"commits_truncated"`
+	// WeeklyCommits is the yearly histogram, exact when taken from the stats endpoint
+	WeeklyCommits analyzer.WeeklyActivity `json:"weekly_commits"`
+	// ActivityTrend compares the last 26 weeks of WeeklyCommits with the
+	// 26 before
+	ActivityTrend analyzer.ActivityTrend `json:"activity_trend"`
+	// Abandonment classifies the project as active, slowing, dormant,
+	// abandoned or archived
+	Abandonment analyzer.AbandonmentRisk `json:"abandonment"`
+	// Hotspots are the files changed most in recent commits, when
+	// Options.Hotspots asked for them
+	Hotspots analyzer.HotspotReport `json:"hotspots"`
+	// DirectoryOwners names the dominant author of each top-level
+	// directory, from the same commits as Hotspots
+	DirectoryOwners []analyzer.DirectoryOwner `json:"directory_owners"`
+	// Churn counts the lines added and deleted over the last year
+	Churn analyzer.ChurnStats `json:"churn"`
+	// Workflow is how changes land on the branch: PR merges, squashes or
+	// direct pushes
+	Workflow analyzer.DevelopmentWorkflow `json:"workflow"`
+	// CommitHygiene rates the messages of the commits in the window
+	CommitHygiene analyzer.CommitHygiene `json:"commit_hygiene"`
+	// Timezones buckets the commits by their author's UTC offset
+	Timezones analyzer.TimezoneSpread `json:"timezones"`
+	// Heatmap counts the commits by weekday and hour
+	Heatmap      analyzer.CommitHeatmap `json:"heatmap"`
+	Contributors []github.Contributor   `json:"contributors"`
+	// ContributorsTruncated is set when the contributor list hit the fetch cap,
+	// making bus factor a lower-confidence estimate
+	ContributorsTruncated bool               `json:"contributors_truncated"`
+	FileTree              []github.TreeEntry `json:"file_tree"`
+	// TreeStats counts FileTree's files by extension, vendored and
+	// generated ones excluded
+	TreeStats analyzer.TreeStats `json:"tree_stats"`
+	// RepoSize flags large checked-in files
+	RepoSize analyzer.RepoSize `json:"repo_size"`
+	// Monorepo lists the packages of a monorepo; zero for a single package
+	Monorepo  analyzer.Monorepo `json:"monorepo"`
+	Languages map[string]int    `json:"languages"`
+	// LanguagesEstimated is set when the host reported no languages and
+	// Languages was estimated from the file tree's extensions
+	LanguagesEstimated bool `json:"languages_estimated"`
+	// AdjustedLanguages leaves generated and vendored code out of the
+	// language breakdown, estimated from the file tree
+	AdjustedLanguages map[string]int `json:"adjusted_languages"`
+	// LanguageProfile is the primary language's share and how polyglot
+	// MetricLanguages is
+	LanguageProfile analyzer.LanguageProfile `json:"language_profile"`
+	// MentionableUsers and Collaborators come from the GraphQL overview;
+	// -1 when it wasn't used, or for collaborators without push access
+	MentionableUsers int `json:"mentionable_users"`
+	Collaborators    int `json:"collaborators"`
+	// Engagement relates forks, watchers, issues and contributors to stars
+	Engagement analyzer.Engagement `json:"engagement"`
+	// Changelog is the changelog's latest entry, compared with the latest
+	// release
+	Changelog analyzer.ChangelogInfo `json:"changelog"`
+	Releases  []github.Release       `json:"releases"`
+	// ReleaseAutomation is the tools found cutting releases
+	ReleaseAutomation analyzer.ReleaseAutomation   `json:"release_automation"`
+	ReleaseStats      analyzer.ReleaseStats        `json:"release_stats"`
+	Issues            analyzer.IssueStats          `json:"issues"`
+	Responsiveness    analyzer.IssueResponsiveness `json:"responsiveness"`
+	// FirstResponse is how soon maintainers reply to recent issues
+	FirstResponse    analyzer.FirstResponse        `json:"first_response"`
+	Friendliness     analyzer.Friendliness         `json:"friendliness"`
+	PullRequests     analyzer.PullRequestStats     `json:"pull_requests"`
+	Reviews          analyzer.ReviewCoverage       `json:"reviews"`
+	BranchProtection analyzer.BranchProtectionInfo `json:"branch_protection"`
+	Branches         analyzer.BranchStats          `json:"branches"`
+	Security         analyzer.SecurityScore        `json:"security"`
+	// SignedCommitRatio is the share of commits in the window with a
+	// verified signature
+	SignedCommitRatio analyzer.SignedCommitRatio `json:"signed_commit_ratio"`
+	// ScorecardChecked is set when Options.Scorecard asked for the OpenSSF
+	// Scorecard results; Scorecard holds them, nil when the repo isn't in
+	// the public dataset
+	ScorecardChecked bool                     `json:"scorecard_checked"`
+	Scorecard        *scorecard.Result        `json:"scorecard"`
+	Community        analyzer.CommunityHealth `json:"community"`
+	Readme           analyzer.ReadmeInfo      `json:"readme"`
+	// Automation lists the .github templates and bots configured
+	Automation analyzer.ProjectAutomation `json:"automation"`
+	// ReadmeQuality is the README checklist and its score
+	ReadmeQuality analyzer.ReadmeQuality `json:"readme_quality"`
+	License       analyzer.LicenseInfo   `json:"license"`
+	Ownership     analyzer.OwnershipInfo `json:"ownership"`
+	CI            analyzer.CIInfo        `json:"ci"`
+	Tests         analyzer.TestStats     `json:"tests"`
+	Stars         analyzer.StarHistory   `json:"stars"`
+	// ForksChecked is set when the repo looked abandoned and its forks
+	// were searched; ActiveForks holds what was found
+	ForksChecked bool                     `json:"forks_checked"`
+	ActiveForks  []analyzer.ForkCandidate `json:"active_forks"`
+	HealthScore  int                      `json:"health_score"`
+	HealthGrade  analyzer.Grade           `json:"health_grade"`
+	// HealthWeights is the formula HealthScore was computed with
+	HealthWeights analyzer.HealthWeights `json:"health_weights"`
+	// HealthComponents breaks HealthScore down by component
+	HealthComponents []analyzer.ScoreComponent `json:"health_components"`
+	BusFactor        int                       `json:"bus_factor"`
+	BusRisk          string                    `json:"bus_risk"`
+	// BusFactorInfo has the 50% and 80% figures behind BusFactor
+	BusFactorInfo analyzer.BusFactorInfo `json:"bus_factor_info"`
+	// Inequality is the Gini coefficient of contributor commits
+	Inequality analyzer.ContributionInequality `json:"inequality"`
+	// ContributorTrend compares this year's contributors with last year's
+	ContributorTrend analyzer.ContributorTrend `json:"contributor_trend"`
+	// ContributorTiers splits contributors into core, regular and drive-by
+	ContributorTiers analyzer.ContributorTiers `json:"contributor_tiers"`
+	MaturityScore    int                       `json:"maturity_score"`
+	MaturityGrade    analyzer.Grade            `json:"maturity_grade"`
+	MaturityLevel    string                    `json:"maturity_level"`
+	// MaturityComponents are the factors MaturityScore is made of
+	MaturityComponents []analyzer.ScoreComponent `json:"maturity_components"`
+	// ReleaseCadence is the release cadence's part of MaturityScore
+	ReleaseCadence analyzer.ReleaseCadenceRating `json:"release_cadence"`
+	// FromStaleCache is set when GitHub was unreachable and some data came
+	// from the on-disk cache, so it may be out of date
+	FromStaleCache bool `json:"from_stale_cache"`
+	// Unavailable maps sections whose fetch failed to the error message
+	Unavailable map[string]string `json:"unavailable"`
+}
+
+// SectionError returns why a section is unavailable, or "" if it loaded
+func (r AnalysisResult) SectionError(section string) string {
+	return r.Unavailable[section]
+}
+
+// MetricLanguages is the language breakdown metrics like the primary
+// language use: the adjusted one when the tree allowed computing it
+func (r AnalysisResult) MetricLanguages() map[string]int {
+	if len(r.AdjustedLanguages) > 0 {
+		return r.AdjustedLanguages
+	}
+	return r.Languages
+}
+
+// RefLabel describes the analyzed ref, e.g. "v1.2.0 (3f2a9c1)" or
+// "main (default branch)"
+func (r AnalysisResult) RefLabel() string {
+	if r.RefSHA == "" {
+		return r.Ref + " (default branch)"
+	}
+	sha := r.RefSHA
+	if len(sha) > 7 {
+		sha = sha[:7]
+	}
+	return r.Ref + " (" + sha + ")"
 }
 
 // CompareResult holds analysis data for two repositories
@@ -20,3 +233,24 @@ type CompareResult struct {
 	Repo1 AnalysisResult
 	Repo2 AnalysisResult
 }
+
+// Diff works out how Repo2 differs from Repo1
+func (c CompareResult) Diff() analyzer.RepoComparison {
+	return analyzer.CompareRepos(compareFigures(c.Repo1), compareFigures(c.Repo2))
+}
+
+func compareFigures(r AnalysisResult) analyzer.CompareFigures {
+	figures := analyzer.CompareFigures{
+		Commits:       commitCount(r),
+		Contributors:  r.Contributors,
+		HealthScore:   r.HealthScore,
+		MaturityScore: r.MaturityScore,
+		SecurityScore: r.Security.Score,
+		BusFactor:     r.BusFactor,
+		Languages:     r.MetricLanguages(),
+	}
+	if r.Repo != nil {
+		figures.Stars, figures.Forks, figures.Topics = r.Repo.Stars, r.Repo.Forks, r.Repo.Topics
+	}
+	return figures
+}
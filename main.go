@@ -1,7 +1,16 @@
 package main
 
-import "github.com/agnivo988/Repo-lyzer/cmd"
+import (
+	"os"
+
+	"github.com/agnivo988/Repo-lyzer/cmd"
+)
 
 func main() {
+	// Run the interactive menu unless a subcommand was given
+	if len(os.Args) > 1 {
+		cmd.Execute()
+		return
+	}
 	cmd.RunMenu()
 }